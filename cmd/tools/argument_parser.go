@@ -1,91 +1,91 @@
 package main
 
 import (
-	"strings"
+	"errors"
+	"fmt"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/flags"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/eventio/csv"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/parser"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
 )
 
-type DXArguments struct {
-	args []string
-}
+const (
+	addressUsage = `The address(es) to connect to retrieve data (remote host or local tape file).
+To pass an authorization token, add to the address: "[login=entitle:<token>]",
+e.g.: demo.dxfeed.com:7300[login=entitle:<token>]`
+	typesUsage   = "Comma-separated list of dxfeed event types (e.g. Quote,TimeAndSale)."
+	symbolsUsage = `Comma-separated list of symbol names to get events for (e.g. "IBM,AAPL,MSFT").
+For Candle event specify symbol with aggregation like in "AAPL{=d}".`
+)
 
-func (a DXArguments) isQuite() bool {
-	for _, arg := range a.args {
-		if arg == "-q" || arg == "--quite" {
-			return true
-		}
-	}
-	return false
+func quietFlag(set *flags.FlagSet) *bool {
+	quiet := set.Bool("q", "quiet", "Does not print received events.")
+	set.Alias("quite", "quiet")
+	return quiet
 }
 
-func (a DXArguments) tape() *string {
-	for index, arg := range a.args {
-		if arg == "-t" || arg == "--tape" {
-			if index+1 >= len(a.args) {
-				panic("Check value after -t parameter")
-			}
-			return &a.args[index+1]
-		}
-	}
-	return nil
+func propertiesFlag(set *flags.FlagSet) map[string]string {
+	return set.Properties("p", "properties", "Properties of the endpoint (e.g. dxfeed.wildcard.enable=true), may be repeated.")
 }
 
-func (a DXArguments) time() *string {
-	for index, arg := range a.args {
-		if arg == "-f" || arg == "--from-time" {
-			if index+1 >= len(a.args) {
-				panic("Check value after -t parameter")
-			}
-			return &a.args[index+1]
-		}
-	}
-	return nil
+func forceStreamFlag(set *flags.FlagSet) *bool {
+	return set.Bool("", "force-stream", "Enforces a streaming contract for subscription. The StreamFeed role is used instead of Feed.")
 }
 
-func (a DXArguments) forceStream() bool {
-	for _, arg := range a.args {
-		if arg == "--force-stream" {
-			return true
-		}
-	}
-	return false
+func fromTimeFlag(set *flags.FlagSet, usage string) *string {
+	return set.String("f", "from-time", "<time>", "", usage)
 }
 
-func (a DXArguments) ignoreExchanges() *string {
-	for index, arg := range a.args {
-		if arg == "--ignore-exchanges" {
-			if index+1 >= len(a.args) {
-				panic("Check value after --ignore-exchanges parameter")
+func intervalFlag(set *flags.FlagSet, defaultValue timeutil.TimePeriod) *timeutil.TimePeriod {
+	interval := defaultValue
+	set.Func("i", "interval", "<period>", fmt.Sprintf("Measurement interval (e.g. 2s, 500ms, PT1M), %s by default.", defaultValue),
+		func(value string) error {
+			period, err := timeutil.ParseTimePeriod(value)
+			if err != nil {
+				return err
 			}
-			return &a.args[index+1]
-		}
-	}
-	return nil
+			if period == timeutil.ZeroPeriod || period.IsUnlimited() {
+				return errors.New("the interval should be positive")
+			}
+			interval = period
+			return nil
+		})
+	return &interval
 }
 
-func (a DXArguments) properties() map[string]string {
-	properties := map[string]string{}
-	for index, arg := range a.args {
-		if arg == "-p" || arg == "--properties" {
-			valueArg := a.args[index+1]
-			for _, property := range strings.Split(valueArg, ",") {
-				propPair := strings.Split(property, "=")
-				properties[propPair[0]] = propPair[1]
-			}
-		}
+// parseEventTypes parses the <types> argument, which should contain at least one known type.
+func parseEventTypes(value string) ([]eventcodes.EventCode, error) {
+	types := parser.ParseEventTypes(value)
+	if len(types) == 0 {
+		return nil, flags.Errorf("no known event types in %q", value)
 	}
-	return properties
+	return types, nil
 }
 
-func (a DXArguments) arguments() []string {
-	var arguments []string
+// printerFlags are the flags of eventPrinter.
+type printerFlags struct {
+	format     *string
+	fields     *[]string
+	timeFormat *csv.TimeFormat
+}
 
-	for index, arg := range a.args {
-		if index < 2 {
-			continue
-		}
-		if !strings.HasPrefix(arg, "-") {
-			arguments = append(arguments, arg)
-		}
+func newPrinterFlags(set *flags.FlagSet) printerFlags {
+	timeFormat := csv.TimeMillis
+	p := printerFlags{
+		format:     set.String("", "format", "<text|json|csv>", textFormat, "Output format of events, text by default."),
+		fields:     set.List("", "fields", "<fields>", "Comma-separated list of fields to print (e.g. eventSymbol,time,price), all by default."),
+		timeFormat: &timeFormat,
 	}
-	return arguments
+	set.Alias("columns", "fields")
+	set.Func("", "time-format", "<millis|nanos|iso>", "Format of times in CSV, millis by default.", func(value string) error {
+		format, err := csv.ParseTimeFormat(value)
+		if err != nil {
+			return err
+		}
+		timeFormat = format
+		return nil
+	})
+	return p
 }
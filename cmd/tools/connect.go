@@ -2,14 +2,19 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/flags"
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/session"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api/Osub"
-	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/parser"
-	"math"
-	"os"
-	"time"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
 )
 
 type Connect struct{}
@@ -18,40 +23,71 @@ func (c Connect) ShortDescription() string {
 	return "Connects to specified address(es)."
 }
 
-func (c Connect) Run(args []string) {
-	dxarguments := DXArguments{args}
-
-	arguments := dxarguments.arguments()
-	if len(arguments) < 3 {
-		fmt.Println(`
-		Connect
-		=======
-		
-		Usage:
-		  Connect <address> <types> <symbols> [-f <time>] [<options>]
-		
-		Where:
-			address - The address to connect to retrieve data (remote host or local tape file).
-					  To pass an authorization token, add to the address: ""[login=entitle:<token>]"",
-					  e.g.: demo.dxfeed.com:7300[login=entitle:<token>]
-			types   - Is comma-separated list of dxfeed event types ({eventTypeNames}).
-			symbol  - Is comma-separated list of symbol names to get events for (e.g. ""IBM,AAPL,MSFT"").
-					  for Candle event specify symbol with aggregation like in ""AAPL{{=d}}""
-			--force-stream    Enforces a streaming contract for subscription. The StreamFeed role is used instead of Feed.
-		
-		Sample: connect "dxlink:wss://demo.dxfeed.com/dxlink-ws" Quote AAPL -p dxfeed.experimental.dxlink.enable=true
-		Sample: connect demo.dxfeed.com:7300 Quote AAPL`)
-		os.Exit(0)
-	}
-
-	address := arguments[0]
-	symbols := parser.ParseSymbols(arguments[2])
-	types := parser.ParseEventTypes(arguments[1])
-
-	err := connect(address, types, symbols, dxarguments.properties(), dxarguments.forceStream(), dxarguments.isQuite(), dxarguments.time())
+func (c Connect) Run(args []string) error {
+	set := flags.NewFlagSet("connect", `Connects to the specified address(es) and prints received events
+until one of the exit conditions is met or the tool is interrupted.`)
+	address := set.Positional("address", addressUsage)
+	types := set.Positional("types", typesUsage)
+	symbols := set.Positional("symbols", symbolsUsage)
+	fromTime := fromTimeFlag(set, `Subscribes to time series (e.g. Candle, TimeAndSale, Greeks) from the time
+(e.g. 20240102-153000, 2024-01-02T15:30:00Z), other types are subscribed as usual.`)
+	forceStream := forceStreamFlag(set)
+	aggregationPeriod := set.Period("", "aggregation-period", "<period>", 0,
+		"Aggregation period of the feed (e.g. 1s, 0.1s, PT0.5S); 0 disables aggregation.")
+	count := set.Int("c", "count", "<count>", 0, "Exits after receiving the number of events.")
+	duration := set.Period("d", "duration", "<period>", 0, "Exits after the period (e.g. 30s, 5m).")
+	untilSnapshot := set.Bool("", "until-snapshot", `Exits after receiving snapshots of all symbols and types:
+the snapshots of indexed events or the first events of other types.`)
+	stats := set.Bool("", "stats", "Prints counts and rates of received events by type on exit.")
+	quiet := quietFlag(set)
+	printer := newPrinterFlags(set)
+	properties := propertiesFlag(set)
+	set.Sample(`"dxlink:wss://demo.dxfeed.com/dxlink-ws" Quote AAPL -p dxfeed.experimental.dxlink.enable=true`)
+	set.Sample("demo.dxfeed.com:7300 Quote AAPL")
+	set.Sample("demo.dxfeed.com:7300 Quote AAPL --format csv --fields eventSymbol,bidPrice,askPrice")
+	set.Sample("demo.dxfeed.com:7300 Candle,Quote AAPL{=d},AAPL -f 20240102 --until-snapshot --format json --stats")
+	set.Sample("demo.dxfeed.com:7300 Quote,Trade AAPL,IBM -q --duration 30s --stats")
+	if err := parseFlags(set, args); err != nil {
+		return err
+	}
+	if *count < 0 {
+		return flags.Errorf("the count should not be negative")
+	}
+
+	eventTypes, err := parseEventTypes(*types)
 	if err != nil {
-		fmt.Printf("Error during connect: %v", err)
+		return err
 	}
+	printEvents, err := eventPrinter(printer)
+	if err != nil {
+		return err
+	}
+	var from *int64
+	if set.IsSet("from-time") {
+		parsed, err := parser.ParseTime(*fromTime)
+		if err != nil {
+			return flags.Errorf("invalid --from-time %s: %v", *fromTime, err)
+		}
+		from = &parsed
+	}
+	var aggregation *timeutil.TimePeriod
+	if set.IsSet("aggregation-period") {
+		aggregation = aggregationPeriod
+	}
+	parsedSymbols := parser.ParseSymbols(*symbols)
+	if *untilSnapshot && hasWildcard(parsedSymbols) {
+		return flags.Errorf("--until-snapshot is not supported for the wildcard symbol")
+	}
+	exit := exitConditions{count: int64(*count), duration: *duration, untilSnapshot: *untilSnapshot}
+	return connect(*address, eventTypes, parsedSymbols, properties,
+		*forceStream, *quiet, from, aggregation, printEvents, exit, *stats)
+}
+
+// exitConditions end connect, it runs until interrupted without them.
+type exitConditions struct {
+	count         int64
+	duration      timeutil.TimePeriod
+	untilSnapshot bool
 }
 
 func connect(
@@ -60,8 +96,12 @@ func connect(
 	symbols []any,
 	properties map[string]string,
 	forceStream bool,
-	isQuite bool,
-	fromTime *string,
+	isQuiet bool,
+	fromTime *int64,
+	aggregationPeriod *timeutil.TimePeriod,
+	printEvents func(eventsList []interface{}),
+	exit exitConditions,
+	printStats bool,
 ) error {
 	for key, value := range properties {
 		api.SetSystemProperty(key, value)
@@ -70,66 +110,141 @@ func connect(
 	if forceStream {
 		role = api.StreamFeed
 	}
-	endpoint, err := api.NewEndpointWithProperties(role, properties)
-
+	builder := api.NewEndpointBuilder().WithRole(role).WithProperties(properties)
+	if aggregationPeriod != nil {
+		builder.WithAggregationPeriod(*aggregationPeriod)
+	}
+	endpoint, err := builder.Build()
 	if err != nil {
-		return fmt.Errorf("CreateEndpoint: %we", err)
+		return fmt.Errorf("CreateEndpoint: %w", err)
 	}
-
 	defer func(endpoint *api.DXEndpoint) {
 		_ = endpoint.Close()
 	}(endpoint)
 
-	err = endpoint.Connect(address)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
 
+	err = endpoint.Connect(address)
 	if err != nil {
-		return fmt.Errorf("Connect to %s: %we", address, err)
+		return fmt.Errorf("Connect to %s: %w", address, err)
 	}
-
 	feed, err := endpoint.GetFeed()
-
 	if err != nil {
-		return fmt.Errorf("GetFeed: %we", err)
+		return fmt.Errorf("GetFeed: %w", err)
 	}
 
-	subscription, err := feed.CreateSubscription(types...)
+	received := session.New()
+	received.SetMaxCount(exit.count)
+	if exit.untilSnapshot {
+		names := make([]string, len(symbols))
+		for i, symbol := range symbols {
+			names[i] = fmt.Sprint(symbol)
+		}
+		received.AwaitSnapshots(types, names)
+	}
+	listener := PrintEvents(func(eventsList []interface{}) {
+		eventsList = received.Add(eventsList)
+		if !isQuiet && len(eventsList) > 0 {
+			printEvents(eventsList)
+		}
+	})
 
-	defer subscription.Close()
-	if !isQuite {
-		err = subscription.AddListener(PrintEvents(func(eventsList []interface{}) {
-			for _, event := range eventsList {
-				switch v := event.(type) {
-				case events.StringConverter:
-					fmt.Printf("%s\n", v.String())
-				default:
-					fmt.Printf("Unsupported event %T!\n", v)
-				}
-			}
-		}))
+	for _, group := range subscriptionGroups(types, symbols, fromTime) {
+		subscription, err := feed.CreateSubscription(group.types...)
 		if err != nil {
-			return fmt.Errorf("AddListener: %we", err)
+			return fmt.Errorf("CreateSubscription: %w", err)
+		}
+		defer subscription.Close()
+		err = subscription.AddListener(listener)
+		if err != nil {
+			return fmt.Errorf("AddListener: %w", err)
+		}
+		for _, symbol := range group.symbols {
+			err = subscription.AddSymbol(symbol)
+			if err != nil {
+				return fmt.Errorf("AddSymbol: %w", err)
+			}
 		}
 	}
 
-	if err != nil {
-		return fmt.Errorf("CreateSubscription: %we", err)
+	var timeout <-chan time.Time
+	if exit.duration > 0 && !exit.duration.IsUnlimited() {
+		timer := time.NewTimer(exit.duration.Duration())
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-received.Done():
+	case <-timeout:
+	case <-signals:
+	}
+	received.Stop()
+	if printStats {
+		printSessionStats(os.Stderr, received)
+	}
+	return nil
+}
+
+type subscriptionGroup struct {
+	types   []eventcodes.EventCode
+	symbols []any
+}
+
+// subscriptionGroups returns the subscriptions of connect. With the time, time series are subscribed
+// from the time in their own subscription and other types are subscribed to the symbols as usual.
+func subscriptionGroups(types []eventcodes.EventCode, symbols []any, fromTime *int64) []subscriptionGroup {
+	if fromTime == nil {
+		return []subscriptionGroup{{types: types, symbols: symbols}}
+	}
+	var timeSeries, others subscriptionGroup
+	for _, eventType := range types {
+		if eventType.IsTimeSeries() {
+			timeSeries.types = append(timeSeries.types, eventType)
+		} else {
+			others.types = append(others.types, eventType)
+		}
 	}
 	for _, symbol := range symbols {
-		if fromTime != nil {
-			parseTime, err := parser.ParseTime(*fromTime)
-			if err != nil {
-				return err
-			}
-			timeSeriesSubscriptionSymbol := Osub.NewTimeSeriesSubscriptionSymbol(symbol, parseTime)
-			err = subscription.AddSymbol(timeSeriesSubscriptionSymbol)
+		others.symbols = append(others.symbols, symbol)
+		if _, ok := symbol.(*Osub.WildcardSymbol); ok {
+			timeSeries.symbols = append(timeSeries.symbols, symbol)
 		} else {
-			err = subscription.AddSymbol(symbol)
+			timeSeries.symbols = append(timeSeries.symbols, Osub.NewTimeSeriesSubscriptionSymbol(symbol, *fromTime))
 		}
-		if err != nil {
-			return fmt.Errorf("AddSymbol: %we", err)
+	}
+	var groups []subscriptionGroup
+	for _, group := range []subscriptionGroup{timeSeries, others} {
+		if len(group.types) > 0 {
+			groups = append(groups, group)
 		}
+	}
+	return groups
+}
 
+func hasWildcard(symbols []any) bool {
+	for _, symbol := range symbols {
+		if _, ok := symbol.(*Osub.WildcardSymbol); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func printSessionStats(w io.Writer, s *session.Session) {
+	elapsed, count, types := s.Stats()
+	seconds := elapsed.Seconds()
+	rate := 0.0
+	if seconds > 0 {
+		rate = float64(count) / seconds
+	}
+	fmt.Fprintf(w, "Received %d events in %.3fs (%.2f events/s)\n", count, seconds, rate)
+	if len(types) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%-15s %12s %14s\n", "Type", "Count", "Rate/s")
+	for _, t := range types {
+		fmt.Fprintf(w, "%-15s %12d %14.2f\n", t.Type, t.Count, t.Rate)
 	}
-	time.Sleep(time.Duration(math.MaxInt64))
-	return nil
 }
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/flags"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/eventio"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/eventio/csv"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+const (
+	textFormat = "text"
+	jsonFormat = "json"
+	csvFormat  = "csv"
+)
+
+// eventPrinter returns a function printing events to the standard output in the format of --format.
+// Text and JSON are printed one event per line.
+func eventPrinter(p printerFlags) (func(eventsList []interface{}), error) {
+	fields := *p.fields
+	switch strings.ToLower(*p.format) {
+	case textFormat:
+		if len(fields) == 0 {
+			return printText, nil
+		}
+		return printLines(func(event interface{}) ([]byte, error) { return formatTextFields(event, fields) }), nil
+	case jsonFormat:
+		return printLines(func(event interface{}) ([]byte, error) { return formatJSONFields(event, fields) }), nil
+	case csvFormat:
+		writer := csv.NewWriter(os.Stdout)
+		writer.SetColumns(fields...)
+		writer.SetTimeFormat(*p.timeFormat)
+		var mu sync.Mutex
+		return func(eventsList []interface{}) {
+			mu.Lock()
+			defer mu.Unlock()
+			if err := writer.WriteEvents(eventsList); err != nil {
+				fmt.Fprintf(os.Stderr, "CSV error %v\n", err)
+			}
+			if err := writer.Flush(); err != nil {
+				fmt.Fprintf(os.Stderr, "CSV error %v\n", err)
+			}
+		}, nil
+	default:
+		return nil, flags.Errorf("unknown format %s", *p.format)
+	}
+}
+
+func printText(eventsList []interface{}) {
+	for _, event := range eventsList {
+		switch v := event.(type) {
+		case events.StringConverter:
+			fmt.Printf("%s\n", v.String())
+		default:
+			fmt.Printf("Unsupported event %T!\n", v)
+		}
+	}
+}
+
+// printLines returns a function printing each event on its own line, the lines of a batch are written at once.
+func printLines(format func(event interface{}) ([]byte, error)) func(eventsList []interface{}) {
+	var mu sync.Mutex
+	return func(eventsList []interface{}) {
+		b := &bytes.Buffer{}
+		for _, event := range eventsList {
+			line, err := format(event)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Unsupported event %T: %v\n", event, err)
+				continue
+			}
+			b.Write(line)
+			b.WriteByte('\n')
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = os.Stdout.Write(b.Bytes())
+	}
+}
+
+// formatTextFields formats the fields of the event like Quote{eventSymbol=AAPL, bidPrice=100.5}.
+func formatTextFields(event interface{}, fields []string) ([]byte, error) {
+	eventType, values, err := eventio.Select(event, fields...)
+	if err != nil {
+		return nil, err
+	}
+	b := &bytes.Buffer{}
+	b.WriteString(eventType)
+	b.WriteString("{")
+	for i, v := range values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(v.Name)
+		b.WriteString("=")
+		var text string
+		if json.Unmarshal(v.JSON, &text) == nil {
+			b.WriteString(text)
+		} else {
+			b.Write(v.JSON)
+		}
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// formatJSONFields encodes the event to JSON, with fields only eventType and the fields are encoded.
+func formatJSONFields(event interface{}, fields []string) ([]byte, error) {
+	if len(fields) == 0 {
+		return json.Marshal(event)
+	}
+	eventType, values, err := eventio.Select(event, fields...)
+	if err != nil {
+		return nil, err
+	}
+	b := &bytes.Buffer{}
+	eventTypeValue, _ := json.Marshal(eventType)
+	b.WriteString(`{"` + eventio.EventTypeField + `":`)
+	b.Write(eventTypeValue)
+	for _, v := range values {
+		if v.Name == eventio.EventTypeField {
+			continue
+		}
+		name, _ := json.Marshal(v.Name)
+		b.WriteString(",")
+		b.Write(name)
+		b.WriteString(":")
+		b.Write(v.JSON)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
@@ -0,0 +1,310 @@
+// Package flags parses the command lines of tools: positional arguments and typed flags declared
+// in a FlagSet, which also generates the usage text.
+//
+// Short flags start with one dash (-p), long flags with two (--properties). A value follows the flag
+// as the next argument or after '=' (--interval=5s). Boolean flags take no value unless it is given
+// after '=' (--quiet=false). Flags may be mixed with positional arguments, and all arguments after "--"
+// are positional. Repeated flags accumulate values for lists and properties, other flags keep the last value.
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// ErrHelp is returned by Parse if -h or --help is given.
+var ErrHelp = errors.New("help requested")
+
+// UsageError is an error in the command line.
+type UsageError struct {
+	message string
+}
+
+func (e *UsageError) Error() string {
+	return e.message
+}
+
+// Errorf returns a *UsageError for the arguments that are invalid beyond the rules of flags, e.g. an unknown event type.
+func Errorf(format string, a ...any) error {
+	return &UsageError{message: fmt.Sprintf(format, a...)}
+}
+
+type flag struct {
+	short     string
+	long      string
+	valueName string
+	usage     string
+	set       func(value string) error
+	isSet     bool
+	required  bool
+}
+
+func (f *flag) isBool() bool {
+	return f.valueName == ""
+}
+
+type positional struct {
+	name     string
+	usage    string
+	optional bool
+	value    *string
+}
+
+// FlagSet declares the positional arguments and flags of a tool.
+type FlagSet struct {
+	name        string
+	description string
+	positionals []*positional
+	flags       []*flag
+	shortFlags  map[string]*flag
+	longFlags   map[string]*flag
+	samples     []string
+}
+
+// NewFlagSet creates a flag set of the tool with the name. The description is the first paragraph of the usage.
+func NewFlagSet(name string, description string) *FlagSet {
+	return &FlagSet{
+		name:        name,
+		description: description,
+		shortFlags:  map[string]*flag{},
+		longFlags:   map[string]*flag{},
+	}
+}
+
+// Positional declares a required positional argument. Required arguments must be declared before optional ones.
+func (f *FlagSet) Positional(name string, usage string) *string {
+	if len(f.positionals) > 0 && f.positionals[len(f.positionals)-1].optional {
+		panic(fmt.Sprintf("flags: required argument %s after an optional one", name))
+	}
+	return f.addPositional(name, usage, false, "")
+}
+
+// OptionalPositional declares an optional positional argument with the default value.
+func (f *FlagSet) OptionalPositional(name string, usage string, defaultValue string) *string {
+	return f.addPositional(name, usage, true, defaultValue)
+}
+
+func (f *FlagSet) addPositional(name string, usage string, optional bool, defaultValue string) *string {
+	value := defaultValue
+	f.positionals = append(f.positionals, &positional{name: name, usage: usage, optional: optional, value: &value})
+	return &value
+}
+
+// Func declares a flag with a value set by the function. An empty valueName declares a boolean flag,
+// the function receives "true" or "false" then. Either of the short and long names may be empty.
+func (f *FlagSet) Func(short string, long string, valueName string, usage string, set func(value string) error) {
+	fl := &flag{short: short, long: long, valueName: valueName, usage: usage, set: set}
+	f.flags = append(f.flags, fl)
+	f.register(short, f.shortFlags, fl)
+	f.register(long, f.longFlags, fl)
+}
+
+func (f *FlagSet) register(name string, names map[string]*flag, fl *flag) {
+	if name == "" {
+		return
+	}
+	if _, ok := names[name]; ok || name == "h" || name == "help" {
+		panic(fmt.Sprintf("flags: flag %s is reserved or declared twice", name))
+	}
+	names[name] = fl
+}
+
+// Alias declares a hidden long name of the flag with the long name, e.g. for a name that was misspelled before.
+func (f *FlagSet) Alias(alias string, long string) {
+	fl, ok := f.longFlags[long]
+	if !ok {
+		panic(fmt.Sprintf("flags: unknown flag %s", long))
+	}
+	f.register(alias, f.longFlags, fl)
+}
+
+func (f *FlagSet) Bool(short string, long string, usage string) *bool {
+	value := false
+	f.Func(short, long, "", usage, func(s string) error {
+		value = s == "true"
+		return nil
+	})
+	return &value
+}
+
+func (f *FlagSet) String(short string, long string, valueName string, defaultValue string, usage string) *string {
+	value := defaultValue
+	f.Func(short, long, valueName, usage, func(s string) error {
+		value = s
+		return nil
+	})
+	return &value
+}
+
+func (f *FlagSet) Int(short string, long string, valueName string, defaultValue int, usage string) *int {
+	value := defaultValue
+	f.Func(short, long, valueName, usage, func(s string) error {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return errors.New("not an integer")
+		}
+		value = v
+		return nil
+	})
+	return &value
+}
+
+func (f *FlagSet) Float(short string, long string, valueName string, defaultValue float64, usage string) *float64 {
+	value := defaultValue
+	f.Func(short, long, valueName, usage, func(s string) error {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return errors.New("not a number")
+		}
+		value = v
+		return nil
+	})
+	return &value
+}
+
+// Period declares a flag with a time period like 5s, 0.5s or PT1M.
+func (f *FlagSet) Period(short string, long string, valueName string, defaultValue timeutil.TimePeriod, usage string) *timeutil.TimePeriod {
+	value := defaultValue
+	f.Func(short, long, valueName, usage, func(s string) error {
+		period, err := timeutil.ParseTimePeriod(s)
+		if err != nil {
+			return err
+		}
+		value = period
+		return nil
+	})
+	return &value
+}
+
+// List declares a flag with a comma-separated list of values, repeated flags append their values.
+func (f *FlagSet) List(short string, long string, valueName string, usage string) *[]string {
+	var value []string
+	f.Func(short, long, valueName, usage, func(s string) error {
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				value = append(value, item)
+			}
+		}
+		return nil
+	})
+	return &value
+}
+
+// Properties declares a flag with a comma-separated list of key=value pairs, repeated flags add their pairs.
+func (f *FlagSet) Properties(short string, long string, usage string) map[string]string {
+	properties := map[string]string{}
+	f.Func(short, long, "<key=value,...>", usage, func(s string) error {
+		for _, property := range strings.Split(s, ",") {
+			key, value, ok := strings.Cut(property, "=")
+			if key = strings.TrimSpace(key); !ok || key == "" {
+				return fmt.Errorf("%q is not a key=value pair", property)
+			}
+			properties[key] = value
+		}
+		return nil
+	})
+	return properties
+}
+
+// Require makes Parse fail if any of the flags with the long names is not given.
+func (f *FlagSet) Require(names ...string) {
+	for _, name := range names {
+		fl, ok := f.longFlags[name]
+		if !ok {
+			panic(fmt.Sprintf("flags: unknown flag %s", name))
+		}
+		fl.required = true
+	}
+}
+
+// Sample adds a sample command line to the usage.
+func (f *FlagSet) Sample(sample string) {
+	f.samples = append(f.samples, sample)
+}
+
+// IsSet returns whether the flag with the short or long name was given.
+func (f *FlagSet) IsSet(name string) bool {
+	if fl, ok := f.longFlags[name]; ok {
+		return fl.isSet
+	}
+	if fl, ok := f.shortFlags[name]; ok {
+		return fl.isSet
+	}
+	return false
+}
+
+// Parse parses the arguments following the name of the tool. It returns ErrHelp if help is requested
+// and a *UsageError if the arguments are invalid.
+func (f *FlagSet) Parse(args []string) error {
+	var values []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			values = append(values, args[i+1:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			values = append(values, arg)
+			continue
+		}
+		names := f.shortFlags
+		name := arg[1:]
+		if strings.HasPrefix(arg, "--") {
+			names = f.longFlags
+			name = arg[2:]
+		}
+		name, value, hasValue := strings.Cut(name, "=")
+		fl, ok := names[name]
+		if !ok {
+			if name == "h" || name == "help" {
+				return ErrHelp
+			}
+			return Errorf("unknown flag %s", arg)
+		}
+		if fl.isBool() {
+			if !hasValue {
+				value = "true"
+			} else if b, err := strconv.ParseBool(value); err != nil {
+				return Errorf("flag %s should be true or false", arg)
+			} else {
+				value = strconv.FormatBool(b)
+			}
+		} else if !hasValue {
+			if i+1 >= len(args) {
+				return Errorf("flag %s requires a value %s", arg, fl.valueName)
+			}
+			i++
+			value = args[i]
+		}
+		if err := fl.set(value); err != nil {
+			return Errorf("invalid value %q of flag %s: %v", value, arg, err)
+		}
+		fl.isSet = true
+	}
+
+	for _, fl := range f.flags {
+		if fl.required && !fl.isSet {
+			return Errorf("missing flag --%s", fl.long)
+		}
+	}
+	required := 0
+	for _, p := range f.positionals {
+		if !p.optional {
+			required++
+		}
+	}
+	if len(values) < required {
+		return Errorf("missing argument <%s>", f.positionals[len(values)].name)
+	}
+	if len(values) > len(f.positionals) {
+		return Errorf("unexpected argument %q", values[len(f.positionals)])
+	}
+	for i, value := range values {
+		*f.positionals[i].value = value
+	}
+	return nil
+}
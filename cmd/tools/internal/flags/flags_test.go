@@ -0,0 +1,172 @@
+package flags
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+type testFlags struct {
+	set        *FlagSet
+	address    *string
+	symbols    *string
+	quiet      *bool
+	tape       *string
+	interval   *timeutil.TimePeriod
+	speed      *float64
+	columns    *[]string
+	properties map[string]string
+}
+
+func newTestFlags() *testFlags {
+	set := NewFlagSet("connect", "Connects to the address.")
+	f := &testFlags{
+		set:        set,
+		address:    set.Positional("address", "The address to connect to."),
+		symbols:    set.OptionalPositional("symbols", "Symbols,\nall by default.", "all"),
+		quiet:      set.Bool("q", "quiet", "Does not print events."),
+		tape:       set.String("t", "tape", "<file>", "", "Writes events to the file."),
+		interval:   set.Period("i", "interval", "<period>", 2*timeutil.SECOND, "Measurement interval."),
+		speed:      set.Float("", "speed", "<speed>", 1, "Replay speed."),
+		columns:    set.List("", "columns", "<columns>", "CSV columns."),
+		properties: set.Properties("p", "properties", "Properties."),
+	}
+	set.Alias("quite", "quiet")
+	set.Sample("demo.dxfeed.com:7300 AAPL")
+	return f
+}
+
+func TestParse(t *testing.T) {
+	f := newTestFlags()
+	err := f.set.Parse([]string{
+		"-t", "out.txt", "demo.dxfeed.com:7300", "--quiet", "--interval=5s",
+		"-p", "a=1,b=x=y", "--properties=c=", "--columns", "time,price", "--columns=size",
+		"AAPL", "--speed", "-1",
+	})
+	if err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	if *f.address != "demo.dxfeed.com:7300" || *f.symbols != "AAPL" {
+		t.Errorf(`Flag values should not be positional. But arguments are %v, %v`, *f.address, *f.symbols)
+	}
+	if *f.tape != "out.txt" || !*f.quiet || *f.interval != 5*timeutil.SECOND || *f.speed != -1 {
+		t.Errorf(`Flags should be parsed. But they are %v, %v, %v, %v`, *f.tape, *f.quiet, *f.interval, *f.speed)
+	}
+	if !reflect.DeepEqual(*f.columns, []string{"time", "price", "size"}) {
+		t.Errorf(`Repeated lists should be appended. But columns are %v`, *f.columns)
+	}
+	if !reflect.DeepEqual(f.properties, map[string]string{"a": "1", "b": "x=y", "c": ""}) {
+		t.Errorf(`Repeated properties should be merged. But properties are %v`, f.properties)
+	}
+	if !f.set.IsSet("tape") || !f.set.IsSet("q") || !f.set.IsSet("columns") || f.set.IsSet("unknown") {
+		t.Errorf(`IsSet should tell the given flags`)
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	f := newTestFlags()
+	if err := f.set.Parse([]string{"localhost:7300"}); err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	if *f.symbols != "all" || *f.quiet || *f.tape != "" || *f.interval != 2*timeutil.SECOND || *f.speed != 1 {
+		t.Errorf(`Defaults should be kept. But they are %v, %v, %v, %v`, *f.symbols, *f.quiet, *f.interval, *f.speed)
+	}
+	if f.set.IsSet("tape") || len(*f.columns) != 0 || len(f.properties) != 0 {
+		t.Errorf(`Flags should not be set`)
+	}
+}
+
+func TestParseBoolAndAliases(t *testing.T) {
+	f := newTestFlags()
+	if err := f.set.Parse([]string{"--quite", "a", "--", "-b"}); err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	if !*f.quiet || *f.symbols != "-b" {
+		t.Errorf(`Alias and -- should be supported. But quiet is %v and symbols are %v`, *f.quiet, *f.symbols)
+	}
+	f = newTestFlags()
+	if err := f.set.Parse([]string{"-q=false", "a"}); err != nil || *f.quiet {
+		t.Errorf(`Bool flag should be false. But it is %v (%v)`, *f.quiet, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, test := range []struct {
+		args    []string
+		message string
+	}{
+		{[]string{}, "missing argument <address>"},
+		{[]string{"a", "b", "c"}, `unexpected argument "c"`},
+		{[]string{"a", "--unknown"}, "unknown flag --unknown"},
+		{[]string{"a", "-quiet"}, "unknown flag -quiet"},
+		{[]string{"a", "--tape"}, "flag --tape requires a value <file>"},
+		{[]string{"a", "-i", "fast"}, `invalid value "fast" of flag -i`},
+		{[]string{"a", "--speed=x"}, `invalid value "x" of flag --speed=x: not a number`},
+		{[]string{"a", "-p", "novalue"}, `"novalue" is not a key=value pair`},
+		{[]string{"a", "--quiet=maybe"}, "flag --quiet=maybe should be true or false"},
+		{[]string{"a", "--speed=2"}, "missing flag --tape"},
+	} {
+		f := newTestFlags()
+		if strings.Contains(test.message, "missing flag") {
+			f.set.Require("tape")
+		}
+		err := f.set.Parse(test.args)
+		var usageError *UsageError
+		if !errors.As(err, &usageError) || !strings.Contains(err.Error(), test.message) {
+			t.Errorf(`Parse of %v should fail with %q. But it returns %v`, test.args, test.message, err)
+		}
+	}
+	for _, help := range []string{"-h", "--help"} {
+		if err := newTestFlags().set.Parse([]string{help}); err != ErrHelp {
+			t.Errorf(`Parse of %v should return ErrHelp. But it returns %v`, help, err)
+		}
+	}
+}
+
+func TestUsage(t *testing.T) {
+	expected := `Usage: tools connect <address> [<symbols>] [<options>]
+
+Connects to the address.
+
+Where:
+  address  The address to connect to.
+  symbols  Symbols,
+           all by default.
+
+Options:
+  -q, --quiet                       Does not print events.
+  -t, --tape <file>                 Writes events to the file.
+  -i, --interval <period>           Measurement interval.
+      --speed <speed>               Replay speed.
+      --columns <columns>           CSV columns.
+  -p, --properties <key=value,...>  Properties.
+  -h, --help                        Prints this help.
+
+Samples:
+  tools connect demo.dxfeed.com:7300 AAPL
+`
+	if actual := newTestFlags().set.Usage(); actual != expected {
+		t.Errorf("Usage should be\n%v\nBut it equals\n%v", expected, actual)
+	}
+}
+
+func TestDeclarationErrors(t *testing.T) {
+	for name, declare := range map[string]func(set *FlagSet){
+		"duplicate":      func(set *FlagSet) { set.Bool("q", "quick", "") },
+		"help":           func(set *FlagSet) { set.Bool("h", "", "") },
+		"unknown alias":  func(set *FlagSet) { set.Alias("x", "unknown") },
+		"required after": func(set *FlagSet) { set.Positional("types", "") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf(`Declaration should panic for %v`, name)
+				}
+			}()
+			declare(newTestFlags().set)
+		}()
+	}
+}
@@ -0,0 +1,103 @@
+package flags
+
+import (
+	"strings"
+)
+
+const (
+	indent = "  "
+
+	// maxLabelWidth limits the column of labels, longer labels are followed by the usage on the next line
+	maxLabelWidth = 32
+)
+
+// Usage returns the usage text of the tool generated from the declared arguments and flags.
+func (f *FlagSet) Usage() string {
+	b := &strings.Builder{}
+	b.WriteString("Usage: tools ")
+	b.WriteString(f.name)
+	for _, p := range f.positionals {
+		if p.optional {
+			b.WriteString(" [<" + p.name + ">]")
+		} else {
+			b.WriteString(" <" + p.name + ">")
+		}
+	}
+	b.WriteString(" [<options>]\n")
+	if f.description != "" {
+		b.WriteString("\n")
+		b.WriteString(f.description)
+		b.WriteString("\n")
+	}
+
+	if len(f.positionals) > 0 {
+		b.WriteString("\nWhere:\n")
+		var labels []string
+		var usages []string
+		for _, p := range f.positionals {
+			labels = append(labels, p.name)
+			usages = append(usages, p.usage)
+		}
+		writeColumns(b, labels, usages)
+	}
+
+	b.WriteString("\nOptions:\n")
+	var labels []string
+	var usages []string
+	for _, fl := range f.flags {
+		labels = append(labels, fl.label())
+		usages = append(usages, fl.usage)
+	}
+	labels = append(labels, "-h, --help")
+	usages = append(usages, "Prints this help.")
+	writeColumns(b, labels, usages)
+
+	if len(f.samples) > 0 {
+		b.WriteString("\nSamples:\n")
+		for _, sample := range f.samples {
+			b.WriteString(indent + "tools " + f.name + " " + sample + "\n")
+		}
+	}
+	return b.String()
+}
+
+func (f *flag) label() string {
+	var names []string
+	if f.short != "" {
+		names = append(names, "-"+f.short)
+	}
+	if f.long != "" {
+		names = append(names, "--"+f.long)
+	}
+	label := strings.Join(names, ", ")
+	if f.short == "" {
+		// long names are aligned with the long names of flags with short names
+		label = "    " + label
+	}
+	if !f.isBool() {
+		label += " " + f.valueName
+	}
+	return label
+}
+
+func writeColumns(b *strings.Builder, labels []string, usages []string) {
+	width := 0
+	for _, label := range labels {
+		if len(label) > width && len(label) <= maxLabelWidth {
+			width = len(label)
+		}
+	}
+	padding := strings.Repeat(" ", len(indent)+width+2)
+	for i, label := range labels {
+		b.WriteString(indent)
+		b.WriteString(label)
+		if len(label) > width {
+			b.WriteString("\n")
+			b.WriteString(padding)
+		} else {
+			b.WriteString(strings.Repeat(" ", width-len(label)+2))
+		}
+		b.WriteString(strings.ReplaceAll(usages[i], "\n", "\n"+padding))
+		b.WriteString("\n")
+	}
+}
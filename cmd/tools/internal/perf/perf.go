@@ -0,0 +1,129 @@
+// Package perf measures the performance of event listeners: rates of events and listener calls
+// together with CPU, memory, GC and goroutine stats of the process, sampled over intervals.
+package perf
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// Counter counts events and listener calls. It is safe for concurrent use.
+type Counter struct {
+	events atomic.Int64
+	calls  atomic.Int64
+}
+
+// Add counts a listener call with the number of events.
+func (c *Counter) Add(events int) {
+	c.events.Add(int64(events))
+	c.calls.Add(1)
+}
+
+// Interval is the stats of a sampling interval. Memory and goroutines are measured at its end.
+type Interval struct {
+	Time            time.Time `json:"time"`
+	Seconds         float64   `json:"seconds"`
+	Events          int64     `json:"events"`
+	Calls           int64     `json:"calls"`
+	EventsPerSecond float64   `json:"eventsPerSecond"`
+	CallsPerSecond  float64   `json:"callsPerSecond"`
+	EventsPerCall   float64   `json:"eventsPerCall"`
+	// CPUPercent is the CPU time of the process relative to the interval, it exceeds 100 when several cores are used.
+	// It and RSSBytes are zero if the platform doesn't support them.
+	CPUPercent     float64 `json:"cpuPercent"`
+	RSSBytes       uint64  `json:"rssBytes"`
+	HeapAllocBytes uint64  `json:"heapAllocBytes"`
+	HeapSysBytes   uint64  `json:"heapSysBytes"`
+	GCCycles       uint32  `json:"gcCycles"`
+	GCPauseSeconds float64 `json:"gcPauseSeconds"`
+	// GCMaxPauseSeconds is the longest pause of the interval, only the last 256 pauses are known.
+	GCMaxPauseSeconds float64 `json:"gcMaxPauseSeconds"`
+	Goroutines        int     `json:"goroutines"`
+}
+
+// Sampler measures intervals between its samples. It is not safe for concurrent use.
+type Sampler struct {
+	counter *Counter
+	time    time.Time
+	events  int64
+	calls   int64
+	cpuTime time.Duration
+	numGC   uint32
+	pause   uint64
+}
+
+// NewSampler starts the first interval.
+func NewSampler(counter *Counter) *Sampler {
+	s := &Sampler{counter: counter}
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	s.reset(time.Now(), &memStats, readProcessOrZero())
+	return s
+}
+
+// Sample ends the current interval, returns its stats and starts the next one.
+func (s *Sampler) Sample() Interval {
+	now := time.Now()
+	p := readProcessOrZero()
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	seconds := now.Sub(s.time).Seconds()
+	result := Interval{
+		Time:           now,
+		Seconds:        seconds,
+		Events:         s.counter.events.Load() - s.events,
+		Calls:          s.counter.calls.Load() - s.calls,
+		RSSBytes:       p.rss,
+		HeapAllocBytes: memStats.HeapAlloc,
+		HeapSysBytes:   memStats.HeapSys,
+		GCCycles:       memStats.NumGC - s.numGC,
+		GCPauseSeconds: time.Duration(memStats.PauseTotalNs - s.pause).Seconds(),
+		Goroutines:     runtime.NumGoroutine(),
+	}
+	if seconds > 0 {
+		result.EventsPerSecond = float64(result.Events) / seconds
+		result.CallsPerSecond = float64(result.Calls) / seconds
+		if p.cpuTime > 0 {
+			result.CPUPercent = (p.cpuTime - s.cpuTime).Seconds() / seconds * 100
+		}
+	}
+	if result.Calls > 0 {
+		result.EventsPerCall = float64(result.Events) / float64(result.Calls)
+	}
+	result.GCMaxPauseSeconds = maxPause(&memStats, s.numGC).Seconds()
+	s.reset(now, &memStats, p)
+	return result
+}
+
+func (s *Sampler) reset(now time.Time, memStats *runtime.MemStats, p process) {
+	s.time = now
+	s.events = s.counter.events.Load()
+	s.calls = s.counter.calls.Load()
+	s.cpuTime = p.cpuTime
+	s.numGC = memStats.NumGC
+	s.pause = memStats.PauseTotalNs
+}
+
+// maxPause returns the longest pause of GC cycles after the given number of cycles.
+func maxPause(memStats *runtime.MemStats, fromGC uint32) time.Duration {
+	var result uint64
+	for gc := memStats.NumGC; gc > fromGC && memStats.NumGC-gc < uint32(len(memStats.PauseNs)); gc-- {
+		// the pause of the cycle n is at (n+255)%256
+		pause := memStats.PauseNs[(gc+uint32(len(memStats.PauseNs))-1)%uint32(len(memStats.PauseNs))]
+		if pause > result {
+			result = pause
+		}
+	}
+	return time.Duration(result)
+}
+
+// readProcessOrZero returns zero usage on platforms that don't support it.
+func readProcessOrZero() process {
+	p, err := readProcess()
+	if err != nil {
+		return process{}
+	}
+	return p
+}
@@ -0,0 +1,60 @@
+package perf
+
+import (
+	"math"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSampler(t *testing.T) {
+	counter := &Counter{}
+	sampler := NewSampler(counter)
+	counter.Add(10)
+	counter.Add(30)
+	runtime.GC()
+	time.Sleep(10 * time.Millisecond)
+	i := sampler.Sample()
+	if i.Events != 40 || i.Calls != 2 || i.EventsPerCall != 20 {
+		t.Fatalf(`Interval should have 40 events in 2 calls. But it has %d events in %d calls`, i.Events, i.Calls)
+	}
+	if i.Seconds <= 0 || i.EventsPerSecond != float64(i.Events)/i.Seconds {
+		t.Fatalf(`EventsPerSecond should be %v. But it equals %v`, float64(i.Events)/i.Seconds, i.EventsPerSecond)
+	}
+	if i.GCCycles == 0 || i.GCMaxPauseSeconds <= 0 || i.GCMaxPauseSeconds > i.GCPauseSeconds {
+		t.Fatalf(`Interval should have GC cycles with pauses. But it has %d cycles, %v max pause of %v`,
+			i.GCCycles, i.GCMaxPauseSeconds, i.GCPauseSeconds)
+	}
+	if i.HeapAllocBytes == 0 || i.Goroutines == 0 {
+		t.Fatalf(`Heap and goroutines should be measured. But they equal %d and %d`, i.HeapAllocBytes, i.Goroutines)
+	}
+	if runtime.GOOS == "linux" && i.RSSBytes == 0 {
+		t.Fatalf(`RSS should be measured on Linux. But it equals 0`)
+	}
+
+	if next := sampler.Sample(); next.Events != 0 || next.Calls != 0 || next.EventsPerCall != 0 {
+		t.Fatalf(`Next interval should have no events. But it has %d events in %d calls`, next.Events, next.Calls)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize([]Interval{
+		{Seconds: 1, Events: 100, Calls: 10, EventsPerSecond: 100, CPUPercent: 50, GCCycles: 1, GCMaxPauseSeconds: 0.001},
+		{Seconds: 3, Events: 900, Calls: 30, EventsPerSecond: 300, CPUPercent: 10, GCCycles: 2, GCMaxPauseSeconds: 0.002},
+	})
+	if s.Intervals != 2 || s.Seconds != 4 || s.Events != 1000 || s.Calls != 40 || s.GCCycles != 3 || s.GCMaxPauseSeconds != 0.002 {
+		t.Fatalf(`Totals should be 2 intervals, 4s, 1000 events, 40 calls, 3 GC cycles, 0.002 max pause. But they equal %+v`, s)
+	}
+	if s.EventsPerSecond != (Stat{Min: 100, Avg: 250, Max: 300}) {
+		t.Fatalf(`EventsPerSecond should be {100 250 300}. But it equals %v`, s.EventsPerSecond)
+	}
+	if s.EventsPerCall.Avg != 25 {
+		t.Fatalf(`EventsPerCall.Avg should be 25. But it equals %v`, s.EventsPerCall.Avg)
+	}
+	if math.Abs(s.CPUPercent.Avg-20) > 1e-9 || s.CPUPercent.Min != 10 || s.CPUPercent.Max != 50 {
+		t.Fatalf(`CPUPercent should be {10 20 50}. But it equals %v`, s.CPUPercent)
+	}
+	if empty := Summarize(nil); empty.Intervals != 0 || empty.EventsPerSecond != (Stat{}) {
+		t.Fatalf(`Empty summary should be zero. But it equals %+v`, empty)
+	}
+}
@@ -0,0 +1,12 @@
+package perf
+
+import (
+	"time"
+)
+
+// process is the resource usage of the current process.
+type process struct {
+	cpuTime time.Duration
+	// rss is the resident set size in bytes, the peak one where the current one is not available
+	rss uint64
+}
@@ -0,0 +1,20 @@
+//go:build darwin
+
+package perf
+
+import (
+	"syscall"
+	"time"
+)
+
+func readProcess() (process, error) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return process{}, err
+	}
+	// the current RSS is not available without cgo, Maxrss is the peak one in bytes on macOS
+	return process{
+		cpuTime: time.Duration(usage.Utime.Nano() + usage.Stime.Nano()),
+		rss:     uint64(usage.Maxrss),
+	}, nil
+}
@@ -0,0 +1,36 @@
+//go:build linux
+
+package perf
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func readProcess() (process, error) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return process{}, err
+	}
+	// the second field of statm is the number of resident pages
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return process{}, err
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return process{}, fmt.Errorf("unexpected /proc/self/statm: %q", statm)
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return process{}, err
+	}
+	return process{
+		cpuTime: time.Duration(usage.Utime.Nano() + usage.Stime.Nano()),
+		rss:     pages * uint64(os.Getpagesize()),
+	}, nil
+}
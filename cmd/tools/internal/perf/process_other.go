@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !windows
+
+package perf
+
+import (
+	"errors"
+)
+
+func readProcess() (process, error) {
+	return process{}, errors.New("process stats are not supported on this platform")
+}
@@ -0,0 +1,50 @@
+//go:build windows
+
+package perf
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// processMemoryCounters is PROCESS_MEMORY_COUNTERS of psapi.h.
+type processMemoryCounters struct {
+	cb                         uint32
+	pageFaultCount             uint32
+	peakWorkingSetSize         uintptr
+	workingSetSize             uintptr
+	quotaPeakPagedPoolUsage    uintptr
+	quotaPagedPoolUsage        uintptr
+	quotaPeakNonPagedPoolUsage uintptr
+	quotaNonPagedPoolUsage     uintptr
+	pagefileUsage              uintptr
+	peakPagefileUsage          uintptr
+}
+
+var getProcessMemoryInfo = syscall.NewLazyDLL("kernel32.dll").NewProc("K32GetProcessMemoryInfo")
+
+func readProcess() (process, error) {
+	handle, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return process{}, err
+	}
+	var creation, exit, kernel, user syscall.Filetime
+	if err = syscall.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return process{}, err
+	}
+	counters := processMemoryCounters{}
+	counters.cb = uint32(unsafe.Sizeof(counters))
+	if ok, _, err := getProcessMemoryInfo.Call(uintptr(handle), uintptr(unsafe.Pointer(&counters)), uintptr(counters.cb)); ok == 0 {
+		return process{}, err
+	}
+	return process{
+		// Filetime counts 100-nanosecond intervals
+		cpuTime: time.Duration((filetimeTicks(kernel) + filetimeTicks(user)) * 100),
+		rss:     uint64(counters.workingSetSize),
+	}, nil
+}
+
+func filetimeTicks(t syscall.Filetime) int64 {
+	return int64(t.HighDateTime)<<32 | int64(t.LowDateTime)
+}
@@ -0,0 +1,78 @@
+package perf
+
+// Stat is the minimal, average and maximal values of a measure across intervals.
+type Stat struct {
+	Min float64 `json:"min"`
+	Avg float64 `json:"avg"`
+	Max float64 `json:"max"`
+}
+
+// Summary summarizes intervals. Average rates are weighted by durations of intervals.
+type Summary struct {
+	Intervals         int     `json:"intervals"`
+	Seconds           float64 `json:"seconds"`
+	Events            int64   `json:"events"`
+	Calls             int64   `json:"calls"`
+	GCCycles          uint32  `json:"gcCycles"`
+	GCPauseSeconds    float64 `json:"gcPauseSeconds"`
+	GCMaxPauseSeconds float64 `json:"gcMaxPauseSeconds"`
+	EventsPerSecond   Stat    `json:"eventsPerSecond"`
+	CallsPerSecond    Stat    `json:"callsPerSecond"`
+	EventsPerCall     Stat    `json:"eventsPerCall"`
+	CPUPercent        Stat    `json:"cpuPercent"`
+	RSSBytes          Stat    `json:"rssBytes"`
+	HeapAllocBytes    Stat    `json:"heapAllocBytes"`
+	Goroutines        Stat    `json:"goroutines"`
+}
+
+func Summarize(intervals []Interval) Summary {
+	s := Summary{Intervals: len(intervals)}
+	if len(intervals) == 0 {
+		return s
+	}
+	var cpuSeconds float64
+	for _, i := range intervals {
+		s.Seconds += i.Seconds
+		s.Events += i.Events
+		s.Calls += i.Calls
+		s.GCCycles += i.GCCycles
+		s.GCPauseSeconds += i.GCPauseSeconds
+		if i.GCMaxPauseSeconds > s.GCMaxPauseSeconds {
+			s.GCMaxPauseSeconds = i.GCMaxPauseSeconds
+		}
+		cpuSeconds += i.CPUPercent / 100 * i.Seconds
+	}
+	s.EventsPerSecond = statOf(intervals, func(i Interval) float64 { return i.EventsPerSecond })
+	s.CallsPerSecond = statOf(intervals, func(i Interval) float64 { return i.CallsPerSecond })
+	s.EventsPerCall = statOf(intervals, func(i Interval) float64 { return i.EventsPerCall })
+	s.CPUPercent = statOf(intervals, func(i Interval) float64 { return i.CPUPercent })
+	s.RSSBytes = statOf(intervals, func(i Interval) float64 { return float64(i.RSSBytes) })
+	s.HeapAllocBytes = statOf(intervals, func(i Interval) float64 { return float64(i.HeapAllocBytes) })
+	s.Goroutines = statOf(intervals, func(i Interval) float64 { return float64(i.Goroutines) })
+	if s.Seconds > 0 {
+		s.EventsPerSecond.Avg = float64(s.Events) / s.Seconds
+		s.CallsPerSecond.Avg = float64(s.Calls) / s.Seconds
+		s.CPUPercent.Avg = cpuSeconds / s.Seconds * 100
+	}
+	if s.Calls > 0 {
+		s.EventsPerCall.Avg = float64(s.Events) / float64(s.Calls)
+	}
+	return s
+}
+
+func statOf(intervals []Interval, value func(i Interval) float64) Stat {
+	s := Stat{Min: value(intervals[0]), Max: value(intervals[0])}
+	sum := 0.0
+	for _, i := range intervals {
+		v := value(i)
+		if v < s.Min {
+			s.Min = v
+		}
+		if v > s.Max {
+			s.Max = v
+		}
+		sum += v
+	}
+	s.Avg = sum / float64(len(intervals))
+	return s
+}
@@ -0,0 +1,176 @@
+// Package session counts the events received by a tool and tells when its exit conditions are met:
+// the number of received events or complete snapshots of all subscribed symbols.
+package session
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+)
+
+type snapshotKey struct {
+	eventType eventcodes.EventCode
+	symbol    string
+}
+
+// Session is safe for concurrent use.
+type Session struct {
+	mu       sync.Mutex
+	start    time.Time
+	end      time.Time
+	maxCount int64
+	count    int64
+	byType   map[eventcodes.EventCode]int64
+	pending  map[snapshotKey]struct{}
+	done     chan struct{}
+}
+
+// New starts the session at the current time.
+func New() *Session {
+	return &Session{start: time.Now(), byType: map[eventcodes.EventCode]int64{}, done: make(chan struct{})}
+}
+
+// SetMaxCount ends the session when the number of events is received, zero means no limit.
+func (s *Session) SetMaxCount(count int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxCount = count
+}
+
+// AwaitSnapshots ends the session when snapshots of all symbols of the types are received.
+// The snapshot of an indexed event type ends with an event having SnapshotEnd or SnapshotSnip flags,
+// the snapshot of other types is their first event.
+func (s *Session) AwaitSnapshots(types []eventcodes.EventCode, symbols []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = map[snapshotKey]struct{}{}
+	for _, eventType := range types {
+		for _, symbol := range symbols {
+			s.pending[snapshotKey{eventType: eventType, symbol: symbol}] = struct{}{}
+		}
+	}
+	if len(s.pending) == 0 {
+		s.stop()
+	}
+}
+
+// Add counts the events and returns the events to process: events received after the session is done
+// and events over the maximal count are dropped.
+func (s *Session) Add(eventsList []interface{}) []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isDone() {
+		return nil
+	}
+	if s.maxCount > 0 && s.count+int64(len(eventsList)) >= s.maxCount {
+		eventsList = eventsList[:s.maxCount-s.count]
+		defer s.stop()
+	}
+	for _, event := range eventsList {
+		s.count++
+		e, ok := event.(events.EventType)
+		if !ok {
+			continue
+		}
+		s.byType[e.Type()]++
+		if len(s.pending) > 0 && isSnapshotEnd(e) {
+			if symbol, ok := symbolOf(event); ok {
+				delete(s.pending, snapshotKey{eventType: e.Type(), symbol: symbol})
+				if len(s.pending) == 0 {
+					s.stop()
+				}
+			}
+		}
+	}
+	return eventsList
+}
+
+// Done is closed when the session ends.
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Stop ends the session.
+func (s *Session) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stop()
+}
+
+func (s *Session) stop() {
+	if !s.isDone() {
+		s.end = time.Now()
+		close(s.done)
+	}
+}
+
+func (s *Session) isDone() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// TypeStats are the number of events of a type and their rate per second.
+type TypeStats struct {
+	Type  eventcodes.EventCode
+	Count int64
+	Rate  float64
+}
+
+// Stats returns the duration of the session, the total count of events and the stats of event types
+// sorted by count in descending order.
+func (s *Session) Stats() (time.Duration, int64, []TypeStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	end := s.end
+	if !s.isDone() {
+		end = time.Now()
+	}
+	elapsed := end.Sub(s.start)
+	result := make([]TypeStats, 0, len(s.byType))
+	for eventType, count := range s.byType {
+		rate := 0.0
+		if elapsed > 0 {
+			rate = float64(count) / elapsed.Seconds()
+		}
+		result = append(result, TypeStats{Type: eventType, Count: count, Rate: rate})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Type < result[j].Type
+	})
+	return elapsed, s.count, result
+}
+
+func isSnapshotEnd(e events.EventType) bool {
+	if !e.Type().IsIndexed() {
+		return true
+	}
+	flagged, ok := e.(interface{ EventFlags() int32 })
+	return ok && flagged.EventFlags()&(events.SnapshotEnd|events.SnapshotSnip) != 0
+}
+
+func symbolOf(event interface{}) (string, bool) {
+	switch e := event.(type) {
+	case *candle.Candle:
+		if e.EventSymbol() == nil {
+			return "", false
+		}
+		return e.EventSymbol().String(), true
+	case interface{ EventSymbol() *string }:
+		if e.EventSymbol() == nil {
+			return "", false
+		}
+		return *e.EventSymbol(), true
+	}
+	return "", false
+}
@@ -0,0 +1,73 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+)
+
+func newTimeAndSale(symbol string, flags int32) *timeandsale.TimeAndSale {
+	t := timeandsale.NewTimeAndSale(symbol)
+	t.SetEventFlags(flags)
+	return t
+}
+
+func isDone(s *Session) bool {
+	select {
+	case <-s.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestMaxCount(t *testing.T) {
+	s := New()
+	s.SetMaxCount(3)
+	if v := s.Add([]interface{}{quote.NewQuote("AAPL"), quote.NewQuote("IBM")}); len(v) != 2 || isDone(s) {
+		t.Fatalf(`2 events should be added and the session should not be done. But %d are added`, len(v))
+	}
+	if v := s.Add([]interface{}{quote.NewQuote("AAPL"), quote.NewQuote("IBM")}); len(v) != 1 || !isDone(s) {
+		t.Fatalf(`1 event should be added and the session should be done. But %d are added`, len(v))
+	}
+	if v := s.Add([]interface{}{quote.NewQuote("AAPL")}); len(v) != 0 {
+		t.Fatalf(`Events should be dropped after the session is done. But %d are added`, len(v))
+	}
+	if _, count, types := s.Stats(); count != 3 || len(types) != 1 || types[0].Type != eventcodes.Quote || types[0].Count != 3 {
+		t.Fatalf(`Stats should count 3 quotes. But they equal %d %v`, count, types)
+	}
+}
+
+func TestAwaitSnapshots(t *testing.T) {
+	s := New()
+	s.AwaitSnapshots([]eventcodes.EventCode{eventcodes.Quote, eventcodes.TimeAndSale}, []string{"AAPL", "IBM"})
+	s.Add([]interface{}{
+		quote.NewQuote("AAPL"),
+		quote.NewQuote("IBM"),
+		newTimeAndSale("AAPL", events.SnapshotBegin),
+		newTimeAndSale("AAPL", 0),
+		newTimeAndSale("AAPL", events.SnapshotEnd),
+	})
+	if isDone(s) {
+		t.Fatalf(`The session should wait for the snapshot of IBM TimeAndSale. But it is done`)
+	}
+	s.Add([]interface{}{newTimeAndSale("IBM", events.SnapshotSnip|events.TxPending)})
+	if !isDone(s) {
+		t.Fatalf(`The session should be done after all snapshots. But it is not`)
+	}
+	if _, _, types := s.Stats(); types[0].Type != eventcodes.TimeAndSale || types[0].Count != 4 || types[1].Count != 2 {
+		t.Fatalf(`Stats should be 4 TimeAndSale and 2 Quote. But they equal %v`, types)
+	}
+}
+
+func TestStop(t *testing.T) {
+	s := New()
+	s.Stop()
+	s.Stop()
+	if !isDone(s) {
+		t.Fatalf(`The session should be done after Stop. But it is not`)
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/flags"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/ipf"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/ipf/profileio"
+)
+
+type Ipf struct{}
+
+func (c Ipf) ShortDescription() string {
+	return "Reads instrument profiles, filters them and converts them to IPF, CSV or JSON."
+}
+
+func (c Ipf) Run(args []string) error {
+	set := flags.NewFlagSet("ipf", `Reads instrument profiles, prints their counts by type and exchange
+and optionally writes the profiles matching the filter to a file.`)
+	source := set.Positional("source", `The URL or the local file of instrument profiles (e.g. https://demo.dxfeed.com/ipf, profiles.ipf.zip).
+Credentials are passed in the URL: https://<user>:<password>@tools.dxfeed.com/ipf`)
+	filter := set.List("", "filter", "<conditions>", `Comma-separated list of conditions FIELD=VALUE or FIELD!=VALUE, may be repeated.
+Fields are named like in IPF (e.g. TYPE, UNDERLYING, LAST_TRADE) ignoring the case,
+values may list alternatives separated by '|' and contain '*' (e.g. TYPE=OPTION|FUTURE).`)
+	fieldNames := set.List("", "fields", "<fields>", "Comma-separated list of fields to write, non-empty fields by default.")
+	out := set.String("o", "out", "<file>", "", `Writes the profiles to the file (.ipf, .csv or .json, optionally followed by .gz),
+or to the standard output if it is "-".`)
+	formatName := set.String("", "format", "<ipf|csv|json>", "", "Format of the output, by default it is chosen by the extension of the file.")
+	set.Sample("https://demo.dxfeed.com/ipf")
+	set.Sample("profiles.ipf.zip --filter TYPE=OPTION,underlying=AAPL --out aapl_options.csv")
+	set.Sample("profiles.ipf --filter TYPE=STOCK --fields SYMBOL,DESCRIPTION,EXCHANGES --out - --format json")
+	if err := parseFlags(set, args); err != nil {
+		return err
+	}
+
+	profileFilter, err := profileio.ParseFilter(*filter...)
+	if err != nil {
+		return flags.Errorf("%v", err)
+	}
+	var fields []profileio.Field
+	if set.IsSet("fields") {
+		if fields, err = profileio.ParseFields(*fieldNames); err != nil {
+			return flags.Errorf("%v", err)
+		}
+	}
+	format := profileio.FormatIPF
+	switch {
+	case *formatName != "":
+		format, err = profileio.ParseFormat(*formatName)
+	case *out != "" && *out != "-":
+		format, err = profileio.FormatOfPath(*out)
+	}
+	if err != nil {
+		return flags.Errorf("%v", err)
+	}
+	return convertProfiles(*source, profileFilter, fields, *out, format)
+}
+
+func convertProfiles(source string, filter *profileio.Filter, fields []profileio.Field, out string, format profileio.Format) error {
+	reader, err := ipf.NewInstrumentProfileReader()
+	if err != nil {
+		return fmt.Errorf("NewInstrumentProfileReader: %w", err)
+	}
+	defer func(reader *ipf.InstrumentProfileReader) {
+		_ = reader.Close()
+	}(reader)
+	profiles, err := reader.ReadFromFile(source)
+	if err != nil {
+		return fmt.Errorf("ReadFromFile %s: %w", source, err)
+	}
+	selected := filter.Apply(profiles)
+
+	// the counts don't mix with the profiles written to the standard output
+	summaryOutput := io.Writer(os.Stdout)
+	if out == "-" {
+		summaryOutput = os.Stderr
+	}
+	printProfileSummary(summaryOutput, len(profiles), profileio.Summarize(selected))
+
+	if out == "" {
+		return nil
+	}
+	return writeProfiles(out, format, fields, selected)
+}
+
+func printProfileSummary(w io.Writer, read int, s profileio.Summary) {
+	fmt.Fprintf(w, "Read %d profiles, selected %d\n", read, s.Total)
+	printCounts(w, "Type", s.Types)
+	printCounts(w, "Exchange", s.Exchanges)
+}
+
+func printCounts(w io.Writer, title string, counts []profileio.Count) {
+	if len(counts) == 0 {
+		return
+	}
+	width := len(title)
+	for _, c := range counts {
+		if len(c.Key) > width {
+			width = len(c.Key)
+		}
+	}
+	fmt.Fprintf(w, "\n%-*s %10s\n", width, title, "Count")
+	for _, c := range counts {
+		fmt.Fprintf(w, "%-*s %10d\n", width, c.Key, c.Count)
+	}
+}
+
+func writeProfiles(out string, format profileio.Format, fields []profileio.Field, profiles []*events.InstrumentProfile) (err error) {
+	output := io.WriteCloser(nopWriteCloser{os.Stdout})
+	if out != "-" {
+		file, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		output = file
+	}
+	defer func() {
+		if closeErr := output.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	w := io.Writer(output)
+	if strings.HasSuffix(strings.ToLower(out), ".gz") {
+		compressed := gzip.NewWriter(output)
+		defer func() {
+			if closeErr := compressed.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = compressed
+	}
+	writer := profileio.NewProfileWriter(format, w)
+	if fields != nil {
+		writer.SetFields(fields...)
+	}
+	if err = writer.Write(profiles); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
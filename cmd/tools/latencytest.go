@@ -2,73 +2,101 @@ package main
 
 import (
 	"fmt"
-	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api"
-	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
-	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
-	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
-	"github.com/dxfeed/dxfeed-graal-go-api/pkg/parser"
-	"github.com/montanaflynn/stats"
+	"io"
 	"math"
 	"os"
 	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
-	"unsafe"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/flags"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/latency"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/parser"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
 )
 
+// maxPrintedBreakdowns limits the symbols printed in the text report, the ones with the highest p99 are printed
+const maxPrintedBreakdowns = 10
+
 type LatencyTest struct{}
 
 func (c LatencyTest) ShortDescription() string {
 	return "Connects to the specified address(es) and calculates latency."
 }
 
-func (c LatencyTest) Run(args []string) {
-	dxarguments := DXArguments{args}
-	arguments := dxarguments.arguments()
-	if len(arguments) < 3 {
-		fmt.Println(`
-		Connects to the specified address(es) and calculates performance counters (events per second, cpu usage, etc).
-		
-		Usage:
-		path_to_app <address> <types> <symbols> [<options>]
-		
-		Where:
-		
-		address (pos. 0)  Required. The address(es) to connect to retrieve data (see "Help address").
-					For Token-Based Authorization, use the following format: "<address>:<port>[login=entitle:<token>]".
-		types (pos. 1)    Required. Comma-separated list of dxfeed event types (only TimeAndSale).
-		symbols (pos. 2)  Required. Comma-separated list of symbol names to get events for (e.g. "IBM, AAPL, MSFT").
-		--ignore-exchanges Ignoring next exchanges.
-		--force-stream    Enforces a streaming contract for subscription. The StreamFeed role is used instead of Feed.`)
-
-		os.Exit(0)
-	}
-	address := arguments[0]
-	types := parser.ParseEventTypes(arguments[1])
-	symbols := parser.ParseSymbols(arguments[2])
-	dxarguments.forceStream()
-	err := latency(address, types, symbols, dxarguments.forceStream(), dxarguments.ignoreExchanges())
+func (c LatencyTest) Run(args []string) error {
+	set := flags.NewFlagSet("latencytest", `Connects to the specified address(es) and calculates the latency of events,
+the difference between the time of receiving and the time of an event.`)
+	address := set.Positional("address", addressUsage)
+	types := set.Positional("types",
+		"Comma-separated list of dxfeed event types (Quote, Trade, TradeETH, TimeAndSale, Order, SpreadOrder, AnalyticOrder).")
+	symbols := set.Positional("symbols", symbolsUsage)
+	ignoreExchanges := set.List("", "ignore-exchanges", "<exchanges>", "Comma-separated list of ignored exchange codes.")
+	forceStream := forceStreamFlag(set)
+	interval := intervalFlag(set, defaultDiagInterval)
+	bySymbol := set.Bool("", "by-symbol", "Reports latencies by symbol.")
+	byExchange := set.Bool("", "by-exchange", "Reports latencies by exchange.")
+	reportFormat := set.String("", "format", "<text|json|csv>", textFormat,
+		"Format of reports, text by default. Latencies in JSON and CSV are in nanoseconds.")
+	output := set.String("", "output", "<file>", "", "Writes reports to the file instead of the standard output.")
+	set.Sample("demo.dxfeed.com:7300 TimeAndSale AAPL,IBM --by-exchange")
+	set.Sample("demo.dxfeed.com:7300 Quote,Trade all -i 10s --format csv --output latency.csv")
+	if err := parseFlags(set, args); err != nil {
+		return err
+	}
+
+	eventTypes, err := parseEventTypes(*types)
+	if err != nil {
+		return err
+	}
+	w := io.Writer(os.Stdout)
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	report, err := latencyReporter(*reportFormat, w)
 	if err != nil {
-		fmt.Printf("Error during dump: %v", err)
+		return err
 	}
+	return latencyTest(*address, eventTypes, parser.ParseSymbols(*symbols), *forceStream, *ignoreExchanges,
+		*interval, *bySymbol, *byExchange, report)
 }
 
-func latency(address string, types []eventcodes.EventCode, symbols []any, forceStream bool, ignoreExchanges *string) error {
+func latencyTest(
+	address string,
+	types []eventcodes.EventCode,
+	symbols []any,
+	forceStream bool,
+	ignoredExchanges []string,
+	interval timeutil.TimePeriod,
+	bySymbol bool,
+	byExchange bool,
+	report func(d *latencyDiag),
+) error {
 	role := api.Feed
 	if forceStream {
 		role = api.StreamFeed
 	}
-	var ignoredExchanges []string
-	if ignoreExchanges != nil {
-		ignoredExchanges = strings.Split(*ignoreExchanges, ",")
-	}
 
 	endpoint, err := api.CreateEndpoint(role)
 	if err != nil {
 		return fmt.Errorf("CreateEndpoint: %we", err)
 	}
 	err = endpoint.Connect(address)
+	if err != nil {
+		return fmt.Errorf("Connect to %s: %we", address, err)
+	}
 	feed, err := endpoint.GetFeed()
 	if err != nil {
 		return fmt.Errorf("GetFeed: %we", err)
@@ -77,34 +105,29 @@ func latency(address string, types []eventcodes.EventCode, symbols []any, forceS
 	if err != nil {
 		return fmt.Errorf("CreateSubscription: %we", err)
 	}
-	d := createLatency()
+	d := createLatency(bySymbol, byExchange)
 
 	err = sub.AddListener(PrintEvents(func(eventsList []interface{}) {
-		currentTime := time.Now().UnixMilli()
-		d.mu.Lock()
-		d.addListenerCounter(1)
-		d.addEventCounter(len(eventsList))
+		currentTime := time.Now().UnixNano()
+		d.addListenerCall(len(eventsList))
 		for _, event := range eventsList {
-			switch v := event.(type) {
-			case *timeandsale.TimeAndSale:
-				{
-					hash += uintptr(unsafe.Pointer(&v))
-					v.EventSymbol()
-					if len(ignoredExchanges) > 0 && slices.Contains(ignoredExchanges, formatutil.FormatChar(rune(v.ExchangeCode()))) {
-						continue
-					}
-					if v.IsNew() {
-						d.addSymbols(v.EventSymbol())
-						delta := float64(currentTime - v.Time())
-						d.addDeltas(delta)
-					}
-				}
-
-			default:
-				fmt.Printf("Unsupported event %T!\n", v)
+			symbol, exchange, timeNanos, ok := latencySample(event)
+			if !ok {
+				d.addUnsupported(event)
+				continue
+			}
+			if symbol == nil || timeNanos == 0 {
+				continue
 			}
+			exchangeCode := ""
+			if exchange != 0 {
+				exchangeCode = formatutil.FormatChar(exchange)
+			}
+			if len(ignoredExchanges) > 0 && slices.Contains(ignoredExchanges, exchangeCode) {
+				continue
+			}
+			d.addSample(*symbol, exchangeCode, currentTime-timeNanos)
 		}
-		d.mu.Unlock()
 	}))
 	if err != nil {
 		return fmt.Errorf("AddListener: %we", err)
@@ -117,102 +140,185 @@ func latency(address string, types []eventcodes.EventCode, symbols []any, forceS
 		}
 	}
 
-	go func() {
-		for {
-			interval := 2
-			time.Sleep(time.Duration(interval) * time.Second)
-			d.PrintDiag(interval)
-		}
-	}()
+	for {
+		time.Sleep(interval.Duration())
+		report(d)
+	}
+}
 
-	time.Sleep(time.Duration(math.MaxInt64))
-	fmt.Println(hash)
-	return nil
+// latencySample returns the symbol, exchange and time in nanoseconds of events latencies are measured for.
+// Only new TimeAndSale events are measured, corrections and cancels refer to past trades.
+func latencySample(event interface{}) (symbol *string, exchange rune, timeNanos int64, ok bool) {
+	switch e := event.(type) {
+	case *timeandsale.TimeAndSale:
+		if !e.IsNew() {
+			return nil, 0, 0, true
+		}
+		return e.EventSymbol(), rune(e.ExchangeCode()), e.TimeNanos(), true
+	case *quote.Quote:
+		exchange = e.BidExchangeCode()
+		if e.AskTime() > e.BidTime() {
+			exchange = e.AskExchangeCode()
+		}
+		return e.EventSymbol(), exchange, e.TimeNanos(), true
+	case interface {
+		EventSymbol() *string
+		ExchangeCode() int16
+		TimeNanos() int64
+	}:
+		// Trade and TradeETH
+		return e.EventSymbol(), rune(e.ExchangeCode()), e.TimeNanos(), true
+	case interface {
+		EventSymbol() *string
+		ExchangeCode() rune
+		TimeNanos() int64
+	}:
+		// Order, SpreadOrder and AnalyticOrder
+		return e.EventSymbol(), e.ExchangeCode(), e.TimeNanos(), true
+	}
+	return nil, 0, 0, false
 }
 
-func createLatency() *latencyDiag {
+func createLatency(bySymbol bool, byExchange bool) *latencyDiag {
 	return &latencyDiag{
-		deltas:    make([]float64, 0),
-		startTime: time.Now(),
-		symbols:   make(map[string]string),
-		minTotal:  math.MaxFloat64,
-		maxTotal:  math.SmallestNonzeroFloat64,
+		tracker:     latency.NewTracker(bySymbol, byExchange),
+		startTime:   time.Now(),
+		symbols:     make(map[string]struct{}),
+		unsupported: make(map[string]struct{}),
 	}
 }
 
 type latencyDiag struct {
+	tracker   *latency.Tracker
+	startTime time.Time
+
+	mu              sync.Mutex
 	listenerCounter int
 	eventCounter    int
-	startTime       time.Time
-	mu              sync.Mutex
-	minTotal        float64
-	maxTotal        float64
-	symbols         map[string]string
-	deltas          []float64
+	symbols         map[string]struct{}
+	unsupported     map[string]struct{}
 }
 
-func (d *latencyDiag) addListenerCounter(i int) {
-	d.listenerCounter += i
+func (d *latencyDiag) addListenerCall(events int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.listenerCounter++
+	d.eventCounter += events
 }
 
-func (d *latencyDiag) addEventCounter(i int) {
-	d.eventCounter += i
+func (d *latencyDiag) addSample(symbol string, exchange string, latency int64) {
+	d.mu.Lock()
+	d.symbols[symbol] = struct{}{}
+	d.mu.Unlock()
+	d.tracker.Record(symbol, exchange, latency)
 }
 
-func (d *latencyDiag) addSymbols(symbol *string) {
-	d.symbols[*symbol] = *symbol
+func (d *latencyDiag) addUnsupported(event interface{}) {
+	eventType := fmt.Sprintf("%T", event)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.unsupported[eventType]; !ok {
+		d.unsupported[eventType] = struct{}{}
+		fmt.Fprintf(os.Stderr, "Unsupported event %s!\n", eventType)
+	}
 }
 
-func (d *latencyDiag) addDeltas(delta float64) {
-	d.deltas = append(d.deltas, delta)
+// counters returns the counters of the interval and resets them.
+func (d *latencyDiag) counters() (listenerCalls int, events int, symbols int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	listenerCalls, events, symbols = d.listenerCounter, d.eventCounter, len(d.symbols)
+	d.listenerCounter = 0
+	d.eventCounter = 0
+	d.symbols = map[string]struct{}{}
+	return
 }
 
-func (d *latencyDiag) PrintDiag(interval int) {
-	d.mu.Lock()
+func latencyReporter(reportFormat string, w io.Writer) (func(d *latencyDiag), error) {
+	switch strings.ToLower(reportFormat) {
+	case textFormat:
+		return func(d *latencyDiag) {
+			d.PrintDiag(w)
+		}, nil
+	case jsonFormat:
+		writer := latency.NewJSONWriter(w)
+		return func(d *latencyDiag) {
+			d.counters()
+			if err := writer.Write(d.tracker.Report()); err != nil {
+				fmt.Fprintf(os.Stderr, "Write report: %v\n", err)
+			}
+		}, nil
+	case csvFormat:
+		writer := latency.NewCSVWriter(w)
+		return func(d *latencyDiag) {
+			d.counters()
+			if err := writer.Write(d.tracker.Report()); err != nil {
+				fmt.Fprintf(os.Stderr, "Write report: %v\n", err)
+			}
+		}, nil
+	}
+	return nil, flags.Errorf("unknown format %s", reportFormat)
+}
 
-	t := time.Now()
+func (d *latencyDiag) PrintDiag(w io.Writer) {
+	listenerCalls, events, symbols := d.counters()
+	report := d.tracker.Report()
+	seconds := report.Interval.Seconds()
+	eventPerSec := float64(events) / seconds
+	listenerCallsPerSec := float64(listenerCalls) / seconds
 
-	average, _ := stats.Mean(d.deltas)
-	minValue, _ := stats.Min(d.deltas)
-	maxValue, _ := stats.Max(d.deltas)
-	percentile, _ := stats.Percentile(d.deltas, 99)
-	stdDev, _ := stats.StdDevP(d.deltas)
-	stdErr := stdDev / math.Sqrt(float64(len(d.deltas)))
+	fmt.Fprintln(w, "----------------------------------------------")
+	fmt.Fprintf(w, "Rate of events (avg)           : %s (events/s)\n", format(eventPerSec))
+	fmt.Fprintf(w, "Rate of listener calls         : %s (calls/s)\n", format(listenerCallsPerSec))
+	fmt.Fprintf(w, "Number of events in call (avg) : %s (events)\n", format(eventPerSec/listenerCallsPerSec))
+	fmt.Fprintf(w, "Rate of unique symbols         : %d symbols/interval\n", symbols)
+	fmt.Fprintf(w, "Sample size (N)                : %d (events)\n", report.All.Interval.Count)
+	if negative := report.All.Interval.Negative; negative > 0 {
+		fmt.Fprintf(w, "Events from the future         : %d (events), check the clock synchronization\n", negative)
+	}
+	fmt.Fprintf(w, "Latency (ms)                   : %9s %9s %9s %9s %9s %9s %9s %9s\n",
+		"N", "min", "mean", "p50", "p90", "p99", "p99.9", "max")
+	printSummary(w, "  interval", report.All.Interval)
+	printSummary(w, "  total", report.All.Total)
+	printBreakdowns(w, "By symbol", report.Symbols)
+	printBreakdowns(w, "By exchange", report.Exchanges)
+	fmt.Fprintf(w, "Measurement interval           : %s\n", report.Interval.Round(time.Millisecond))
+	fmt.Fprintf(w, "Running time                   : %s \n", time.Since(d.startTime).Round(time.Millisecond))
+	fmt.Fprintf(w, "Timestamp                      : %s \n", report.Time.Format("20060102-150405.000000"))
+}
 
-	eventPerSec := float64(d.eventCounter) / 2.0
-	listenerCallsPerSec := float64(d.listenerCounter) / 2.0
-	if !math.IsNaN(minValue) {
-		d.minTotal = math.Min(d.minTotal, minValue)
+func printBreakdowns(w io.Writer, title string, windows map[string]latency.Window) {
+	if len(windows) == 0 {
+		return
 	}
-	if !math.IsNaN(maxValue) {
-		d.maxTotal = math.Max(d.maxTotal, maxValue)
+	keys := make([]string, 0, len(windows))
+	for key, window := range windows {
+		if window.Interval.Count > 0 {
+			keys = append(keys, key)
+		}
 	}
+	sort.Slice(keys, func(i, j int) bool {
+		return windows[keys[i]].Interval.P99 > windows[keys[j]].Interval.P99
+	})
+	if len(keys) > maxPrintedBreakdowns {
+		fmt.Fprintf(w, "%s (top %d of %d by p99):\n", title, maxPrintedBreakdowns, len(keys))
+		keys = keys[:maxPrintedBreakdowns]
+	} else {
+		fmt.Fprintf(w, "%s:\n", title)
+	}
+	for _, key := range keys {
+		printSummary(w, "  "+key, windows[key].Interval)
+	}
+}
 
-	fmt.Println("----------------------------------------------")
-	fmt.Printf("Rate of events (avg)           : %s (events/s)\n", format(eventPerSec))
-	fmt.Printf("Rate of listener calls         : %s (calls/s)\n", format(listenerCallsPerSec))
-	fmt.Printf("Number of events in call (avg) : %s (events)\n", format(eventPerSec/listenerCallsPerSec))
-
-	fmt.Printf("Rate of unique symbols         : %d symbols/interval\n", len(d.symbols))
-	fmt.Printf("Min current                    : %s (ms)\n", format(minValue))
-	fmt.Printf("Max current                    : %s (ms)\n", format(maxValue))
-	fmt.Printf("Min total                      : %s (ms)\n", format(d.minTotal))
-	fmt.Printf("Max total                      : %s (ms)\n", format(d.maxTotal))
-	fmt.Printf("99th percentile                : %s (ms)\n", format(percentile))
-	fmt.Printf("Mean                           : %s (ms)\n", format(average))
-	fmt.Printf("StdDev                         : %s (ms)\n", format(stdDev))
-	fmt.Printf("Error                          : %s (ms)\n", format(stdErr))
-	fmt.Printf("Sample size (N)                : %d (events)\n", len(d.deltas))
-
-	fmt.Printf("Measurement interval           : %d (s)\n", interval)
-	fmt.Printf("Running time                   : %s \n", time.Since(d.startTime))
-	fmt.Printf("Timestamp                      : %s \n", t.Format("20060102-150405.000000"))
+func printSummary(w io.Writer, title string, s latency.Summary) {
+	fmt.Fprintf(w, "%-31s: %9d %9s %9s %9s %9s %9s %9s %9s\n", title, s.Count,
+		millis(float64(s.Min)), millis(s.Mean), millis(float64(s.P50)), millis(float64(s.P90)),
+		millis(float64(s.P99)), millis(float64(s.P999)), millis(float64(s.Max)))
+}
 
-	d.eventCounter = 0
-	d.listenerCounter = 0
-	d.symbols = map[string]string{}
-	d.deltas = []float64{}
-	d.mu.Unlock()
+func millis(nanos float64) string {
+	return fmt.Sprintf("%.3f", nanos/float64(time.Millisecond))
 }
 
 func format(value float64) string {
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/flags"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/batch"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/loopback"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// loopbackWarmupEvents are published before every measurement, they also make sure the subscription is in effect
+const loopbackWarmupEvents = 10_000
+
+type Loopback struct{}
+
+func (c Loopback) ShortDescription() string {
+	return "Publishes events to a local hub and measures latency, throughput and allocations."
+}
+
+func (c Loopback) Run(args []string) error {
+	set := flags.NewFlagSet("loopback", `Publishes synthetic events to a local hub endpoint and receives them on a subscription
+of the same endpoint, measuring the overhead of the library without a server. For every event type
+prints the end-to-end latency, the throughput and the allocations per event.
+Quotes are conflated by the hub, so some of them may be lost at high rates.`)
+	types := set.String("t", "types", "<types>", "Quote,TimeAndSale,Order",
+		"Comma-separated list of event types (Quote, TimeAndSale, Order).")
+	rate := set.Float("r", "rate", "<events/s>", 0, "Events published per second, as fast as possible by default.")
+	duration := set.Period("d", "duration", "<period>", 10*timeutil.SECOND, "Publishing period for every event type.")
+	batchSize := set.Int("b", "batch-size", "<n>", 100, "Number of events published by one call.")
+	pooled := set.Bool("", "pooled", "Receives events with a pooled listener, events are reused instead of allocated.")
+	batches := set.Bool("", "batches", "Receives events with a batch listener, as columns instead of events.")
+	symbolCount := set.Int("", "symbols", "<n>", 100, "Number of synthetic symbols events are published for.")
+	format := set.String("", "format", "<text|json>", textFormat,
+		"Output format, text by default; JSON is one object per event type, latencies are in nanoseconds.")
+	set.Sample("-d 1m")
+	set.Sample("-t Quote -r 100000 -d 30s")
+	set.Sample("--batch-size 1 --format json")
+	set.Sample("-t TimeAndSale --pooled")
+	set.Sample("-t Quote,Order --batches")
+	if err := parseFlags(set, args); err != nil {
+		return err
+	}
+
+	eventTypes, err := parseEventTypes(*types)
+	if err != nil {
+		return err
+	}
+	var report func(result loopback.Result)
+	switch strings.ToLower(*format) {
+	case textFormat:
+		report = func(result loopback.Result) { printLoopbackResult(os.Stdout, result) }
+	case jsonFormat:
+		encoder := json.NewEncoder(os.Stdout)
+		report = func(result loopback.Result) { _ = encoder.Encode(result) }
+	default:
+		return flags.Errorf("unknown format %s", *format)
+	}
+	if *pooled && *batches {
+		return flags.Errorf("--pooled and --batches are mutually exclusive")
+	}
+	if *symbolCount <= 0 {
+		return flags.Errorf("number of symbols must be positive")
+	}
+
+	endpoint, err := api.NewEndpoint(api.LocalHub)
+	if err != nil {
+		return fmt.Errorf("NewEndpoint: %w", err)
+	}
+	defer func(endpoint *api.DXEndpoint) {
+		_ = endpoint.Close()
+	}(endpoint)
+	for _, eventType := range eventTypes {
+		result, err := runLoopback(endpoint, loopback.Config{
+			EventType: eventType,
+			Symbols:   loopback.Symbols(*symbolCount),
+			Rate:      *rate,
+			BatchSize: *batchSize,
+			Duration:  duration.Duration(),
+		}, *pooled, *batches)
+		if err != nil {
+			return err
+		}
+		report(result)
+	}
+	return nil
+}
+
+// runLoopback subscribes to the event type on the endpoint, publishes warm-up events and then measures.
+func runLoopback(endpoint *api.DXEndpoint, config loopback.Config, pooled bool, batches bool) (loopback.Result, error) {
+	publisher, err := endpoint.GetPublisher()
+	if err != nil {
+		return loopback.Result{}, fmt.Errorf("GetPublisher: %w", err)
+	}
+	feed, err := endpoint.GetFeed()
+	if err != nil {
+		return loopback.Result{}, fmt.Errorf("GetFeed: %w", err)
+	}
+	sub, err := feed.CreateSubscription(config.EventType)
+	if err != nil {
+		return loopback.Result{}, fmt.Errorf("CreateSubscription: %w", err)
+	}
+	defer sub.Close()
+
+	listener := &loopbackListener{}
+	switch {
+	case pooled:
+		err = sub.AddPooledListener(listener)
+	case batches:
+		err = sub.AddBatchListener(listener)
+	default:
+		err = sub.AddListener(listener)
+	}
+	if err != nil {
+		return loopback.Result{}, fmt.Errorf("AddListener: %w", err)
+	}
+	for _, symbol := range config.Symbols {
+		err = sub.AddSymbol(symbol)
+		if err != nil {
+			return loopback.Result{}, fmt.Errorf("AddSymbol: %w", err)
+		}
+	}
+
+	measure := func(config loopback.Config) (loopback.Result, error) {
+		run, err := loopback.NewRun(config, publisher)
+		if err != nil {
+			return loopback.Result{}, flags.Errorf("%v", err)
+		}
+		listener.current.Store(run)
+		return run.Execute()
+	}
+	warmup := config
+	warmup.Rate, warmup.Duration, warmup.Count = 0, 0, loopbackWarmupEvents
+	if _, err := measure(warmup); err != nil {
+		return loopback.Result{}, err
+	}
+	return measure(config)
+}
+
+// loopbackListener passes received events to the current run, the warm-up or the measurement.
+type loopbackListener struct {
+	current atomic.Pointer[loopback.Run]
+}
+
+func (l *loopbackListener) Update(events []interface{}) {
+	l.current.Load().Receive(events)
+}
+
+func (l *loopbackListener) UpdateBatch(b batch.Batch) {
+	l.current.Load().ReceiveBatch(b)
+}
+
+func printLoopbackResult(w io.Writer, result loopback.Result) {
+	fmt.Fprintln(w, "----------------------------------------------")
+	fmt.Fprintf(w, "Event type                     : %s\n", result.EventType)
+	fmt.Fprintf(w, "Published / received           : %d / %d (lost %d)\n", result.Published, result.Received, result.Lost())
+	fmt.Fprintf(w, "Throughput                     : %.2f (events/s) in %.2f s\n", result.Throughput, result.Seconds)
+	fmt.Fprintf(w, "Allocations per event          : %.2f (%.0f bytes)\n", result.AllocsPerEvent, result.BytesPerEvent)
+	fmt.Fprintf(w, "Latency (ms)                   : %9s %9s %9s %9s %9s %9s %9s %9s\n",
+		"N", "min", "mean", "p50", "p90", "p99", "p99.9", "max")
+	printSummary(w, "  total", result.Latency)
+}
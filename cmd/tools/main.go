@@ -10,5 +10,5 @@ func main() {
 	api.SetSystemProperty("dxfeed.experimental.dxlink.enable", "true")
 	// Set scheme for dxLink.
 	api.SetSystemProperty("scheme", "ext:opt:sysprops,resource:dxlink.xml")
-	Run(os.Args)
+	os.Exit(Run(os.Args))
 }
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/flags"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/parser"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+type OnDemand struct{}
+
+func (c OnDemand) ShortDescription() string {
+	return "Replays market data from the specified moment in the past."
+}
+
+func (c OnDemand) Run(args []string) error {
+	set := flags.NewFlagSet("ondemand", `Replays market data from the specified moment in the past.
+
+While replaying, the following commands are read from the standard input:
+  pause          Pauses the replay.
+  speed <speed>  Changes the replay speed.
+  replay <time>  Restarts the replay from the time.
+  resume         Stops the replay and resumes real-time data.
+  clear          Stops the replay and clears the data.
+  time           Prints the current replay time.
+  quit           Exits.`)
+	address := set.Positional("address", `The address of the on-demand data provider (e.g. ondemand:demo.dxfeed.com:7680).
+Credentials are passed with properties: -p dxfeed.user=<user>,dxfeed.password=<password>`)
+	types := set.Positional("types", typesUsage)
+	symbols := set.Positional("symbols", symbolsUsage)
+	fromTime := fromTimeFlag(set, "Time to start the replay from (e.g. 20240102-153000-0500, 2024-01-02T15:30:00Z).")
+	speed := set.Float("", "speed", "<speed>", 1, "Replay speed, 1 by default; 0 starts the replay paused.")
+	quiet := quietFlag(set)
+	printer := newPrinterFlags(set)
+	properties := propertiesFlag(set)
+	set.Require("from-time")
+	set.Sample("ondemand:demo.dxfeed.com:7680 Quote,Trade AAPL -f 2024-01-02T15:30:00Z --speed 10 -p dxfeed.user=demo,dxfeed.password=demo")
+	if err := parseFlags(set, args); err != nil {
+		return err
+	}
+	if *speed < 0 {
+		return flags.Errorf("the speed should not be negative")
+	}
+
+	eventTypes, err := parseEventTypes(*types)
+	if err != nil {
+		return err
+	}
+	printEvents, err := eventPrinter(printer)
+	if err != nil {
+		return err
+	}
+	return onDemand(*address, eventTypes, parser.ParseSymbols(*symbols), properties, *quiet, *fromTime, *speed, printEvents)
+}
+
+func onDemand(
+	address string,
+	types []eventcodes.EventCode,
+	symbols []any,
+	properties map[string]string,
+	isQuiet bool,
+	fromTime string,
+	speed float64,
+	printEvents func(eventsList []interface{}),
+) error {
+	for key, value := range properties {
+		api.SetSystemProperty(key, value)
+	}
+	replayTime, err := parser.ParseTime(fromTime)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := api.NewEndpointWithProperties(api.OnDemandFeed, properties)
+	if err != nil {
+		return fmt.Errorf("CreateEndpoint: %we", err)
+	}
+	defer func(endpoint *api.DXEndpoint) {
+		_ = endpoint.Close()
+	}(endpoint)
+
+	service, err := api.GetOnDemandService(endpoint)
+	if err != nil {
+		return fmt.Errorf("GetOnDemandService: %we", err)
+	}
+
+	feed, err := endpoint.GetFeed()
+	if err != nil {
+		return fmt.Errorf("GetFeed: %we", err)
+	}
+	subscription, err := feed.CreateSubscription(types...)
+	if err != nil {
+		return fmt.Errorf("CreateSubscription: %we", err)
+	}
+	defer subscription.Close()
+	if !isQuiet {
+		err = subscription.AddListener(PrintEvents(printEvents))
+		if err != nil {
+			return fmt.Errorf("AddListener: %we", err)
+		}
+	}
+	for _, symbol := range symbols {
+		err = subscription.AddSymbol(symbol)
+		if err != nil {
+			return fmt.Errorf("AddSymbol: %we", err)
+		}
+	}
+
+	err = endpoint.Connect(address)
+	if err != nil {
+		return fmt.Errorf("Connect to %s: %we", address, err)
+	}
+	err = service.ReplayWithSpeed(replayTime, speed)
+	if err != nil {
+		return fmt.Errorf("Replay: %we", err)
+	}
+	return controlReplay(service)
+}
+
+// controlReplay executes the commands read from the standard input until it is closed or quit is read.
+func controlReplay(service *api.OnDemandService) error {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var err error
+		switch strings.ToLower(fields[0]) {
+		case "pause":
+			err = service.Pause()
+		case "speed":
+			var speed float64
+			if len(fields) < 2 {
+				err = fmt.Errorf("speed is not specified")
+			} else if speed, err = strconv.ParseFloat(fields[1], 64); err == nil {
+				err = service.SetSpeed(speed)
+			}
+		case "replay":
+			var replayTime int64
+			if len(fields) < 2 {
+				err = fmt.Errorf("time is not specified")
+			} else if replayTime, err = parser.ParseTime(fields[1]); err == nil {
+				err = service.Replay(replayTime)
+			}
+		case "resume":
+			err = service.StopAndResume()
+		case "clear":
+			err = service.StopAndClear()
+		case "time":
+			var replayTime int64
+			if replayTime, err = service.GetTime(); err == nil {
+				fmt.Println(timeutil.DefaultTimeFormat.WithMillis().Format(replayTime))
+			}
+		case "quit", "exit":
+			return nil
+		default:
+			err = fmt.Errorf("unknown command %s", fields[0])
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", fields[0], err)
+		}
+	}
+	return scanner.Err()
+}
@@ -1,112 +1,156 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/flags"
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/perf"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
-	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
-	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/parser"
-	"math"
-	"os"
-	"sync"
-	"time"
-	"unsafe"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
 )
 
 type PerfTest struct{}
 
-var hash uintptr
+const (
+	defaultDiagInterval timeutil.TimePeriod = 2 * timeutil.SECOND
+
+	megabyte = 1024 * 1024
+)
 
 func (c PerfTest) ShortDescription() string {
 	return "Connects to specified address and calculates performance counters."
 }
 
-func (c PerfTest) Run(args []string) {
-	dxarguments := DXArguments{args}
-	arguments := dxarguments.arguments()
-	if len(arguments) < 3 {
-		fmt.Println(`
-		Connects to the specified address(es) and calculates performance counters (events per second, cpu usage, etc).
-		
-		Usage:
-		path_to_app <address> <types> <symbols> [<options>]
-		
-		Where:
-		
-		address (pos. 0)  Required. The address(es) to connect to retrieve data (see "Help address").
-					For Token-Based Authorization, use the following format: "<address>:<port>[login=entitle:<token>]".
-		types (pos. 1)    Required. Comma-separated list of dxfeed event types (e.g. Quote, TimeAndSale).
-		symbols (pos. 2)  Required. Comma-separated list of symbol names to get events for (e.g. "IBM, AAPL, MSFT").
-		--force-stream    Enforces a streaming contract for subscription. The StreamFeed role is used instead of Feed.`)
-		os.Exit(0)
+func (c PerfTest) Run(args []string) error {
+	set := flags.NewFlagSet("perftest", `Connects to the specified address(es) and calculates performance counters: rates of events
+and listener calls, CPU usage, memory, GC pauses and goroutines. They are printed for every interval
+after the warm-up, and a summary with min/avg/max across intervals is printed on exit.`)
+	address := set.Positional("address", addressUsage)
+	types := set.Positional("types", typesUsage)
+	symbols := set.Positional("symbols", symbolsUsage)
+	forceStream := forceStreamFlag(set)
+	interval := intervalFlag(set, defaultDiagInterval)
+	warmup := set.Period("w", "warmup", "<period>", 0, "Period before the measurement starts (e.g. 10s), events are received but not measured.")
+	duration := set.Period("d", "duration", "<period>", 0, "Period of the measurement after the warm-up, until interrupted by default.")
+	format := set.String("", "format", "<text|json>", textFormat, "Output format, text by default; JSON is one object per line.")
+	set.Sample("demo.dxfeed.com:7300 TimeAndSale all")
+	set.Sample("demo.dxfeed.com:7300 Quote,TimeAndSale all -i 5s --warmup 10s --duration 1m --format json")
+	if err := parseFlags(set, args); err != nil {
+		return err
 	}
-	address := arguments[0]
-	types := parser.ParseEventTypes(arguments[1])
-	symbols := parser.ParseSymbols(arguments[2])
 
-	err := perf(address, types, symbols, dxarguments.forceStream())
+	eventTypes, err := parseEventTypes(*types)
 	if err != nil {
-		fmt.Printf("Error during dump: %v", err)
+		return err
 	}
+	var report perfReporter
+	switch strings.ToLower(*format) {
+	case textFormat:
+		report = textPerfReporter{os.Stdout}
+	case jsonFormat:
+		report = jsonPerfReporter{json.NewEncoder(os.Stdout)}
+	default:
+		return flags.Errorf("unknown format %s", *format)
+	}
+	return perfTest(*address, eventTypes, parser.ParseSymbols(*symbols), *forceStream, *interval, *warmup, *duration, report)
 }
 
-func perf(address string, types []eventcodes.EventCode, symbols []any, forceStream bool) error {
+func perfTest(
+	address string,
+	types []eventcodes.EventCode,
+	symbols []any,
+	forceStream bool,
+	interval timeutil.TimePeriod,
+	warmup timeutil.TimePeriod,
+	duration timeutil.TimePeriod,
+	report perfReporter,
+) error {
 	role := api.Feed
 	if forceStream {
 		role = api.StreamFeed
 	}
 	endpoint, err := api.CreateEndpoint(role)
 	if err != nil {
-		return fmt.Errorf("CreateEndpoint: %we", err)
+		return fmt.Errorf("CreateEndpoint: %w", err)
 	}
+	defer func(endpoint *api.DXEndpoint) {
+		_ = endpoint.Close()
+	}(endpoint)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
 	err = endpoint.Connect(address)
+	if err != nil {
+		return fmt.Errorf("Connect to %s: %w", address, err)
+	}
 	feed, err := endpoint.GetFeed()
 	if err != nil {
-		return fmt.Errorf("GetFeed: %we", err)
+		return fmt.Errorf("GetFeed: %w", err)
 	}
 	sub, err := feed.CreateSubscription(types...)
 	if err != nil {
-		return fmt.Errorf("CreateSubscription: %we", err)
+		return fmt.Errorf("CreateSubscription: %w", err)
 	}
-	d := &diag{}
+	defer sub.Close()
 
+	counter := &perf.Counter{}
 	err = sub.AddListener(PrintEvents(func(eventsList []interface{}) {
-		d.mu.Lock()
-		d.addListenerCounter(1)
-		d.addEventCounter(len(eventsList))
-		for _, event := range eventsList {
-			switch v := event.(type) {
-			case *quote.Quote:
-				fmt.Printf("%s\n", v.String())
-			case *timeandsale.TimeAndSale:
-				hash += uintptr(unsafe.Pointer(&v))
-			default:
-				fmt.Printf("Unsupported event %T!\n", v)
-			}
-		}
-		d.mu.Unlock()
+		counter.Add(len(eventsList))
 	}))
 	if err != nil {
-		return fmt.Errorf("AddListener: %we", err)
+		return fmt.Errorf("AddListener: %w", err)
 	}
 	for _, symbol := range symbols {
 		err = sub.AddSymbol(symbol)
 		if err != nil {
-			return fmt.Errorf("AddSymbol: %we", err)
-
+			return fmt.Errorf("AddSymbol: %w", err)
 		}
 	}
 
-	go func() {
-		for {
-			time.Sleep(2 * time.Second)
-			d.PrintDiag()
+	if warmup > 0 {
+		select {
+		case <-time.After(warmup.Duration()):
+		case <-signals:
+			return nil
 		}
-	}()
-
-	time.Sleep(time.Duration(math.MaxInt64))
-	fmt.Println(hash)
+	}
+	var deadline <-chan time.Time
+	if duration > 0 && !duration.IsUnlimited() {
+		timer := time.NewTimer(duration.Duration())
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(interval.Duration())
+	defer ticker.Stop()
+
+	// the interval in progress on exit is not reported, it would skew min and max
+	sampler := perf.NewSampler(counter)
+	var intervals []perf.Interval
+measure:
+	for {
+		select {
+		case <-ticker.C:
+			i := sampler.Sample()
+			intervals = append(intervals, i)
+			report.Interval(i)
+		case <-deadline:
+			break measure
+		case <-signals:
+			break measure
+		}
+	}
+	report.Summary(perf.Summarize(intervals))
 	return nil
 }
 
@@ -116,29 +160,58 @@ func (pr PrintEvents) Update(events []any) {
 	pr(events)
 }
 
-type diag struct {
-	listenerCounter int
-	eventCounter    int
-	mu              sync.Mutex
+type perfReporter interface {
+	Interval(i perf.Interval)
+	Summary(s perf.Summary)
+}
+
+type textPerfReporter struct {
+	w io.Writer
+}
+
+func (r textPerfReporter) Interval(i perf.Interval) {
+	fmt.Fprintln(r.w, "----------------------------------------------")
+	fmt.Fprintf(r.w, "Rate of events (avg)           : %.2f (events/s)\n", i.EventsPerSecond)
+	fmt.Fprintf(r.w, "Rate of listener calls         : %.2f (calls/s)\n", i.CallsPerSecond)
+	fmt.Fprintf(r.w, "Number of events in call (avg) : %.2f (events)\n", i.EventsPerCall)
+	fmt.Fprintf(r.w, "CPU usage                      : %.2f (%%)\n", i.CPUPercent)
+	fmt.Fprintf(r.w, "Memory usage (RSS)             : %.2f (MB)\n", float64(i.RSSBytes)/megabyte)
+	fmt.Fprintf(r.w, "Go heap (alloc / sys)          : %.2f / %.2f (MB)\n",
+		float64(i.HeapAllocBytes)/megabyte, float64(i.HeapSysBytes)/megabyte)
+	fmt.Fprintf(r.w, "GC cycles                      : %d (pauses %.3f ms, max %.3f ms)\n",
+		i.GCCycles, i.GCPauseSeconds*1000, i.GCMaxPauseSeconds*1000)
+	fmt.Fprintf(r.w, "Goroutines                     : %d\n", i.Goroutines)
+}
+
+func (r textPerfReporter) Summary(s perf.Summary) {
+	fmt.Fprintln(r.w, "==============================================")
+	fmt.Fprintf(r.w, "Summary of %d intervals: %d events in %.2f s\n", s.Intervals, s.Events, s.Seconds)
+	if s.Intervals == 0 {
+		return
+	}
+	fmt.Fprintf(r.w, "%-28s %14s %14s %14s\n", "", "Min", "Avg", "Max")
+	row := func(name string, stat perf.Stat, scale float64) {
+		fmt.Fprintf(r.w, "%-28s %14.2f %14.2f %14.2f\n", name, stat.Min/scale, stat.Avg/scale, stat.Max/scale)
+	}
+	row("Rate of events (events/s)", s.EventsPerSecond, 1)
+	row("Rate of listener calls", s.CallsPerSecond, 1)
+	row("Events in call", s.EventsPerCall, 1)
+	row("CPU usage (%)", s.CPUPercent, 1)
+	row("Memory usage, RSS (MB)", s.RSSBytes, megabyte)
+	row("Go heap alloc (MB)", s.HeapAllocBytes, megabyte)
+	row("Goroutines", s.Goroutines, 1)
+	fmt.Fprintf(r.w, "GC cycles: %d, pauses %.3f ms, max %.3f ms\n", s.GCCycles, s.GCPauseSeconds*1000, s.GCMaxPauseSeconds*1000)
 }
 
-func (d *diag) addListenerCounter(i int) {
-	d.listenerCounter += i
+// jsonPerfReporter writes {"interval":{...}} for every interval and {"summary":{...}} on exit.
+type jsonPerfReporter struct {
+	encoder *json.Encoder
 }
 
-func (d *diag) addEventCounter(i int) {
-	d.eventCounter += i
+func (r jsonPerfReporter) Interval(i perf.Interval) {
+	_ = r.encoder.Encode(map[string]perf.Interval{"interval": i})
 }
 
-func (d *diag) PrintDiag() {
-	d.mu.Lock()
-	eventPerSec := float64(d.eventCounter) / 2.0
-	listenerCallsPerSec := float64(d.listenerCounter) / 2.0
-	fmt.Println("----------------------------------------------")
-	fmt.Printf("Rate of events (avg)           : %f (events/s)\n", eventPerSec)
-	fmt.Printf("Rate of listener calls         : %f (calls/s)\n", listenerCallsPerSec)
-	fmt.Printf("Number of events in call (avg) : %f (events)\n", eventPerSec/listenerCallsPerSec)
-	d.eventCounter = 0
-	d.listenerCounter = 0
-	d.mu.Unlock()
+func (r jsonPerfReporter) Summary(s perf.Summary) {
+	_ = r.encoder.Encode(map[string]perf.Summary{"summary": s})
 }
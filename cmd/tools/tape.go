@@ -0,0 +1,525 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/flags"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api/Osub"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/common"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/eventio/csv"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/parser"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/replay"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+const (
+	csvExtension  = ".csv"
+	jsonExtension = ".json"
+
+	// tapeBatchSize is the number of events read from CSV and JSON files at once
+	tapeBatchSize = 1000
+)
+
+type Tape struct{}
+
+type tapeCommand struct {
+	description string
+	run         func(args []string) error
+}
+
+func tapeCommands() map[string]tapeCommand {
+	return map[string]tapeCommand{
+		"stats":   {"Prints event counts by type and symbol, the time range and gaps of a tape.", tapeStats},
+		"cat":     {"Prints events of a tape filtered by symbol, type and time.", tapeCat},
+		"convert": {"Converts events between tapes, CSV and JSON.", tapeConvert},
+		"slice":   {"Extracts events of a time window into a new tape.", tapeSlice},
+	}
+}
+
+func (c Tape) ShortDescription() string {
+	return "Inspects and converts recorded tapes."
+}
+
+func (c Tape) Run(args []string) error {
+	if len(args) == 0 {
+		printTapeCommands()
+		return flags.Errorf("no tape command")
+	}
+	if args[0] == "-h" || args[0] == "--help" {
+		printTapeCommands()
+		return flags.ErrHelp
+	}
+	command, ok := tapeCommands()[strings.ToLower(args[0])]
+	if !ok {
+		return flags.Errorf("unknown tape command %s", args[0])
+	}
+	return command.run(args[1:])
+}
+
+func printTapeCommands() {
+	fmt.Println(`Usage: tools tape <command> [...]
+
+Tapes are files recorded by dump or by the tape: connector, CSV and JSON files are recognized by their extensions.
+Where <command> is one of:`)
+	commands := tapeCommands()
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %-9s %s\n", name, commands[name].description)
+	}
+	fmt.Println("\nRun tools tape <command> --help for the usage of the command.")
+}
+
+// tapeFilterFlags are the flags selecting events of tape commands.
+type tapeFilterFlags struct {
+	types    *string
+	symbols  *string
+	fromTime *string
+	toTime   *string
+}
+
+func newTapeFilterFlags(set *flags.FlagSet) tapeFilterFlags {
+	return tapeFilterFlags{
+		types:    set.String("t", "types", "<types>", "all", "Comma-separated list of event types (e.g. Quote,TimeAndSale), all by default."),
+		symbols:  set.String("s", "symbols", "<symbols>", "all", "Comma-separated list of symbols (e.g. AAPL,IBM), all by default."),
+		fromTime: fromTimeFlag(set, "Selects events at or after the time (e.g. 20240102-153000, 2024-01-02T15:30:00Z)."),
+		toTime:   set.String("", "to-time", "<time>", "", "Selects events before the time."),
+	}
+}
+
+// parse returns the types and symbols to subscribe to and the filter of received events.
+func (f tapeFilterFlags) parse() ([]eventcodes.EventCode, []any, *replay.Filter, error) {
+	types, err := parseEventTypes(*f.types)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	symbols := parser.ParseSymbols(*f.symbols)
+	var fromTime, toTime int64
+	if *f.fromTime != "" {
+		if fromTime, err = parser.ParseTime(*f.fromTime); err != nil {
+			return nil, nil, nil, flags.Errorf("invalid --from-time %s: %v", *f.fromTime, err)
+		}
+	}
+	if *f.toTime != "" {
+		if toTime, err = parser.ParseTime(*f.toTime); err != nil {
+			return nil, nil, nil, flags.Errorf("invalid --to-time %s: %v", *f.toTime, err)
+		}
+	}
+	if toTime != 0 && toTime <= fromTime {
+		return nil, nil, nil, flags.Errorf("--to-time should be after --from-time")
+	}
+
+	filter := replay.NewFilter()
+	filter.SetEventTypes(types...)
+	filter.SetTimeWindow(fromTime, toTime)
+	var names []string
+	for _, symbol := range symbols {
+		if _, ok := symbol.(*Osub.WildcardSymbol); ok {
+			names = nil
+			break
+		}
+		names = append(names, fmt.Sprint(symbol))
+	}
+	filter.SetSymbols(names...)
+	return types, symbols, filter, nil
+}
+
+func tapeStats(args []string) error {
+	set := flags.NewFlagSet("tape stats", "Prints event counts by type and symbol, the time range and gaps longer than the threshold.")
+	input := set.Positional("input", "The tape file, CSV or JSON file to read.")
+	filterFlags := newTapeFilterFlags(set)
+	gap := set.Period("g", "gap", "<period>", timeutil.TimePeriodOf(time.Minute), "Reports periods without events longer than the period, 1m by default; 0 disables gaps.")
+	top := set.Int("", "top", "<count>", 20, "Number of the most frequent symbols to print, 20 by default.")
+	properties := propertiesFlag(set)
+	set.Sample("tapeK2.tape")
+	set.Sample("tapeK2.tape -t Quote,Trade --gap 5s --top 50")
+	if err := parseFlags(set, args); err != nil {
+		return err
+	}
+	types, symbols, filter, err := filterFlags.parse()
+	if err != nil {
+		return err
+	}
+
+	stats := replay.NewStats(gap.Millis())
+	err = readEvents(*input, types, symbols, filter, properties, csv.TimeMillis, stats.Add)
+	if err != nil {
+		return err
+	}
+	printTapeStats(os.Stdout, stats, *top)
+	return nil
+}
+
+func printTapeStats(w io.Writer, stats *replay.Stats, top int) {
+	timeFormat := timeutil.DefaultTimeFormat.WithMillis()
+	fmt.Fprintf(w, "Events:       %d\n", stats.Count())
+	if from, to := stats.TimeRange(); from != 0 {
+		fmt.Fprintf(w, "Time range:   %s - %s (%s)\n", timeFormat.Format(from), timeFormat.Format(to), timeutil.TimePeriod(to-from))
+	}
+	fmt.Fprintf(w, "Out of order: %d\n", stats.OutOfOrder())
+	count, gaps := stats.Gaps()
+	fmt.Fprintf(w, "Gaps:         %d\n", count)
+	for _, g := range gaps {
+		fmt.Fprintf(w, "  %s - %s (%s)\n", timeFormat.Format(g.From), timeFormat.Format(g.To), timeutil.TimePeriod(g.To-g.From))
+	}
+	if int64(len(gaps)) < count {
+		fmt.Fprintf(w, "  ... %d more\n", count-int64(len(gaps)))
+	}
+	printEventCounts(w, "Type", stats.ByType(), 0)
+	printEventCounts(w, "Symbol", stats.BySymbol(), top)
+}
+
+func printEventCounts(w io.Writer, title string, counts []replay.Count, top int) {
+	if len(counts) == 0 {
+		return
+	}
+	rest := 0
+	if top > 0 && len(counts) > top {
+		rest = len(counts) - top
+		counts = counts[:top]
+	}
+	width := len(title)
+	for _, c := range counts {
+		if len(c.Key) > width {
+			width = len(c.Key)
+		}
+	}
+	fmt.Fprintf(w, "\n%-*s %12s\n", width, title, "Count")
+	for _, c := range counts {
+		fmt.Fprintf(w, "%-*s %12d\n", width, c.Key, c.Count)
+	}
+	if rest > 0 {
+		fmt.Fprintf(w, "... %d more\n", rest)
+	}
+}
+
+func tapeCat(args []string) error {
+	set := flags.NewFlagSet("tape cat", "Prints events of a tape filtered by symbol, type and time.")
+	input := set.Positional("input", "The tape file, CSV or JSON file to read.")
+	filterFlags := newTapeFilterFlags(set)
+	printer := newPrinterFlags(set)
+	properties := propertiesFlag(set)
+	set.Sample("tapeK2.tape -s AAPL -t Quote")
+	set.Sample("tapeK2.tape -f 20231114-221300 --to-time 20231114-221400 --format csv --time-format iso")
+	if err := parseFlags(set, args); err != nil {
+		return err
+	}
+	types, symbols, filter, err := filterFlags.parse()
+	if err != nil {
+		return err
+	}
+	printEvents, err := eventPrinter(printer)
+	if err != nil {
+		return err
+	}
+	return readEvents(*input, types, symbols, filter, properties, *printer.timeFormat, printEvents)
+}
+
+func tapeConvert(args []string) error {
+	set := flags.NewFlagSet("tape convert", `Converts events between tapes, CSV and JSON, optionally selecting them by symbol, type and time.
+The format is chosen by the extension: .csv, .json, other files are tapes (e.g. out.tape[format=text]).`)
+	input := set.Positional("input", "The tape file, CSV or JSON file to read.")
+	output := set.Positional("output", "The tape file, CSV or JSON file to write.")
+	return copyTape(set, input, output, args, false,
+		"tapeK2.tape tapeK2.csv --time-format iso",
+		"tapeK2.csv tapeK2.tape[format=text]",
+		"tapeK2.tape quotes.json -t Quote -s AAPL,IBM")
+}
+
+func tapeSlice(args []string) error {
+	set := flags.NewFlagSet("tape slice", `Extracts events of the time window [--from-time, --to-time) into a new tape.
+Events without time, like profiles, are kept.`)
+	input := set.Positional("input", "The tape file, CSV or JSON file to read.")
+	output := set.Positional("output", "The tape file, CSV or JSON file to write.")
+	return copyTape(set, input, output, args, true,
+		"tapeK2.tape slice.tape -f 20231114-221300 --to-time 20231114-221400",
+		"tapeK2.tape slice.tape[format=text] -f 2023-11-14T22:13:00Z -s AAPL")
+}
+
+// copyTape parses the flags of convert and slice and copies the selected events from the input to the output.
+func copyTape(set *flags.FlagSet, input *string, output *string, args []string, requireWindow bool, samples ...string) error {
+	filterFlags := newTapeFilterFlags(set)
+	timeFormat := timeFormatFlag(set)
+	properties := propertiesFlag(set)
+	for _, sample := range samples {
+		set.Sample(sample)
+	}
+	if err := parseFlags(set, args); err != nil {
+		return err
+	}
+	if requireWindow && *filterFlags.fromTime == "" && *filterFlags.toTime == "" {
+		return flags.Errorf("--from-time or --to-time should be specified")
+	}
+	types, symbols, filter, err := filterFlags.parse()
+	if err != nil {
+		return err
+	}
+
+	sink, err := newEventSink(*output, properties, *timeFormat)
+	if err != nil {
+		return err
+	}
+	var writeErr error
+	var mu sync.Mutex
+	err = readEvents(*input, types, symbols, filter, properties, *timeFormat, func(eventsList []interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		if writeErr == nil {
+			writeErr = sink.Write(eventsList)
+		}
+	})
+	closeErr := sink.Close()
+	if err = errors.Join(err, writeErr, closeErr); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %d events to %s\n", sink.Count(), *output)
+	return nil
+}
+
+func timeFormatFlag(set *flags.FlagSet) *csv.TimeFormat {
+	timeFormat := csv.TimeMillis
+	set.Func("", "time-format", "<millis|nanos|iso>", "Format of times in CSV files, millis by default.", func(value string) error {
+		format, err := csv.ParseTimeFormat(value)
+		if err != nil {
+			return err
+		}
+		timeFormat = format
+		return nil
+	})
+	return &timeFormat
+}
+
+// fileExtension returns the lower case extension of the file without the options of the address like [format=text].
+func fileExtension(address string) string {
+	if i := strings.Index(address, "["); i >= 0 {
+		address = address[:i]
+	}
+	return strings.ToLower(filepath.Ext(address))
+}
+
+// readEvents passes the events of the input accepted by the filter to the listener. CSV and JSON files are read
+// directly, other inputs like tapes are read through a StreamFeed endpoint at the maximal speed.
+func readEvents(
+	input string,
+	types []eventcodes.EventCode,
+	symbols []any,
+	filter *replay.Filter,
+	properties map[string]string,
+	timeFormat csv.TimeFormat,
+	listener func(eventsList []interface{}),
+) error {
+	var source replay.Source
+	switch fileExtension(input) {
+	case csvExtension, jsonExtension:
+		file, err := os.Open(input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if fileExtension(input) == csvExtension {
+			reader := csv.NewReader(file)
+			reader.SetTimeFormat(timeFormat)
+			source = reader
+		} else {
+			source = replay.NewJSONSource(file)
+		}
+	default:
+		if !strings.Contains(input, "[") {
+			input += "[speed=max]"
+		}
+		var mu sync.Mutex
+		return readEndpoint(input, types, symbols, properties, DumpEvents(func(eventsList []interface{}) {
+			mu.Lock()
+			defer mu.Unlock()
+			if eventsList = filter.Apply(eventsList); len(eventsList) > 0 {
+				listener(eventsList)
+			}
+		}))
+	}
+
+	batch := make([]interface{}, 0, tapeBatchSize)
+	for {
+		event, err := source.Read()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s: %w", input, err)
+		}
+		if err == nil && filter.Accepts(event) {
+			batch = append(batch, event)
+		}
+		if len(batch) == tapeBatchSize || err != nil && len(batch) > 0 {
+			listener(batch)
+			batch = make([]interface{}, 0, tapeBatchSize)
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// readEndpoint connects a StreamFeed endpoint to the address, usually a tape file, and passes received events
+// to the listeners until the endpoint is disconnected.
+func readEndpoint(
+	address string,
+	types []eventcodes.EventCode,
+	symbols []any,
+	properties map[string]string,
+	listeners ...common.EventListener,
+) error {
+	for key, value := range properties {
+		api.SetSystemProperty(key, value)
+	}
+	endpoint, err := api.NewEndpointWithProperties(api.StreamFeed, properties)
+	if err != nil {
+		return fmt.Errorf("NewEndpoint: %w", err)
+	}
+	defer func(endpoint *api.DXEndpoint) {
+		_ = endpoint.Close()
+	}(endpoint)
+	feed, err := endpoint.GetFeed()
+	if err != nil {
+		return fmt.Errorf("GetFeed: %w", err)
+	}
+	subscription, err := feed.CreateSubscription(types...)
+	if err != nil {
+		return fmt.Errorf("CreateSubscription: %w", err)
+	}
+	defer subscription.Close()
+	for _, listener := range listeners {
+		err = subscription.AddListener(listener)
+		if err != nil {
+			return fmt.Errorf("AddListener: %w", err)
+		}
+	}
+	for _, symbol := range symbols {
+		err = subscription.AddSymbol(symbol)
+		if err != nil {
+			return fmt.Errorf("AddSymbol: %w", err)
+		}
+	}
+
+	err = endpoint.Connect(address)
+	if err != nil {
+		return fmt.Errorf("Connect to %s: %w", address, err)
+	}
+	err = endpoint.AwaitNotConnected()
+	if err != nil {
+		return fmt.Errorf("AwaitNotConnected: %w", err)
+	}
+	err = endpoint.CloseAndAwaitTermination()
+	if err != nil {
+		return fmt.Errorf("CloseAndAwaitTermination: %w", err)
+	}
+	return nil
+}
+
+// eventSink writes the events of tape commands. It is closed after all events are written.
+type eventSink interface {
+	Write(eventsList []interface{}) error
+	Close() error
+	Count() int64
+}
+
+// newEventSink returns the sink writing to the file, CSV and JSON files are written directly
+// and other files are written as tapes through a StreamPublisher endpoint.
+func newEventSink(output string, properties map[string]string, timeFormat csv.TimeFormat) (eventSink, error) {
+	switch fileExtension(output) {
+	case csvExtension, jsonExtension:
+		file, err := os.Create(output)
+		if err != nil {
+			return nil, err
+		}
+		if fileExtension(output) == jsonExtension {
+			return &fileSink{file: file, sink: replay.NewJSONSink(file)}, nil
+		}
+		writer := csv.NewWriter(file)
+		writer.SetTimeFormat(timeFormat)
+		return &fileSink{file: file, sink: writer}, nil
+	default:
+		return newTapePublisher(output, properties)
+	}
+}
+
+type fileSink struct {
+	file  *os.File
+	sink  replay.Sink
+	count int64
+}
+
+func (s *fileSink) Write(eventsList []interface{}) error {
+	for _, event := range eventsList {
+		if err := s.sink.Write(event); err != nil {
+			return err
+		}
+		s.count++
+	}
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	return errors.Join(s.sink.Flush(), s.file.Close())
+}
+
+func (s *fileSink) Count() int64 {
+	return s.count
+}
+
+// tapePublisher writes events to a tape through a StreamPublisher endpoint connected to tape:<file>.
+type tapePublisher struct {
+	endpoint  *api.DXEndpoint
+	publisher *api.DXPublisher
+	count     int64
+}
+
+func newTapePublisher(file string, properties map[string]string) (*tapePublisher, error) {
+	endpoint, err := api.NewEndpointWithProperties(api.StreamPublisher, properties)
+	if err != nil {
+		return nil, fmt.Errorf("NewEndpoint Publisher: %w", err)
+	}
+	publisher, err := endpoint.GetPublisher()
+	if err != nil {
+		_ = endpoint.Close()
+		return nil, fmt.Errorf("GetPublisher: %w", err)
+	}
+	err = endpoint.Connect("tape:" + file)
+	if err != nil {
+		_ = endpoint.Close()
+		return nil, fmt.Errorf("Connect to %s: %w", file, err)
+	}
+	return &tapePublisher{endpoint: endpoint, publisher: publisher}, nil
+}
+
+func (p *tapePublisher) Write(eventsList []interface{}) error {
+	p.count += int64(len(eventsList))
+	return p.publisher.Publish(eventsList)
+}
+
+// Close waits until published events are written and closes the endpoint.
+func (p *tapePublisher) Close() error {
+	err := p.endpoint.AwaitProcessed()
+	if err != nil {
+		_ = p.endpoint.Close()
+		return fmt.Errorf("AwaitProcessed: %w", err)
+	}
+	err = p.endpoint.CloseAndAwaitTermination()
+	if err != nil {
+		return fmt.Errorf("CloseAndAwaitTermination: %w", err)
+	}
+	return nil
+}
+
+func (p *tapePublisher) Count() int64 {
+	return p.count
+}
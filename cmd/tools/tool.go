@@ -1,40 +1,85 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/cmd/tools/internal/flags"
+)
+
+const (
+	exitFailure = 1
+	exitUsage   = 2
 )
 
 type Tool interface {
-	Run(args []string)
+	// Run runs the tool with the arguments following its name.
+	Run(args []string) error
 	ShortDescription() string
 }
 
-func Run(args []string) {
-	if len(args) == 1 {
-		fmt.Println(`
-Usage: tools <tool> [...]
-Where <tool> is one of:`)
-		for key, tool := range tools() {
-			fmt.Printf("\t%-15s   -%s\n", key, tool.ShortDescription())
-		}
-	} else {
-		tool := createTool(args)
-		Tool.Run(tool, args)
+// Run runs the tool named by the first argument and returns the exit code.
+func Run(args []string) int {
+	if len(args) < 2 {
+		printTools()
+		return 0
+	}
+	tool, ok := tools()[strings.ToLower(args[1])]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown tool %s\n", args[1])
+		printTools()
+		return exitUsage
+	}
+	err := tool.Run(args[2:])
+	var usageError *flags.UsageError
+	switch {
+	case err == nil || errors.Is(err, flags.ErrHelp):
+		return 0
+	case errors.As(err, &usageError):
+		fmt.Fprintf(os.Stderr, "Error: %v\nRun tools %s --help for usage.\n", err, strings.ToLower(args[1]))
+		return exitUsage
+	default:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
 	}
 }
 
-func createTool(args []string) Tool {
+// parseFlags parses the arguments and prints the usage if it is requested.
+func parseFlags(set *flags.FlagSet, args []string) error {
+	err := set.Parse(args)
+	if errors.Is(err, flags.ErrHelp) {
+		fmt.Print(set.Usage())
+	}
+	return err
+}
+
+func printTools() {
+	fmt.Println(`
+Usage: tools <tool> [...]
+Where <tool> is one of:`)
 	tools := tools()
-	value := strings.ToLower(args[1])
-	return tools[value]
+	names := make([]string, 0, len(tools))
+	for name := range tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("\t%-15s   -%s\n", name, tools[name].ShortDescription())
+	}
 }
 
 func tools() map[string]Tool {
 	return map[string]Tool{
 		"connect":     Connect{},
 		"dump":        Dump{},
+		"ipf":         Ipf{},
+		"loopback":    Loopback{},
+		"ondemand":    OnDemand{},
 		"perftest":    PerfTest{},
+		"tape":        Tape{},
 		"latencytest": LatencyTest{},
 	}
 }
@@ -1,5 +1,3 @@
 module github.com/dxfeed/dxfeed-graal-go-api
 
 go 1.20
-
-require github.com/montanaflynn/stats v0.7.1
@@ -0,0 +1,77 @@
+// Package eventtest provides events shared by tests of encodings of events.
+package eventtest
+
+import (
+	"math"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/profile"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+)
+
+// Time is the time of events in milliseconds, 20231114-221320.123 UTC.
+const Time = int64(1700000000123)
+
+// Events returns new events of the common types with fields that every encoding keeps: a quote of AAPL,
+// a time and sale of IBM, a trade of MSFT, a profile of GOOG, an order of AAPL from NTV, a candle of AAPL,
+// a quote of IBM with default fields and a regional quote of IBM.
+func Events() []interface{} {
+	q := quote.NewQuote("AAPL")
+	// bid and ask times are kept in seconds by tapes
+	q.SetBidTime(Time - 123)
+	q.SetBidExchangeCode('Q')
+	q.SetBidPrice(100.5)
+	q.SetAskPrice(math.Inf(1))
+
+	tns := timeandsale.NewTimeAndSale("IBM")
+	tns.SetEventFlags(events.SnapshotBegin | events.TxPending)
+	tns.SetTime(Time)
+	tns.SetTimeNanoPart(456)
+	tns.SetPrice(150)
+	tns.SetSize(300)
+	conditions := "@, T"
+	tns.SetExchangeSaleConditions(&conditions)
+	tns.SetAggressorSide(side.Sell)
+
+	tr := trade.NewTrade("MSFT")
+	tr.SetTime(Time)
+	tr.SetPrice(410)
+	tr.SetDayVolume(1_000_000)
+	tr.SetTickDirection(trade.Up)
+
+	p := profile.NewProfile("GOOG")
+	description := "Alphabet \"Class A\""
+	p.SetDescription(&description)
+	p.SetTradingStatus(profile.ActiveTradingStatus)
+
+	o := order.NewOrder("AAPL")
+	o.SetOrderSource(order.NtvL3())
+	_ = o.SetIndex(o.Index() | 42)
+	o.SetTime(Time)
+	_ = o.SetSequence(3)
+	o.SetPrice(189.4)
+	o.SetSize(100)
+	o.SetSide(side.Buy)
+	o.SetScope(order.ScopeOrder)
+	marketMaker := "NSDQ"
+	o.SetMarketMaker(&marketMaker)
+
+	c := candle.NewCandle("AAPL{=1m}")
+	c.SetTime(Time)
+	c.SetOpen(1)
+	c.SetHigh(3)
+	c.SetLow(0.5)
+	c.SetClose(2)
+
+	regional := quote.NewQuote("IBM&Q")
+	regional.SetBidExchangeCode('Q')
+	regional.SetAskExchangeCode('Q')
+	regional.SetAskPrice(101)
+
+	return []interface{}{q, tns, tr, p, o, c, quote.NewQuote("IBM"), regional}
+}
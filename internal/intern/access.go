@@ -0,0 +1,6 @@
+package intern
+
+// Access is the first argument of the SetInterned methods of events, which point string fields of events to
+// interned strings without copying them, unlike their setters. Packages outside the module can not name
+// the type, so only decoders of the module call these methods, and the strings are never written through.
+type Access struct{}
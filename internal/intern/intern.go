@@ -0,0 +1,63 @@
+// Package intern keeps values created from strings, like the symbols of decoded events, so that
+// equal strings are converted once and share the result.
+package intern
+
+// Cache maps strings to values in a bounded number of entries. It keeps two generations of at most
+// maxSize entries each: when the current generation is full it replaces the previous one, and entries
+// found in the previous generation move back to the current one, so frequently used strings stay cached.
+// A Cache is not safe for concurrent use.
+type Cache[V any] struct {
+	maxSize  int
+	create   func(string) V
+	current  map[string]entry[V]
+	previous map[string]entry[V]
+}
+
+// entry keeps the key to move it between generations without converting bytes again.
+type entry[V any] struct {
+	key   string
+	value V
+}
+
+// New creates a cache of at most 2*maxSize values created by create.
+func New[V any](maxSize int, create func(string) V) *Cache[V] {
+	if maxSize < 1 {
+		maxSize = 1
+	}
+	return &Cache[V]{
+		maxSize: maxSize,
+		create:  create,
+		current: make(map[string]entry[V]),
+	}
+}
+
+// Get returns the value of the string held by the bytes. The bytes are not retained,
+// it does not allocate when the value is cached.
+func (c *Cache[V]) Get(bytes []byte) V {
+	if e, ok := c.current[string(bytes)]; ok {
+		return e.value
+	}
+	e, ok := c.previous[string(bytes)]
+	if ok {
+		delete(c.previous, e.key)
+	} else {
+		key := string(bytes)
+		e = entry[V]{key: key, value: c.create(key)}
+	}
+	if len(c.current) >= c.maxSize {
+		c.previous = c.current
+		c.current = make(map[string]entry[V], c.maxSize)
+	}
+	c.current[e.key] = e
+	return e.value
+}
+
+// Len returns the number of cached values.
+func (c *Cache[V]) Len() int {
+	return len(c.current) + len(c.previous)
+}
+
+// String returns a pointer to a copy of the string, the create function of caches of interned strings.
+func String(s string) *string {
+	return &s
+}
@@ -0,0 +1,49 @@
+package intern
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	created := 0
+	cache := New(2, func(s string) string {
+		created++
+		return strings.ToLower(s)
+	})
+	for _, symbol := range []string{"AAPL", "IBM", "AAPL", "IBM"} {
+		if value := cache.Get([]byte(symbol)); value != strings.ToLower(symbol) {
+			t.Fatalf(`Get(%v) should be %v. But it equals %v`, symbol, strings.ToLower(symbol), value)
+		}
+	}
+	if created != 2 {
+		t.Errorf(`Created values should be 2. But it equals %v`, created)
+	}
+
+	// MSFT starts a new generation, AAPL moves back to it, IBM stays in the previous one
+	cache.Get([]byte("MSFT"))
+	cache.Get([]byte("AAPL"))
+	if created != 3 || cache.Len() != 3 {
+		t.Errorf(`Created and cached values should be 3 and 3. But they equal %v and %v`, created, cache.Len())
+	}
+	// GOOG drops IBM
+	cache.Get([]byte("GOOG"))
+	cache.Get([]byte("IBM"))
+	if created != 5 || cache.Len() > 4 {
+		t.Errorf(`Created values should be 5 with at most 4 cached. But they equal %v and %v`, created, cache.Len())
+	}
+}
+
+func TestGetDoesNotAllocate(t *testing.T) {
+	cache := New(100, String)
+	symbol := []byte("AAPL")
+	first := cache.Get(symbol)
+	allocs := testing.AllocsPerRun(100, func() {
+		if cache.Get(symbol) != first {
+			t.Fatalf(`Get should return the cached pointer`)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf(`Allocations of cached Get should be 0. But it equals %v`, allocs)
+	}
+}
@@ -22,6 +22,9 @@ type DXEndpointHandle struct {
 
 	publisherOnce sync.Once
 	publisher     *DXPublisherHandle
+
+	onDemandOnce sync.Once
+	onDemand     *OnDemandServiceHandle
 }
 
 func NewDXEndpointHandle(role common.Role) (*DXEndpointHandle, error) {
@@ -157,6 +160,21 @@ func (e *DXEndpointHandle) GetPublisher() (*DXPublisherHandle, error) {
 	return e.publisher, err
 }
 
+func (e *DXEndpointHandle) GetOnDemandService() (*OnDemandServiceHandle, error) {
+	var err error
+	e.onDemandOnce.Do(func() {
+		var ptr *C.dxfg_on_demand_service_t
+		err = dispatchOnIsolateThread(func(thread *isolateThread) error {
+			return checkCall(func() {
+				ptr = C.dxfg_OnDemandService_getInstance2(thread.ptr, e.ptr())
+			})
+		})
+		e.onDemand = NewOnDemandServiceHandle(ptr)
+	})
+
+	return e.onDemand, err
+}
+
 //export OnStateChanged
 func OnStateChanged(thread *C.graal_isolatethread_t, old C.dxfg_endpoint_state_t, new C.dxfg_endpoint_state_t, userData unsafe.Pointer) {
 	Restore(userData).(common.ConnectionStateListener).UpdateState(common.ConnectionState(old), common.ConnectionState(new))
@@ -172,7 +190,7 @@ func (e *DXEndpointHandle) AttachListener(listener common.ConnectionStateListene
 }
 
 func (e *DXEndpointHandle) Free() error {
-	return errors.Join(e.feed.Free(), e.publisher.Free(), e.self.Free())
+	return errors.Join(e.feed.Free(), e.publisher.Free(), e.onDemand.Free(), e.self.Free())
 }
 
 func (e *DXEndpointHandle) ptr() *C.dxfg_endpoint_t {
@@ -9,11 +9,18 @@ import "C"
 
 import (
 	"fmt"
+	"sync"
+	"time"
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/native/mappers"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/batch"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/common"
 )
 
+// pooledSymbolCacheSize bounds the strings interned for a pooled or batch listener, twice as many are kept at most
+const pooledSymbolCacheSize = 1 << 16
+
 type DXFeedSubscription struct {
 	ptr *C.dxfg_subscription_t
 }
@@ -50,14 +57,68 @@ func ConvertString(value *C.char) *string {
 	}
 }
 
+// pooledListener decodes events into a pool, they are reused once Update returns.
+type pooledListener struct {
+	mu       sync.Mutex
+	pool     *mappers.EventPool
+	listener common.EventListener
+}
+
+// batchListener decodes events into columns, the batches are reused once UpdateBatch returns.
+type batchListener struct {
+	mu       sync.Mutex
+	decoder  *mappers.BatchDecoder
+	listener batch.Listener
+}
+
 //export OnEventReceived
 func OnEventReceived(thread *C.graal_isolatethread_t, eventsList *C.dxfg_event_type_list, userData unsafe.Pointer) {
-	Restore(userData).(common.EventListener).Update(eventMapper.goEvents(eventsList))
+	switch l := Restore(userData).(type) {
+	case *pooledListener:
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		notifyListener(l.listener, l.pool.Decode(unsafe.Pointer(eventsList)))
+	case *batchListener:
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		batches := l.decoder.Decode(unsafe.Pointer(eventsList))
+		countReceivedBatches(batches)
+		start := time.Now()
+		for _, b := range batches {
+			l.listener.UpdateBatch(b)
+		}
+		listenerDuration.ObserveSince(start)
+	default:
+		notifyListener(l.(common.EventListener), eventMapper.goEvents(eventsList))
+	}
+}
+
+func notifyListener(listener common.EventListener, events []interface{}) {
+	countReceivedEvents(events)
+	start := time.Now()
+	listener.Update(events)
+	listenerDuration.ObserveSince(start)
 }
 
 func (s DXFeedSubscription) AttachListener(listener common.EventListener) error {
+	return s.attach(listener)
+}
+
+// AttachPooledListener attaches the listener with events decoded into a pool of the listener,
+// the events and the slice passed to Update are reused after it returns.
+func (s DXFeedSubscription) AttachPooledListener(listener common.EventListener) error {
+	return s.attach(&pooledListener{pool: mappers.NewEventPool(pooledSymbolCacheSize), listener: listener})
+}
+
+// AttachBatchListener attaches the listener with events decoded into columns of batches of the listener.
+func (s DXFeedSubscription) AttachBatchListener(listener batch.Listener) error {
+	return s.attach(&batchListener{decoder: mappers.NewBatchDecoder(pooledSymbolCacheSize), listener: listener})
+}
+
+// attach adds a native listener calling OnEventReceived with the user data.
+func (s DXFeedSubscription) attach(userData interface{}) error {
 	err := dispatchOnIsolateThread(func(thread *isolateThread) error {
-		l := C.dxfg_DXFeedEventListener_new(thread.ptr, (*[0]byte)(C.OnEventReceived), Save(listener))
+		l := C.dxfg_DXFeedEventListener_new(thread.ptr, (*[0]byte)(C.OnEventReceived), Save(userData))
 		C.dxfg_DXFeedSubscription_addEventListener(thread.ptr, s.ptr, l)
 		return nil
 	})
@@ -13,6 +13,7 @@ import (
 	"github.com/dxfeed/dxfeed-graal-go-api/internal/native/mappers"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api/Osub"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
 )
 
 type eventMapperUtil int
@@ -49,6 +50,8 @@ func (m eventMapperUtil) cSymbol(symbol any) unsafe.Pointer {
 	switch value := symbol.(type) {
 	case string:
 		return unsafe.Pointer(m.cStringSymbol(value))
+	case *candle.CandleSymbol:
+		return unsafe.Pointer(m.cStringSymbol(value.String()))
 	case *Osub.WildcardSymbol:
 		return unsafe.Pointer(m.cWildCardSymbol())
 	case *Osub.IndexedEventSubscriptionSymbol:
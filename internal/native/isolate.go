@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 const (
@@ -64,6 +65,7 @@ func init() {
 	workerCount := getConfiguredWorkerCount()
 	pool = newWorkerPool(workerCount)
 	pool.start()
+	registerPoolMetrics(pool)
 }
 
 // getConfiguredWorkerCount determines the number of workers based on environment
@@ -200,7 +202,13 @@ func detachThreadFromIsolate(thread *isolateThread) error {
 // The function is executed by one of the worker threads in the pool.
 // This function is kept for backward compatibility.
 func dispatchOnIsolateThread(fn func(*isolateThread) error) error {
-	return pool.submit(fn)
+	start := time.Now()
+	err := pool.submit(fn)
+	isolateCallDuration.ObserveSince(start)
+	if err != nil {
+		isolateCallErrors.Inc()
+	}
+	return err
 }
 
 // attachCurrentThread attaches the current OS thread to the isolate.
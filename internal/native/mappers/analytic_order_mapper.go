@@ -9,14 +9,31 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
 )
 
 type AnalyticOrderMapper struct{}
 
-func (AnalyticOrderMapper) GoEvent(native unsafe.Pointer) interface{} {
+func (m AnalyticOrderMapper) GoEvent(native unsafe.Pointer) interface{} {
 	orderNative := (*C.dxfg_analytic_order_t)(native)
 	o := order.NewAnalyticOrder(C.GoString(orderNative.order_base.order_base.market_event.event_symbol))
+	m.fill(native, o, nil)
+	return o
+}
+
+func (AnalyticOrderMapper) newEvent() interface{} {
+	return order.NewAnalyticOrder("")
+}
+
+func (AnalyticOrderMapper) setSymbol(native unsafe.Pointer, event interface{}, strings *Strings) {
+	symbol := strings.symbol((*C.dxfg_analytic_order_t)(native).order_base.order_base.market_event.event_symbol)
+	event.(*order.AnalyticOrder).SetInternedEventSymbol(intern.Access{}, symbol)
+}
+
+func (AnalyticOrderMapper) fill(native unsafe.Pointer, event interface{}, strings *Strings) {
+	orderNative := (*C.dxfg_analytic_order_t)(native)
+	o := event.(*order.AnalyticOrder)
 	o.SetEventTime(int64(orderNative.order_base.order_base.market_event.event_time))
 
 	o.SetEventFlags(int32(orderNative.order_base.order_base.event_flags))
@@ -35,14 +52,12 @@ func (AnalyticOrderMapper) GoEvent(native unsafe.Pointer) interface{} {
 	o.SetTradePrice(float64(orderNative.order_base.order_base.trade_price))
 	o.SetTradeSize(float64(orderNative.order_base.order_base.trade_size))
 
-	o.SetMarketMaker(convertString(orderNative.order_base.market_maker))
+	o.SetInternedMarketMaker(intern.Access{}, strings.string(orderNative.order_base.market_maker))
 
 	o.SetIcebergPeakSize(float64(orderNative.iceberg_peak_size))
 	o.SetIcebergHiddenSize(float64(orderNative.iceberg_hidden_size))
 	o.SetIcebergExecutedSize(float64(orderNative.iceberg_executed_size))
 	o.SetIcebergFlags(int32(orderNative.iceberg_flags))
-
-	return o
 }
 
 func (a AnalyticOrderMapper) CEvent(event interface{}) unsafe.Pointer {
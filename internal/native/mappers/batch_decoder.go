@@ -0,0 +1,150 @@
+package mappers
+
+/*
+#include "../graal/dxfg_api.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/batch"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+)
+
+// BatchDecoder decodes lists of native events directly into columns, without creating events.
+// Batches are reused by the next Decode, strings are interned. A BatchDecoder is not safe for concurrent use.
+type BatchDecoder struct {
+	strings   *intern.Cache[string]
+	collector batch.Collector
+}
+
+// NewBatchDecoder creates a decoder interning at most 2*maxStrings strings.
+func NewBatchDecoder(maxStrings int) *BatchDecoder {
+	return &BatchDecoder{strings: intern.New(maxStrings, func(s string) string { return s })}
+}
+
+// Decode decodes a *dxfg_event_type_list, events of types without batches are skipped.
+func (d *BatchDecoder) Decode(list unsafe.Pointer) []batch.Batch {
+	d.collector.Reset()
+	eventsList := (*C.dxfg_event_type_list)(list)
+	if eventsList == nil || eventsList.elements == nil || int(eventsList.size) == 0 {
+		return nil
+	}
+	for _, event := range unsafe.Slice(eventsList.elements, C.size_t(eventsList.size)) {
+		switch event.clazz {
+		case C.DXFG_EVENT_QUOTE:
+			d.quote((*C.dxfg_quote_t)(unsafe.Pointer(event)))
+		case C.DXFG_EVENT_TIME_AND_SALE:
+			d.timeAndSale((*C.dxfg_time_and_sale_t)(unsafe.Pointer(event)))
+		case C.DXFG_EVENT_TRADE:
+			d.trade((*C.dxfg_trade_t)(unsafe.Pointer(event)))
+		case C.DXFG_EVENT_CANDLE:
+			d.candle((*C.dxfg_candle_t)(unsafe.Pointer(event)))
+		case C.DXFG_EVENT_ORDER:
+			d.order((*C.dxfg_order_t)(unsafe.Pointer(event)))
+		}
+	}
+	return d.collector.Batches()
+}
+
+func (d *BatchDecoder) string(value *C.char) string {
+	if value == nil {
+		return ""
+	}
+	return d.strings.Get(cBytes(value))
+}
+
+func (d *BatchDecoder) quote(native *C.dxfg_quote_t) {
+	b := d.collector.Batch(eventcodes.Quote).(*batch.QuoteBatch)
+	b.Symbols = append(b.Symbols, d.string(native.market_event.event_symbol))
+	b.EventTime = append(b.EventTime, int64(native.market_event.event_time))
+	b.BidTime = append(b.BidTime, int64(native.bid_time))
+	b.BidExchangeCode = append(b.BidExchangeCode, rune(native.bid_exchange_code))
+	b.BidPrice = append(b.BidPrice, float64(native.bid_price))
+	b.BidSize = append(b.BidSize, float64(native.bid_size))
+	b.AskTime = append(b.AskTime, int64(native.ask_time))
+	b.AskExchangeCode = append(b.AskExchangeCode, rune(native.ask_exchange_code))
+	b.AskPrice = append(b.AskPrice, float64(native.ask_price))
+	b.AskSize = append(b.AskSize, float64(native.ask_size))
+	b.TimeMillisSequence = append(b.TimeMillisSequence, int32(native.time_millis_sequence))
+	b.TimeNanoPart = append(b.TimeNanoPart, int32(native.time_nano_part))
+}
+
+func (d *BatchDecoder) timeAndSale(native *C.dxfg_time_and_sale_t) {
+	b := d.collector.Batch(eventcodes.TimeAndSale).(*batch.TimeAndSaleBatch)
+	b.Symbols = append(b.Symbols, d.string(native.market_event.event_symbol))
+	b.EventTime = append(b.EventTime, int64(native.market_event.event_time))
+	b.EventFlags = append(b.EventFlags, int32(native.event_flags))
+	b.Index = append(b.Index, int64(native.index))
+	b.TimeNanoPart = append(b.TimeNanoPart, int32(native.time_nano_part))
+	b.ExchangeCode = append(b.ExchangeCode, int16(native.exchange_code))
+	b.Price = append(b.Price, float64(native.price))
+	b.Size = append(b.Size, float64(native.size))
+	b.BidPrice = append(b.BidPrice, float64(native.bid_price))
+	b.AskPrice = append(b.AskPrice, float64(native.ask_price))
+	b.ExchangeSaleConditions = append(b.ExchangeSaleConditions, d.string(native.exchange_sale_conditions))
+	b.Buyer = append(b.Buyer, d.string(native.buyer))
+	b.Seller = append(b.Seller, d.string(native.seller))
+	b.Flags = append(b.Flags, int32(native.flags))
+}
+
+func (d *BatchDecoder) trade(native *C.dxfg_trade_t) {
+	b := d.collector.Batch(eventcodes.Trade).(*batch.TradeBatch)
+	b.Symbols = append(b.Symbols, d.string(native.trade_base.market_event.event_symbol))
+	b.EventTime = append(b.EventTime, int64(native.trade_base.market_event.event_time))
+	b.TimeSequence = append(b.TimeSequence, int64(native.trade_base.time_sequence))
+	b.TimeNanoPart = append(b.TimeNanoPart, int32(native.trade_base.time_nano_part))
+	b.ExchangeCode = append(b.ExchangeCode, int16(native.trade_base.exchange_code))
+	b.Price = append(b.Price, float64(native.trade_base.price))
+	b.Size = append(b.Size, float64(native.trade_base.size))
+	b.Change = append(b.Change, float64(native.trade_base.change))
+	b.DayId = append(b.DayId, int32(native.trade_base.day_id))
+	b.DayVolume = append(b.DayVolume, float64(native.trade_base.day_volume))
+	b.DayTurnover = append(b.DayTurnover, float64(native.trade_base.day_turnover))
+	b.Flags = append(b.Flags, int32(native.trade_base.flags))
+}
+
+func (d *BatchDecoder) candle(native *C.dxfg_candle_t) {
+	b := d.collector.Batch(eventcodes.Candle).(*batch.CandleBatch)
+	b.Symbols = append(b.Symbols, d.string(native.event_symbol))
+	b.EventTime = append(b.EventTime, int64(native.event_time))
+	b.EventFlags = append(b.EventFlags, int32(native.event_flags))
+	b.Index = append(b.Index, int64(native.index))
+	b.Count = append(b.Count, int64(native.count))
+	b.Open = append(b.Open, float64(native.open))
+	b.High = append(b.High, float64(native.high))
+	b.Low = append(b.Low, float64(native.low))
+	b.Close = append(b.Close, float64(native.close))
+	b.Volume = append(b.Volume, float64(native.volume))
+	b.Vwap = append(b.Vwap, float64(native.vwap))
+	b.BidVolume = append(b.BidVolume, float64(native.bid_volume))
+	b.AskVolume = append(b.AskVolume, float64(native.ask_volume))
+	b.ImpVolatility = append(b.ImpVolatility, float64(native.imp_volatility))
+	b.OpenInterest = append(b.OpenInterest, float64(native.open_interest))
+}
+
+func (d *BatchDecoder) order(native *C.dxfg_order_t) {
+	b := d.collector.Batch(eventcodes.Order).(*batch.OrderBatch)
+	base := &native.order_base
+	b.Symbols = append(b.Symbols, d.string(base.market_event.event_symbol))
+	b.EventTime = append(b.EventTime, int64(base.market_event.event_time))
+	b.EventFlags = append(b.EventFlags, int32(base.event_flags))
+	b.Index = append(b.Index, int64(base.index))
+	b.TimeSequence = append(b.TimeSequence, int64(base.time_sequence))
+	b.TimeNanoPart = append(b.TimeNanoPart, int32(base.time_nano_part))
+	b.ActionTime = append(b.ActionTime, int64(base.action_time))
+	b.OrderId = append(b.OrderId, int64(base.order_id))
+	b.AuxOrderId = append(b.AuxOrderId, int64(base.aux_order_id))
+	b.Price = append(b.Price, float64(base.price))
+	b.Size = append(b.Size, float64(base.size))
+	b.ExecutedSize = append(b.ExecutedSize, float64(base.executed_size))
+	b.Count = append(b.Count, int64(base.count))
+	b.Flags = append(b.Flags, int32(base.flags))
+	b.TradeId = append(b.TradeId, int64(base.trade_id))
+	b.TradePrice = append(b.TradePrice, float64(base.trade_price))
+	b.TradeSize = append(b.TradeSize, float64(base.trade_size))
+	b.MarketMaker = append(b.MarketMaker, d.string(native.market_maker))
+}
@@ -9,15 +9,30 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
 )
 
 type CandleMapper struct{}
 
-func (CandleMapper) GoEvent(nativeEvent unsafe.Pointer) interface{} {
+func (m CandleMapper) GoEvent(nativeEvent unsafe.Pointer) interface{} {
 	candleNative := (*C.dxfg_candle_t)(nativeEvent)
-
 	newCandle := candle.NewCandle(C.GoString(candleNative.event_symbol))
+	m.fill(nativeEvent, newCandle, nil)
+	return newCandle
+}
+
+func (CandleMapper) newEvent() interface{} {
+	return candle.NewCandle("")
+}
+
+func (CandleMapper) setSymbol(nativeEvent unsafe.Pointer, event interface{}, strings *Strings) {
+	event.(*candle.Candle).SetInternedEventSymbol(intern.Access{}, strings.candleSymbol((*C.dxfg_candle_t)(nativeEvent).event_symbol))
+}
+
+func (CandleMapper) fill(nativeEvent unsafe.Pointer, event interface{}, _ *Strings) {
+	candleNative := (*C.dxfg_candle_t)(nativeEvent)
+	newCandle := event.(*candle.Candle)
 	newCandle.SetEventTime(int64(candleNative.event_time))
 	newCandle.SetEventFlags(int32(candleNative.event_flags))
 	newCandle.SetIndex(int64(candleNative.index))
@@ -34,7 +49,6 @@ func (CandleMapper) GoEvent(nativeEvent unsafe.Pointer) interface{} {
 	newCandle.SetAskVolume(float64(candleNative.ask_volume))
 	newCandle.SetImpVolatility(float64(candleNative.imp_volatility))
 	newCandle.SetOpenInterest(float64(candleNative.open_interest))
-	return newCandle
 }
 
 func (CandleMapper) CEvent(event interface{}) unsafe.Pointer {
@@ -0,0 +1,129 @@
+package mappers
+
+/*
+#include "../graal/dxfg_api.h"
+#include <stdlib.h>
+#include <string.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+)
+
+// poolMapper decodes native events into events that are created once and reused.
+type poolMapper interface {
+	newEvent() interface{}
+	// setSymbol sets the event symbol interned by the strings.
+	setSymbol(native unsafe.Pointer, event interface{}, strings *Strings)
+	// fill sets all fields but the event symbol, strings are copied when strings is nil.
+	fill(native unsafe.Pointer, event interface{}, strings *Strings)
+}
+
+// Strings interns native strings of decoded events, events with equal strings share them.
+// Fields of events are pointed to interned strings with their SetInterned methods, without copying them.
+// Strings is not safe for concurrent use.
+type Strings struct {
+	strings       *intern.Cache[*string]
+	candleSymbols *intern.Cache[*candle.CandleSymbol]
+}
+
+// NewStrings creates caches of at most 2*maxSize strings and as many candle symbols.
+func NewStrings(maxSize int) *Strings {
+	return &Strings{
+		strings:       intern.New(maxSize, intern.String),
+		candleSymbols: intern.New(maxSize, candle.NewCandleSymbol),
+	}
+}
+
+// string returns the interned string, or a copy of it when strings is nil.
+func (s *Strings) string(value *C.char) *string {
+	if value == nil {
+		return nil
+	}
+	if s == nil {
+		return convertString(value)
+	}
+	return s.strings.Get(cBytes(value))
+}
+
+// symbol returns the interned symbol, nil is the empty symbol.
+func (s *Strings) symbol(value *C.char) *string {
+	return s.strings.Get(cBytes(value))
+}
+
+func (s *Strings) candleSymbol(value *C.char) *candle.CandleSymbol {
+	return s.candleSymbols.Get(cBytes(value))
+}
+
+func cBytes(value *C.char) []byte {
+	if value == nil {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(value)), C.strlen(value))
+}
+
+// EventPool decodes lists of native events into events that are reused by the next Decode,
+// along with the returned slice. Strings of events are interned and shared by events.
+// An EventPool is not safe for concurrent use.
+type EventPool struct {
+	strings *Strings
+	slabs   map[int32]*slab
+	events  []interface{}
+}
+
+// slab keeps the events of one type, the first used of them are in use.
+type slab struct {
+	mapper poolMapper
+	events []interface{}
+	used   int
+}
+
+// NewEventPool creates a pool interning at most 2*maxStrings strings.
+func NewEventPool(maxStrings int) *EventPool {
+	return &EventPool{
+		strings: NewStrings(maxStrings),
+		slabs:   make(map[int32]*slab),
+	}
+}
+
+// Decode decodes a *dxfg_event_type_list, events returned by the previous call are overwritten.
+func (p *EventPool) Decode(list unsafe.Pointer) []interface{} {
+	eventsList := (*C.dxfg_event_type_list)(list)
+	if eventsList == nil || eventsList.elements == nil || int(eventsList.size) == 0 {
+		return nil
+	}
+	for _, s := range p.slabs {
+		s.used = 0
+	}
+	p.events = p.events[:0]
+	for _, event := range unsafe.Slice(eventsList.elements, C.size_t(eventsList.size)) {
+		p.events = append(p.events, p.decode(event))
+	}
+	return p.events
+}
+
+func (p *EventPool) decode(native *C.dxfg_event_type_t) interface{} {
+	clazz := int32(native.clazz)
+	s, ok := p.slabs[clazz]
+	if !ok {
+		mapper, _ := SelectMapper(clazz).(poolMapper)
+		if mapper == nil {
+			panic(fmt.Sprintf("unknown (or unsupported) eventcode %d", clazz))
+		}
+		s = &slab{mapper: mapper}
+		p.slabs[clazz] = s
+	}
+	if s.used == len(s.events) {
+		s.events = append(s.events, s.mapper.newEvent())
+	}
+	event := s.events[s.used]
+	s.used++
+	s.mapper.setSymbol(unsafe.Pointer(native), event, p.strings)
+	s.mapper.fill(unsafe.Pointer(native), event, p.strings)
+	return event
+}
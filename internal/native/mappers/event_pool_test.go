@@ -0,0 +1,82 @@
+package mappers
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+)
+
+// eventList has the layout of dxfg_event_type_list.
+type eventList struct {
+	size     int32
+	elements *unsafe.Pointer
+}
+
+func newEventList(eventsList ...events.EventType) unsafe.Pointer {
+	elements := make([]unsafe.Pointer, len(eventsList))
+	for i, event := range eventsList {
+		elements[i] = SelectMapper(int32(event.Type())).CEvent(event)
+	}
+	return unsafe.Pointer(&eventList{size: int32(len(elements)), elements: &elements[0]})
+}
+
+func newQuote(symbol string, bidPrice float64) *quote.Quote {
+	q := quote.NewQuote(symbol)
+	q.SetBidPrice(bidPrice)
+	return q
+}
+
+func TestEventPoolReusesEvents(t *testing.T) {
+	pool := NewEventPool(10)
+	first := pool.Decode(newEventList(newQuote("AAPL", 1), trade.NewTrade("IBM"), newQuote("IBM", 2)))
+	if len(first) != 3 || *first[0].(*quote.Quote).EventSymbol() != "AAPL" ||
+		first[2].(*quote.Quote).BidPrice() != 2 {
+		t.Fatalf(`Events should be decoded. But they equal %v`, first)
+	}
+	aapl, ibm := first[0], first[2]
+
+	second := pool.Decode(newEventList(newQuote("MSFT", 3)))
+	if len(second) != 1 || second[0] != aapl {
+		t.Errorf(`The first quote should be reused. But it equals %v`, second)
+	}
+	// the previous batch is overwritten
+	if q := first[0].(*quote.Quote); *q.EventSymbol() != "MSFT" || q.BidPrice() != 3 {
+		t.Errorf(`The previous quote should be overwritten. But it equals %v`, q)
+	}
+
+	third := pool.Decode(newEventList(trade.NewTrade("AAPL"), newQuote("GOOG", 4), newQuote("IBM", 5)))
+	if third[1] != aapl || third[2] != ibm || *third[2].(*quote.Quote).EventSymbol() != "IBM" {
+		t.Errorf(`Quotes should be reused in their order. But they equal %v`, third)
+	}
+	if _, ok := third[0].(*trade.Trade); !ok || *third[0].(*trade.Trade).EventSymbol() != "AAPL" {
+		t.Errorf(`Trade should be decoded. But it equals %v`, third[0])
+	}
+	if pool.Decode(nil) != nil {
+		t.Errorf(`Decode of no events should return nil`)
+	}
+}
+
+func TestEventPoolSharesInternedStrings(t *testing.T) {
+	tns := timeandsale.NewTimeAndSale("AAPL")
+	buyer := "BUYER"
+	tns.SetBuyer(&buyer)
+	list := newEventList(newQuote("AAPL", 1), tns, newQuote("AAPL", 2))
+	pool := NewEventPool(10)
+	decoded := pool.Decode(list)
+	symbol := decoded[0].(*quote.Quote).EventSymbol()
+	if decoded[1].(*timeandsale.TimeAndSale).EventSymbol() != symbol ||
+		decoded[2].(*quote.Quote).EventSymbol() != symbol {
+		t.Errorf(`Events should share the interned symbol. But they equal %v`, decoded)
+	}
+	buyerOf := decoded[1].(*timeandsale.TimeAndSale).Buyer()
+	if allocs := testing.AllocsPerRun(10, func() { pool.Decode(list) }); allocs != 0 {
+		t.Errorf(`Decode of interned strings should not allocate. But it allocates %v times`, allocs)
+	}
+	if pool.Decode(list)[1].(*timeandsale.TimeAndSale).Buyer() != buyerOf {
+		t.Errorf(`Buyer should be the interned string`)
+	}
+}
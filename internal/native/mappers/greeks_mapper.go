@@ -9,6 +9,7 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/greeks"
 )
 
@@ -33,10 +34,24 @@ func (GreeksMapper) CEvent(event interface{}) unsafe.Pointer {
 	return unsafe.Pointer(g)
 }
 
-func (GreeksMapper) GoEvent(native unsafe.Pointer) interface{} {
+func (m GreeksMapper) GoEvent(native unsafe.Pointer) interface{} {
 	greeksNative := (*C.dxfg_greeks_t)(native)
 	g := greeks.NewGreeks(C.GoString(greeksNative.market_event.event_symbol))
-	g.SetEventSymbol(C.GoString(greeksNative.market_event.event_symbol))
+	m.fill(native, g, nil)
+	return g
+}
+
+func (GreeksMapper) newEvent() interface{} {
+	return greeks.NewGreeks("")
+}
+
+func (GreeksMapper) setSymbol(native unsafe.Pointer, event interface{}, strings *Strings) {
+	event.(*greeks.Greeks).SetInternedEventSymbol(intern.Access{}, strings.symbol((*C.dxfg_greeks_t)(native).market_event.event_symbol))
+}
+
+func (GreeksMapper) fill(native unsafe.Pointer, event interface{}, _ *Strings) {
+	greeksNative := (*C.dxfg_greeks_t)(native)
+	g := event.(*greeks.Greeks)
 	g.SetEventTime(int64(greeksNative.market_event.event_time))
 	g.SetEventFlags(int32(greeksNative.event_flags))
 	g.SetIndex(int64(greeksNative.index))
@@ -47,5 +62,4 @@ func (GreeksMapper) GoEvent(native unsafe.Pointer) interface{} {
 	g.SetTheta(float64(greeksNative.theta))
 	g.SetRho(float64(greeksNative.rho))
 	g.SetVega(float64(greeksNative.vega))
-	return g
 }
@@ -9,6 +9,7 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
 )
 
@@ -46,9 +47,25 @@ func (OrderMapper) CEvent(event interface{}) unsafe.Pointer {
 	return unsafe.Pointer(q)
 }
 
-func (OrderMapper) GoEvent(native unsafe.Pointer) interface{} {
+func (m OrderMapper) GoEvent(native unsafe.Pointer) interface{} {
 	orderNative := (*C.dxfg_order_t)(native)
 	o := order.NewOrder(C.GoString(orderNative.order_base.market_event.event_symbol))
+	m.fill(native, o, nil)
+	return o
+}
+
+func (OrderMapper) newEvent() interface{} {
+	return order.NewOrder("")
+}
+
+func (OrderMapper) setSymbol(native unsafe.Pointer, event interface{}, strings *Strings) {
+	symbol := strings.symbol((*C.dxfg_order_t)(native).order_base.market_event.event_symbol)
+	event.(*order.Order).SetInternedEventSymbol(intern.Access{}, symbol)
+}
+
+func (OrderMapper) fill(native unsafe.Pointer, event interface{}, strings *Strings) {
+	orderNative := (*C.dxfg_order_t)(native)
+	o := event.(*order.Order)
 	o.SetEventTime(int64(orderNative.order_base.market_event.event_time))
 
 	o.SetEventFlags(int32(orderNative.order_base.event_flags))
@@ -67,7 +84,5 @@ func (OrderMapper) GoEvent(native unsafe.Pointer) interface{} {
 	o.SetTradePrice(float64(orderNative.order_base.trade_price))
 	o.SetTradeSize(float64(orderNative.order_base.trade_size))
 
-	o.SetMarketMaker(convertString(orderNative.market_maker))
-
-	return o
+	o.SetInternedMarketMaker(intern.Access{}, strings.string(orderNative.market_maker))
 }
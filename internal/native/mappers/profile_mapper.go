@@ -9,6 +9,7 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/profile"
 )
 
@@ -44,13 +45,26 @@ func (ProfileMapper) CEvent(event interface{}) unsafe.Pointer {
 	return unsafe.Pointer(p)
 }
 
-func (ProfileMapper) GoEvent(nativeEvent unsafe.Pointer) interface{} {
+func (m ProfileMapper) GoEvent(nativeEvent unsafe.Pointer) interface{} {
 	native := (*C.dxfg_profile_t)(nativeEvent)
-
 	p := profile.NewProfile(C.GoString(native.market_event.event_symbol))
+	m.fill(nativeEvent, p, nil)
+	return p
+}
+
+func (ProfileMapper) newEvent() interface{} {
+	return profile.NewProfile("")
+}
+
+func (ProfileMapper) setSymbol(nativeEvent unsafe.Pointer, event interface{}, strings *Strings) {
+	event.(*profile.Profile).SetInternedEventSymbol(intern.Access{}, strings.symbol((*C.dxfg_profile_t)(nativeEvent).market_event.event_symbol))
+}
+
+func (ProfileMapper) fill(nativeEvent unsafe.Pointer, event interface{}, strings *Strings) {
+	native := (*C.dxfg_profile_t)(nativeEvent)
+	p := event.(*profile.Profile)
 	p.SetEventTime(int64(native.market_event.event_time))
-	p.SetDescription(convertString(native.description))
-	p.SetStatusReason(convertString(native.status_reason))
+	p.SetInternedStrings(intern.Access{}, strings.string(native.description), strings.string(native.status_reason))
 	p.SetHaltStartTime(int64(native.halt_start_time))
 	p.SetHaltEndTime(int64(native.halt_end_time))
 	p.SetHighLimitPrice(float64(native.high_limit_price))
@@ -65,5 +79,4 @@ func (ProfileMapper) GoEvent(nativeEvent unsafe.Pointer) interface{} {
 	p.SetShares(float64(native.shares))
 	p.SetFreeFloat(float64(native.free_float))
 	p.SetFlags(int32(native.flags))
-	return p
 }
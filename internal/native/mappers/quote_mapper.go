@@ -9,6 +9,7 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
 )
 
@@ -34,12 +35,25 @@ func (QuoteMapper) CEvent(event interface{}) unsafe.Pointer {
 	return unsafe.Pointer(q)
 }
 
-func (QuoteMapper) GoEvent(native unsafe.Pointer) interface{} {
+func (m QuoteMapper) GoEvent(native unsafe.Pointer) interface{} {
 	quoteNative := (*C.dxfg_quote_t)(native)
 	q := quote.NewQuote(C.GoString(quoteNative.market_event.event_symbol))
-	q.SetEventSymbol(C.GoString(quoteNative.market_event.event_symbol))
+	m.fill(native, q, nil)
+	return q
+}
+
+func (QuoteMapper) newEvent() interface{} {
+	return quote.NewQuote("")
+}
+
+func (QuoteMapper) setSymbol(native unsafe.Pointer, event interface{}, strings *Strings) {
+	event.(*quote.Quote).SetInternedEventSymbol(intern.Access{}, strings.symbol((*C.dxfg_quote_t)(native).market_event.event_symbol))
+}
+
+func (QuoteMapper) fill(native unsafe.Pointer, event interface{}, _ *Strings) {
+	quoteNative := (*C.dxfg_quote_t)(native)
+	q := event.(*quote.Quote)
 	q.SetEventTime(int64(quoteNative.market_event.event_time))
-	q.SetTimeMillisSequence(int32(quoteNative.time_millis_sequence))
 	q.SetTimeNanoPart(int32(quoteNative.time_nano_part))
 	q.SetBidTime(int64(quoteNative.bid_time))
 	q.SetBidExchangeCode(rune(quoteNative.bid_exchange_code))
@@ -49,5 +63,6 @@ func (QuoteMapper) GoEvent(native unsafe.Pointer) interface{} {
 	q.SetAskExchangeCode(rune(quoteNative.ask_exchange_code))
 	q.SetAskPrice(float64(quoteNative.ask_price))
 	q.SetAskSize(float64(quoteNative.ask_size))
-	return q
+	// bid and ask times recompute the millis of the sequence from times in seconds, so it is set last
+	q.SetTimeMillisSequence(int32(quoteNative.time_millis_sequence))
 }
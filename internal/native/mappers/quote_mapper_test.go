@@ -0,0 +1,30 @@
+package mappers
+
+import (
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+)
+
+func TestQuoteMapperKeepsTimeMillisSequence(t *testing.T) {
+	q := quote.NewQuote("AAPL")
+	// bid and ask times of the scheme are in seconds, the millis of the quote time are in the sequence
+	q.SetBidTime(1700000000000)
+	q.SetAskTime(1699999999000)
+	q.SetTimeMillisSequence(123<<22 | 7)
+	native := QuoteMapper{}.CEvent(q)
+
+	// pooled quotes are filled with interned strings
+	pooled := quote.NewQuote("")
+	QuoteMapper{}.fill(native, pooled, NewStrings(10))
+	for _, decoded := range []*quote.Quote{QuoteMapper{}.GoEvent(native).(*quote.Quote), pooled} {
+		if decoded.TimeMillisSequence() != q.TimeMillisSequence() {
+			t.Errorf(`TimeMillisSequence should be %v. But it equals %v`,
+				q.TimeMillisSequence(), decoded.TimeMillisSequence())
+		}
+		if decoded.Time() != 1700000000123 || decoded.Sequence() != 7 {
+			t.Errorf(`Time and sequence should be 1700000000123 and 7. But they equal %v and %v`,
+				decoded.Time(), decoded.Sequence())
+		}
+	}
+}
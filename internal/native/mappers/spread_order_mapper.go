@@ -9,6 +9,7 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
 )
 
@@ -46,9 +47,25 @@ func (SpreadOrderMapper) CEvent(event interface{}) unsafe.Pointer {
 	return unsafe.Pointer(q)
 }
 
-func (SpreadOrderMapper) GoEvent(native unsafe.Pointer) interface{} {
+func (m SpreadOrderMapper) GoEvent(native unsafe.Pointer) interface{} {
 	orderNative := (*C.dxfg_spread_order_t)(native)
 	o := order.NewSpreadOrder(C.GoString(orderNative.order_base.market_event.event_symbol))
+	m.fill(native, o, nil)
+	return o
+}
+
+func (SpreadOrderMapper) newEvent() interface{} {
+	return order.NewSpreadOrder("")
+}
+
+func (SpreadOrderMapper) setSymbol(native unsafe.Pointer, event interface{}, strings *Strings) {
+	symbol := strings.symbol((*C.dxfg_spread_order_t)(native).order_base.market_event.event_symbol)
+	event.(*order.SpreadOrder).SetInternedEventSymbol(intern.Access{}, symbol)
+}
+
+func (SpreadOrderMapper) fill(native unsafe.Pointer, event interface{}, strings *Strings) {
+	orderNative := (*C.dxfg_spread_order_t)(native)
+	o := event.(*order.SpreadOrder)
 	o.SetEventTime(int64(orderNative.order_base.market_event.event_time))
 
 	o.SetEventFlags(int32(orderNative.order_base.event_flags))
@@ -67,7 +84,5 @@ func (SpreadOrderMapper) GoEvent(native unsafe.Pointer) interface{} {
 	o.SetTradePrice(float64(orderNative.order_base.trade_price))
 	o.SetTradeSize(float64(orderNative.order_base.trade_size))
 
-	o.SetSpreadSymbol(convertString(orderNative.spread_symbol))
-
-	return o
+	o.SetInternedSpreadSymbol(intern.Access{}, strings.string(orderNative.spread_symbol))
 }
@@ -9,6 +9,7 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
 )
 
@@ -35,9 +36,25 @@ func (TimeAndSaleMapper) CEvent(event interface{}) unsafe.Pointer {
 	return unsafe.Pointer(t)
 }
 
-func (TimeAndSaleMapper) GoEvent(native unsafe.Pointer) interface{} {
+func (m TimeAndSaleMapper) GoEvent(native unsafe.Pointer) interface{} {
 	newTimeAndSale := (*C.dxfg_time_and_sale_t)(native)
 	t := timeandsale.NewTimeAndSale(C.GoString(newTimeAndSale.market_event.event_symbol))
+	m.fill(native, t, nil)
+	return t
+}
+
+func (TimeAndSaleMapper) newEvent() interface{} {
+	return timeandsale.NewTimeAndSale("")
+}
+
+func (TimeAndSaleMapper) setSymbol(native unsafe.Pointer, event interface{}, strings *Strings) {
+	symbol := strings.symbol((*C.dxfg_time_and_sale_t)(native).market_event.event_symbol)
+	event.(*timeandsale.TimeAndSale).SetInternedEventSymbol(intern.Access{}, symbol)
+}
+
+func (TimeAndSaleMapper) fill(native unsafe.Pointer, event interface{}, strings *Strings) {
+	newTimeAndSale := (*C.dxfg_time_and_sale_t)(native)
+	t := event.(*timeandsale.TimeAndSale)
 	t.SetEventTime(int64(newTimeAndSale.market_event.event_time))
 	t.SetTimeNanoPart(int32(newTimeAndSale.time_nano_part))
 	t.SetExchangeCode(int16(newTimeAndSale.exchange_code))
@@ -45,11 +62,11 @@ func (TimeAndSaleMapper) GoEvent(native unsafe.Pointer) interface{} {
 	t.SetSize(float64(newTimeAndSale.size))
 	t.SetBidPrice(float64(newTimeAndSale.bid_price))
 	t.SetAskPrice(float64(newTimeAndSale.ask_price))
-	t.SetExchangeSaleConditions(convertString(newTimeAndSale.exchange_sale_conditions))
-	t.SetBuyer(convertString(newTimeAndSale.buyer))
-	t.SetSeller(convertString(newTimeAndSale.seller))
+	t.SetInternedStrings(intern.Access{},
+		strings.string(newTimeAndSale.exchange_sale_conditions),
+		strings.string(newTimeAndSale.buyer),
+		strings.string(newTimeAndSale.seller))
 	t.SetEventFlags(int32(newTimeAndSale.event_flags))
 	t.SetIndex(int64(newTimeAndSale.index))
 	t.SetFlags(int32(newTimeAndSale.flags))
-	return t
 }
@@ -9,14 +9,31 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
 )
 
 type TradeETHMapper struct{}
 
-func (TradeETHMapper) GoEvent(native unsafe.Pointer) interface{} {
+func (m TradeETHMapper) GoEvent(native unsafe.Pointer) interface{} {
 	tradeNative := (*C.dxfg_trade_eth_t)(native)
 	tradeEvent := trade.NewTradeETH(C.GoString(tradeNative.trade_base.market_event.event_symbol))
+	m.fill(native, tradeEvent, nil)
+	return tradeEvent
+}
+
+func (TradeETHMapper) newEvent() interface{} {
+	return trade.NewTradeETH("")
+}
+
+func (TradeETHMapper) setSymbol(native unsafe.Pointer, event interface{}, strings *Strings) {
+	symbol := strings.symbol((*C.dxfg_trade_eth_t)(native).trade_base.market_event.event_symbol)
+	event.(*trade.TradeETH).SetInternedEventSymbol(intern.Access{}, symbol)
+}
+
+func (TradeETHMapper) fill(native unsafe.Pointer, event interface{}, _ *Strings) {
+	tradeNative := (*C.dxfg_trade_eth_t)(native)
+	tradeEvent := event.(*trade.TradeETH)
 	tradeEvent.SetEventTime(int64(tradeNative.trade_base.market_event.event_time))
 	tradeEvent.SetTimeSequence(int64(tradeNative.trade_base.time_sequence))
 	tradeEvent.SetTimeNanoPart(int32(tradeNative.trade_base.time_nano_part))
@@ -28,7 +45,6 @@ func (TradeETHMapper) GoEvent(native unsafe.Pointer) interface{} {
 	tradeEvent.SetDayVolume(float64(tradeNative.trade_base.day_volume))
 	tradeEvent.SetDayTurnover(float64(tradeNative.trade_base.day_turnover))
 	tradeEvent.SetFlags(int32(tradeNative.trade_base.flags))
-	return tradeEvent
 }
 
 func (TradeETHMapper) CEvent(event interface{}) unsafe.Pointer {
@@ -9,14 +9,31 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
 )
 
 type TradeMapper struct{}
 
-func (TradeMapper) GoEvent(native unsafe.Pointer) interface{} {
+func (m TradeMapper) GoEvent(native unsafe.Pointer) interface{} {
 	tradeNative := (*C.dxfg_trade_t)(native)
 	tradeEvent := trade.NewTrade(C.GoString(tradeNative.trade_base.market_event.event_symbol))
+	m.fill(native, tradeEvent, nil)
+	return tradeEvent
+}
+
+func (TradeMapper) newEvent() interface{} {
+	return trade.NewTrade("")
+}
+
+func (TradeMapper) setSymbol(native unsafe.Pointer, event interface{}, strings *Strings) {
+	symbol := strings.symbol((*C.dxfg_trade_t)(native).trade_base.market_event.event_symbol)
+	event.(*trade.Trade).SetInternedEventSymbol(intern.Access{}, symbol)
+}
+
+func (TradeMapper) fill(native unsafe.Pointer, event interface{}, _ *Strings) {
+	tradeNative := (*C.dxfg_trade_t)(native)
+	tradeEvent := event.(*trade.Trade)
 	tradeEvent.SetEventTime(int64(tradeNative.trade_base.market_event.event_time))
 	tradeEvent.SetTimeSequence(int64(tradeNative.trade_base.time_sequence))
 	tradeEvent.SetTimeNanoPart(int32(tradeNative.trade_base.time_nano_part))
@@ -28,7 +45,6 @@ func (TradeMapper) GoEvent(native unsafe.Pointer) interface{} {
 	tradeEvent.SetDayVolume(float64(tradeNative.trade_base.day_volume))
 	tradeEvent.SetDayTurnover(float64(tradeNative.trade_base.day_turnover))
 	tradeEvent.SetFlags(int32(tradeNative.trade_base.flags))
-	return tradeEvent
 }
 
 func (TradeMapper) CEvent(event interface{}) unsafe.Pointer {
@@ -0,0 +1,99 @@
+package native
+
+import (
+	"strconv"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/batch"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/metrics"
+)
+
+var (
+	isolateCallDuration = metrics.DefaultRegistry.NewHistogram(
+		"dxfeed_isolate_call_duration_seconds",
+		"Duration of calls dispatched to isolate threads, including the time in the queue.",
+		nil,
+	)
+	isolateCallErrors = metrics.DefaultRegistry.NewCounter(
+		"dxfeed_isolate_call_errors_total",
+		"Number of calls dispatched to isolate threads that returned an error.",
+	)
+	eventsReceived = metrics.DefaultRegistry.NewCounterVec(
+		"dxfeed_events_received_total",
+		"Number of events received by subscription listeners.",
+		"type",
+	)
+	listenerBatchSize = metrics.DefaultRegistry.NewHistogram(
+		"dxfeed_listener_batch_size",
+		"Number of events in batches passed to subscription listeners.",
+		metrics.SizeBuckets,
+	)
+	listenerDuration = metrics.DefaultRegistry.NewHistogram(
+		"dxfeed_listener_duration_seconds",
+		"Duration of calls of subscription listeners.",
+		nil,
+	)
+
+	// receivedByType avoids the lookup of counters by labels for each batch
+	receivedByType [eventcodes.OptionSale + 1]*metrics.Counter
+)
+
+func init() {
+	for code := range receivedByType {
+		receivedByType[code] = eventsReceived.WithLabelValues(eventcodes.EventCode(code).String())
+	}
+}
+
+func registerPoolMetrics(p *workerPool) {
+	queueDepth := metrics.DefaultRegistry.NewGaugeVec(
+		"dxfeed_isolate_queue_depth",
+		"Number of calls waiting in the queue of an isolate worker.",
+		"worker",
+	)
+	for i, requests := range p.requestChans {
+		requests := requests
+		queueDepth.WithLabelValues(strconv.Itoa(i)).SetFunc(func() float64 {
+			return float64(len(requests))
+		})
+	}
+}
+
+func countReceivedEvents(eventsList []interface{}) {
+	listenerBatchSize.Observe(float64(len(eventsList)))
+	// events of a batch usually have the same type, so the counter is updated once per run of a type
+	var counter *metrics.Counter
+	var count uint64
+	for _, event := range eventsList {
+		next := counterOf(event)
+		if next != counter {
+			if count > 0 {
+				counter.Add(count)
+			}
+			counter, count = next, 0
+		}
+		count++
+	}
+	if count > 0 {
+		counter.Add(count)
+	}
+}
+
+func countReceivedBatches(batches []batch.Batch) {
+	size := 0
+	for _, b := range batches {
+		size += b.Len()
+		receivedByType[b.Type()].Add(uint64(b.Len()))
+	}
+	listenerBatchSize.Observe(float64(size))
+}
+
+func counterOf(event interface{}) *metrics.Counter {
+	if e, ok := event.(events.EventType); ok {
+		if code := int(e.Type()); code >= 0 && code < len(receivedByType) {
+			return receivedByType[code]
+		}
+		return eventsReceived.WithLabelValues(e.Type().String())
+	}
+	return eventsReceived.WithLabelValues("Unknown")
+}
@@ -0,0 +1,126 @@
+package native
+
+/*
+#include "graal/dxfg_api.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+type OnDemandServiceHandle struct {
+	handle Handler
+}
+
+func NewOnDemandServiceHandle(ptr *C.dxfg_on_demand_service_t) *OnDemandServiceHandle {
+	return &OnDemandServiceHandle{handle: NewJavaHandle(unsafe.Pointer(ptr))}
+}
+
+func (s *OnDemandServiceHandle) IsReplaySupported() (bool, error) {
+	return s.boolCall(func(thread *isolateThread) C.int32_t {
+		return C.dxfg_OnDemandService_isReplaySupported(thread.ptr, s.ptr())
+	})
+}
+
+func (s *OnDemandServiceHandle) IsReplay() (bool, error) {
+	return s.boolCall(func(thread *isolateThread) C.int32_t {
+		return C.dxfg_OnDemandService_isReplay(thread.ptr, s.ptr())
+	})
+}
+
+func (s *OnDemandServiceHandle) IsClear() (bool, error) {
+	return s.boolCall(func(thread *isolateThread) C.int32_t {
+		return C.dxfg_OnDemandService_isClear(thread.ptr, s.ptr())
+	})
+}
+
+func (s *OnDemandServiceHandle) GetTime() (int64, error) {
+	var result C.int64_t
+	err := dispatchOnIsolateThread(func(thread *isolateThread) error {
+		return checkCall(func() {
+			result = C.dxfg_OnDemandService_getTime(thread.ptr, s.ptr())
+		})
+	})
+	return int64(result), err
+}
+
+func (s *OnDemandServiceHandle) GetSpeed() (float64, error) {
+	var result C.double
+	err := dispatchOnIsolateThread(func(thread *isolateThread) error {
+		return checkCall(func() {
+			result = C.dxfg_OnDemandService_getSpeed(thread.ptr, s.ptr())
+		})
+	})
+	return float64(result), err
+}
+
+func (s *OnDemandServiceHandle) Replay(time int64) error {
+	return dispatchOnIsolateThread(func(thread *isolateThread) error {
+		return checkCall(func() {
+			C.dxfg_OnDemandService_replay(thread.ptr, s.ptr(), C.int64_t(time))
+		})
+	})
+}
+
+func (s *OnDemandServiceHandle) ReplayWithSpeed(time int64, speed float64) error {
+	return dispatchOnIsolateThread(func(thread *isolateThread) error {
+		return checkCall(func() {
+			C.dxfg_OnDemandService_replay2(thread.ptr, s.ptr(), C.int64_t(time), C.double(speed))
+		})
+	})
+}
+
+func (s *OnDemandServiceHandle) Pause() error {
+	return dispatchOnIsolateThread(func(thread *isolateThread) error {
+		return checkCall(func() {
+			C.dxfg_OnDemandService_pause(thread.ptr, s.ptr())
+		})
+	})
+}
+
+func (s *OnDemandServiceHandle) StopAndResume() error {
+	return dispatchOnIsolateThread(func(thread *isolateThread) error {
+		return checkCall(func() {
+			C.dxfg_OnDemandService_stopAndResume(thread.ptr, s.ptr())
+		})
+	})
+}
+
+func (s *OnDemandServiceHandle) StopAndClear() error {
+	return dispatchOnIsolateThread(func(thread *isolateThread) error {
+		return checkCall(func() {
+			C.dxfg_OnDemandService_stopAndClear(thread.ptr, s.ptr())
+		})
+	})
+}
+
+func (s *OnDemandServiceHandle) SetSpeed(speed float64) error {
+	return dispatchOnIsolateThread(func(thread *isolateThread) error {
+		return checkCall(func() {
+			C.dxfg_OnDemandService_setSpeed(thread.ptr, s.ptr(), C.double(speed))
+		})
+	})
+}
+
+func (s *OnDemandServiceHandle) Free() error {
+	if s != nil {
+		return s.handle.Free()
+	}
+	return nil
+}
+
+func (s *OnDemandServiceHandle) boolCall(call func(thread *isolateThread) C.int32_t) (bool, error) {
+	var result C.int32_t
+	err := dispatchOnIsolateThread(func(thread *isolateThread) error {
+		return checkCall(func() {
+			result = call(thread)
+		})
+	})
+	return result == 1, err
+}
+
+func (s *OnDemandServiceHandle) ptr() *C.dxfg_on_demand_service_t {
+	return (*C.dxfg_on_demand_service_t)(s.handle.Ptr())
+}
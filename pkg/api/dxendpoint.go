@@ -24,6 +24,7 @@ type DXEndpoint struct {
 }
 
 func (e *DXEndpoint) UpdateState(old common.ConnectionState, new common.ConnectionState) {
+	stateTransitions.WithLabelValues(old.String(), new.String()).Inc()
 	for _, listener := range e.stateListenerList {
 		listener.UpdateState(old, new)
 	}
@@ -61,6 +62,11 @@ func NewEndpointWithProperties(role common.Role, properties map[string]string) (
 		role:           role,
 		endpointHandle: handle,
 	}
+	err = handle.AttachListener(e)
+	if err != nil {
+		_ = handle.Close()
+		return nil, err
+	}
 	return e, nil
 }
 
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/common"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+const (
+	DXEndpointNameProperty          = "name"
+	DXFeedPropertiesProperty        = "dxfeed.properties"
+	DXFeedAddressProperty           = "dxfeed.address"
+	DXFeedUserProperty              = "dxfeed.user"
+	DXFeedPasswordProperty          = "dxfeed.password"
+	DXFeedThreadPoolSizeProperty    = "dxfeed.threadPoolSize"
+	DXFeedAggregationPeriodProperty = "dxfeed.aggregationPeriod"
+	DXPublisherPropertiesProperty   = "dxpublisher.properties"
+	DXPublisherAddressProperty      = "dxpublisher.address"
+	DXSchemeNanoTimeProperty        = "dxscheme.nanoTime"
+)
+
+// DXEndpointBuilder collects the role and properties of an endpoint. The Feed role is used by default.
+type DXEndpointBuilder struct {
+	role       common.Role
+	properties map[string]string
+}
+
+func NewEndpointBuilder() *DXEndpointBuilder {
+	return &DXEndpointBuilder{role: Feed, properties: map[string]string{}}
+}
+
+func (b *DXEndpointBuilder) WithRole(role common.Role) *DXEndpointBuilder {
+	b.role = role
+	return b
+}
+
+func (b *DXEndpointBuilder) WithName(name string) *DXEndpointBuilder {
+	return b.WithProperty(DXEndpointNameProperty, name)
+}
+
+func (b *DXEndpointBuilder) WithProperty(key string, value string) *DXEndpointBuilder {
+	b.properties[key] = value
+	return b
+}
+
+func (b *DXEndpointBuilder) WithProperties(properties map[string]string) *DXEndpointBuilder {
+	for key, value := range properties {
+		b.properties[key] = value
+	}
+	return b
+}
+
+// WithAggregationPeriod sets the period of data aggregation for the feed. Zero period disables aggregation.
+func (b *DXEndpointBuilder) WithAggregationPeriod(period timeutil.TimePeriod) *DXEndpointBuilder {
+	return b.WithProperty(DXFeedAggregationPeriodProperty, period.String())
+}
+
+// Build creates the endpoint. The builder may be reused to create more endpoints.
+func (b *DXEndpointBuilder) Build() (*DXEndpoint, error) {
+	properties := make(map[string]string, len(b.properties))
+	for key, value := range b.properties {
+		properties[key] = value
+	}
+	return NewEndpointWithProperties(b.role, properties)
+}
@@ -15,5 +15,5 @@ func (f *DXFeed) CreateSubscription(eventType ...eventcodes.EventCode) (*DXFeedS
 		data[i] = eventType[i].NativeCode()
 	}
 	sub, err := f.feed.CreateSubscription(data...)
-	return &DXFeedSubscription{sub: sub}, err
+	return &DXFeedSubscription{sub: sub, eventTypes: eventType}, err
 }
@@ -1,12 +1,17 @@
 package api
 
 import (
+	"fmt"
+
 	"github.com/dxfeed/dxfeed-graal-go-api/internal/native"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/batch"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/common"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
 )
 
 type DXFeedSubscription struct {
 	sub               *native.DXFeedSubscription
+	eventTypes        []eventcodes.EventCode
 	eventListenerList []common.EventListener
 }
 
@@ -19,6 +24,26 @@ func (s *DXFeedSubscription) AddListener(listener common.EventListener) error {
 	return s.sub.AttachListener(listener)
 }
 
+// AddPooledListener adds a listener receiving events that are reused by the subscription: the events
+// and the slice passed to Update are only valid until it returns, and strings of events, like symbols,
+// are interned and shared by events, they must not be written through. Listeners keep clones of events
+// (see Quote.Clone). In exchange events are decoded without allocations.
+func (s *DXFeedSubscription) AddPooledListener(listener common.EventListener) error {
+	s.eventListenerList = append(s.eventListenerList, listener)
+	return s.sub.AttachPooledListener(listener)
+}
+
+// AddBatchListener adds a listener receiving events as columns, a batch per event type, decoded without creating
+// events. Batches are only valid until UpdateBatch returns. Only the event types of batch.EventTypes are supported.
+func (s *DXFeedSubscription) AddBatchListener(listener batch.Listener) error {
+	for _, eventType := range s.eventTypes {
+		if !batch.IsSupported(eventType) {
+			return fmt.Errorf("%s events are not supported by batch listeners", eventType)
+		}
+	}
+	return s.sub.AttachBatchListener(listener)
+}
+
 func (s *DXFeedSubscription) RemoveListener(listener common.EventListener) {
 	s.eventListenerList = removeFromSlice(s.eventListenerList, listener)
 }
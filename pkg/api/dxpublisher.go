@@ -1,6 +1,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/dxfeed/dxfeed-graal-go-api/internal/native"
 )
 
@@ -9,5 +11,13 @@ type DXPublisher struct {
 }
 
 func (p *DXPublisher) Publish(events []interface{}) error {
-	return p.publisher.Publish(events)
+	start := time.Now()
+	err := p.publisher.Publish(events)
+	publishDuration.ObserveSince(start)
+	if err != nil {
+		publishErrors.Inc()
+		return err
+	}
+	eventsPublished.Add(uint64(len(events)))
+	return nil
 }
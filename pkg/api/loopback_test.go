@@ -0,0 +1,144 @@
+package api
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/batch"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/loopback"
+)
+
+const loopbackSymbols = 100
+
+type loopbackListener struct {
+	current atomic.Pointer[loopback.Run]
+}
+
+func (l *loopbackListener) Update(events []interface{}) {
+	l.current.Load().Receive(events)
+}
+
+func (l *loopbackListener) UpdateBatch(b batch.Batch) {
+	l.current.Load().ReceiveBatch(b)
+}
+
+// listenerMode is how a loopback benchmark receives events.
+type listenerMode int
+
+const (
+	eventsMode listenerMode = iota
+	pooledMode
+	batchMode
+)
+
+// benchmarkLoopback publishes b.N events to a local hub and receives them on a subscription of the same hub.
+// Besides ns/op and allocs/op per event it reports the percentiles of the end-to-end latency.
+// Pooled and Batch benchmarks receive events with AddPooledListener and AddBatchListener.
+func benchmarkLoopback(b *testing.B, eventType eventcodes.EventCode, batchSize int, mode listenerMode) {
+	endpoint, err := NewEndpoint(LocalHub)
+	if err != nil {
+		b.Fatalf(`NewEndpoint should not fail. But it returns %v`, err)
+	}
+	defer func() {
+		_ = endpoint.Close()
+	}()
+	publisher, err := endpoint.GetPublisher()
+	if err != nil {
+		b.Fatalf(`GetPublisher should not fail. But it returns %v`, err)
+	}
+	feed, err := endpoint.GetFeed()
+	if err != nil {
+		b.Fatalf(`GetFeed should not fail. But it returns %v`, err)
+	}
+	sub, err := feed.CreateSubscription(eventType)
+	if err != nil {
+		b.Fatalf(`CreateSubscription should not fail. But it returns %v`, err)
+	}
+	defer sub.Close()
+	listener := &loopbackListener{}
+	switch mode {
+	case pooledMode:
+		err = sub.AddPooledListener(listener)
+	case batchMode:
+		err = sub.AddBatchListener(listener)
+	default:
+		err = sub.AddListener(listener)
+	}
+	if err != nil {
+		b.Fatalf(`AddListener should not fail. But it returns %v`, err)
+	}
+	symbols := loopback.Symbols(loopbackSymbols)
+	for _, symbol := range symbols {
+		if err = sub.AddSymbol(symbol); err != nil {
+			b.Fatalf(`AddSymbol should not fail. But it returns %v`, err)
+		}
+	}
+
+	execute := func(count int) loopback.Result {
+		run, err := loopback.NewRun(loopback.Config{
+			EventType: eventType,
+			Symbols:   symbols,
+			BatchSize: batchSize,
+			Count:     int64(count),
+		}, publisher)
+		if err != nil {
+			b.Fatalf(`NewRun should not fail. But it returns %v`, err)
+		}
+		listener.current.Store(run)
+		result, err := run.Execute()
+		if err != nil {
+			b.Fatalf(`Execute should not fail. But it returns %v`, err)
+		}
+		return result
+	}
+	execute(10_000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	result := execute(b.N)
+	b.StopTimer()
+	b.ReportMetric(float64(result.Latency.P50), "p50-ns")
+	b.ReportMetric(float64(result.Latency.P99), "p99-ns")
+	b.ReportMetric(float64(result.Lost())/float64(b.N), "lost/op")
+}
+
+func BenchmarkLoopbackQuote(b *testing.B) {
+	benchmarkLoopback(b, eventcodes.Quote, 100, eventsMode)
+}
+
+func BenchmarkLoopbackQuotePooled(b *testing.B) {
+	benchmarkLoopback(b, eventcodes.Quote, 100, pooledMode)
+}
+
+func BenchmarkLoopbackQuoteBatch(b *testing.B) {
+	benchmarkLoopback(b, eventcodes.Quote, 100, batchMode)
+}
+
+func BenchmarkLoopbackTimeAndSale(b *testing.B) {
+	benchmarkLoopback(b, eventcodes.TimeAndSale, 100, eventsMode)
+}
+
+func BenchmarkLoopbackTimeAndSalePooled(b *testing.B) {
+	benchmarkLoopback(b, eventcodes.TimeAndSale, 100, pooledMode)
+}
+
+func BenchmarkLoopbackTimeAndSaleBatch(b *testing.B) {
+	benchmarkLoopback(b, eventcodes.TimeAndSale, 100, batchMode)
+}
+
+func BenchmarkLoopbackOrder(b *testing.B) {
+	benchmarkLoopback(b, eventcodes.Order, 100, eventsMode)
+}
+
+func BenchmarkLoopbackOrderPooled(b *testing.B) {
+	benchmarkLoopback(b, eventcodes.Order, 100, pooledMode)
+}
+
+func BenchmarkLoopbackOrderBatch(b *testing.B) {
+	benchmarkLoopback(b, eventcodes.Order, 100, batchMode)
+}
+
+func BenchmarkLoopbackTimeAndSaleUnbatched(b *testing.B) {
+	benchmarkLoopback(b, eventcodes.TimeAndSale, 1, eventsMode)
+}
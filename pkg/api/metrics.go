@@ -0,0 +1,26 @@
+package api
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/metrics"
+)
+
+var (
+	eventsPublished = metrics.DefaultRegistry.NewCounter(
+		"dxfeed_events_published_total",
+		"Number of events published by publishers.",
+	)
+	publishErrors = metrics.DefaultRegistry.NewCounter(
+		"dxfeed_publish_errors_total",
+		"Number of failed calls of Publish.",
+	)
+	publishDuration = metrics.DefaultRegistry.NewHistogram(
+		"dxfeed_publish_duration_seconds",
+		"Duration of calls of Publish.",
+		nil,
+	)
+	stateTransitions = metrics.DefaultRegistry.NewCounterVec(
+		"dxfeed_endpoint_state_transitions_total",
+		"Number of transitions between connection states of endpoints.",
+		"from", "to",
+	)
+)
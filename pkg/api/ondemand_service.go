@@ -0,0 +1,94 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/native"
+)
+
+// OnDemandService replays historical market data from a moment in the past through an endpoint
+// of the OnDemandFeed role. Subscriptions of the feed of the endpoint receive the replayed data.
+//
+// The endpoint is connected to an on-demand data provider, e.g. "ondemand:demo.dxfeed.com:7680"
+// with the user and password properties set, and switches between the real-time and replay modes:
+//
+//	endpoint, _ := api.NewEndpointBuilder().WithRole(api.OnDemandFeed).Build()
+//	_ = endpoint.Connect("ondemand:demo.dxfeed.com:7680")
+//	service, _ := api.GetOnDemandService(endpoint)
+//	_ = service.Replay(time.Date(2024, 1, 2, 15, 30, 0, 0, time.UTC).UnixMilli())
+type OnDemandService struct {
+	endpoint *DXEndpoint
+	service  *native.OnDemandServiceHandle
+}
+
+// GetOnDemandService returns the on-demand service bound to the endpoint of the OnDemandFeed role.
+func GetOnDemandService(endpoint *DXEndpoint) (*OnDemandService, error) {
+	if endpoint.role != OnDemandFeed {
+		return nil, errors.New("OnDemandService requires an endpoint with the OnDemandFeed role")
+	}
+	handle, err := endpoint.endpointHandle.GetOnDemandService()
+	if err != nil {
+		return nil, err
+	}
+	return &OnDemandService{endpoint: endpoint, service: handle}, nil
+}
+
+func (s *OnDemandService) Endpoint() *DXEndpoint {
+	return s.endpoint
+}
+
+// IsReplaySupported returns whether the connected data provider supports replay.
+// It returns false until the endpoint is connected.
+func (s *OnDemandService) IsReplaySupported() (bool, error) {
+	return s.service.IsReplaySupported()
+}
+
+// IsReplay returns whether the service is in the replay mode, including when the replay is paused.
+func (s *OnDemandService) IsReplay() (bool, error) {
+	return s.service.IsReplay()
+}
+
+// IsClear returns whether the service was stopped with StopAndClear and subscriptions receive no data.
+func (s *OnDemandService) IsClear() (bool, error) {
+	return s.service.IsClear()
+}
+
+// GetTime returns the current time of the replay in milliseconds, or the current time in the real-time mode.
+func (s *OnDemandService) GetTime() (int64, error) {
+	return s.service.GetTime()
+}
+
+// GetSpeed returns the replay speed, 0 when the replay is paused and 1 in the real-time mode.
+func (s *OnDemandService) GetSpeed() (float64, error) {
+	return s.service.GetSpeed()
+}
+
+// Replay starts the replay of data from the time in milliseconds at the normal speed.
+func (s *OnDemandService) Replay(time int64) error {
+	return s.service.Replay(time)
+}
+
+// ReplayWithSpeed starts the replay of data from the time in milliseconds at the speed.
+func (s *OnDemandService) ReplayWithSpeed(time int64, speed float64) error {
+	return s.service.ReplayWithSpeed(time, speed)
+}
+
+// Pause pauses the replay, subscriptions keep the last received data.
+func (s *OnDemandService) Pause() error {
+	return s.service.Pause()
+}
+
+// StopAndResume stops the replay and resumes receiving real-time data.
+func (s *OnDemandService) StopAndResume() error {
+	return s.service.StopAndResume()
+}
+
+// StopAndClear stops the replay or the real-time data and clears the data of subscriptions.
+func (s *OnDemandService) StopAndClear() error {
+	return s.service.StopAndClear()
+}
+
+// SetSpeed changes the replay speed, 0 pauses the replay. Speeds above 1 replay data faster than real time.
+func (s *OnDemandService) SetSpeed(speed float64) error {
+	return s.service.SetSpeed(speed)
+}
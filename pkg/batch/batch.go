@@ -0,0 +1,150 @@
+// Package batch keeps events as columns, a slice per field, for consumers processing many events of a type
+// at once, like analytics. Batches avoid the allocation of every event and the boxing of events into interfaces.
+//
+// Missing strings, like the market maker of an order, are empty in columns.
+package batch
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+)
+
+// EventTypes are the event types kept in batches.
+var EventTypes = []eventcodes.EventCode{
+	eventcodes.Quote, eventcodes.TimeAndSale, eventcodes.Trade, eventcodes.Candle, eventcodes.Order,
+}
+
+// Batch is one of *QuoteBatch, *TimeAndSaleBatch, *TradeBatch, *CandleBatch and *OrderBatch.
+type Batch interface {
+	Type() eventcodes.EventCode
+	Len() int
+	Reset()
+}
+
+// Listener receives the batches of a subscription, a batch per event type in the order of the first event
+// of each type. Batches are reused once UpdateBatch returns.
+type Listener interface {
+	UpdateBatch(b Batch)
+}
+
+type ListenerFunc func(b Batch)
+
+func (f ListenerFunc) UpdateBatch(b Batch) {
+	f(b)
+}
+
+// IsSupported returns whether events of the type are kept in batches.
+func IsSupported(eventType eventcodes.EventCode) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Collector splits events into batches by event type. Batches are reused by the next Reset.
+type Collector struct {
+	quotes       QuoteBatch
+	timeAndSales TimeAndSaleBatch
+	trades       TradeBatch
+	candles      CandleBatch
+	orders       OrderBatch
+	batches      []Batch
+}
+
+// Reset empties the collected batches.
+func (c *Collector) Reset() {
+	for _, b := range c.batches {
+		b.Reset()
+	}
+	c.batches = c.batches[:0]
+}
+
+// Batch returns the batch of the event type, it is collected from now on.
+// It returns nil for event types without batches.
+func (c *Collector) Batch(eventType eventcodes.EventCode) Batch {
+	var b Batch
+	switch eventType {
+	case eventcodes.Quote:
+		b = &c.quotes
+	case eventcodes.TimeAndSale:
+		b = &c.timeAndSales
+	case eventcodes.Trade:
+		b = &c.trades
+	case eventcodes.Candle:
+		b = &c.candles
+	case eventcodes.Order:
+		b = &c.orders
+	default:
+		return nil
+	}
+	if !c.collected(b) {
+		c.batches = append(c.batches, b)
+	}
+	return b
+}
+
+func (c *Collector) collected(b Batch) bool {
+	for _, collected := range c.batches {
+		if collected == b {
+			return true
+		}
+	}
+	return false
+}
+
+// Batches returns the non-empty batches collected since the last Reset.
+func (c *Collector) Batches() []Batch {
+	batches := c.batches[:0]
+	for _, b := range c.batches {
+		if b.Len() > 0 {
+			batches = append(batches, b)
+		}
+	}
+	c.batches = batches
+	return batches
+}
+
+// Collect resets the collector and adds the events, events of types without batches are skipped.
+func (c *Collector) Collect(events []interface{}) []Batch {
+	c.Reset()
+	for _, event := range events {
+		switch e := event.(type) {
+		case *quote.Quote:
+			c.Batch(eventcodes.Quote).(*QuoteBatch).Append(e)
+		case *timeandsale.TimeAndSale:
+			c.Batch(eventcodes.TimeAndSale).(*TimeAndSaleBatch).Append(e)
+		case *trade.Trade:
+			c.Batch(eventcodes.Trade).(*TradeBatch).Append(e)
+		case *candle.Candle:
+			c.Batch(eventcodes.Candle).(*CandleBatch).Append(e)
+		case *order.Order:
+			c.Batch(eventcodes.Order).(*OrderBatch).Append(e)
+		}
+	}
+	return c.Batches()
+}
+
+// timeOfSequence returns the time in milliseconds packed with a sequence into the index of time series events.
+func timeOfSequence(timeSequence int64) int64 {
+	return ((timeSequence >> 32) * 1000) + ((timeSequence >> 22) & 0x3ff)
+}
+
+func stringOf(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func pointerOf(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
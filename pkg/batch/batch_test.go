@@ -0,0 +1,86 @@
+package batch
+
+import (
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/eventtest"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+)
+
+func TestCollect(t *testing.T) {
+	events := eventtest.Events()
+	collector := &Collector{}
+	batches := collector.Collect(events)
+	types := []eventcodes.EventCode{eventcodes.Quote, eventcodes.TimeAndSale, eventcodes.Trade, eventcodes.Order, eventcodes.Candle}
+	if len(batches) != len(types) {
+		t.Fatalf(`Number of batches should be %v. But it equals %v`, len(types), len(batches))
+	}
+	for i, b := range batches {
+		if b.Type() != types[i] {
+			t.Errorf(`Type of batch %d should be %v. But it equals %v`, i, types[i], b.Type())
+		}
+	}
+	quotes := batches[0].(*QuoteBatch)
+	if quotes.Len() != 3 || quotes.Symbols[2] != "IBM&Q" || quotes.BidPrice[0] != 100.5 || quotes.AskPrice[2] != 101 {
+		t.Errorf(`Quotes should be AAPL 100.5 bid and IBM&Q 101 ask. But they equal %v %v %v`,
+			quotes.Symbols, quotes.BidPrice, quotes.AskPrice)
+	}
+	if batches[1].(*TimeAndSaleBatch).Buyer[0] != "" || batches[3].(*OrderBatch).MarketMaker[0] != "NSDQ" {
+		t.Errorf(`Missing strings should be empty and present strings kept`)
+	}
+
+	if quotes.TimeNanos(0) != events[0].(*quote.Quote).TimeNanos() ||
+		batches[1].(*TimeAndSaleBatch).TimeNanos(0) != eventtest.Time*1_000_000+456 ||
+		batches[2].(*TradeBatch).TimeNanos(0) != eventtest.Time*1_000_000 ||
+		batches[3].(*OrderBatch).TimeNanos(0) != eventtest.Time*1_000_000 ||
+		batches[4].(*CandleBatch).Time(0) != eventtest.Time {
+		t.Errorf(`Times of batches should equal times of events`)
+	}
+
+	// events rebuilt from batches equal the collected events
+	expected := []interface{}{events[0], events[6], events[7], events[1], events[2], events[4], events[5]}
+	var actual []interface{}
+	for _, b := range batches {
+		for i := 0; i < b.Len(); i++ {
+			switch b := b.(type) {
+			case *QuoteBatch:
+				actual = append(actual, b.Event(i))
+			case *TimeAndSaleBatch:
+				actual = append(actual, b.Event(i))
+			case *TradeBatch:
+				actual = append(actual, b.Event(i))
+			case *CandleBatch:
+				actual = append(actual, b.Event(i))
+			case *OrderBatch:
+				actual = append(actual, b.Event(i))
+			}
+		}
+	}
+	for i := range expected {
+		e, a := expected[i].(interface{ String() string }), actual[i].(interface{ String() string })
+		if e.String() != a.String() {
+			t.Errorf(`Event should be %v. But it equals %v`, e.String(), a.String())
+		}
+	}
+}
+
+func TestCollectReusesBatches(t *testing.T) {
+	collector := &Collector{}
+	events := eventtest.Events()
+	collector.Collect(events)
+	allocs := testing.AllocsPerRun(100, func() {
+		collector.Collect(events)
+	})
+	if allocs != 0 {
+		t.Errorf(`Allocations of Collect should be 0. But it equals %v`, allocs)
+	}
+
+	batches := collector.Collect(events[2:3])
+	if len(batches) != 1 || batches[0].Len() != 1 || batches[0].(*TradeBatch).Symbols[0] != "MSFT" {
+		t.Errorf(`Batches should be a trade of MSFT. But they equal %v`, batches)
+	}
+	if collector.Batch(eventcodes.Profile) != nil || IsSupported(eventcodes.Profile) || !IsSupported(eventcodes.Candle) {
+		t.Errorf(`Profiles should not have batches`)
+	}
+}
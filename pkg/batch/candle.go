@@ -0,0 +1,95 @@
+package batch
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+)
+
+// CandleBatch keeps candles as columns, symbols are candle symbols like "AAPL{=d}".
+type CandleBatch struct {
+	Symbols       []string
+	EventTime     []int64
+	EventFlags    []int32
+	Index         []int64
+	Count         []int64
+	Open          []float64
+	High          []float64
+	Low           []float64
+	Close         []float64
+	Volume        []float64
+	Vwap          []float64
+	BidVolume     []float64
+	AskVolume     []float64
+	ImpVolatility []float64
+	OpenInterest  []float64
+}
+
+func (b *CandleBatch) Type() eventcodes.EventCode {
+	return eventcodes.Candle
+}
+
+func (b *CandleBatch) Len() int {
+	return len(b.Symbols)
+}
+
+// Reset empties the columns keeping their capacity.
+func (b *CandleBatch) Reset() {
+	b.Symbols = b.Symbols[:0]
+	b.EventTime = b.EventTime[:0]
+	b.EventFlags = b.EventFlags[:0]
+	b.Index = b.Index[:0]
+	b.Count = b.Count[:0]
+	b.Open = b.Open[:0]
+	b.High = b.High[:0]
+	b.Low = b.Low[:0]
+	b.Close = b.Close[:0]
+	b.Volume = b.Volume[:0]
+	b.Vwap = b.Vwap[:0]
+	b.BidVolume = b.BidVolume[:0]
+	b.AskVolume = b.AskVolume[:0]
+	b.ImpVolatility = b.ImpVolatility[:0]
+	b.OpenInterest = b.OpenInterest[:0]
+}
+
+func (b *CandleBatch) Append(e *candle.Candle) {
+	b.Symbols = append(b.Symbols, e.EventSymbol().String())
+	b.EventTime = append(b.EventTime, e.EventTime())
+	b.EventFlags = append(b.EventFlags, e.EventFlags())
+	b.Index = append(b.Index, e.Index())
+	b.Count = append(b.Count, e.Count())
+	b.Open = append(b.Open, e.Open())
+	b.High = append(b.High, e.High())
+	b.Low = append(b.Low, e.Low())
+	b.Close = append(b.Close, e.Close())
+	b.Volume = append(b.Volume, e.Volume())
+	b.Vwap = append(b.Vwap, e.Vwap())
+	b.BidVolume = append(b.BidVolume, e.BidVolume())
+	b.AskVolume = append(b.AskVolume, e.AskVolume())
+	b.ImpVolatility = append(b.ImpVolatility, e.ImpVolatility())
+	b.OpenInterest = append(b.OpenInterest, e.OpenInterest())
+}
+
+// Event returns the i-th event as a new Candle.
+func (b *CandleBatch) Event(i int) *candle.Candle {
+	e := candle.NewCandle(b.Symbols[i])
+	e.SetEventTime(b.EventTime[i])
+	e.SetEventFlags(b.EventFlags[i])
+	e.SetIndex(b.Index[i])
+	e.SetCount(b.Count[i])
+	e.SetOpen(b.Open[i])
+	e.SetHigh(b.High[i])
+	e.SetLow(b.Low[i])
+	e.SetClose(b.Close[i])
+	e.SetVolume(b.Volume[i])
+	e.SetVwap(b.Vwap[i])
+	e.SetBidVolume(b.BidVolume[i])
+	e.SetAskVolume(b.AskVolume[i])
+	e.SetImpVolatility(b.ImpVolatility[i])
+	e.SetOpenInterest(b.OpenInterest[i])
+	return e
+}
+
+// Time returns the time of the i-th candle in milliseconds, like Candle.Time.
+func (b *CandleBatch) Time(i int) int64 {
+	return timeOfSequence(b.Index[i])
+}
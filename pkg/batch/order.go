@@ -0,0 +1,108 @@
+package batch
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// OrderBatch keeps orders as columns.
+type OrderBatch struct {
+	Symbols      []string
+	EventTime    []int64
+	EventFlags   []int32
+	Index        []int64
+	TimeSequence []int64
+	TimeNanoPart []int32
+	ActionTime   []int64
+	OrderId      []int64
+	AuxOrderId   []int64
+	Price        []float64
+	Size         []float64
+	ExecutedSize []float64
+	Count        []int64
+	Flags        []int32
+	TradeId      []int64
+	TradePrice   []float64
+	TradeSize    []float64
+	MarketMaker  []string
+}
+
+func (b *OrderBatch) Type() eventcodes.EventCode {
+	return eventcodes.Order
+}
+
+func (b *OrderBatch) Len() int {
+	return len(b.Symbols)
+}
+
+// Reset empties the columns keeping their capacity.
+func (b *OrderBatch) Reset() {
+	b.Symbols = b.Symbols[:0]
+	b.EventTime = b.EventTime[:0]
+	b.EventFlags = b.EventFlags[:0]
+	b.Index = b.Index[:0]
+	b.TimeSequence = b.TimeSequence[:0]
+	b.TimeNanoPart = b.TimeNanoPart[:0]
+	b.ActionTime = b.ActionTime[:0]
+	b.OrderId = b.OrderId[:0]
+	b.AuxOrderId = b.AuxOrderId[:0]
+	b.Price = b.Price[:0]
+	b.Size = b.Size[:0]
+	b.ExecutedSize = b.ExecutedSize[:0]
+	b.Count = b.Count[:0]
+	b.Flags = b.Flags[:0]
+	b.TradeId = b.TradeId[:0]
+	b.TradePrice = b.TradePrice[:0]
+	b.TradeSize = b.TradeSize[:0]
+	b.MarketMaker = b.MarketMaker[:0]
+}
+
+func (b *OrderBatch) Append(e *order.Order) {
+	b.Symbols = append(b.Symbols, *e.EventSymbol())
+	b.EventTime = append(b.EventTime, e.EventTime())
+	b.EventFlags = append(b.EventFlags, e.EventFlags())
+	b.Index = append(b.Index, e.Index())
+	b.TimeSequence = append(b.TimeSequence, e.TimeSequence())
+	b.TimeNanoPart = append(b.TimeNanoPart, e.TimeNanoPart())
+	b.ActionTime = append(b.ActionTime, e.ActionTime())
+	b.OrderId = append(b.OrderId, e.OrderId())
+	b.AuxOrderId = append(b.AuxOrderId, e.AuxOrderId())
+	b.Price = append(b.Price, e.Price())
+	b.Size = append(b.Size, e.Size())
+	b.ExecutedSize = append(b.ExecutedSize, e.ExecutedSize())
+	b.Count = append(b.Count, e.Count())
+	b.Flags = append(b.Flags, e.Flags())
+	b.TradeId = append(b.TradeId, e.TradeId())
+	b.TradePrice = append(b.TradePrice, e.TradePrice())
+	b.TradeSize = append(b.TradeSize, e.TradeSize())
+	b.MarketMaker = append(b.MarketMaker, stringOf(e.MarketMaker()))
+}
+
+// Event returns the i-th event as a new Order.
+func (b *OrderBatch) Event(i int) *order.Order {
+	e := order.NewOrder(b.Symbols[i])
+	e.SetEventTime(b.EventTime[i])
+	e.SetEventFlags(b.EventFlags[i])
+	_ = e.SetIndex(b.Index[i])
+	e.SetTimeSequence(b.TimeSequence[i])
+	e.SetTimeNanoPart(b.TimeNanoPart[i])
+	e.SetActionTime(b.ActionTime[i])
+	e.SetOrderId(b.OrderId[i])
+	e.SetAuxOrderId(b.AuxOrderId[i])
+	e.SetPrice(b.Price[i])
+	e.SetSize(b.Size[i])
+	e.SetExecutedSize(b.ExecutedSize[i])
+	e.SetCount(b.Count[i])
+	e.SetFlags(b.Flags[i])
+	e.SetTradeId(b.TradeId[i])
+	e.SetTradePrice(b.TradePrice[i])
+	e.SetTradeSize(b.TradeSize[i])
+	e.SetMarketMaker(pointerOf(b.MarketMaker[i]))
+	return e
+}
+
+// TimeNanos returns the time of the i-th order in nanoseconds, like Order.TimeNanos.
+func (b *OrderBatch) TimeNanos(i int) int64 {
+	return timeutil.GetNanosFromMillisAndNanoPart(timeOfSequence(b.TimeSequence[i]), b.TimeNanoPart[i])
+}
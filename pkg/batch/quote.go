@@ -0,0 +1,87 @@
+package batch
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// QuoteBatch keeps quotes as columns.
+type QuoteBatch struct {
+	Symbols            []string
+	EventTime          []int64
+	BidTime            []int64
+	BidExchangeCode    []rune
+	BidPrice           []float64
+	BidSize            []float64
+	AskTime            []int64
+	AskExchangeCode    []rune
+	AskPrice           []float64
+	AskSize            []float64
+	TimeMillisSequence []int32
+	TimeNanoPart       []int32
+}
+
+func (b *QuoteBatch) Type() eventcodes.EventCode {
+	return eventcodes.Quote
+}
+
+func (b *QuoteBatch) Len() int {
+	return len(b.Symbols)
+}
+
+// Reset empties the columns keeping their capacity.
+func (b *QuoteBatch) Reset() {
+	b.Symbols = b.Symbols[:0]
+	b.EventTime = b.EventTime[:0]
+	b.BidTime = b.BidTime[:0]
+	b.BidExchangeCode = b.BidExchangeCode[:0]
+	b.BidPrice = b.BidPrice[:0]
+	b.BidSize = b.BidSize[:0]
+	b.AskTime = b.AskTime[:0]
+	b.AskExchangeCode = b.AskExchangeCode[:0]
+	b.AskPrice = b.AskPrice[:0]
+	b.AskSize = b.AskSize[:0]
+	b.TimeMillisSequence = b.TimeMillisSequence[:0]
+	b.TimeNanoPart = b.TimeNanoPart[:0]
+}
+
+func (b *QuoteBatch) Append(e *quote.Quote) {
+	b.Symbols = append(b.Symbols, *e.EventSymbol())
+	b.EventTime = append(b.EventTime, e.EventTime())
+	b.BidTime = append(b.BidTime, e.BidTime())
+	b.BidExchangeCode = append(b.BidExchangeCode, e.BidExchangeCode())
+	b.BidPrice = append(b.BidPrice, e.BidPrice())
+	b.BidSize = append(b.BidSize, e.BidSize())
+	b.AskTime = append(b.AskTime, e.AskTime())
+	b.AskExchangeCode = append(b.AskExchangeCode, e.AskExchangeCode())
+	b.AskPrice = append(b.AskPrice, e.AskPrice())
+	b.AskSize = append(b.AskSize, e.AskSize())
+	b.TimeMillisSequence = append(b.TimeMillisSequence, e.TimeMillisSequence())
+	b.TimeNanoPart = append(b.TimeNanoPart, e.TimeNanoPart())
+}
+
+// Event returns the i-th event as a new Quote.
+func (b *QuoteBatch) Event(i int) *quote.Quote {
+	e := quote.NewQuote(b.Symbols[i])
+	e.SetEventTime(b.EventTime[i])
+	e.SetBidTime(b.BidTime[i])
+	e.SetBidExchangeCode(b.BidExchangeCode[i])
+	e.SetBidPrice(b.BidPrice[i])
+	e.SetBidSize(b.BidSize[i])
+	e.SetAskTime(b.AskTime[i])
+	e.SetAskExchangeCode(b.AskExchangeCode[i])
+	e.SetAskPrice(b.AskPrice[i])
+	e.SetAskSize(b.AskSize[i])
+	e.SetTimeMillisSequence(b.TimeMillisSequence[i])
+	e.SetTimeNanoPart(b.TimeNanoPart[i])
+	return e
+}
+
+// TimeNanos returns the time of the i-th quote in nanoseconds, like Quote.TimeNanos.
+func (b *QuoteBatch) TimeNanos(i int) int64 {
+	timeMillis := mathutil.FloorDivInt(mathutil.MaxInt(b.BidTime[i], b.AskTime[i]), 1000)*1000 +
+		int64(uint32(b.TimeMillisSequence[i])>>22)
+	return timeutil.GetNanosFromMillisAndNanoPart(timeMillis, b.TimeNanoPart[i])
+}
@@ -0,0 +1,92 @@
+package batch
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// TimeAndSaleBatch keeps time and sales as columns.
+type TimeAndSaleBatch struct {
+	Symbols                []string
+	EventTime              []int64
+	EventFlags             []int32
+	Index                  []int64
+	TimeNanoPart           []int32
+	ExchangeCode           []int16
+	Price                  []float64
+	Size                   []float64
+	BidPrice               []float64
+	AskPrice               []float64
+	ExchangeSaleConditions []string
+	Buyer                  []string
+	Seller                 []string
+	Flags                  []int32
+}
+
+func (b *TimeAndSaleBatch) Type() eventcodes.EventCode {
+	return eventcodes.TimeAndSale
+}
+
+func (b *TimeAndSaleBatch) Len() int {
+	return len(b.Symbols)
+}
+
+// Reset empties the columns keeping their capacity.
+func (b *TimeAndSaleBatch) Reset() {
+	b.Symbols = b.Symbols[:0]
+	b.EventTime = b.EventTime[:0]
+	b.EventFlags = b.EventFlags[:0]
+	b.Index = b.Index[:0]
+	b.TimeNanoPart = b.TimeNanoPart[:0]
+	b.ExchangeCode = b.ExchangeCode[:0]
+	b.Price = b.Price[:0]
+	b.Size = b.Size[:0]
+	b.BidPrice = b.BidPrice[:0]
+	b.AskPrice = b.AskPrice[:0]
+	b.ExchangeSaleConditions = b.ExchangeSaleConditions[:0]
+	b.Buyer = b.Buyer[:0]
+	b.Seller = b.Seller[:0]
+	b.Flags = b.Flags[:0]
+}
+
+func (b *TimeAndSaleBatch) Append(e *timeandsale.TimeAndSale) {
+	b.Symbols = append(b.Symbols, *e.EventSymbol())
+	b.EventTime = append(b.EventTime, e.EventTime())
+	b.EventFlags = append(b.EventFlags, e.EventFlags())
+	b.Index = append(b.Index, e.Index())
+	b.TimeNanoPart = append(b.TimeNanoPart, e.TimeNanoPart())
+	b.ExchangeCode = append(b.ExchangeCode, e.ExchangeCode())
+	b.Price = append(b.Price, e.Price())
+	b.Size = append(b.Size, e.Size())
+	b.BidPrice = append(b.BidPrice, e.BidPrice())
+	b.AskPrice = append(b.AskPrice, e.AskPrice())
+	b.ExchangeSaleConditions = append(b.ExchangeSaleConditions, stringOf(e.ExchangeSaleConditions()))
+	b.Buyer = append(b.Buyer, stringOf(e.Buyer()))
+	b.Seller = append(b.Seller, stringOf(e.Seller()))
+	b.Flags = append(b.Flags, e.Flags())
+}
+
+// Event returns the i-th event as a new TimeAndSale.
+func (b *TimeAndSaleBatch) Event(i int) *timeandsale.TimeAndSale {
+	e := timeandsale.NewTimeAndSale(b.Symbols[i])
+	e.SetEventTime(b.EventTime[i])
+	e.SetEventFlags(b.EventFlags[i])
+	e.SetIndex(b.Index[i])
+	e.SetTimeNanoPart(b.TimeNanoPart[i])
+	e.SetExchangeCode(b.ExchangeCode[i])
+	e.SetPrice(b.Price[i])
+	e.SetSize(b.Size[i])
+	e.SetBidPrice(b.BidPrice[i])
+	e.SetAskPrice(b.AskPrice[i])
+	e.SetExchangeSaleConditions(pointerOf(b.ExchangeSaleConditions[i]))
+	e.SetBuyer(pointerOf(b.Buyer[i]))
+	e.SetSeller(pointerOf(b.Seller[i]))
+	e.SetFlags(b.Flags[i])
+	return e
+}
+
+// TimeNanos returns the time of the i-th time and sale in nanoseconds, like TimeAndSale.TimeNanos.
+func (b *TimeAndSaleBatch) TimeNanos(i int) int64 {
+	return timeutil.GetNanosFromMillisAndNanoPart(timeOfSequence(b.Index[i]), b.TimeNanoPart[i])
+}
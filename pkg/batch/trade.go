@@ -0,0 +1,84 @@
+package batch
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// TradeBatch keeps trades as columns.
+type TradeBatch struct {
+	Symbols      []string
+	EventTime    []int64
+	TimeSequence []int64
+	TimeNanoPart []int32
+	ExchangeCode []int16
+	Price        []float64
+	Size         []float64
+	Change       []float64
+	DayId        []int32
+	DayVolume    []float64
+	DayTurnover  []float64
+	Flags        []int32
+}
+
+func (b *TradeBatch) Type() eventcodes.EventCode {
+	return eventcodes.Trade
+}
+
+func (b *TradeBatch) Len() int {
+	return len(b.Symbols)
+}
+
+// Reset empties the columns keeping their capacity.
+func (b *TradeBatch) Reset() {
+	b.Symbols = b.Symbols[:0]
+	b.EventTime = b.EventTime[:0]
+	b.TimeSequence = b.TimeSequence[:0]
+	b.TimeNanoPart = b.TimeNanoPart[:0]
+	b.ExchangeCode = b.ExchangeCode[:0]
+	b.Price = b.Price[:0]
+	b.Size = b.Size[:0]
+	b.Change = b.Change[:0]
+	b.DayId = b.DayId[:0]
+	b.DayVolume = b.DayVolume[:0]
+	b.DayTurnover = b.DayTurnover[:0]
+	b.Flags = b.Flags[:0]
+}
+
+func (b *TradeBatch) Append(e *trade.Trade) {
+	b.Symbols = append(b.Symbols, *e.EventSymbol())
+	b.EventTime = append(b.EventTime, e.EventTime())
+	b.TimeSequence = append(b.TimeSequence, e.TimeSequence())
+	b.TimeNanoPart = append(b.TimeNanoPart, e.TimeNanoPart())
+	b.ExchangeCode = append(b.ExchangeCode, e.ExchangeCode())
+	b.Price = append(b.Price, e.Price())
+	b.Size = append(b.Size, e.Size())
+	b.Change = append(b.Change, e.Change())
+	b.DayId = append(b.DayId, e.DayId())
+	b.DayVolume = append(b.DayVolume, e.DayVolume())
+	b.DayTurnover = append(b.DayTurnover, e.DayTurnover())
+	b.Flags = append(b.Flags, e.Flags())
+}
+
+// Event returns the i-th event as a new Trade.
+func (b *TradeBatch) Event(i int) *trade.Trade {
+	e := trade.NewTrade(b.Symbols[i])
+	e.SetEventTime(b.EventTime[i])
+	e.SetTimeSequence(b.TimeSequence[i])
+	e.SetTimeNanoPart(b.TimeNanoPart[i])
+	e.SetExchangeCode(b.ExchangeCode[i])
+	e.SetPrice(b.Price[i])
+	e.SetSize(b.Size[i])
+	e.SetChange(b.Change[i])
+	e.SetDayId(b.DayId[i])
+	e.SetDayVolume(b.DayVolume[i])
+	e.SetDayTurnover(b.DayTurnover[i])
+	e.SetFlags(b.Flags[i])
+	return e
+}
+
+// TimeNanos returns the time of the i-th trade in nanoseconds, like Trade.TimeNanos.
+func (b *TradeBatch) TimeNanos(i int) int64 {
+	return timeutil.GetNanosFromMillisAndNanoPart(timeOfSequence(b.TimeSequence[i]), b.TimeNanoPart[i])
+}
@@ -0,0 +1,226 @@
+// Package conflation limits the rate of events delivered from a subscription to its listeners.
+package conflation
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/common"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// Subscription is the part of api.DXFeedSubscription used by the Conflator.
+type Subscription interface {
+	AddListener(listener common.EventListener) error
+	RemoveListener(listener common.EventListener)
+}
+
+type symbolEvent interface {
+	EventSymbol() *string
+}
+
+type indexedEvent interface {
+	Index() int64
+	EventFlags() int32
+}
+
+// unconflatedFlags are the flags of indexed events that are never conflated.
+const unconflatedFlags = events.TxPending | events.RemoveEvent | events.SnapshotBegin | events.SnapshotEnd |
+	events.SnapshotSnip | events.RemoveSymbol
+
+type conflationKey struct {
+	eventType reflect.Type
+	symbol    string
+	index     int64
+}
+
+type Stats struct {
+	// Received is the number of events received from the subscription.
+	Received int64
+	// Delivered is the number of events delivered to the listeners.
+	Delivered int64
+	// Pending is the number of events waiting for the next flush.
+	Pending int64
+}
+
+// Conflated returns the number of events replaced by newer events.
+func (s Stats) Conflated() int64 {
+	return s.Received - s.Delivered - s.Pending
+}
+
+// Ratio returns the number of received events per delivered or pending event, or 1 if nothing was received.
+func (s Stats) Ratio() float64 {
+	if s.Received == 0 {
+		return 1
+	}
+	return float64(s.Received) / float64(s.Delivered+s.Pending)
+}
+
+// Conflator keeps the latest event per event type, symbol and index (for indexed events like orders,
+// whose index also contains the source) and delivers kept events to its listeners every interval,
+// or earlier when the number of kept events reaches the batch size.
+//
+// Indexed events with removal, snapshot or transaction flags are never conflated: they and the
+// events of the same key received after them are delivered as is in the order they were received.
+type Conflator struct {
+	subscription Subscription
+	interval     timeutil.TimePeriod
+	batchSize    int
+
+	mu        sync.Mutex
+	listeners []common.EventListener
+	pending   []interface{}
+	positions map[conflationKey]int
+	stats     Stats
+	closed    bool
+	flushMu   sync.Mutex
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewConflator creates a conflator that flushes events every interval and attaches it to the subscription.
+func NewConflator(subscription Subscription, interval timeutil.TimePeriod) (*Conflator, error) {
+	c := &Conflator{
+		subscription: subscription,
+		interval:     interval,
+		positions:    map[conflationKey]int{},
+		done:         make(chan struct{}),
+	}
+	if err := subscription.AddListener(c); err != nil {
+		return nil, err
+	}
+	if interval > 0 && !interval.IsUnlimited() {
+		go c.run()
+	}
+	return c, nil
+}
+
+// SetBatchSize sets the number of kept events that triggers a flush before the interval ends.
+// Zero (the default) disables flushing by size.
+func (c *Conflator) SetBatchSize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.batchSize = size
+}
+
+func (c *Conflator) AddListener(listener common.EventListener) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, listener)
+}
+
+func (c *Conflator) RemoveListener(listener common.EventListener) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, l := range c.listeners {
+		if l == listener {
+			c.listeners = append(c.listeners[:i:i], c.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// Update receives events from the subscription. Events received after Close, like those delivered by
+// the subscription while the conflator was being removed from it, are delivered immediately.
+func (c *Conflator) Update(eventsList []interface{}) {
+	c.mu.Lock()
+	for _, event := range eventsList {
+		c.add(event)
+	}
+	full := c.closed || c.batchSize > 0 && len(c.pending) >= c.batchSize
+	c.mu.Unlock()
+	if full {
+		c.Flush()
+	}
+}
+
+// Flush delivers the kept events to the listeners immediately.
+func (c *Conflator) Flush() {
+	// keeps the order of batches when flushes by time and size overlap
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+	c.mu.Lock()
+	batch := c.pending
+	listeners := c.listeners
+	c.pending = nil
+	c.positions = map[conflationKey]int{}
+	c.stats.Delivered += int64(len(batch))
+	c.stats.Pending = 0
+	c.mu.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+	for _, listener := range listeners {
+		listener.Update(batch)
+	}
+}
+
+func (c *Conflator) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Close detaches the conflator from the subscription, stops flushing by time and delivers the kept events.
+func (c *Conflator) Close() {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		c.closed = true
+		c.mu.Unlock()
+		c.subscription.RemoveListener(c)
+		close(c.done)
+		c.Flush()
+	})
+}
+
+func (c *Conflator) run() {
+	ticker := time.NewTicker(c.interval.Duration())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.Flush()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *Conflator) add(event interface{}) {
+	c.stats.Received++
+	key, conflatable := keyOf(event)
+	if position, ok := c.positions[key]; ok && conflatable {
+		c.pending[position] = event
+		return
+	}
+	if conflatable {
+		c.positions[key] = len(c.pending)
+	} else {
+		delete(c.positions, key)
+	}
+	c.pending = append(c.pending, event)
+	c.stats.Pending++
+}
+
+// keyOf returns the conflation key of the event and whether the event may be conflated.
+func keyOf(event interface{}) (conflationKey, bool) {
+	key := conflationKey{eventType: reflect.TypeOf(event)}
+	switch e := event.(type) {
+	case *candle.Candle:
+		if e.EventSymbol() != nil {
+			key.symbol = e.EventSymbol().String()
+		}
+	case symbolEvent:
+		if e.EventSymbol() != nil {
+			key.symbol = *e.EventSymbol()
+		}
+	}
+	if e, ok := event.(indexedEvent); ok {
+		key.index = e.Index()
+		return key, e.EventFlags()&unconflatedFlags == 0
+	}
+	return key, true
+}
@@ -0,0 +1,223 @@
+package conflation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/common"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+type testSubscription struct {
+	listeners []common.EventListener
+}
+
+func (s *testSubscription) AddListener(listener common.EventListener) error {
+	s.listeners = append(s.listeners, listener)
+	return nil
+}
+
+func (s *testSubscription) RemoveListener(listener common.EventListener) {
+	for i, l := range s.listeners {
+		if l == listener {
+			s.listeners = append(s.listeners[:i], s.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *testSubscription) publish(eventsList ...interface{}) {
+	for _, listener := range s.listeners {
+		listener.Update(eventsList)
+	}
+}
+
+type testListener struct {
+	batches chan []interface{}
+}
+
+func newTestListener() *testListener {
+	return &testListener{batches: make(chan []interface{}, 10)}
+}
+
+func (l *testListener) Update(eventsList []interface{}) {
+	l.batches <- eventsList
+}
+
+func (l *testListener) next(t *testing.T) []interface{} {
+	t.Helper()
+	select {
+	case batch := <-l.batches:
+		return batch
+	case <-time.After(5 * time.Second):
+		t.Fatalf(`Batch should be delivered`)
+		return nil
+	}
+}
+
+func newQuote(symbol string, bidPrice float64) *quote.Quote {
+	q := quote.NewQuote(symbol)
+	q.SetBidPrice(bidPrice)
+	return q
+}
+
+func newTimeAndSale(symbol string, index int64, flags int32) *timeandsale.TimeAndSale {
+	t := timeandsale.NewTimeAndSale(symbol)
+	t.SetIndex(index)
+	t.SetEventFlags(flags)
+	return t
+}
+
+func TestConflatorKeepsLatestEventPerSymbol(t *testing.T) {
+	subscription := &testSubscription{}
+	conflator, err := NewConflator(subscription, timeutil.UnlimitedPeriod)
+	if err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	listener := newTestListener()
+	conflator.AddListener(listener)
+
+	subscription.publish(newQuote("AAPL", 1), newQuote("MSFT", 2), newQuote("AAPL", 3))
+	subscription.publish(newQuote("AAPL", 4))
+	conflator.Flush()
+	batch := listener.next(t)
+	if len(batch) != 2 {
+		t.Fatalf(`Batch length should be %v. But it equals %v`, 2, len(batch))
+	}
+	if q := batch[0].(*quote.Quote); *q.EventSymbol() != "AAPL" || q.BidPrice() != 4 {
+		t.Errorf(`First event should be the latest AAPL quote. But it equals %v`, q)
+	}
+	stats := conflator.Stats()
+	if stats.Received != 4 || stats.Delivered != 2 || stats.Conflated() != 2 || stats.Ratio() != 2 {
+		t.Errorf(`Stats should be 4 received, 2 delivered and 2 conflated. But they equal %+v`, stats)
+	}
+
+	conflator.Flush()
+	select {
+	case batch = <-listener.batches:
+		t.Errorf(`Empty batch should not be delivered. But it equals %v`, batch)
+	default:
+	}
+}
+
+func TestConflatorKeepsIndexedEventSemantics(t *testing.T) {
+	subscription := &testSubscription{}
+	conflator, _ := NewConflator(subscription, timeutil.UnlimitedPeriod)
+	listener := newTestListener()
+	conflator.AddListener(listener)
+
+	subscription.publish(
+		newTimeAndSale("AAPL", 1, 0),
+		newTimeAndSale("AAPL", 2, 0),
+		newTimeAndSale("AAPL", 1, 0),
+		newTimeAndSale("AAPL", 2, events.RemoveEvent),
+		newTimeAndSale("AAPL", 2, 0),
+		newTimeAndSale("AAPL", 2, 0),
+		newTimeAndSale("AAPL", 3, events.TxPending),
+		newTimeAndSale("AAPL", 3, 0),
+	)
+	conflator.Flush()
+	batch := listener.next(t)
+	expected := []struct {
+		index int64
+		flags int32
+	}{{1, 0}, {2, 0}, {2, events.RemoveEvent}, {2, 0}, {3, events.TxPending}, {3, 0}}
+	if len(batch) != len(expected) {
+		t.Fatalf(`Batch length should be %v. But it equals %v`, len(expected), len(batch))
+	}
+	for i, e := range expected {
+		tns := batch[i].(*timeandsale.TimeAndSale)
+		if tns.Index() != e.index || tns.EventFlags() != e.flags {
+			t.Errorf(`Event %d should have index %v and flags %v. But it has %v and %v`,
+				i, e.index, e.flags, tns.Index(), tns.EventFlags())
+		}
+	}
+}
+
+func TestConflatorKeysCandlesBySymbol(t *testing.T) {
+	subscription := &testSubscription{}
+	conflator, _ := NewConflator(subscription, timeutil.UnlimitedPeriod)
+	listener := newTestListener()
+	conflator.AddListener(listener)
+
+	newCandle := func(symbol string, close float64) *candle.Candle {
+		c := candle.NewCandle(symbol)
+		c.SetTime(1700000000000)
+		c.SetClose(close)
+		return c
+	}
+	subscription.publish(newCandle("AAPL{=1m}", 1), newCandle("MSFT{=1m}", 2), newCandle("AAPL{=1m}", 3))
+	conflator.Flush()
+	batch := listener.next(t)
+	if len(batch) != 2 {
+		t.Fatalf(`Batch length should be %v. But it equals %v`, 2, len(batch))
+	}
+	if c := batch[0].(*candle.Candle); c.EventSymbol().String() != "AAPL{=1m}" || c.Close() != 3 {
+		t.Errorf(`First event should be the latest AAPL candle. But it equals %v`, c)
+	}
+	if c := batch[1].(*candle.Candle); c.EventSymbol().String() != "MSFT{=1m}" || c.Close() != 2 {
+		t.Errorf(`Second event should be the MSFT candle. But it equals %v`, c)
+	}
+	if stats := conflator.Stats(); stats.Received != 3 || stats.Delivered != 2 {
+		t.Errorf(`Stats should be 3 received and 2 delivered. But they equal %+v`, stats)
+	}
+}
+
+func TestConflatorFlushesByBatchSize(t *testing.T) {
+	subscription := &testSubscription{}
+	conflator, _ := NewConflator(subscription, timeutil.UnlimitedPeriod)
+	conflator.SetBatchSize(2)
+	listener := newTestListener()
+	conflator.AddListener(listener)
+
+	subscription.publish(newQuote("AAPL", 1), newQuote("AAPL", 2))
+	if len(listener.batches) != 0 {
+		t.Errorf(`Conflated events should not be flushed`)
+	}
+	subscription.publish(newQuote("MSFT", 1))
+	if batch := listener.next(t); len(batch) != 2 {
+		t.Errorf(`Batch length should be %v. But it equals %v`, 2, len(batch))
+	}
+}
+
+func TestConflatorFlushesByInterval(t *testing.T) {
+	subscription := &testSubscription{}
+	conflator, _ := NewConflator(subscription, 10)
+	listener := newTestListener()
+	conflator.AddListener(listener)
+
+	subscription.publish(newQuote("AAPL", 1), newQuote("AAPL", 2))
+	if batch := listener.next(t); len(batch) != 1 {
+		t.Errorf(`Batch length should be %v. But it equals %v`, 1, len(batch))
+	}
+
+	subscription.publish(newQuote("AAPL", 3))
+	conflator.Close()
+	if len(subscription.listeners) != 0 {
+		t.Errorf(`Conflator should be removed from the subscription`)
+	}
+	if q := listener.next(t)[0].(*quote.Quote); q.BidPrice() != 3 {
+		t.Errorf(`BidPrice should be %v. But it equals %v`, 3, q.BidPrice())
+	}
+}
+
+func TestConflatorDeliversEventsAfterClose(t *testing.T) {
+	subscription := &testSubscription{}
+	conflator, _ := NewConflator(subscription, timeutil.UnlimitedPeriod)
+	listener := newTestListener()
+	conflator.AddListener(listener)
+	conflator.Close()
+
+	// the subscription may still deliver events it received before the conflator was removed
+	conflator.Update([]interface{}{newQuote("AAPL", 1), newQuote("AAPL", 2)})
+	if batch := listener.next(t); len(batch) != 1 || batch[0].(*quote.Quote).BidPrice() != 2 {
+		t.Errorf(`Batch should be the latest quote. But it equals %v`, batch)
+	}
+	if stats := conflator.Stats(); stats.Pending != 0 || stats.Delivered != 1 {
+		t.Errorf(`Stats should have %v delivered and %v pending events. But they equal %+v`, 1, 0, stats)
+	}
+}
@@ -0,0 +1,216 @@
+package eventio
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+// nullValue keeps the default value of literal fields when it is decoded, as in the JSON decoding of events.
+const nullValue = "null"
+
+// accessor encodes a field of events to its JSON value and decodes the field from it.
+type accessor struct {
+	Field
+	get func(event interface{}) json.RawMessage
+	set func(event interface{}, value json.RawMessage) error
+}
+
+func newAccessor[E any, T any](f Field, get func(E) T, set func(E, T) error,
+	encode func(T) json.RawMessage, decode func(json.RawMessage) (T, bool, error)) accessor {
+	return accessor{
+		Field: f,
+		get: func(event interface{}) json.RawMessage {
+			return encode(get(event.(E)))
+		},
+		set: func(event interface{}, value json.RawMessage) error {
+			v, ok, err := decode(value)
+			if err != nil || !ok {
+				return err
+			}
+			return set(event.(E), v)
+		},
+	}
+}
+
+// embedded returns accessors of events E for accessors of events B embedded in them.
+func embedded[E any, B any](base func(E) B, accessors ...accessor) []accessor {
+	result := make([]accessor, len(accessors))
+	for i, a := range accessors {
+		a := a
+		result[i] = accessor{
+			Field: a.Field,
+			get: func(event interface{}) json.RawMessage {
+				return a.get(base(event.(E)))
+			},
+			set: func(event interface{}, value json.RawMessage) error {
+				return a.set(base(event.(E)), value)
+			},
+		}
+	}
+	return result
+}
+
+// unchecked adapts a setter that can't fail.
+func unchecked[E any, T any](set func(E, T)) func(E, T) error {
+	return func(event E, value T) error {
+		set(event, value)
+		return nil
+	}
+}
+
+func integer[E any, T int16 | int32 | int64](name string, get func(E) T, set func(E, T)) accessor {
+	return checkedInteger(name, get, unchecked(set))
+}
+
+func checkedInteger[E any, T int16 | int32 | int64](name string, get func(E) T, set func(E, T) error) accessor {
+	return newAccessor(Field{Name: name, Kind: LiteralField}, get, set, encodeInteger[T], decodeInteger[T])
+}
+
+// timestamp is a time in milliseconds.
+func timestamp[E any](name string, get func(E) int64, set func(E, int64)) accessor {
+	return newAccessor(Field{Name: name, Kind: LiteralField, Time: true}, get, unchecked(set),
+		encodeInteger[int64], decodeInteger[int64])
+}
+
+func float[E any](name string, get func(E) float64, set func(E, float64)) accessor {
+	return newAccessor(Field{Name: name, Kind: LiteralField}, get, unchecked(set),
+		func(value float64) json.RawMessage {
+			data, _ := events.JSONFloat(value).MarshalJSON()
+			return data
+		},
+		func(value json.RawMessage) (float64, bool, error) {
+			var f events.JSONFloat
+			err := f.UnmarshalJSON(value)
+			return float64(f), err == nil, err
+		})
+}
+
+func boolean[E any](name string, get func(E) bool, set func(E, bool)) accessor {
+	return newAccessor(Field{Name: name, Kind: LiteralField}, get, unchecked(set),
+		func(value bool) json.RawMessage {
+			return strconv.AppendBool(nil, value)
+		},
+		func(value json.RawMessage) (bool, bool, error) {
+			switch string(value) {
+			case "true":
+				return true, true, nil
+			case "false":
+				return false, true, nil
+			case nullValue:
+				return false, false, nil
+			}
+			return false, false, fmt.Errorf("invalid boolean %s", value)
+		})
+}
+
+// text is a string that may be null.
+func text[E any](name string, get func(E) *string, set func(E, *string)) accessor {
+	return newAccessor(Field{Name: name, Kind: TextField}, get, unchecked(set),
+		func(value *string) json.RawMessage {
+			if value == nil {
+				return json.RawMessage(nullValue)
+			}
+			data, _ := json.Marshal(*value)
+			return data
+		},
+		func(value json.RawMessage) (*string, bool, error) {
+			var s *string
+			err := json.Unmarshal(value, &s)
+			return s, err == nil, err
+		})
+}
+
+// symbol is the event symbol, a null symbol keeps the default one.
+func symbol[E any](get func(E) *string, set func(E, string)) accessor {
+	return word(eventSymbolField, func(event E) string {
+		if s := get(event); s != nil {
+			return *s
+		}
+		return ""
+	}, unchecked(set))
+}
+
+// word is a string that is never null.
+func word[E any](name string, get func(E) string, set func(E, string) error) accessor {
+	return newAccessor(Field{Name: name, Kind: TextField}, get, set,
+		func(value string) json.RawMessage {
+			data, _ := json.Marshal(value)
+			return data
+		},
+		func(value json.RawMessage) (string, bool, error) {
+			var s *string
+			if err := json.Unmarshal(value, &s); err != nil || s == nil {
+				return "", false, err
+			}
+			return *s, true, nil
+		})
+}
+
+// enum is encoded by the names of its values as returned by String.
+func enum[E any, T fmt.Stringer](name string, kind string, get func(E) T, set func(E, T), values ...T) accessor {
+	return word(name, func(event E) string {
+		return get(event).String()
+	}, func(event E, value string) error {
+		v, err := events.ParseEnum(kind, value, values...)
+		if err == nil {
+			set(event, v)
+		}
+		return err
+	})
+}
+
+func char[E any, T int16 | int32](name string, get func(E) T, set func(E, T)) accessor {
+	return checkedChar(name, get, unchecked(set))
+}
+
+func checkedChar[E any, T int16 | int32](name string, get func(E) T, set func(E, T) error) accessor {
+	return newAccessor(Field{Name: name, Kind: CharField}, get, set,
+		func(value T) json.RawMessage {
+			data, _ := events.JSONChar(value).MarshalJSON()
+			return data
+		},
+		func(value json.RawMessage) (T, bool, error) {
+			var c events.JSONChar
+			if err := c.UnmarshalJSON(value); err != nil {
+				return 0, false, err
+			}
+			if rune(T(c)) != rune(c) {
+				return 0, false, fmt.Errorf("character %q is out of range", rune(c))
+			}
+			return T(c), true, nil
+		})
+}
+
+func eventFlags[E any](get func(E) int32, set func(E, int32)) accessor {
+	return newAccessor(Field{Name: EventFlagsField, Kind: FlagsField}, get, unchecked(set),
+		func(value int32) json.RawMessage {
+			data, _ := events.JSONEventFlags(value).MarshalJSON()
+			return data
+		},
+		func(value json.RawMessage) (int32, bool, error) {
+			var f events.JSONEventFlags
+			err := f.UnmarshalJSON(value)
+			return int32(f), err == nil, err
+		})
+}
+
+func encodeInteger[T int16 | int32 | int64](value T) json.RawMessage {
+	return strconv.AppendInt(nil, int64(value), 10)
+}
+
+func decodeInteger[T int16 | int32 | int64](value json.RawMessage) (T, bool, error) {
+	if string(value) == nullValue {
+		return 0, false, nil
+	}
+	n, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid integer %s", value)
+	}
+	if int64(T(n)) != n {
+		return 0, false, fmt.Errorf("integer %d is out of range", n)
+	}
+	return T(n), true, nil
+}
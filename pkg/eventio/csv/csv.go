@@ -0,0 +1,35 @@
+// Package csv writes events to CSV and reads them back.
+//
+// Events of different types may be mixed in one file. The first row of each event type is preceded
+// by a header row of that type: its first cell is the name of the event type prefixed with '=',
+// other cells are names of columns. The first cell of a data row is the name of its event type:
+//
+//	=Quote,eventSymbol,eventTime,time,sequence,timeNanoPart,bidTime,bidExchangeCode,bidPrice,...
+//	Quote,AAPL,0,1700000000123,0,0,1700000000000,Q,100.5,...
+//
+// Columns are the fields of events (see package eventio): enums are written by their names,
+// event flags are separated by '|', NaN is written as NaN.
+// Empty cells and missing columns keep the default values of an event when it is read.
+package csv
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/eventio"
+)
+
+const (
+	headerPrefix   = "="
+	flagsSeparator = "|"
+)
+
+// Columns returns the names of all columns of the event type in the order they are written.
+func Columns(eventType string) ([]string, error) {
+	s, err := eventio.SchemaOf(eventType)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(s.Fields()))
+	for i, f := range s.Fields() {
+		names[i] = f.Name
+	}
+	return names, nil
+}
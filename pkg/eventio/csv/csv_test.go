@@ -0,0 +1,139 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/eventtest"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+)
+
+func jsonOf(t *testing.T, event interface{}) string {
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	return string(data)
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []TimeFormat{TimeMillis, TimeNanos, TimeISO} {
+		buffer := &bytes.Buffer{}
+		writer := NewWriter(buffer)
+		writer.SetTimeFormat(format)
+		if err := writer.WriteEvents(eventtest.Events()); err != nil {
+			t.Fatalf(`Unexpected error %v`, err)
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatalf(`Unexpected error %v`, err)
+		}
+		reader := NewReader(bytes.NewReader(buffer.Bytes()))
+		reader.SetTimeFormat(format)
+		result, err := reader.ReadAll()
+		if err != nil {
+			t.Fatalf(`Unexpected error %v in %v:\n%s`, err, format, buffer)
+		}
+		expected := eventtest.Events()
+		if len(result) != len(expected) {
+			t.Fatalf(`Count should be %v. But it equals %v`, len(expected), len(result))
+		}
+		for i := range expected {
+			if jsonOf(t, result[i]) != jsonOf(t, expected[i]) {
+				t.Errorf(`Event should be %s. But it equals %s in %v`, jsonOf(t, expected[i]), jsonOf(t, result[i]), format)
+			}
+		}
+	}
+}
+
+func TestHeadersAndCells(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer := NewWriter(buffer)
+	_ = writer.WriteEvents(eventtest.Events())
+	_ = writer.Flush()
+	lines := strings.Split(buffer.String(), "\n")
+	if !strings.HasPrefix(lines[0], "=Quote,eventSymbol,eventTime,time,") {
+		t.Errorf(`Header should start with =Quote. But it equals %s`, lines[0])
+	}
+	if !strings.Contains(lines[1], ",Q,100.5,NaN,") || !strings.Contains(lines[1], ",Infinity,NaN") {
+		t.Errorf(`Quote row is wrong: %s`, lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "=TimeAndSale,eventSymbol,eventTime,eventFlags,") {
+		t.Errorf(`Header should contain event flags. But it equals %s`, lines[2])
+	}
+	if !strings.Contains(lines[3], ",TxPending|SnapshotBegin,") || !strings.Contains(lines[3], `"@, T"`) {
+		t.Errorf(`TimeAndSale row is wrong: %s`, lines[3])
+	}
+	if strings.Count(buffer.String(), "=Quote") != 1 {
+		t.Errorf(`Header of Quote should be written once:\n%s`, buffer)
+	}
+}
+
+func TestSelectedColumnsAndTimeFormats(t *testing.T) {
+	cases := []struct {
+		format   TimeFormat
+		expected string
+	}{
+		{TimeMillis, "TimeAndSale,IBM,1700000000123,150\n"},
+		{TimeNanos, "TimeAndSale,IBM,1700000000123000456,150\n"},
+		{TimeISO, "TimeAndSale,IBM,2023-11-14T22:13:20.123000456Z,150\n"},
+	}
+	for _, c := range cases {
+		buffer := &bytes.Buffer{}
+		writer := NewWriter(buffer)
+		writer.SetTimeFormat(c.format)
+		writer.SetColumns("eventSymbol", "time", "price", "bidTime")
+		_ = writer.Write(eventtest.Events()[1])
+		_ = writer.Flush()
+		expected := "=TimeAndSale,eventSymbol,time,price\n" + c.expected
+		if buffer.String() != expected {
+			t.Errorf(`CSV should be %q. But it equals %q`, expected, buffer.String())
+		}
+
+		reader := NewReader(bytes.NewReader(buffer.Bytes()))
+		reader.SetTimeFormat(c.format)
+		event, err := reader.Read()
+		if err != nil {
+			t.Fatalf(`Unexpected error %v`, err)
+		}
+		tns := event.(*timeandsale.TimeAndSale)
+		if tns.Time() != eventtest.Time || tns.Price() != 150 || !math.IsNaN(tns.Size()) {
+			t.Errorf(`Event is wrong: %v`, tns)
+		}
+		if c.format != TimeMillis && tns.TimeNanoPart() != 456 {
+			t.Errorf(`TimeNanoPart should be 456. But it equals %v`, tns.TimeNanoPart())
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, data := range []string{
+		"Quote,AAPL\n",
+		"=Unknown,eventSymbol\n",
+		"=Quote,bidPrize\n",
+		"=Quote,bidPrice\nQuote,abc\n",
+		"=Quote,eventSymbol\nQuote,AAPL,1\n",
+		"=TimeAndSale,aggressorSide\nTimeAndSale,Both\n",
+	} {
+		if _, err := NewReader(strings.NewReader(data)).ReadAll(); err == nil {
+			t.Errorf(`Reading of %q should fail`, data)
+		}
+	}
+	if err := NewWriter(&bytes.Buffer{}).Write(struct{}{}); err == nil {
+		t.Errorf(`Writing of unsupported event should fail`)
+	}
+}
+
+func TestParseTimeFormat(t *testing.T) {
+	for _, f := range []TimeFormat{TimeMillis, TimeNanos, TimeISO} {
+		parsed, err := ParseTimeFormat(strings.ToUpper(f.String()))
+		if err != nil || parsed != f {
+			t.Errorf(`Format should be %v. But it equals %v (%v)`, f, parsed, err)
+		}
+	}
+	if _, err := ParseTimeFormat("seconds"); err == nil {
+		t.Errorf(`Parsing of unknown format should fail`)
+	}
+}
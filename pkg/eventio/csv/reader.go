@@ -0,0 +1,141 @@
+package csv
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/eventio"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// Reader reads events written by Writer. Read events can be published with DXPublisher.Publish.
+type Reader struct {
+	reader     *csv.Reader
+	timeFormat TimeFormat
+	columns    map[string][]eventio.Field
+}
+
+func NewReader(r io.Reader) *Reader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	return &Reader{reader: reader, columns: map[string][]eventio.Field{}}
+}
+
+// SetTimeFormat sets the format of time columns, it must match the format they were written in.
+func (r *Reader) SetTimeFormat(format TimeFormat) {
+	r.timeFormat = format
+}
+
+// Read reads the next event. It returns io.EOF when there are no more events.
+func (r *Reader) Read() (interface{}, error) {
+	for {
+		record, err := r.reader.Read()
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.reader.FieldPos(0)
+		if strings.HasPrefix(record[0], headerPrefix) {
+			if err := r.readHeader(record); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			continue
+		}
+		event, err := r.decode(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		return event, nil
+	}
+}
+
+// ReadAll reads all remaining events.
+func (r *Reader) ReadAll() ([]interface{}, error) {
+	var result []interface{}
+	for {
+		event, err := r.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, event)
+	}
+}
+
+func (r *Reader) readHeader(record []string) error {
+	eventType := strings.TrimPrefix(record[0], headerPrefix)
+	s, err := eventio.SchemaOf(eventType)
+	if err != nil {
+		return err
+	}
+	columns := make([]eventio.Field, 0, len(record)-1)
+	for _, name := range record[1:] {
+		f, ok := s.Field(name)
+		if !ok {
+			return fmt.Errorf("unknown column %s of %s", name, eventType)
+		}
+		columns = append(columns, f)
+	}
+	r.columns[eventType] = columns
+	return nil
+}
+
+func (r *Reader) decode(record []string) (interface{}, error) {
+	eventType := record[0]
+	columns, ok := r.columns[eventType]
+	if !ok {
+		return nil, fmt.Errorf("no header for %s", eventType)
+	}
+	if len(record)-1 > len(columns) {
+		return nil, fmt.Errorf("%d cells of %s for %d columns", len(record)-1, eventType, len(columns))
+	}
+	values := make(map[string]json.RawMessage, len(record))
+	var nanoPart json.RawMessage
+	for i, cell := range record[1:] {
+		if cell == "" {
+			continue
+		}
+		c := columns[i]
+		value, err := r.value(c, cell)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", eventType, c.Name, err)
+		}
+		if c.Nanos && r.timeFormat != TimeMillis {
+			nanos, _ := r.timeFormat.parse(cell)
+			nanoPart = json.RawMessage(strconv.FormatInt(timeutil.GetNanoPartFromNanos(nanos), 10))
+		}
+		values[c.Name] = value
+	}
+	if _, ok := values[eventio.TimeNanoPartField]; !ok && nanoPart != nil {
+		values[eventio.TimeNanoPartField] = nanoPart
+	}
+	return eventio.Decode(eventType, values)
+}
+
+func (r *Reader) value(c eventio.Field, cell string) (json.RawMessage, error) {
+	switch {
+	case c.Kind == eventio.FlagsField:
+		return json.Marshal(strings.Split(cell, flagsSeparator))
+	case c.Kind == eventio.TextField || c.Kind == eventio.CharField:
+		return json.Marshal(cell)
+	case c.Time:
+		nanos, err := r.timeFormat.parse(cell)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(strconv.FormatInt(timeutil.GetMillisFromNanos(nanos), 10)), nil
+	case cell == "NaN":
+		return json.RawMessage("null"), nil
+	case cell == "Infinity" || cell == "-Infinity":
+		return json.Marshal(cell)
+	case !json.Valid([]byte(cell)):
+		return nil, fmt.Errorf("invalid value %q", cell)
+	default:
+		return json.RawMessage(cell), nil
+	}
+}
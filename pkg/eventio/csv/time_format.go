@@ -0,0 +1,78 @@
+package csv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/eventio"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// TimeFormat is the format of time columns. Nanoseconds include the nano part of the time of an event
+// like TimeAndSale.Time and TimeAndSale.TimeNanoPart.
+type TimeFormat int32
+
+const (
+	// TimeMillis writes times as milliseconds since epoch.
+	TimeMillis TimeFormat = iota
+	// TimeNanos writes times as nanoseconds since epoch.
+	TimeNanos
+	// TimeISO writes times like 2024-01-02T15:04:05.123Z.
+	TimeISO
+)
+
+var (
+	isoMillisFormat = timeutil.GMTTimeFormat.WithMillis().AsFullIso()
+	isoNanosFormat  = timeutil.GMTTimeFormat.WithNanos().AsFullIso()
+)
+
+func (f TimeFormat) String() string {
+	switch f {
+	case TimeMillis:
+		return "millis"
+	case TimeNanos:
+		return "nanos"
+	case TimeISO:
+		return "iso"
+	default:
+		return fmt.Sprintf("TimeFormat: Wrong value %d", f)
+	}
+}
+
+// ParseTimeFormat returns the time format by its name: millis, nanos or iso.
+func ParseTimeFormat(name string) (TimeFormat, error) {
+	for _, f := range []TimeFormat{TimeMillis, TimeNanos, TimeISO} {
+		if strings.EqualFold(name, f.String()) {
+			return f, nil
+		}
+	}
+	return TimeMillis, fmt.Errorf("unknown time format %q", name)
+}
+
+func (f TimeFormat) format(field eventio.Field, timeNanos int64) string {
+	switch {
+	case f == TimeNanos && field.Nanos:
+		return strconv.FormatInt(timeNanos, 10)
+	case f == TimeNanos:
+		return strconv.FormatInt(timeutil.GetMillisFromNanos(timeNanos)*timeutil.NanosInMillis, 10)
+	case f == TimeISO && field.Nanos:
+		return isoNanosFormat.FormatNanos(timeNanos)
+	case f == TimeISO:
+		return isoMillisFormat.FormatNanos(timeNanos)
+	default:
+		return strconv.FormatInt(timeutil.GetMillisFromNanos(timeNanos), 10)
+	}
+}
+
+func (f TimeFormat) parse(value string) (int64, error) {
+	switch f {
+	case TimeNanos:
+		return strconv.ParseInt(value, 10, 64)
+	case TimeISO:
+		return isoMillisFormat.ParseNanos(value)
+	default:
+		millis, err := strconv.ParseInt(value, 10, 64)
+		return millis * timeutil.NanosInMillis, err
+	}
+}
@@ -0,0 +1,138 @@
+package csv
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/eventio"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// Writer writes events as CSV rows. It is not safe for concurrent use.
+type Writer struct {
+	writer     *csv.Writer
+	selected   []string
+	timeFormat TimeFormat
+	columns    map[string][]eventio.Field
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{writer: csv.NewWriter(w), columns: map[string][]eventio.Field{}}
+}
+
+// SetColumns selects the columns to write in the given order, all columns are written by default.
+// Selected columns an event type doesn't have are skipped for that type.
+func (w *Writer) SetColumns(names ...string) {
+	w.selected = names
+	w.columns = map[string][]eventio.Field{}
+}
+
+func (w *Writer) SetTimeFormat(format TimeFormat) {
+	w.timeFormat = format
+}
+
+// Write writes the event, preceded by the header of its type if it is the first event of that type.
+func (w *Writer) Write(event interface{}) error {
+	eventType, values, err := eventio.Encode(event)
+	if err != nil {
+		return err
+	}
+	columns, err := w.columnsOf(eventType)
+	if err != nil {
+		return err
+	}
+	record := make([]string, 0, len(columns)+1)
+	record = append(record, eventType)
+	for _, c := range columns {
+		cell, err := w.cell(c, values)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", eventType, c.Name, err)
+		}
+		record = append(record, cell)
+	}
+	return w.writer.Write(record)
+}
+
+func (w *Writer) WriteEvents(events []interface{}) error {
+	for _, event := range events {
+		if err := w.Write(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes buffered rows to the underlying writer.
+func (w *Writer) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *Writer) columnsOf(eventType string) ([]eventio.Field, error) {
+	if columns, ok := w.columns[eventType]; ok {
+		return columns, nil
+	}
+	s, err := eventio.SchemaOf(eventType)
+	if err != nil {
+		return nil, err
+	}
+	columns := s.Fields()
+	if w.selected != nil {
+		columns = nil
+		for _, name := range w.selected {
+			if f, ok := s.Field(name); ok {
+				columns = append(columns, f)
+			}
+		}
+	}
+	header := make([]string, 0, len(columns)+1)
+	header = append(header, headerPrefix+eventType)
+	for _, c := range columns {
+		header = append(header, c.Name)
+	}
+	if err := w.writer.Write(header); err != nil {
+		return nil, err
+	}
+	w.columns[eventType] = columns
+	return columns, nil
+}
+
+func (w *Writer) cell(c eventio.Field, values map[string]json.RawMessage) (string, error) {
+	value, ok := values[c.Name]
+	if !ok {
+		return "", nil
+	}
+	switch {
+	case c.Kind == eventio.FlagsField:
+		var flags []string
+		if err := json.Unmarshal(value, &flags); err != nil {
+			return "", err
+		}
+		return strings.Join(flags, flagsSeparator), nil
+	case c.Kind == eventio.TextField || c.Kind == eventio.CharField:
+		var text *string
+		if err := json.Unmarshal(value, &text); err != nil || text == nil {
+			return "", err
+		}
+		return *text, nil
+	case c.Time && w.timeFormat != TimeMillis:
+		var millis int64
+		if err := json.Unmarshal(value, &millis); err != nil {
+			return "", err
+		}
+		var nanoPart int32
+		if c.Nanos {
+			_ = json.Unmarshal(values[eventio.TimeNanoPartField], &nanoPart)
+		}
+		return w.timeFormat.format(c, timeutil.GetNanosFromMillisAndNanoPart(millis, nanoPart)), nil
+	case string(value) == "null":
+		return "NaN", nil
+	case eventio.IsInfinity(value):
+		return strings.Trim(string(value), `"`), nil
+	default:
+		return string(value), nil
+	}
+}
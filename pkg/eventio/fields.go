@@ -0,0 +1,268 @@
+package eventio
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/greeks"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/profile"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// Fields of event types are listed in the order of their JSON encoding.
+
+const (
+	maxSequence = (1 << 22) - 1
+	// millisShift is the shift of milliseconds in times packed with sequences.
+	millisShift = 22
+)
+
+func init() {
+	register("Quote", func() interface{} { return quote.NewQuote("") }, quoteFields()...)
+	register("Trade", func() interface{} { return trade.NewTrade("") },
+		embedded(func(t *trade.Trade) *trade.TradeBase { return &t.TradeBase }, tradeFields()...)...)
+	register("TradeETH", func() interface{} { return trade.NewTradeETH("") },
+		embedded(func(t *trade.TradeETH) *trade.TradeBase { return &t.TradeBase }, tradeFields()...)...)
+	register("TimeAndSale", func() interface{} { return timeandsale.NewTimeAndSale("") }, timeAndSaleFields()...)
+	register("Profile", func() interface{} { return profile.NewProfile("") }, profileFields()...)
+	register("Greeks", func() interface{} { return greeks.NewGreeks("") }, greeksFields()...)
+	register("Candle", func() interface{} { return candle.NewCandle("") }, candleFields()...)
+	register("Order", func() interface{} { return order.NewOrder("") }, orderFields()...)
+	register("AnalyticOrder", func() interface{} { return order.NewAnalyticOrder("") },
+		append(embedded(func(o *order.AnalyticOrder) *order.Order { return &o.Order }, orderFields()...),
+			float("icebergPeakSize", (*order.AnalyticOrder).IcebergPeakSize, (*order.AnalyticOrder).SetIcebergPeakSize),
+			float("icebergHiddenSize", (*order.AnalyticOrder).IcebergHiddenSize, (*order.AnalyticOrder).SetIcebergHiddenSize),
+			float("icebergExecutedSize", (*order.AnalyticOrder).IcebergExecutedSize,
+				(*order.AnalyticOrder).SetIcebergExecutedSize),
+			enum("icebergType", "iceberg type", (*order.AnalyticOrder).IcebergType, (*order.AnalyticOrder).SetIcebergType,
+				order.IcebergType(order.Undefined), order.Native, order.Synthetic),
+		)...)
+	register("SpreadOrder", func() interface{} { return order.NewSpreadOrder("") },
+		append(embedded(func(o *order.SpreadOrder) *order.Base { return &o.Base }, orderBaseFields()...),
+			text("spreadSymbol", (*order.SpreadOrder).SpreadSymbol, (*order.SpreadOrder).SetSpreadSymbol),
+		)...)
+}
+
+func quoteFields() []accessor {
+	type event = quote.Quote
+	// the time of quotes is the time of the last of bid and ask, except milliseconds that are kept
+	// with the sequence, so bid and ask times don't change them
+	keepMillis := func(set func(*event, int64)) func(*event, int64) {
+		return func(q *event, value int64) {
+			timeMillisSequence := q.TimeMillisSequence()
+			set(q, value)
+			q.SetTimeMillisSequence(timeMillisSequence)
+		}
+	}
+	return []accessor{
+		symbol((*event).EventSymbol, (*event).SetEventSymbol),
+		timestamp("eventTime", (*event).EventTime, (*event).SetEventTime),
+		timestamp(TimeField, (*event).Time, func(q *event, value int64) {
+			q.SetTimeMillisSequence(timeutil.GetMillisFromTime(value)<<millisShift | q.Sequence())
+		}),
+		integer("sequence", (*event).Sequence, func(q *event, value int32) {
+			q.SetSequence(value & maxSequence)
+		}),
+		integer(TimeNanoPartField, (*event).TimeNanoPart, (*event).SetTimeNanoPart),
+		timestamp("bidTime", (*event).BidTime, keepMillis((*event).SetBidTime)),
+		char("bidExchangeCode", (*event).BidExchangeCode, (*event).SetBidExchangeCode),
+		float("bidPrice", (*event).BidPrice, (*event).SetBidPrice),
+		float("bidSize", (*event).BidSize, (*event).SetBidSize),
+		timestamp("askTime", (*event).AskTime, keepMillis((*event).SetAskTime)),
+		char("askExchangeCode", (*event).AskExchangeCode, (*event).SetAskExchangeCode),
+		float("askPrice", (*event).AskPrice, (*event).SetAskPrice),
+		float("askSize", (*event).AskSize, (*event).SetAskSize),
+	}
+}
+
+func tradeFields() []accessor {
+	type event = trade.TradeBase
+	return []accessor{
+		symbol((*event).EventSymbol, func(t *event, value string) {
+			t.SetEventSymbol(&value)
+		}),
+		timestamp("eventTime", (*event).EventTime, (*event).SetEventTime),
+		timestamp(TimeField, (*event).Time, (*event).SetTime),
+		checkedInteger("sequence", (*event).Sequence, (*event).SetSequence),
+		integer(TimeNanoPartField, (*event).TimeNanoPart, (*event).SetTimeNanoPart),
+		char("exchangeCode", (*event).ExchangeCode, (*event).SetExchangeCode),
+		float("price", (*event).Price, (*event).SetPrice),
+		float("change", (*event).Change, (*event).SetChange),
+		float("size", (*event).Size, (*event).SetSize),
+		integer("dayId", (*event).DayId, (*event).SetDayId),
+		float("dayVolume", (*event).DayVolume, (*event).SetDayVolume),
+		float("dayTurnover", (*event).DayTurnover, (*event).SetDayTurnover),
+		enum("tickDirection", "direction", (*event).TickDirection, (*event).SetTickDirection,
+			trade.Direction(trade.Undefined), trade.Down, trade.ZeroDown, trade.Zero, trade.ZeroUp, trade.Up),
+		boolean("extendedTradingHours", (*event).IsExtendedTradingHours, (*event).SetIsExtendedTradingHours),
+	}
+}
+
+func timeAndSaleFields() []accessor {
+	type event = timeandsale.TimeAndSale
+	return []accessor{
+		symbol((*event).EventSymbol, (*event).SetEventSymbol),
+		timestamp("eventTime", (*event).EventTime, (*event).SetEventTime),
+		eventFlags((*event).EventFlags, (*event).SetEventFlags),
+		timestamp(TimeField, (*event).Time, (*event).SetTime),
+		checkedInteger("sequence", (*event).Sequence, (*event).SetSequence),
+		integer(TimeNanoPartField, (*event).TimeNanoPart, (*event).SetTimeNanoPart),
+		char("exchangeCode", (*event).ExchangeCode, (*event).SetExchangeCode),
+		float("price", (*event).Price, (*event).SetPrice),
+		float("size", (*event).Size, (*event).SetSize),
+		float("bidPrice", (*event).BidPrice, (*event).SetBidPrice),
+		float("askPrice", (*event).AskPrice, (*event).SetAskPrice),
+		text("exchangeSaleConditions", (*event).ExchangeSaleConditions, (*event).SetExchangeSaleConditions),
+		char("tradeThroughExempt", (*event).TradeThroughExempt, (*event).SetTradeThroughExempt),
+		enum("aggressorSide", "side", (*event).AggressorSide, (*event).SetAggressorSide,
+			side.Side(side.Undefined), side.Buy, side.Sell),
+		boolean("spreadLeg", (*event).IsSpreadLeg, (*event).SetIsSpreadLeg),
+		boolean("extendedTradingHours", (*event).IsExtendedTradingHours, (*event).SetIsExtendedTradingHours),
+		boolean("validTick", (*event).IsValidTick, (*event).SetIsValidTick),
+		enum("type", "time and sale type", (*event).TimeAndSaleType, (*event).SetTimeAndSaleType,
+			timeandsale.Type(timeandsale.TypeNew), timeandsale.TypeCorrection, timeandsale.TypeCancel),
+		text("buyer", (*event).Buyer, (*event).SetBuyer),
+		text("seller", (*event).Seller, (*event).SetSeller),
+	}
+}
+
+func profileFields() []accessor {
+	type event = profile.Profile
+	return []accessor{
+		symbol((*event).EventSymbol, func(p *event, value string) {
+			p.SetEventSymbol(&value)
+		}),
+		timestamp("eventTime", (*event).EventTime, (*event).SetEventTime),
+		text("description", (*event).Description, (*event).SetDescription),
+		enum("shortSaleRestriction", "short sale restriction", (*event).ShortSaleRestriction,
+			(*event).SetShortSaleRestriction, profile.ShortSaleRestriction(profile.UndefinedShortSaleRestriction),
+			profile.ActiveShortSaleRestriction, profile.InactiveShortSaleRestriction),
+		enum("tradingStatus", "trading status", (*event).TradingStatus, (*event).SetTradingStatus,
+			profile.TradingStatus(profile.UndefinedTradingStatus), profile.HaltedTradingStatus,
+			profile.ActiveTradingStatus),
+		text("statusReason", (*event).StatusReason, (*event).SetStatusReason),
+		timestamp("haltStartTime", (*event).HaltStartTime, (*event).SetHaltStartTime),
+		timestamp("haltEndTime", (*event).HaltEndTime, (*event).SetHaltEndTime),
+		float("highLimitPrice", (*event).HighLimitPrice, (*event).SetHighLimitPrice),
+		float("lowLimitPrice", (*event).LowLimitPrice, (*event).SetLowLimitPrice),
+		float("high52WeekPrice", (*event).High52WeekPrice, (*event).SetHigh52WeekPrice),
+		float("low52WeekPrice", (*event).Low52WeekPrice, (*event).SetLow52WeekPrice),
+		float("beta", (*event).Beta, (*event).SetBeta),
+		float("earningsPerShare", (*event).EarningsPerShare, (*event).SetEarningsPerShare),
+		float("dividendFrequency", (*event).DividendFrequency, (*event).SetDividendFrequency),
+		float("exDividendAmount", (*event).ExDividendAmount, (*event).SetExDividendAmount),
+		integer("exDividendDayId", (*event).ExDividendDayId, (*event).SetExDividendDayId),
+		float("shares", (*event).Shares, (*event).SetShares),
+		float("freeFloat", (*event).FreeFloat, (*event).SetFreeFloat),
+	}
+}
+
+func greeksFields() []accessor {
+	type event = greeks.Greeks
+	// the time and the sequence of greeks are packed into the index
+	return []accessor{
+		symbol((*event).EventSymbol, (*event).SetEventSymbol),
+		timestamp("eventTime", (*event).EventTime, (*event).SetEventTime),
+		eventFlags((*event).EventFlags, (*event).SetEventFlags),
+		timestamp(TimeField, func(g *event) int64 {
+			return (g.Index()>>32)*1000 + (g.Index()>>millisShift)&0x3ff
+		}, func(g *event, value int64) {
+			g.SetIndex(timeutil.GetSecondsFromTime(value)<<32 |
+				int64(timeutil.GetMillisFromTime(value))<<millisShift | g.Index()&maxSequence)
+		}),
+		integer("sequence", func(g *event) int64 {
+			return g.Index() & maxSequence
+		}, func(g *event, value int64) {
+			g.SetIndex(g.Index()&^maxSequence | value&maxSequence)
+		}),
+		float("price", (*event).Price, (*event).SetPrice),
+		float("volatility", (*event).Volatility, (*event).SetVolatility),
+		float("delta", (*event).Delta, (*event).SetDelta),
+		float("gamma", (*event).Gamma, (*event).SetGamma),
+		float("theta", (*event).Theta, (*event).SetTheta),
+		float("rho", (*event).Rho, (*event).SetRho),
+		float("vega", (*event).Vega, (*event).SetVega),
+	}
+}
+
+func candleFields() []accessor {
+	type event = candle.Candle
+	return []accessor{
+		symbol(func(c *event) *string {
+			if c.EventSymbol() == nil {
+				return nil
+			}
+			return c.EventSymbol().Symbol()
+		}, func(c *event, value string) {
+			c.SetEventSymbol(candle.NewCandleSymbol(value))
+		}),
+		timestamp("eventTime", (*event).EventTime, (*event).SetEventTime),
+		eventFlags((*event).EventFlags, (*event).SetEventFlags),
+		timestamp(TimeField, (*event).Time, (*event).SetTime),
+		checkedInteger("sequence", (*event).Sequence, (*event).SetSequence),
+		integer("count", (*event).Count, (*event).SetCount),
+		float("open", (*event).Open, (*event).SetOpen),
+		float("high", (*event).High, (*event).SetHigh),
+		float("low", (*event).Low, (*event).SetLow),
+		float("close", (*event).Close, (*event).SetClose),
+		float("volume", (*event).Volume, (*event).SetVolume),
+		float("vwap", (*event).Vwap, (*event).SetVwap),
+		float("bidVolume", (*event).BidVolume, (*event).SetBidVolume),
+		float("askVolume", (*event).AskVolume, (*event).SetAskVolume),
+		float("impVolatility", (*event).ImpVolatility, (*event).SetImpVolatility),
+		float("openInterest", (*event).OpenInterest, (*event).SetOpenInterest),
+	}
+}
+
+func orderFields() []accessor {
+	return append(embedded(func(o *order.Order) *order.Base { return &o.Base }, orderBaseFields()...),
+		text("marketMaker", (*order.Order).MarketMaker, (*order.Order).SetMarketMaker))
+}
+
+func orderBaseFields() []accessor {
+	type event = order.Base
+	return []accessor{
+		symbol((*event).EventSymbol, func(o *event, value string) {
+			o.SetEventSymbol(&value)
+		}),
+		timestamp("eventTime", (*event).EventTime, (*event).SetEventTime),
+		eventFlags((*event).EventFlags, (*event).SetEventFlags),
+		checkedInteger("index", (*event).Index, (*event).SetIndex),
+		// the source is packed into the index, so it replaces the source of the index decoded before
+		word("source", func(o *event) string {
+			return *o.OrderSourceName()
+		}, func(o *event, value string) error {
+			if value == "" {
+				return nil
+			}
+			source, err := order.ValueOfName(value)
+			if err == nil {
+				o.SetOrderSource(source)
+			}
+			return err
+		}),
+		timestamp(TimeField, (*event).Time, (*event).SetTime),
+		checkedInteger("sequence", (*event).Sequence, (*event).SetSequence),
+		integer(TimeNanoPartField, (*event).TimeNanoPart, (*event).SetTimeNanoPart),
+		enum("action", "action", (*event).Action, (*event).SetAction, order.Action(order.ActionUndefined),
+			order.ActionNew, order.ActionReplace, order.ActionModify, order.ActionDelete, order.ActionPartial,
+			order.ActionExecute, order.ActionTrade, order.ActionBurst),
+		timestamp("actionTime", (*event).ActionTime, (*event).SetActionTime),
+		integer("orderId", (*event).OrderId, (*event).SetOrderId),
+		integer("auxOrderId", (*event).AuxOrderId, (*event).SetAuxOrderId),
+		float("price", (*event).Price, (*event).SetPrice),
+		float("size", (*event).Size, (*event).SetSize),
+		float("executedSize", (*event).ExecutedSize, (*event).SetExecutedSize),
+		integer("count", (*event).Count, (*event).SetCount),
+		checkedChar("exchangeCode", (*event).ExchangeCode, (*event).SetExchangeCode),
+		enum("side", "side", (*event).Side, (*event).SetSide, side.Side(side.Undefined), side.Buy, side.Sell),
+		enum("scope", "scope", (*event).Scope, (*event).SetScope, order.Scope(order.ScopeComposite),
+			order.ScopeRegional, order.ScopeAggregate, order.ScopeOrder),
+		integer("tradeId", (*event).TradeId, (*event).SetTradeId),
+		float("tradePrice", (*event).TradePrice, (*event).SetTradePrice),
+		float("tradeSize", (*event).TradeSize, (*event).SetTradeSize),
+	}
+}
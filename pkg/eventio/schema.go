@@ -0,0 +1,157 @@
+// Package eventio maps events to flat rows of named fields for text formats like CSV or tapes.
+//
+// Fields of an event type are the fields of the JSON encoding of its events (see package events)
+// in the same order, values of fields are their JSON values. Fields of every market event type are listed
+// explicitly, events are encoded and decoded field by field through their getters and setters.
+package eventio
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+const (
+	EventTypeField    = "eventType"
+	EventFlagsField   = "eventFlags"
+	TimeField         = "time"
+	TimeNanoPartField = "timeNanoPart"
+	eventSymbolField  = "eventSymbol"
+)
+
+type FieldKind int32
+
+const (
+	// LiteralField holds JSON numbers and booleans, NaN is null, infinities are "Infinity" and "-Infinity".
+	LiteralField FieldKind = iota
+	// TextField holds JSON strings or null.
+	TextField
+	// CharField holds one-character JSON strings, the zero character is an empty string.
+	CharField
+	// FlagsField holds an array of event flag names.
+	FlagsField
+)
+
+func (k FieldKind) String() string {
+	switch k {
+	case LiteralField:
+		return "Literal"
+	case TextField:
+		return "Text"
+	case CharField:
+		return "Char"
+	case FlagsField:
+		return "Flags"
+	default:
+		return fmt.Sprintf("FieldKind: Wrong value %d", k)
+	}
+}
+
+type Field struct {
+	Name string
+	Kind FieldKind
+	// Time is set for times in milliseconds, Nanos is set for the time that has a nano part.
+	Time  bool
+	Nanos bool
+}
+
+type Schema struct {
+	eventType string
+	newEvent  func() interface{}
+	fields    []Field
+	accessors []accessor
+	index     map[string]int
+}
+
+var (
+	schemas       = map[string]*Schema{}
+	schemasByType = map[reflect.Type]*Schema{}
+)
+
+// register registers the schema of the event type with accessors of its fields.
+func register(eventType string, newEvent func() interface{}, accessors ...accessor) {
+	s := &Schema{eventType: eventType, newEvent: newEvent, accessors: accessors, index: map[string]int{}}
+	hasNanoPart := false
+	for _, a := range accessors {
+		hasNanoPart = hasNanoPart || a.Name == TimeNanoPartField
+	}
+	for i := range accessors {
+		accessors[i].Nanos = accessors[i].Name == TimeField && hasNanoPart
+		s.index[accessors[i].Name] = i
+		s.fields = append(s.fields, accessors[i].Field)
+	}
+	schemas[eventType] = s
+	schemasByType[reflect.TypeOf(newEvent())] = s
+}
+
+func (s *Schema) EventType() string {
+	return s.eventType
+}
+
+// Fields returns all fields of the event type in the order of the JSON encoding.
+func (s *Schema) Fields() []Field {
+	return s.fields
+}
+
+func (s *Schema) Field(name string) (Field, bool) {
+	i, ok := s.index[name]
+	if !ok {
+		return Field{}, false
+	}
+	return s.fields[i], true
+}
+
+// SchemaOf returns the schema of the event type.
+func SchemaOf(eventType string) (*Schema, error) {
+	s, ok := schemas[eventType]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", eventType)
+	}
+	return s, nil
+}
+
+func schemaOfEvent(event interface{}) (*Schema, error) {
+	s, ok := schemasByType[reflect.TypeOf(event)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported event %T", event)
+	}
+	return s, nil
+}
+
+// IsInfinity reports whether the JSON value is an infinite float.
+func IsInfinity(value json.RawMessage) bool {
+	return string(value) == `"Infinity"` || string(value) == `"-Infinity"`
+}
+
+// Encode encodes the event to its type and JSON values of its fields.
+func Encode(event interface{}) (string, map[string]json.RawMessage, error) {
+	s, err := schemaOfEvent(event)
+	if err != nil {
+		return "", nil, err
+	}
+	values := make(map[string]json.RawMessage, len(s.accessors))
+	for _, a := range s.accessors {
+		values[a.Name] = a.get(event)
+	}
+	return s.eventType, values, nil
+}
+
+// Decode decodes an event of the type from JSON values of its fields, missing fields keep default values
+// and unknown fields are ignored. Fields are decoded in the order of the schema, values are not changed.
+func Decode(eventType string, values map[string]json.RawMessage) (interface{}, error) {
+	s, err := SchemaOf(eventType)
+	if err != nil {
+		return nil, err
+	}
+	event := s.newEvent()
+	for _, a := range s.accessors {
+		value, ok := values[a.Name]
+		if !ok {
+			continue
+		}
+		if err := a.set(event, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", a.Name, err)
+		}
+	}
+	return event, nil
+}
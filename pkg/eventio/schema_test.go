@@ -0,0 +1,140 @@
+package eventio
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/eventtest"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+)
+
+// schemaEvents returns the default event of every schema, the shared events and events with packed fields.
+func schemaEvents() []interface{} {
+	result := []interface{}{}
+	for _, s := range schemas {
+		result = append(result, s.newEvent())
+	}
+	q := quote.NewQuote("AAPL")
+	q.SetAskTime(eventtest.Time - 123)
+	q.SetTimeMillisSequence(456<<millisShift | 7)
+	q.SetAskSize(math.Inf(1))
+	tns := timeandsale.NewTimeAndSale("IBM")
+	tns.SetTimeNanos(eventtest.Time*1_000_000 + 5)
+	tns.SetEventFlags(events.TxPending | events.SnapshotEnd)
+	tns.SetTradeThroughExempt('X')
+	buyer := "BUYER"
+	tns.SetBuyer(&buyer)
+	o := order.NewOrder("MSFT")
+	_ = o.SetExchangeCode('N')
+	result = append(result, eventtest.Events()...)
+	return append(result, q, tns, o)
+}
+
+// jsonFields returns names and values of fields of the JSON encoding of the event in their order.
+func jsonFields(t *testing.T, event interface{}) ([]string, map[string]json.RawMessage) {
+	t.Helper()
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf(`Marshal of %T should not fail. But it fails with %v`, event, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	_, _ = decoder.Token()
+	var names []string
+	values := map[string]json.RawMessage{}
+	for decoder.More() {
+		token, _ := decoder.Token()
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			t.Fatalf(`JSON of %T should be decoded. But it fails with %v`, event, err)
+		}
+		names = append(names, token.(string))
+		values[token.(string)] = value
+	}
+	return names, values
+}
+
+func TestFieldsMatchJSON(t *testing.T) {
+	for _, event := range schemaEvents() {
+		eventType, values, err := Encode(event)
+		if err != nil {
+			t.Fatalf(`Encode of %T should not fail. But it fails with %v`, event, err)
+		}
+		names, expected := jsonFields(t, event)
+		s, _ := SchemaOf(eventType)
+		var fields []string
+		for _, f := range s.Fields() {
+			// event flags are omitted from JSON when there are none
+			if _, ok := expected[f.Name]; ok || f.Name != EventFlagsField {
+				fields = append(fields, f.Name)
+			}
+		}
+		if string(expected[EventTypeField]) != `"`+eventType+`"` || len(fields) != len(names)-1 {
+			t.Fatalf(`Fields of %s should be %v. But they equal %v`, eventType, names[1:], fields)
+		}
+		for i, name := range fields {
+			if name != names[i+1] || string(values[name]) != string(expected[name]) {
+				t.Errorf(`%s.%s should be %s. But it equals %s.%s = %s`,
+					eventType, names[i+1], expected[names[i+1]], eventType, name, values[name])
+			}
+		}
+	}
+}
+
+func TestDecodeRestoresEncodedEvents(t *testing.T) {
+	for _, event := range schemaEvents() {
+		eventType, values, _ := Encode(event)
+		decoded, err := Decode(eventType, values)
+		if err != nil {
+			t.Fatalf(`Decode of %s should not fail. But it fails with %v`, eventType, err)
+		}
+		expected, _ := json.Marshal(event)
+		actual, _ := json.Marshal(decoded)
+		if string(actual) != string(expected) {
+			t.Errorf(`Decoded %s should be %s. But it equals %s`, eventType, expected, actual)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	values := map[string]json.RawMessage{"bidPrice": json.RawMessage("10"), "unknown": json.RawMessage("1")}
+	event, err := Decode("Quote", values)
+	if err != nil || event.(*quote.Quote).BidPrice() != 10 {
+		t.Fatalf(`Quote should be decoded. But it equals %v (%v)`, event, err)
+	}
+	if len(values) != 2 {
+		t.Errorf(`Decode should not change values. But they equal %v`, values)
+	}
+	if _, err := Decode("Quote", map[string]json.RawMessage{"bidExchangeCode": json.RawMessage(`"xy"`)}); err == nil {
+		t.Errorf(`Decode of a string into a character should fail`)
+	}
+	if _, err := Decode("Order", map[string]json.RawMessage{"side": json.RawMessage(`"Up"`)}); err == nil {
+		t.Errorf(`Decode of an unknown side should fail`)
+	}
+	if _, err := Decode("Unknown", values); err == nil {
+		t.Errorf(`Decode of an unknown event type should fail`)
+	}
+}
+
+func TestFieldKinds(t *testing.T) {
+	kinds := map[string]map[string]FieldKind{
+		"Order":       {"source": TextField, "marketMaker": TextField, "exchangeCode": CharField, "eventFlags": FlagsField},
+		"TimeAndSale": {"buyer": TextField, "tradeThroughExempt": CharField, "price": LiteralField},
+		"Quote":       {"eventSymbol": TextField, "bidExchangeCode": CharField, "bidTime": LiteralField},
+	}
+	for eventType, fields := range kinds {
+		s, _ := SchemaOf(eventType)
+		for name, kind := range fields {
+			if f, ok := s.Field(name); !ok || f.Kind != kind {
+				t.Errorf(`%s.%s should be %v. But it is %v`, eventType, name, kind, f.Kind)
+			}
+		}
+	}
+	if f, _ := schemas["TimeAndSale"].Field(TimeField); !f.Time || !f.Nanos {
+		t.Errorf(`TimeAndSale.time should be a time with nanos. But it equals %+v`, f)
+	}
+}
@@ -0,0 +1,34 @@
+package eventio
+
+import (
+	"encoding/json"
+)
+
+// Value is the JSON value of a field of an event.
+type Value struct {
+	Name string
+	JSON json.RawMessage
+}
+
+// Select encodes the event to its type and values of the named fields in the given order, names the type
+// doesn't have are skipped. Without names all fields are returned in the order of the JSON encoding.
+func Select(event interface{}, names ...string) (string, []Value, error) {
+	s, err := schemaOfEvent(event)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(names) == 0 {
+		values := make([]Value, len(s.accessors))
+		for i, a := range s.accessors {
+			values[i] = Value{Name: a.Name, JSON: a.get(event)}
+		}
+		return s.eventType, values, nil
+	}
+	var values []Value
+	for _, name := range names {
+		if i, ok := s.index[name]; ok {
+			values = append(values, Value{Name: name, JSON: s.accessors[i].get(event)})
+		}
+	}
+	return s.eventType, values, nil
+}
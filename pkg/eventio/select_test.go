@@ -0,0 +1,26 @@
+package eventio
+
+import (
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+)
+
+func TestSelect(t *testing.T) {
+	q := quote.NewQuote("AAPL")
+	q.SetBidPrice(100.5)
+	eventType, values, err := Select(q, "bidPrice", "unknown", "eventSymbol")
+	if err != nil {
+		t.Fatalf(`Select should not fail. But it fails with %v`, err)
+	}
+	if eventType != "Quote" || len(values) != 2 {
+		t.Fatalf(`Select should return 2 values of Quote. But it returns %d values of %s`, len(values), eventType)
+	}
+	if values[0].Name != "bidPrice" || string(values[0].JSON) != "100.5" || string(values[1].JSON) != `"AAPL"` {
+		t.Fatalf(`Values should be bidPrice=100.5 and eventSymbol="AAPL". But they equal %v`, values)
+	}
+	_, all, _ := Select(q)
+	if len(all) == 0 || all[0].Name == EventTypeField {
+		t.Fatalf(`All values should be returned without eventType. But they equal %v`, all)
+	}
+}
@@ -0,0 +1,160 @@
+package tape
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const maxLineSize = 1 << 20
+
+// UnknownRecordError is returned for data lines of records that are not mapped to events.
+type UnknownRecordError struct {
+	Record string
+}
+
+func (e *UnknownRecordError) Error() string {
+	return fmt.Sprintf("unknown record %s", e.Record)
+}
+
+// description is the described layout of a record, columns of unknown fields are nil.
+type description struct {
+	record  *record
+	suffix  string
+	columns []*column
+}
+
+// Reader reads events from a text tape. Read events can be published with DXPublisher.Publish.
+type Reader struct {
+	scanner     *bufio.Scanner
+	line        int
+	textTime    bool
+	skipUnknown bool
+	skipped     map[string]int
+	// descriptions holds the described records, nil for unknown records
+	descriptions map[string]*description
+}
+
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	return &Reader{scanner: scanner, skipped: map[string]int{}, descriptions: map[string]*description{}}
+}
+
+// SetSkipUnknownRecords sets whether data lines of unknown records are skipped instead of being reported
+// with UnknownRecordError. Skipped lines are counted by SkippedRecords.
+func (r *Reader) SetSkipUnknownRecords(skip bool) {
+	r.skipUnknown = skip
+}
+
+// SkippedRecords returns the number of skipped data lines by unknown records.
+func (r *Reader) SkippedRecords() map[string]int {
+	return r.skipped
+}
+
+// Read reads the next event. It returns io.EOF when there are no more events.
+// After an UnknownRecordError the reader continues with the next line.
+func (r *Reader) Read() (interface{}, error) {
+	for r.scanner.Scan() {
+		r.line++
+		event, err := r.readLine(r.scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		if event != nil {
+			return event, nil
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// ReadAll reads all remaining events.
+func (r *Reader) ReadAll() ([]interface{}, error) {
+	var result []interface{}
+	for {
+		event, err := r.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, event)
+	}
+}
+
+// readLine returns the event of the data line or nil for other lines.
+func (r *Reader) readLine(line string) (interface{}, error) {
+	if strings.HasPrefix(line, commentPrefix) {
+		return nil, nil
+	}
+	tokens, err := tokenize(line)
+	if err != nil || len(tokens) == 0 {
+		return nil, err
+	}
+	name := tokens[0].text
+	switch {
+	case strings.HasPrefix(name, messagePrefix):
+		r.readMessage(tokens[1:])
+		return nil, nil
+	case strings.HasPrefix(name, descriptionPrefix):
+		r.readDescription(strings.TrimPrefix(name, descriptionPrefix), tokens[1:])
+		return nil, nil
+	}
+	d, ok := r.descriptions[name]
+	if !ok {
+		return nil, fmt.Errorf("no description of %s", name)
+	}
+	if d == nil {
+		if r.skipUnknown {
+			r.skipped[name]++
+			return nil, nil
+		}
+		return nil, &UnknownRecordError{Record: name}
+	}
+	if len(tokens)-1 > len(d.columns) {
+		return nil, fmt.Errorf("%d values of %s for %d fields", len(tokens)-1, name, len(d.columns))
+	}
+	event := d.record.newEvent()
+	for i, t := range tokens[1:] {
+		c := d.columns[i]
+		if c == nil {
+			continue
+		}
+		if err := c.parse(event, t, r.textTime); err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", name, c.name, err)
+		}
+	}
+	if err := d.record.setSuffix(event, d.suffix); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return event, nil
+}
+
+func (r *Reader) readMessage(properties []token) {
+	for _, p := range properties {
+		key, value, ok := strings.Cut(p.text, "=")
+		if ok && key == timeProperty {
+			r.textTime = value == textTime
+		}
+	}
+}
+
+func (r *Reader) readDescription(name string, names []token) {
+	rec, suffix, ok := recordOf(name)
+	if !ok {
+		r.descriptions[name] = nil
+		return
+	}
+	d := &description{record: rec, suffix: suffix, columns: make([]*column, len(names))}
+	for i, n := range names {
+		if c, ok := rec.column(n.text); ok {
+			d.columns[i] = &c
+		}
+	}
+	r.descriptions[name] = d
+}
@@ -0,0 +1,677 @@
+package tape
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/greeks"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/profile"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+)
+
+// Records and fields of the QDS scheme. Fields are named by their property names like BidPrice, their names
+// in the scheme like Bid.Price are accepted too. Fields of events that are packed natively are packed
+// the same way: the index of time series is split into Time in seconds and Sequence holding milliseconds
+// and the sequence, enums and boolean properties are packed into Flags.
+
+const (
+	regionalSeparator = "&"
+	sourceSeparator   = "#"
+	// sequenceMask is the lower half of indices and time sequences, the upper half is Time in seconds.
+	sequenceMask = 0xffffffff
+)
+
+// column is a field of a record, it formats a field of events as a token and parses it back.
+type column struct {
+	name string
+	// alias is the name of the field in the QDS scheme when it differs from the property name
+	alias string
+	// exchange is set for exchange codes that regional records keep in their name instead
+	exchange bool
+	format   func(event interface{}, textTime bool) string
+	parse    func(event interface{}, t token, textTime bool) error
+}
+
+func (c column) as(alias string) column {
+	c.alias = alias
+	return c
+}
+
+func newColumn[E any](name string, format func(E, bool) string, parse func(E, token, bool) error) column {
+	return column{
+		name: name,
+		format: func(event interface{}, textTime bool) string {
+			return format(event.(E), textTime)
+		},
+		parse: func(event interface{}, t token, textTime bool) error {
+			return parse(event.(E), t, textTime)
+		},
+	}
+}
+
+// record is a record of the QDS scheme. Regional records like Quote&Q and records of order sources
+// like Order#NTV have a suffix after the name of the record.
+type record struct {
+	name     string
+	newEvent func() interface{}
+	columns  []column
+	// suffixOf returns the suffix of the record of the event
+	suffixOf func(event interface{}) (string, error)
+	// setSuffix applies the suffix of the record to a read event
+	setSuffix func(event interface{}, suffix string) error
+}
+
+var (
+	records       = map[string]*record{}
+	recordsByType = map[reflect.Type]*record{}
+)
+
+func register(r *record) {
+	if r.suffixOf == nil {
+		r.suffixOf = func(interface{}) (string, error) { return "", nil }
+		r.setSuffix = func(_ interface{}, suffix string) error {
+			if suffix != "" {
+				return fmt.Errorf("unexpected suffix %s", suffix)
+			}
+			return nil
+		}
+	}
+	records[r.name] = r
+	recordsByType[reflect.TypeOf(r.newEvent())] = r
+}
+
+// recordOf returns the record by its name with a suffix like Quote&Q and the suffix.
+func recordOf(name string) (*record, string, bool) {
+	i := strings.IndexAny(name, regionalSeparator+sourceSeparator)
+	if i < 0 {
+		i = len(name)
+	}
+	r, ok := records[name[:i]]
+	return r, name[i:], ok
+}
+
+// columnsOf returns the columns of the record with the suffix.
+func (r *record) columnsOf(suffix string) []column {
+	if !strings.HasPrefix(suffix, regionalSeparator) {
+		return r.columns
+	}
+	var result []column
+	for _, c := range r.columns {
+		if !c.exchange {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (r *record) column(name string) (column, bool) {
+	for _, c := range r.columns {
+		if c.name == name || c.alias == name {
+			return c, true
+		}
+	}
+	return column{}, false
+}
+
+// regional makes the record regional: the quote of AAPL&Q is written to the record Quote&Q with the symbol AAPL.
+func regional[E any](r *record, symbolOf func(E) *string, setSymbol func(E, string), setExchange func(E, rune)) *record {
+	r.suffixOf = func(event interface{}) (string, error) {
+		_, suffix := splitRegional(symbolOf(event.(E)))
+		return suffix, nil
+	}
+	r.setSuffix = func(event interface{}, suffix string) error {
+		if suffix == "" {
+			return nil
+		}
+		exchange := []rune(strings.TrimPrefix(suffix, regionalSeparator))
+		if !strings.HasPrefix(suffix, regionalSeparator) || len(exchange) != 1 {
+			return fmt.Errorf("unexpected suffix %s", suffix)
+		}
+		e := event.(E)
+		symbol := ""
+		if s := symbolOf(e); s != nil {
+			symbol = *s
+		}
+		setSymbol(e, symbol+suffix)
+		setExchange(e, exchange[0])
+		return nil
+	}
+	return r
+}
+
+// sourced makes the record a record of order sources: orders of the source NTV are written to Order#NTV,
+// orders of the default source are written to Order.
+func sourced[E any](r *record, eventType eventcodes.EventCode, base func(E) *order.Base) *record {
+	r.suffixOf = func(event interface{}) (string, error) {
+		source, err := base(event.(E)).OrderSource()
+		if err != nil {
+			return "", err
+		}
+		if err := checkPublishable(source, eventType); err != nil {
+			return "", err
+		}
+		if source.Id() == order.Default().Id() {
+			return "", nil
+		}
+		return sourceSeparator + *source.Name(), nil
+	}
+	r.setSuffix = func(event interface{}, suffix string) error {
+		source := order.Default()
+		if suffix != "" {
+			if !strings.HasPrefix(suffix, sourceSeparator) {
+				return fmt.Errorf("unexpected suffix %s", suffix)
+			}
+			var err error
+			if source, err = order.ValueOfName(strings.TrimPrefix(suffix, sourceSeparator)); err != nil {
+				return err
+			}
+			if err := checkPublishable(source, eventType); err != nil {
+				return err
+			}
+		}
+		base(event.(E)).SetOrderSource(source)
+		return nil
+	}
+	return r
+}
+
+// checkPublishable returns an error for sources that events of the type are not published with,
+// their records are not in the scheme.
+func checkPublishable(source *order.Source, eventType eventcodes.EventCode) error {
+	if !source.IsPublishable(eventType) {
+		return fmt.Errorf("source %s is not publishable for %s events", *source.Name(), eventType)
+	}
+	return nil
+}
+
+// splitRegional splits the symbol like AAPL&Q into the symbol and the suffix of the regional record.
+func splitRegional(symbol *string) (string, string) {
+	if symbol == nil {
+		return "", ""
+	}
+	s := *symbol
+	if i := strings.LastIndex(s, regionalSeparator); i >= 0 && len([]rune(s[i+1:])) == 1 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+func init() {
+	register(regional(&record{
+		name:     "Quote",
+		newEvent: func() interface{} { return quote.NewQuote("") },
+		columns:  quoteColumns(),
+	}, (*quote.Quote).EventSymbol, (*quote.Quote).SetEventSymbol, func(q *quote.Quote, exchange rune) {
+		q.SetBidExchangeCode(exchange)
+		q.SetAskExchangeCode(exchange)
+	}))
+	setTradeSymbol := func(t *trade.TradeBase, symbol string) { t.SetEventSymbol(&symbol) }
+	setTradeExchange := func(t *trade.TradeBase, exchange rune) { t.SetExchangeCode(int16(exchange)) }
+	register(regional(&record{
+		name:     "Trade",
+		newEvent: func() interface{} { return trade.NewTrade("") },
+		columns:  embedded(func(t *trade.Trade) *trade.TradeBase { return &t.TradeBase }, tradeColumns("Last", "")...),
+	}, func(t *trade.Trade) *string { return t.EventSymbol() },
+		func(t *trade.Trade, symbol string) { setTradeSymbol(&t.TradeBase, symbol) },
+		func(t *trade.Trade, exchange rune) { setTradeExchange(&t.TradeBase, exchange) }))
+	register(regional(&record{
+		name:     "TradeETH",
+		newEvent: func() interface{} { return trade.NewTradeETH("") },
+		columns: embedded(func(t *trade.TradeETH) *trade.TradeBase { return &t.TradeBase },
+			tradeColumns("ETHLast", "ETH")...),
+	}, func(t *trade.TradeETH) *string { return t.EventSymbol() },
+		func(t *trade.TradeETH, symbol string) { setTradeSymbol(&t.TradeBase, symbol) },
+		func(t *trade.TradeETH, exchange rune) { setTradeExchange(&t.TradeBase, exchange) }))
+	register(regional(&record{
+		name:     "TimeAndSale",
+		newEvent: func() interface{} { return timeandsale.NewTimeAndSale("") },
+		columns:  timeAndSaleColumns(),
+	}, (*timeandsale.TimeAndSale).EventSymbol, (*timeandsale.TimeAndSale).SetEventSymbol,
+		func(t *timeandsale.TimeAndSale, exchange rune) { t.SetExchangeCode(int16(exchange)) }))
+	register(&record{
+		name:     "Profile",
+		newEvent: func() interface{} { return profile.NewProfile("") },
+		columns:  profileColumns(),
+	})
+	register(&record{
+		name:     "Greeks",
+		newEvent: func() interface{} { return greeks.NewGreeks("") },
+		columns:  greeksColumns(),
+	})
+	register(&record{
+		name:     "Candle",
+		newEvent: func() interface{} { return candle.NewCandle("") },
+		columns:  candleColumns(),
+	})
+	register(sourced(&record{
+		name:     "Order",
+		newEvent: func() interface{} { return order.NewOrder("") },
+		columns:  orderColumns(),
+	}, eventcodes.Order, func(o *order.Order) *order.Base { return &o.Base }))
+	register(sourced(&record{
+		name:     "AnalyticOrder",
+		newEvent: func() interface{} { return order.NewAnalyticOrder("") },
+		columns: append(embedded(func(o *order.AnalyticOrder) *order.Order { return &o.Order }, orderColumns()...),
+			decimal("IcebergPeakSize", (*order.AnalyticOrder).IcebergPeakSize, (*order.AnalyticOrder).SetIcebergPeakSize),
+			decimal("IcebergHiddenSize", (*order.AnalyticOrder).IcebergHiddenSize,
+				(*order.AnalyticOrder).SetIcebergHiddenSize),
+			decimal("IcebergExecutedSize", (*order.AnalyticOrder).IcebergExecutedSize,
+				(*order.AnalyticOrder).SetIcebergExecutedSize),
+			integer("IcebergFlags", (*order.AnalyticOrder).IcebergFlags, (*order.AnalyticOrder).SetIcebergFlags),
+		),
+	}, eventcodes.AnalyticOrder, func(o *order.AnalyticOrder) *order.Base { return &o.Base }))
+	register(sourced(&record{
+		name:     "SpreadOrder",
+		newEvent: func() interface{} { return order.NewSpreadOrder("") },
+		columns: append(embedded(func(o *order.SpreadOrder) *order.Base { return &o.Base }, orderBaseColumns()...),
+			text("SpreadSymbol", (*order.SpreadOrder).SpreadSymbol, (*order.SpreadOrder).SetSpreadSymbol),
+		),
+	}, eventcodes.SpreadOrder, func(o *order.SpreadOrder) *order.Base { return &o.Base }))
+}
+
+func quoteColumns() []column {
+	type event = quote.Quote
+	// milliseconds of the quote time are kept with the sequence, bid and ask times in seconds don't change them
+	keepMillis := func(set func(*event, int64)) func(*event, int64) {
+		return func(q *event, value int64) {
+			timeMillisSequence := q.TimeMillisSequence()
+			set(q, value)
+			q.SetTimeMillisSequence(timeMillisSequence)
+		}
+	}
+	return []column{
+		regionalSymbol((*event).EventSymbol, (*event).SetEventSymbol),
+		millis("EventTime", (*event).EventTime, (*event).SetEventTime),
+		integer("Sequence", (*event).TimeMillisSequence, (*event).SetTimeMillisSequence),
+		integer("TimeNanoPart", (*event).TimeNanoPart, (*event).SetTimeNanoPart),
+		seconds("BidTime", (*event).BidTime, keepMillis((*event).SetBidTime)).as("Bid.Time"),
+		exchange(char("BidExchangeCode", (*event).BidExchangeCode, (*event).SetBidExchangeCode).as("Bid.Exchange")),
+		decimal("BidPrice", (*event).BidPrice, (*event).SetBidPrice).as("Bid.Price"),
+		decimal("BidSize", (*event).BidSize, (*event).SetBidSize).as("Bid.Size"),
+		seconds("AskTime", (*event).AskTime, keepMillis((*event).SetAskTime)).as("Ask.Time"),
+		exchange(char("AskExchangeCode", (*event).AskExchangeCode, (*event).SetAskExchangeCode).as("Ask.Exchange")),
+		decimal("AskPrice", (*event).AskPrice, (*event).SetAskPrice).as("Ask.Price"),
+		decimal("AskSize", (*event).AskSize, (*event).SetAskSize).as("Ask.Size"),
+	}
+}
+
+// tradeColumns returns the columns of Trade or TradeETH, their fields in the scheme have different prefixes.
+func tradeColumns(last string, day string) []column {
+	type event = trade.TradeBase
+	timeColumn, sequenceColumn := timeAndSequence((*event).TimeSequence, (*event).SetTimeSequence)
+	return []column{
+		regionalSymbol((*event).EventSymbol, func(t *event, symbol string) { t.SetEventSymbol(&symbol) }),
+		millis("EventTime", (*event).EventTime, (*event).SetEventTime),
+		timeColumn.as(last + ".Time"),
+		sequenceColumn.as(last + ".Sequence"),
+		integer("TimeNanoPart", (*event).TimeNanoPart, (*event).SetTimeNanoPart).as(last + ".TimeNanoPart"),
+		exchange(char("ExchangeCode", (*event).ExchangeCode, (*event).SetExchangeCode).as(last + ".Exchange")),
+		decimal("Price", (*event).Price, (*event).SetPrice).as(last + ".Price"),
+		decimal("Size", (*event).Size, (*event).SetSize).as(last + ".Size"),
+		decimal("Change", (*event).Change, (*event).SetChange).as(last + ".Change"),
+		integer("DayId", (*event).DayId, (*event).SetDayId).as(last + ".DayId"),
+		decimal("DayVolume", (*event).DayVolume, (*event).SetDayVolume).as(day + "Volume"),
+		decimal("DayTurnover", (*event).DayTurnover, (*event).SetDayTurnover).as(day + "DayTurnover"),
+		integer("Flags", (*event).Flags, (*event).SetFlags).as(last + ".Flags"),
+	}
+}
+
+func timeAndSaleColumns() []column {
+	type event = timeandsale.TimeAndSale
+	timeColumn, sequenceColumn := timeAndSequence((*event).Index, (*event).SetIndex)
+	return []column{
+		regionalSymbol((*event).EventSymbol, (*event).SetEventSymbol),
+		millis("EventTime", (*event).EventTime, (*event).SetEventTime),
+		eventFlags((*event).EventFlags, (*event).SetEventFlags),
+		timeColumn,
+		sequenceColumn,
+		integer("TimeNanoPart", (*event).TimeNanoPart, (*event).SetTimeNanoPart),
+		exchange(char("ExchangeCode", (*event).ExchangeCode, (*event).SetExchangeCode).as("Exchange")),
+		decimal("Price", (*event).Price, (*event).SetPrice),
+		decimal("Size", (*event).Size, (*event).SetSize),
+		decimal("BidPrice", (*event).BidPrice, (*event).SetBidPrice).as("Bid.Price"),
+		decimal("AskPrice", (*event).AskPrice, (*event).SetAskPrice).as("Ask.Price"),
+		text("ExchangeSaleConditions", (*event).ExchangeSaleConditions, (*event).SetExchangeSaleConditions),
+		integer("Flags", (*event).Flags, (*event).SetFlags),
+		text("Buyer", (*event).Buyer, (*event).SetBuyer),
+		text("Seller", (*event).Seller, (*event).SetSeller),
+	}
+}
+
+func profileColumns() []column {
+	type event = profile.Profile
+	return []column{
+		symbol((*event).EventSymbol, func(p *event, symbol string) { p.SetEventSymbol(&symbol) }),
+		millis("EventTime", (*event).EventTime, (*event).SetEventTime),
+		decimal("Beta", (*event).Beta, (*event).SetBeta),
+		decimal("EarningsPerShare", (*event).EarningsPerShare, (*event).SetEarningsPerShare).as("Eps"),
+		decimal("DividendFrequency", (*event).DividendFrequency, (*event).SetDividendFrequency).as("DivFreq"),
+		decimal("ExDividendAmount", (*event).ExDividendAmount, (*event).SetExDividendAmount).as("ExdDiv.Amount"),
+		integer("ExDividendDayId", (*event).ExDividendDayId, (*event).SetExDividendDayId).as("ExdDiv.Date"),
+		decimal("High52WeekPrice", (*event).High52WeekPrice, (*event).SetHigh52WeekPrice).as("52High.Price"),
+		decimal("Low52WeekPrice", (*event).Low52WeekPrice, (*event).SetLow52WeekPrice).as("52Low.Price"),
+		decimal("Shares", (*event).Shares, (*event).SetShares),
+		decimal("FreeFloat", (*event).FreeFloat, (*event).SetFreeFloat),
+		decimal("HighLimitPrice", (*event).HighLimitPrice, (*event).SetHighLimitPrice),
+		decimal("LowLimitPrice", (*event).LowLimitPrice, (*event).SetLowLimitPrice),
+		seconds("HaltStartTime", (*event).HaltStartTime, (*event).SetHaltStartTime).as("Halt.StartTime"),
+		seconds("HaltEndTime", (*event).HaltEndTime, (*event).SetHaltEndTime).as("Halt.EndTime"),
+		integer("Flags", (*event).Flags, (*event).SetFlags),
+		text("Description", (*event).Description, (*event).SetDescription),
+		text("StatusReason", (*event).StatusReason, (*event).SetStatusReason),
+	}
+}
+
+func greeksColumns() []column {
+	type event = greeks.Greeks
+	timeColumn, sequenceColumn := timeAndSequence((*event).Index, (*event).SetIndex)
+	return []column{
+		symbol((*event).EventSymbol, (*event).SetEventSymbol),
+		millis("EventTime", (*event).EventTime, (*event).SetEventTime),
+		eventFlags((*event).EventFlags, (*event).SetEventFlags),
+		timeColumn,
+		sequenceColumn,
+		decimal("Price", (*event).Price, (*event).SetPrice).as("Greeks.Price"),
+		decimal("Volatility", (*event).Volatility, (*event).SetVolatility),
+		decimal("Delta", (*event).Delta, (*event).SetDelta),
+		decimal("Gamma", (*event).Gamma, (*event).SetGamma),
+		decimal("Theta", (*event).Theta, (*event).SetTheta),
+		decimal("Rho", (*event).Rho, (*event).SetRho),
+		decimal("Vega", (*event).Vega, (*event).SetVega),
+	}
+}
+
+func candleColumns() []column {
+	type event = candle.Candle
+	timeColumn, sequenceColumn := timeAndSequence((*event).Index, (*event).SetIndex)
+	return []column{
+		symbol(func(c *event) *string {
+			if c.EventSymbol() == nil {
+				return nil
+			}
+			return c.EventSymbol().Symbol()
+		}, func(c *event, symbol string) {
+			c.SetEventSymbol(candle.NewCandleSymbol(symbol))
+		}),
+		millis("EventTime", (*event).EventTime, (*event).SetEventTime),
+		eventFlags((*event).EventFlags, (*event).SetEventFlags),
+		timeColumn,
+		sequenceColumn,
+		integer("Count", (*event).Count, (*event).SetCount),
+		decimal("Open", (*event).Open, (*event).SetOpen),
+		decimal("High", (*event).High, (*event).SetHigh),
+		decimal("Low", (*event).Low, (*event).SetLow),
+		decimal("Close", (*event).Close, (*event).SetClose),
+		decimal("Volume", (*event).Volume, (*event).SetVolume),
+		decimal("VWAP", (*event).Vwap, (*event).SetVwap),
+		decimal("BidVolume", (*event).BidVolume, (*event).SetBidVolume).as("Bid.Volume"),
+		decimal("AskVolume", (*event).AskVolume, (*event).SetAskVolume).as("Ask.Volume"),
+		decimal("ImpVolatility", (*event).ImpVolatility, (*event).SetImpVolatility),
+		decimal("OpenInterest", (*event).OpenInterest, (*event).SetOpenInterest),
+	}
+}
+
+func orderColumns() []column {
+	return append(embedded(func(o *order.Order) *order.Base { return &o.Base }, orderBaseColumns()...),
+		text("MarketMaker", (*order.Order).MarketMaker, (*order.Order).SetMarketMaker).as("MMID"))
+}
+
+func orderBaseColumns() []column {
+	type event = order.Base
+	timeColumn, sequenceColumn := timeAndSequence((*event).TimeSequence, (*event).SetTimeSequence)
+	return []column{
+		symbol((*event).EventSymbol, func(o *event, symbol string) { o.SetEventSymbol(&symbol) }),
+		millis("EventTime", (*event).EventTime, (*event).SetEventTime),
+		eventFlags((*event).EventFlags, (*event).SetEventFlags),
+		// the source is kept in the name of the record and is packed into the index when the event is read
+		newColumn("Index", func(o *event, _ bool) string {
+			mask := int64(sequenceMask)
+			if order.IsSpecialSourceId(o.Index() >> 48) {
+				mask = 1<<48 - 1
+			}
+			return strconv.FormatInt(o.Index()&mask, 10)
+		}, func(o *event, t token, _ bool) error {
+			value, err := parseInteger(t, 64)
+			if err != nil {
+				return err
+			}
+			return o.SetIndex(value)
+		}),
+		timeColumn,
+		sequenceColumn,
+		integer("TimeNanoPart", (*event).TimeNanoPart, (*event).SetTimeNanoPart),
+		millis("ActionTime", (*event).ActionTime, (*event).SetActionTime),
+		integer("OrderId", (*event).OrderId, (*event).SetOrderId),
+		integer("AuxOrderId", (*event).AuxOrderId, (*event).SetAuxOrderId),
+		decimal("Price", (*event).Price, (*event).SetPrice),
+		decimal("Size", (*event).Size, (*event).SetSize),
+		decimal("ExecutedSize", (*event).ExecutedSize, (*event).SetExecutedSize),
+		integer("Count", (*event).Count, (*event).SetCount),
+		integer("Flags", (*event).Flags, (*event).SetFlags),
+		integer("TradeId", (*event).TradeId, (*event).SetTradeId),
+		decimal("TradePrice", (*event).TradePrice, (*event).SetTradePrice),
+		decimal("TradeSize", (*event).TradeSize, (*event).SetTradeSize),
+	}
+}
+
+// embedded returns columns of events E for columns of events B embedded in them.
+func embedded[E any, B any](base func(E) B, columns ...column) []column {
+	result := make([]column, len(columns))
+	for i, c := range columns {
+		c := c
+		result[i] = c
+		result[i].format = func(event interface{}, textTime bool) string {
+			return c.format(base(event.(E)), textTime)
+		}
+		result[i].parse = func(event interface{}, t token, textTime bool) error {
+			return c.parse(base(event.(E)), t, textTime)
+		}
+	}
+	return result
+}
+
+func exchange(c column) column {
+	c.exchange = true
+	return c
+}
+
+func symbol[E any](get func(E) *string, set func(E, string)) column {
+	return newColumn("EventSymbol", func(event E, _ bool) string {
+		if s := get(event); s != nil {
+			return quoteText(*s)
+		}
+		return nullString
+	}, func(event E, t token, _ bool) error {
+		if !t.null {
+			set(event, t.text)
+		}
+		return nil
+	})
+}
+
+// regionalSymbol is the symbol of regional records without the exchange code kept in the name of the record.
+func regionalSymbol[E any](get func(E) *string, set func(E, string)) column {
+	return symbol(func(event E) *string {
+		if s := get(event); s != nil {
+			symbol, _ := splitRegional(s)
+			return &symbol
+		}
+		return nil
+	}, set)
+}
+
+func integer[E any, T int16 | int32 | int64](name string, get func(E) T, set func(E, T)) column {
+	return newColumn(name, func(event E, _ bool) string {
+		return strconv.FormatInt(int64(get(event)), 10)
+	}, func(event E, t token, _ bool) error {
+		var zero T
+		value, err := parseInteger(t, int(reflect.TypeOf(zero).Size())*8)
+		if err == nil {
+			set(event, T(value))
+		}
+		return err
+	})
+}
+
+func decimal[E any](name string, get func(E) float64, set func(E, float64)) column {
+	return newColumn(name, func(event E, _ bool) string {
+		value := get(event)
+		switch {
+		case math.IsInf(value, 1):
+			return "Infinity"
+		case math.IsInf(value, -1):
+			return "-Infinity"
+		}
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}, func(event E, t token, _ bool) error {
+		value, err := strconv.ParseFloat(t.text, 64)
+		if t.null {
+			value, err = math.NaN(), nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value %q", t.text)
+		}
+		set(event, value)
+		return nil
+	})
+}
+
+func char[E any, T int16 | int32](name string, get func(E) T, set func(E, T)) column {
+	return newColumn(name, func(event E, _ bool) string {
+		if value := get(event); value != 0 {
+			return quoteText(string(rune(value)))
+		}
+		return zeroChar
+	}, func(event E, t token, _ bool) error {
+		value := []rune(t.text)
+		switch {
+		case t.zero || t.null || len(value) == 0:
+			set(event, 0)
+		case len(value) != 1 || rune(T(value[0])) != value[0]:
+			return fmt.Errorf("invalid character %q", t.text)
+		default:
+			set(event, T(value[0]))
+		}
+		return nil
+	})
+}
+
+func text[E any](name string, get func(E) *string, set func(E, *string)) column {
+	return newColumn(name, func(event E, _ bool) string {
+		if s := get(event); s != nil {
+			return quoteText(*s)
+		}
+		return nullString
+	}, func(event E, t token, _ bool) error {
+		if t.null {
+			set(event, nil)
+		} else {
+			value := t.text
+			set(event, &value)
+		}
+		return nil
+	})
+}
+
+// millis is a time in milliseconds.
+func millis[E any](name string, get func(E) int64, set func(E, int64)) column {
+	return newColumn(name, func(event E, textTime bool) string {
+		if textTime {
+			return millisTimeFormat.Format(get(event))
+		}
+		return strconv.FormatInt(get(event), 10)
+	}, func(event E, t token, textTime bool) error {
+		value, err := parseTime(t, textTime, 1)
+		if err == nil {
+			set(event, value)
+		}
+		return err
+	})
+}
+
+// seconds is a time in milliseconds kept in seconds.
+func seconds[E any](name string, get func(E) int64, set func(E, int64)) column {
+	return newColumn(name, func(event E, textTime bool) string {
+		value := mathutil.FloorDivInt(get(event), 1000)
+		if textTime {
+			return secondsTimeFormat.Format(value * 1000)
+		}
+		return strconv.FormatInt(value, 10)
+	}, func(event E, t token, textTime bool) error {
+		value, err := parseTime(t, textTime, 1000)
+		if err == nil {
+			set(event, value)
+		}
+		return err
+	})
+}
+
+// timeAndSequence returns the Time and Sequence columns of the index or the time sequence, Time is in seconds
+// and Sequence holds milliseconds and the sequence.
+func timeAndSequence[E any](get func(E) int64, set func(E, int64)) (column, column) {
+	timeColumn := seconds("Time", func(event E) int64 {
+		return (get(event) >> 32) * 1000
+	}, func(event E, value int64) {
+		set(event, value/1000<<32|get(event)&sequenceMask)
+	})
+	sequenceColumn := newColumn("Sequence", func(event E, _ bool) string {
+		return strconv.FormatInt(int64(int32(get(event))), 10)
+	}, func(event E, t token, _ bool) error {
+		value, err := parseInteger(t, 64)
+		if err == nil {
+			set(event, get(event)&^sequenceMask|value&sequenceMask)
+		}
+		return err
+	})
+	return timeColumn, sequenceColumn
+}
+
+func eventFlags[E any](get func(E) int32, set func(E, int32)) column {
+	return newColumn("EventFlags", func(event E, _ bool) string {
+		return strconv.FormatInt(int64(get(event)), 10)
+	}, func(event E, t token, _ bool) error {
+		if flags, err := strconv.ParseInt(t.text, 0, 32); err == nil {
+			set(event, int32(flags))
+			return nil
+		}
+		// flags written by names are accepted too
+		names, _ := json.Marshal(strings.Split(t.text, flagsSeparator))
+		var flags events.JSONEventFlags
+		if err := flags.UnmarshalJSON(names); err != nil {
+			return err
+		}
+		set(event, int32(flags))
+		return nil
+	})
+}
+
+func parseInteger(t token, bitSize int) (int64, error) {
+	value, err := strconv.ParseInt(t.text, 0, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", t.text)
+	}
+	return value, nil
+}
+
+// parseTime parses the time in units of milliseconds or as text and returns it in milliseconds.
+func parseTime(t token, textTime bool, unit int64) (int64, error) {
+	value, err := strconv.ParseInt(t.text, 10, 64)
+	if err == nil && !textTime {
+		return value * unit, nil
+	}
+	if value, err = millisTimeFormat.Parse(t.text); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
@@ -0,0 +1,183 @@
+// Package tape reads and writes events in the dxFeed text tape format without the native library.
+//
+// A tape is a sequence of lines:
+//   - Lines starting with "==" are messages like the ==DXP3 header followed by properties key=value,
+//     the "time" property tells whether times are written as numbers (long) or as text.
+//   - Lines starting with "=" describe records: the record name is followed by the names of its fields.
+//     Each description applies to the following data lines of the record until it is described again.
+//   - Other lines are data lines: the record name followed by values of the described fields.
+//   - Lines starting with "#" and blank lines are ignored.
+//
+// For example:
+//
+//	==DXP3	type=tape	time=text
+//	=Quote&Q	EventSymbol	EventTime	Sequence	TimeNanoPart	BidTime	BidPrice	BidSize	AskTime	...
+//	Quote&Q	AAPL	0	0	0	20231114-221320+00:00	100.5	NaN	0	...
+//	=Order#NTV	EventSymbol	EventTime	EventFlags	Index	Time	Sequence	TimeNanoPart	ActionTime	...
+//	Order#NTV	AAPL	0	0	42	20231114-221320+00:00	515899392	0	0	...
+//
+// Tokens are separated by tabs or spaces. Tokens that are empty or contain spaces, quotes, backslashes
+// or control characters are quoted like "a \"b\"\t", the zero character is written as \0 and null strings
+// are written as \NULL.
+//
+// Records and fields are those of the QDS scheme used by the native connector. Fields are written by
+// their property names like BidPrice, their names in the scheme like Bid.Price are read too. Times of
+// the scheme are in seconds (Time, BidTime) or in milliseconds (EventTime, ActionTime), the index of
+// time series is split into Time and Sequence with milliseconds and the sequence, enums and boolean
+// properties are packed into Flags, event flags are numbers. Regional events like AAPL&Q are written to
+// regional records like Quote&Q without exchange codes, orders are written to records of their source
+// like Order#NTV, orders of the default source to Order.
+//
+// On reading, unknown fields are skipped and missing fields keep default values. Data lines of unknown
+// records are reported with UnknownRecordError, the reader can be used after it or set to skip them.
+package tape
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+const (
+	messagePrefix     = "=="
+	descriptionPrefix = "="
+	commentPrefix     = "#"
+	headerMessage     = "DXP3"
+	typeProperty      = "type"
+	timeProperty      = "time"
+	tapeType          = "tape"
+	longTime          = "long"
+	textTime          = "text"
+	flagsSeparator    = "|"
+	zeroChar          = `\0`
+	nullString        = `\NULL`
+)
+
+var (
+	secondsTimeFormat = timeutil.GMTTimeFormat
+	millisTimeFormat  = timeutil.GMTTimeFormat.WithMillis()
+)
+
+type token struct {
+	text string
+	// null is set for \NULL, zero is set for \0
+	null bool
+	zero bool
+}
+
+// quoteText returns the token for the text, quoting it when needed.
+func quoteText(text string) string {
+	if text != "" && !needsQuotes(text) {
+		return text
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range text {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < ' ' || r == utf8.RuneError {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func needsQuotes(text string) bool {
+	if strings.HasPrefix(text, descriptionPrefix) || strings.HasPrefix(text, commentPrefix) {
+		return true
+	}
+	for _, r := range text {
+		if r <= ' ' || r == '"' || r == '\\' || r == utf8.RuneError {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenize splits the line into tokens.
+func tokenize(line string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for {
+		for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+			i++
+		}
+		if i >= len(line) {
+			return tokens, nil
+		}
+		if line[i] != '"' {
+			start := i
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				i++
+			}
+			text := line[start:i]
+			tokens = append(tokens, token{text: text, null: text == nullString, zero: text == zeroChar})
+			continue
+		}
+		text, next, err := unquote(line, i)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token{text: text})
+		i = next
+	}
+}
+
+// unquote parses the quoted token starting at the index and returns its text and the index after it.
+func unquote(line string, start int) (string, int, error) {
+	var b strings.Builder
+	for i := start + 1; i < len(line); i++ {
+		c := line[i]
+		if c == '"' {
+			return b.String(), i + 1, nil
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(line) {
+			break
+		}
+		switch line[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case '0':
+			b.WriteByte(0)
+		case 'u':
+			if i+4 >= len(line) {
+				return "", 0, strconv.ErrSyntax
+			}
+			r, err := strconv.ParseUint(line[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", 0, err
+			}
+			b.WriteRune(rune(r))
+			i += 4
+		default:
+			b.WriteByte(line[i])
+		}
+	}
+	return "", 0, strconv.ErrSyntax
+}
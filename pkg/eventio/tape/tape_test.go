@@ -0,0 +1,237 @@
+package tape
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/eventtest"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/profile"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+)
+
+// escapedEvents returns events with symbols and strings that are quoted and escaped in tapes.
+func escapedEvents() []interface{} {
+	tns := timeandsale.NewTimeAndSale("BRK B")
+	tns.SetEventFlags(events.SnapshotBegin | events.TxPending)
+	tns.SetTime(eventtest.Time)
+	tns.SetTimeNanoPart(456)
+	conditions, buyer := "\"@\"\t\\T\x01", ""
+	tns.SetExchangeSaleConditions(&conditions)
+	tns.SetBuyer(&buyer)
+
+	p := profile.NewProfile("=GOOG")
+	description := "#1 search"
+	p.SetDescription(&description)
+	return []interface{}{tns, p}
+}
+
+// tapeEvents returns the shared events followed by the escaped ones.
+func tapeEvents() []interface{} {
+	return append(eventtest.Events(), escapedEvents()...)
+}
+
+func jsonOf(t *testing.T, event interface{}) string {
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	return string(data)
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, textTime := range []bool{false, true} {
+		buffer := &bytes.Buffer{}
+		writer := NewWriter(buffer)
+		writer.SetTextTime(textTime)
+		if err := writer.WriteEvents(tapeEvents()); err != nil {
+			t.Fatalf(`Unexpected error %v`, err)
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatalf(`Unexpected error %v`, err)
+		}
+		result, err := NewReader(bytes.NewReader(buffer.Bytes())).ReadAll()
+		if err != nil {
+			t.Fatalf(`Unexpected error %v in:\n%s`, err, buffer)
+		}
+		expected := tapeEvents()
+		if len(result) != len(expected) {
+			t.Fatalf(`Count should be %v. But it equals %v`, len(expected), len(result))
+		}
+		for i := range expected {
+			if jsonOf(t, result[i]) != jsonOf(t, expected[i]) {
+				t.Errorf(`Event should be %s. But it equals %s`, jsonOf(t, expected[i]), jsonOf(t, result[i]))
+			}
+		}
+	}
+}
+
+func TestWrittenLines(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer := NewWriter(buffer)
+	writer.SetTextTime(true)
+	_ = writer.WriteEvents(tapeEvents())
+	_ = writer.Flush()
+	lines := strings.Split(buffer.String(), "\n")
+	expected := map[int]string{
+		0: "==DXP3\ttype=tape\ttime=text",
+		1: "=Quote\tEventSymbol\tEventTime\tSequence\tTimeNanoPart\tBidTime\tBidExchangeCode\tBidPrice\tBidSize\t" +
+			"AskTime\tAskExchangeCode\tAskPrice\tAskSize",
+		2: "Quote\tAAPL\t0\t0\t0\t20231114-221320+00:00\tQ\t100.5\tNaN\t" +
+			"0\t\\0\tInfinity\tNaN",
+		9: "=Order#NTV\tEventSymbol\tEventTime\tEventFlags\tIndex\tTime\tSequence\tTimeNanoPart\tActionTime\t" +
+			"OrderId\tAuxOrderId\tPrice\tSize\tExecutedSize\tCount\tFlags\tTradeId\tTradePrice\tTradeSize\tMarketMaker",
+		14: "=Quote&Q\tEventSymbol\tEventTime\tSequence\tTimeNanoPart\tBidTime\tBidPrice\tBidSize\t" +
+			"AskTime\tAskPrice\tAskSize",
+		15: "Quote&Q\tIBM\t0\t0\t0\t0\tNaN\tNaN\t" +
+			"0\t101\tNaN",
+	}
+	for i, line := range expected {
+		if lines[i] != line {
+			t.Errorf(`Line %d should be %q. But it equals %q`, i, line, lines[i])
+		}
+	}
+	// event flags are numbers, the time is split into seconds and the sequence with milliseconds
+	if !strings.HasPrefix(lines[16], "TimeAndSale\t\"BRK B\"\t0\t5\t20231114-221320+00:00\t"+
+		"515899392\t456\t") ||
+		!strings.Contains(lines[16], "\t\"\\\"@\\\"\\t\\\\T\\u0001\"\t") || !strings.HasSuffix(lines[16], "\t\"\"\t\\NULL") {
+		t.Errorf(`TimeAndSale line is wrong: %q`, lines[16])
+	}
+	if !strings.HasPrefix(lines[17], "Profile\t\"=GOOG\"\t") || !strings.HasSuffix(lines[17], "\t\"#1 search\"\t\\NULL") {
+		t.Errorf(`Profile line is wrong: %q`, lines[17])
+	}
+	if !strings.HasPrefix(lines[10], "Order#NTV\tAAPL\t0\t0\t42\t20231114-221320+00:00\t"+
+		"515899395\t") {
+		t.Errorf(`Order line is wrong: %q`, lines[10])
+	}
+}
+
+// nativeTape follows the layout of tapes written by the native connector: regional and source records,
+// fields named as in the QDS scheme, packed Flags and fields and records that are not mapped.
+const nativeTape = `==DXP3	type=tape	version=QDS-3.325	time=long	compression=none
+==STREAM_DATA
+=Quote&Q	EventSymbol	EventTime	Sequence	Bid.Time	Bid.Price	Bid.Size	Ask.Time	Ask.Price	Ask.Size
+Quote&Q	AAPL	0	515899392	1700000000	1.5	100	1700000000	"2.5"	NaN
+=Order#NTV	EventSymbol	EventFlags	Index	Time	Sequence	ActionTime	OrderId	Price	Size	Count	Flags	MMID	Unknown
+Order#NTV	IBM	8	42	1700000000	515899392	1700000000123	7	150	10	1	1255	\NULL	x
+=Summary	EventSymbol	DayId	DayOpen.Price
+Summary	IBM	19675	149
+=TimeAndSale	EventSymbol	EventFlags	Time	Sequence	Exchange	Price	Flags
+TimeAndSale	IBM	0x5	1700000000	515899392	N	150	2
+`
+
+func TestReadNativeTape(t *testing.T) {
+	reader := NewReader(strings.NewReader(nativeTape))
+	var result []interface{}
+	var unknown *UnknownRecordError
+	for {
+		event, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if errors.As(err, &unknown) {
+			continue
+		}
+		if err != nil {
+			t.Fatalf(`Unexpected error %v`, err)
+		}
+		result = append(result, event)
+	}
+	if unknown == nil || unknown.Record != "Summary" {
+		t.Errorf(`Summary should be reported as unknown record. But the error is %v`, unknown)
+	}
+	if len(result) != 3 {
+		t.Fatalf(`Count should be 3. But it equals %v`, len(result))
+	}
+
+	q := result[0].(*quote.Quote)
+	if *q.EventSymbol() != "AAPL&Q" || q.BidExchangeCode() != 'Q' || q.AskExchangeCode() != 'Q' ||
+		q.BidPrice() != 1.5 || q.AskPrice() != 2.5 || !math.IsNaN(q.AskSize()) {
+		t.Errorf(`Quote is wrong: %v`, q)
+	}
+	if q.Time() != eventtest.Time {
+		t.Errorf(`Quote time should be %v. But it equals %v`, eventtest.Time, q.Time())
+	}
+
+	o := result[1].(*order.Order)
+	source, err := o.OrderSource()
+	if err != nil || source.Id() != order.NtvL3().Id() {
+		t.Errorf(`Order source should be NTV. But it equals %v (%v)`, source, err)
+	}
+	if o.Index()&0xffffffff != 42 || o.Time() != eventtest.Time || o.Sequence() != 0 || o.ActionTime() != eventtest.Time {
+		t.Errorf(`Order index and times are wrong: %v`, o)
+	}
+	if o.EventFlags() != events.SnapshotEnd || o.OrderId() != 7 || o.MarketMaker() != nil {
+		t.Errorf(`Order is wrong: %v`, o)
+	}
+	// flags hold the exchange code N, the side Buy and the scope Order
+	if o.Flags() != 1255 || o.ExchangeCode() != 'N' || o.Side() != side.Buy || o.Scope() != order.ScopeOrder {
+		t.Errorf(`Order flags are wrong: %v`, o)
+	}
+
+	tns := result[2].(*timeandsale.TimeAndSale)
+	if tns.Time() != eventtest.Time || tns.EventFlags() != events.TxPending|events.SnapshotBegin || tns.ExchangeCode() != 'N' {
+		t.Errorf(`TimeAndSale is wrong: %v`, tns)
+	}
+}
+
+func TestSkipUnknownRecords(t *testing.T) {
+	reader := NewReader(strings.NewReader(nativeTape))
+	reader.SetSkipUnknownRecords(true)
+	result, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	if len(result) != 3 || reader.SkippedRecords()["Summary"] != 1 {
+		t.Errorf(`Summary should be skipped. But events are %v and skipped records %v`,
+			result, reader.SkippedRecords())
+	}
+}
+
+func TestReadEventFlagNames(t *testing.T) {
+	data := "=TimeAndSale\tEventSymbol\tEventFlags\nTimeAndSale\tIBM\tTxPending|SnapshotBegin\n"
+	result, err := NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil || len(result) != 1 {
+		t.Fatalf(`Unexpected result %v (%v)`, result, err)
+	}
+	if flags := result[0].(*timeandsale.TimeAndSale).EventFlags(); flags != events.TxPending|events.SnapshotBegin {
+		t.Errorf(`EventFlags should be 5. But they equal %v`, flags)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, data := range []string{
+		"Quote\tAAPL\n",
+		"=Quote\tEventSymbol\nQuote\tAAPL\t1\n",
+		"=Quote\tEventSymbol\tBidPrice\nQuote\tAAPL\tabc\n",
+		"=Quote\tEventSymbol\nQuote\t\"AAPL\n",
+		"=Quote&QQ\tEventSymbol\nQuote&QQ\tAAPL\n",
+		"=Order#UNKNOWN\tEventSymbol\nOrder#UNKNOWN\tAAPL\n",
+		"=Book\tEventSymbol\nBook\tAAPL\n",
+	} {
+		if _, err := NewReader(strings.NewReader(data)).ReadAll(); err == nil {
+			t.Errorf(`Reading of %q should fail`, data)
+		}
+	}
+}
+
+func TestWriteUnpublishableSource(t *testing.T) {
+	custom, _ := order.ValueOfName("ABC")
+	o := order.NewOrder("AAPL")
+	o.SetOrderSource(custom)
+	spread := order.NewSpreadOrder("AAPL")
+	spread.SetOrderSource(order.NtvL3())
+	for _, event := range []interface{}{o, spread} {
+		if err := NewWriter(&bytes.Buffer{}).Write(event); err == nil {
+			t.Errorf(`Writing of %v should fail`, event)
+		}
+	}
+}
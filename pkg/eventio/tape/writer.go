@@ -0,0 +1,93 @@
+package tape
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Writer writes events as a text tape. It is not safe for concurrent use.
+type Writer struct {
+	writer   *bufio.Writer
+	textTime bool
+	started  bool
+	// described holds the columns of described records by their names like Quote&Q
+	described map[string][]column
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{writer: bufio.NewWriter(w), described: map[string][]column{}}
+}
+
+// SetTextTime sets whether times are written as text like 20231114-221320.123+00:00 instead of numbers.
+// It must be set before the first event is written.
+func (w *Writer) SetTextTime(textTime bool) {
+	w.textTime = textTime
+}
+
+// Write writes the event, preceded by the description of its record if it is the first event of that record.
+func (w *Writer) Write(event interface{}) error {
+	rec, ok := recordsByType[reflect.TypeOf(event)]
+	if !ok {
+		return fmt.Errorf("unsupported event %T", event)
+	}
+	suffix, err := rec.suffixOf(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", rec.name, err)
+	}
+	if !w.started {
+		timeFormat := longTime
+		if w.textTime {
+			timeFormat = textTime
+		}
+		w.writeLine(messagePrefix+headerMessage, typeProperty+"="+tapeType, timeProperty+"="+timeFormat)
+		w.started = true
+	}
+	name := rec.name + suffix
+	columns := w.describe(name, rec, suffix)
+	tokens := make([]string, 0, len(columns)+1)
+	tokens = append(tokens, name)
+	for _, c := range columns {
+		tokens = append(tokens, c.format(event, w.textTime))
+	}
+	w.writeLine(tokens...)
+	return nil
+}
+
+func (w *Writer) WriteEvents(events []interface{}) error {
+	for _, event := range events {
+		if err := w.Write(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes buffered lines to the underlying writer.
+func (w *Writer) Flush() error {
+	return w.writer.Flush()
+}
+
+// describe returns the columns of the record, writing its description if it is not described yet.
+func (w *Writer) describe(name string, rec *record, suffix string) []column {
+	if columns, ok := w.described[name]; ok {
+		return columns
+	}
+	columns := rec.columnsOf(suffix)
+	description := make([]string, 0, len(columns)+1)
+	description = append(description, descriptionPrefix+name)
+	for _, c := range columns {
+		description = append(description, c.name)
+	}
+	w.writeLine(description...)
+	w.described[name] = columns
+	return columns
+}
+
+func (w *Writer) writeLine(tokens ...string) {
+	// errors are sticky in bufio.Writer and returned by Flush
+	_, _ = w.writer.WriteString(strings.Join(tokens, "\t"))
+	_ = w.writer.WriteByte('\n')
+}
@@ -0,0 +1,129 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/greeks"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/profile"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+)
+
+func TestOrderBuilder(t *testing.T) {
+	o, err := order.NewBuilder("AAPL").
+		Index(42).
+		Source(order.NtvL3()).
+		Time(testTime).
+		Sequence(7).
+		Side(side.Buy).
+		ExchangeCode('Q').
+		Price(99.5).
+		Size(100).
+		MarketMaker("NSDQ").
+		Build()
+	if err != nil {
+		t.Fatalf(`Build should not fail. But it returns %v`, err)
+	}
+	source, err := o.OrderSource()
+	if err != nil || source.Id() != order.NtvL3().Id() {
+		t.Errorf(`Source should be %v. But it equals %v (%v)`, *order.NtvL3().Name(), source, err)
+	}
+	if o.Index()&0xffffffff != 42 || o.Time() != testTime || o.Sequence() != 7 {
+		t.Errorf(`Index, time and sequence should be %v, %v and %v. But they equal %v, %v and %v`,
+			42, testTime, 7, o.Index()&0xffffffff, o.Time(), o.Sequence())
+	}
+	if o.Side() != side.Buy || o.ExchangeCode() != 'Q' || *o.MarketMaker() != "NSDQ" || o.Price() != 99.5 {
+		t.Errorf(`Order fields should be set. But the order equals %v`, o)
+	}
+}
+
+func TestOrderBuilderErrors(t *testing.T) {
+	if _, err := order.NewBuilder("AAPL").Sequence(1 << 22).Build(); err == nil {
+		t.Errorf(`Build of a too large sequence should fail`)
+	}
+	if _, err := order.NewBuilder("AAPL").ExchangeCode('é').Build(); err == nil {
+		t.Errorf(`Build of a non ASCII exchange code should fail`)
+	}
+	if _, err := order.NewBuilder("AAPL").Index(-1).Build(); err == nil {
+		t.Errorf(`Build of a negative index should fail`)
+	}
+	if _, err := order.NewBuilder("AAPL").Source(order.AgregateBid()).Build(); err == nil {
+		t.Errorf(`Build of an unpublishable source should fail`)
+	}
+	if _, err := order.NewSpreadOrderBuilder("AAPL").Source(order.NtvL3()).Build(); err == nil {
+		t.Errorf(`Build of a spread order from an order only source should fail`)
+	}
+	if _, err := order.NewBuilder("AAPL").Index(1 << 40).Source(order.NtvL3()).Build(); err == nil {
+		t.Errorf(`Build of an index overlapping the source should fail`)
+	}
+	if _, err := order.NewAnalyticOrderBuilder("AAPL").Source(order.GlbxL3()).IcebergPeakSize(10).Build(); err != nil {
+		t.Errorf(`Build of an analytic order should not fail. But it returns %v`, err)
+	}
+}
+
+func TestBuilderIsTemplate(t *testing.T) {
+	b := order.NewBuilder("AAPL").Source(order.NtvL3()).Price(10)
+	first, err := b.Build()
+	if err != nil {
+		t.Fatalf(`Build should not fail. But it returns %v`, err)
+	}
+	second, err := b.Price(11).Build()
+	if err != nil {
+		t.Fatalf(`Second Build should not fail. But it returns %v`, err)
+	}
+	if first.Price() != 10 || second.Price() != 11 || first.Index() != second.Index() {
+		t.Errorf(`Built orders should be independent. But they equal %v and %v`, first, second)
+	}
+}
+
+func TestEventBuilders(t *testing.T) {
+	q, err := quote.NewBuilder("AAPL").BidTime(testTime).AskTime(testTime).Sequence(3).
+		BidExchangeCode('Q').BidPrice(100).AskExchangeCode('X').AskPrice(101).Build()
+	if err != nil || q.Time() != testTime || q.Sequence() != 3 || q.BidExchangeCode() != 'Q' {
+		t.Errorf(`Quote should be built. But it equals %v (%v)`, q, err)
+	}
+	if _, err = quote.NewBuilder("AAPL").Sequence(-1).Build(); err == nil {
+		t.Errorf(`Build of a negative quote sequence should fail`)
+	}
+	if _, err = quote.NewBuilder("AAPL").AskExchangeCode(200).Build(); err == nil {
+		t.Errorf(`Build of a non ASCII ask exchange code should fail`)
+	}
+
+	tns, err := timeandsale.NewBuilder("IBM").TimeNanos(testTime*1_000_000 + 5).Sequence(1).ExchangeCode('N').
+		TradeThroughExempt('X').Buyer("BUYER").Type(timeandsale.TypeCorrection).Build()
+	if err != nil || tns.TimeNanos() != testTime*1_000_000+5 || *tns.Buyer() != "BUYER" || !tns.IsCorrection() {
+		t.Errorf(`Time and sale should be built. But it equals %v (%v)`, tns, err)
+	}
+	if _, err = timeandsale.NewBuilder("IBM").TimeNanoPart(1_000_000).Build(); err == nil {
+		t.Errorf(`Build of a too large time nano part should fail`)
+	}
+
+	tr, err := trade.NewBuilder("MSFT").Time(testTime).Price(300).ExtendedTradingHours(true).Build()
+	if err != nil || tr.Time() != testTime || !tr.IsExtendedTradingHours() {
+		t.Errorf(`Trade should be built. But it equals %v (%v)`, tr, err)
+	}
+	if _, err = trade.NewETHBuilder("MSFT").ExchangeCode(-1).Build(); err == nil {
+		t.Errorf(`Build of a negative exchange code should fail`)
+	}
+
+	c, err := candle.NewBuilder("AAPL{=1m}").Time(testTime).Sequence(2).Open(1).Close(2).Build()
+	if err != nil || c.Time() != testTime || c.Sequence() != 2 || c.EventSymbol().String() != "AAPL{=1m}" {
+		t.Errorf(`Candle should be built. But it equals %v (%v)`, c, err)
+	}
+	// indices packing times before 1970 are negative
+	if c, err = candle.NewBuilder("AAPL{=1m}").Index(-1 << 32).Build(); err != nil || c.Time() != -1000 {
+		t.Errorf(`Candle with a negative index should be built. But it equals %v (%v)`, c, err)
+	}
+
+	if g, err := greeks.NewBuilder("AAPL").Index(-1 << 32).Build(); err != nil || g.Index() != -1<<32 {
+		t.Errorf(`Greeks with a negative index should be built. But it equals %v (%v)`, g, err)
+	}
+	p, err := profile.NewBuilder("GOOG").Description("Alphabet Inc.").TradingStatus(profile.HaltedTradingStatus).Build()
+	if err != nil || *p.Description() != "Alphabet Inc." || !p.IsTradingHalted() {
+		t.Errorf(`Profile should be built. But it equals %v (%v)`, p, err)
+	}
+}
@@ -0,0 +1,114 @@
+package candle
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+type AggregationType int32
+
+const (
+	AggregationTime AggregationType = iota
+	AggregationTick
+	AggregationVolume
+	AggregationPriceRange
+)
+
+func (t AggregationType) String() string {
+	switch t {
+	case AggregationTime:
+		return "Time"
+	case AggregationTick:
+		return "Tick"
+	case AggregationVolume:
+		return "Volume"
+	case AggregationPriceRange:
+		return "PriceRange"
+	default:
+		return fmt.Sprintf("AggregationType: Wrong value %d", t)
+	}
+}
+
+// AggregationPeriod defines when Aggregator starts a new candle: after a period of time,
+// a number of ticks, a traded volume or when the price range of the candle is exceeded.
+type AggregationPeriod struct {
+	aggregationType AggregationType
+	value           float64
+}
+
+func TimeAggregation(period timeutil.TimePeriod) (AggregationPeriod, error) {
+	if period <= timeutil.ZeroPeriod || period.IsUnlimited() {
+		return AggregationPeriod{}, fmt.Errorf("invalid time aggregation period %s", period)
+	}
+	return AggregationPeriod{AggregationTime, float64(period)}, nil
+}
+
+func TickAggregation(ticks int64) (AggregationPeriod, error) {
+	if ticks <= 0 {
+		return AggregationPeriod{}, fmt.Errorf("invalid number of ticks %d", ticks)
+	}
+	return AggregationPeriod{AggregationTick, float64(ticks)}, nil
+}
+
+func VolumeAggregation(volume float64) (AggregationPeriod, error) {
+	if !isPositive(volume) {
+		return AggregationPeriod{}, fmt.Errorf("invalid aggregation volume %v", volume)
+	}
+	return AggregationPeriod{AggregationVolume, volume}, nil
+}
+
+func PriceRangeAggregation(priceRange float64) (AggregationPeriod, error) {
+	if !isPositive(priceRange) {
+		return AggregationPeriod{}, fmt.Errorf("invalid aggregation price range %v", priceRange)
+	}
+	return AggregationPeriod{AggregationPriceRange, priceRange}, nil
+}
+
+func (p AggregationPeriod) Type() AggregationType {
+	return p.aggregationType
+}
+
+// Value returns milliseconds for time periods, the number of ticks, the volume or the price range.
+func (p AggregationPeriod) Value() float64 {
+	return p.value
+}
+
+func (p AggregationPeriod) TimePeriod() timeutil.TimePeriod {
+	if p.aggregationType != AggregationTime {
+		return timeutil.ZeroPeriod
+	}
+	return timeutil.TimePeriod(p.value)
+}
+
+// String returns the period in candle symbol notation, e.g. "7s", "5m", "1d", "3t", "1000v" or "0.5p".
+func (p AggregationPeriod) String() string {
+	switch p.aggregationType {
+	case AggregationTime:
+		millis := int64(p.value)
+		switch {
+		case millis%timeutil.DAY == 0:
+			return strconv.FormatInt(millis/timeutil.DAY, 10) + "d"
+		case millis%timeutil.HOUR == 0:
+			return strconv.FormatInt(millis/timeutil.HOUR, 10) + "h"
+		case millis%timeutil.MINUTE == 0:
+			return strconv.FormatInt(millis/timeutil.MINUTE, 10) + "m"
+		default:
+			return strconv.FormatFloat(p.value/timeutil.SECOND, 'f', -1, 64) + "s"
+		}
+	case AggregationTick:
+		return strconv.FormatFloat(p.value, 'f', -1, 64) + "t"
+	case AggregationVolume:
+		return strconv.FormatFloat(p.value, 'f', -1, 64) + "v"
+	case AggregationPriceRange:
+		return strconv.FormatFloat(p.value, 'f', -1, 64) + "p"
+	default:
+		return p.aggregationType.String()
+	}
+}
+
+func isPositive(value float64) bool {
+	return value > 0 && !math.IsInf(value, 0)
+}
@@ -0,0 +1,500 @@
+package candle
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/common"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/schedule"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+const defaultHistorySize = 100
+
+type tickAction int32
+
+const (
+	tickNew tickAction = iota
+	tickCorrection
+	tickCancel
+)
+
+type aggregatorTick struct {
+	index     int64
+	time      int64
+	eventTime int64
+	price     float64
+	size      float64
+	valid     bool
+	side      side.Side
+}
+
+type aggregatorCandle struct {
+	candle       *Candle
+	ticks        []aggregatorTick
+	sessionStart int64
+	closed       bool
+	removed      bool
+	turnover     float64
+	vwapVolume   float64
+}
+
+type aggregatorState struct {
+	symbol    *CandleSymbol
+	candles   []*aggregatorCandle
+	lastTrade int64
+	// lastIndex is the largest index of aggregated ticks, ticks with larger indices are not aggregated yet
+	lastIndex int64
+}
+
+// Aggregator builds candles from timeandsale.TimeAndSale or trade.Trade events.
+//
+// TimeAndSale events are aggregated tick by tick: corrections and new ticks with an already aggregated index
+// replace, cancels and removals remove the tick with the same index and recompute the affected candle, ticks that are not valid (see IsValidTick) contribute to the count
+// and volumes but not to prices and VWAP. Bid and ask volumes are classified by the aggressor side or,
+// if it is undefined, by comparing the price with the bid and ask prices. Trade events carry the last trade only,
+// so each Trade with a new time and sequence is taken as a valid tick without aggressor side.
+//
+// Candles of time periods start at multiples of the period since midnight GMT (since epoch for periods longer
+// than a day) or, when a schedule is set, since the start of the trading session. Candles of other periods start with the first tick after
+// the previous candle is complete and have sequences that distinguish candles starting at the same millisecond.
+// Corrections and cancels of ticks older than the kept history (100 candles per symbol by default) are ignored.
+type Aggregator struct {
+	period           AggregationPeriod
+	schedule         *schedule.Schedule
+	regularHoursOnly bool
+	historySize      int
+	listeners        []common.EventListener
+
+	mu     sync.Mutex
+	states map[string]*aggregatorState
+}
+
+func NewAggregator(period AggregationPeriod) *Aggregator {
+	return &Aggregator{
+		period:      period,
+		historySize: defaultHistorySize,
+		states:      map[string]*aggregatorState{},
+	}
+}
+
+// SetSchedule aligns candles to the trading sessions of the schedule. Ticks outside of trading sessions
+// (or outside of regular sessions if regularHoursOnly is set) are ignored.
+func (a *Aggregator) SetSchedule(s *schedule.Schedule, regularHoursOnly bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.schedule = s
+	a.regularHoursOnly = regularHoursOnly && s != nil
+}
+
+// SetHistorySize sets the number of the latest candles kept per symbol.
+func (a *Aggregator) SetHistorySize(size int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.historySize = mathutil.MaxInt(size, 1)
+}
+
+func (a *Aggregator) AddListener(listener common.EventListener) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.listeners = append(a.listeners, listener)
+}
+
+// CandleSymbol returns the symbol of candles built for the symbol, e.g. "AAPL{=7s,a=s}".
+func (a *Aggregator) CandleSymbol(symbol string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.candleSymbol(symbol)
+}
+
+func (a *Aggregator) candleSymbol(symbol string) string {
+	result := symbol + "{=" + a.period.String()
+	if a.schedule != nil {
+		result += ",a=s"
+	}
+	if a.regularHoursOnly {
+		result += ",tho=true"
+	}
+	return result + "}"
+}
+
+// Update aggregates the events and notifies the listeners about updated candles.
+// It allows to attach the aggregator to a subscription as a listener.
+func (a *Aggregator) Update(eventsList []interface{}) {
+	candles := a.Aggregate(eventsList)
+	if len(candles) == 0 {
+		return
+	}
+	result := make([]interface{}, len(candles))
+	for i, c := range candles {
+		result[i] = c
+	}
+	a.mu.Lock()
+	listeners := a.listeners
+	a.mu.Unlock()
+	for _, listener := range listeners {
+		listener.Update(result)
+	}
+}
+
+// Aggregate aggregates the events and returns copies of the updated candles. Removed candles
+// have the events.RemoveEvent flag set. Events of other types are ignored.
+func (a *Aggregator) Aggregate(eventsList []interface{}) []*Candle {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var updated []*aggregatorCandle
+	for _, event := range eventsList {
+		for _, c := range a.process(event) {
+			if c != nil && !containsCandle(updated, c) {
+				updated = append(updated, c)
+			}
+		}
+	}
+	result := make([]*Candle, len(updated))
+	for i, c := range updated {
+		result[i] = c.snapshot()
+	}
+	return result
+}
+
+// Candles returns copies of the kept candles of the symbol in ascending order.
+func (a *Aggregator) Candles(symbol string) []*Candle {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	state, ok := a.states[symbol]
+	if !ok {
+		return nil
+	}
+	result := make([]*Candle, len(state.candles))
+	for i, c := range state.candles {
+		result[i] = c.snapshot()
+	}
+	return result
+}
+
+func (a *Aggregator) process(event interface{}) []*aggregatorCandle {
+	var symbol *string
+	var tick aggregatorTick
+	action := tickNew
+	switch v := event.(type) {
+	case *timeandsale.TimeAndSale:
+		symbol = v.EventSymbol()
+		tick = aggregatorTick{
+			index:     v.Index(),
+			time:      v.Time(),
+			eventTime: v.EventTime(),
+			price:     v.Price(),
+			size:      v.Size(),
+			valid:     v.IsValidTick(),
+			side:      classify(v),
+		}
+		switch {
+		case v.EventFlags()&events.RemoveEvent != 0 || v.IsCancel():
+			action = tickCancel
+		case v.IsCorrection():
+			action = tickCorrection
+		}
+	case *trade.Trade:
+		symbol = v.EventSymbol()
+		tick = aggregatorTick{
+			index:     v.TimeSequence(),
+			time:      v.Time(),
+			eventTime: v.EventTime(),
+			price:     v.Price(),
+			size:      v.Size(),
+			valid:     true,
+		}
+	default:
+		return nil
+	}
+	if symbol == nil {
+		return nil
+	}
+	state := a.state(*symbol)
+	if action == tickCancel {
+		return []*aggregatorCandle{a.cancel(state, tick.index)}
+	}
+	if !isPositive(tick.size) || tick.valid && math.IsNaN(tick.price) {
+		return nil
+	}
+	if _, ok := event.(*trade.Trade); ok {
+		if tick.index <= state.lastTrade {
+			return nil
+		}
+		state.lastTrade = tick.index
+	}
+	if action == tickNew && tick.index <= state.lastIndex && state.contains(tick.index) {
+		action = tickCorrection
+	}
+	state.lastIndex = mathutil.MaxInt(state.lastIndex, tick.index)
+	if action == tickCorrection {
+		return a.correct(state, tick)
+	}
+	return []*aggregatorCandle{a.add(state, tick)}
+}
+
+func (a *Aggregator) state(symbol string) *aggregatorState {
+	state, ok := a.states[symbol]
+	if !ok {
+		state = &aggregatorState{
+			symbol:    NewCandleSymbol(a.candleSymbol(symbol)),
+			lastTrade: math.MinInt64,
+			lastIndex: math.MinInt64,
+		}
+		a.states[symbol] = state
+	}
+	return state
+}
+
+// contains returns whether a kept candle has the tick with the index.
+func (s *aggregatorState) contains(index int64) bool {
+	for i := len(s.candles) - 1; i >= 0; i-- {
+		for _, tick := range s.candles[i].ticks {
+			if tick.index == index {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *Aggregator) add(state *aggregatorState, tick aggregatorTick) *aggregatorCandle {
+	var sessionStart int64
+	if a.schedule != nil {
+		session := a.schedule.SessionByTime(tick.time)
+		if !session.IsTrading() || a.regularHoursOnly && session.Type() != schedule.Regular {
+			return nil
+		}
+		sessionStart = session.StartTime()
+	}
+	var c *aggregatorCandle
+	if a.period.Type() == AggregationTime {
+		c = a.timeCandle(state, tick, sessionStart)
+	} else {
+		c = a.barCandle(state, tick, sessionStart)
+	}
+	if c == nil {
+		return nil
+	}
+	c.insert(tick)
+	switch a.period.Type() {
+	case AggregationTick:
+		c.closed = float64(len(c.ticks)) >= a.period.Value()
+	case AggregationVolume:
+		c.closed = c.candle.Volume() >= a.period.Value()
+	}
+	if len(state.candles) > a.historySize {
+		state.candles = state.candles[len(state.candles)-a.historySize:]
+	}
+	return c
+}
+
+func (a *Aggregator) timeCandle(state *aggregatorState, tick aggregatorTick, sessionStart int64) *aggregatorCandle {
+	period := a.period.TimePeriod()
+	var origin int64
+	switch {
+	case a.schedule != nil:
+		origin = sessionStart
+	case period <= timeutil.DAY:
+		origin = timeutil.TimePeriod(timeutil.DAY).Floor(tick.time)
+	}
+	candleTime := origin + period.Floor(tick.time-origin)
+	i := sort.Search(len(state.candles), func(i int) bool {
+		return state.candles[i].candle.Time() >= candleTime
+	})
+	if i < len(state.candles) && state.candles[i].candle.Time() == candleTime {
+		return state.candles[i]
+	}
+	if i == 0 && len(state.candles) >= a.historySize {
+		return nil
+	}
+	c := newAggregatorCandle(state.symbol, candleTime, 0, sessionStart)
+	state.candles = append(state.candles, nil)
+	copy(state.candles[i+1:], state.candles[i:])
+	state.candles[i] = c
+	return c
+}
+
+func (a *Aggregator) barCandle(state *aggregatorState, tick aggregatorTick, sessionStart int64) *aggregatorCandle {
+	var last *aggregatorCandle
+	if len(state.candles) > 0 {
+		last = state.candles[len(state.candles)-1]
+	}
+	if last != nil && !last.closed && last.sessionStart == sessionStart {
+		if a.period.Type() != AggregationPriceRange || !tick.valid || math.IsNaN(last.candle.High()) ||
+			math.Max(last.candle.High(), tick.price)-math.Min(last.candle.Low(), tick.price) <= a.period.Value() {
+			return last
+		}
+		last.closed = true
+	}
+	var sequence int64
+	if last != nil && last.candle.Time() == tick.time && last.candle.Sequence() < maxSequence {
+		sequence = last.candle.Sequence() + 1
+	}
+	c := newAggregatorCandle(state.symbol, tick.time, sequence, sessionStart)
+	state.candles = append(state.candles, c)
+	return c
+}
+
+func (a *Aggregator) cancel(state *aggregatorState, index int64) *aggregatorCandle {
+	for i := len(state.candles) - 1; i >= 0; i-- {
+		c := state.candles[i]
+		if !c.remove(index) {
+			continue
+		}
+		if len(c.ticks) == 0 {
+			c.removed = true
+			state.candles = removeCandle(state.candles, c)
+		}
+		return c
+	}
+	return nil
+}
+
+func (a *Aggregator) correct(state *aggregatorState, tick aggregatorTick) []*aggregatorCandle {
+	for i := len(state.candles) - 1; i >= 0; i-- {
+		c := state.candles[i]
+		if !c.remove(tick.index) {
+			continue
+		}
+		if a.period.Type() != AggregationTime {
+			c.insert(tick)
+			return []*aggregatorCandle{c}
+		}
+		// the corrected tick may move to another candle
+		added := a.add(state, tick)
+		if len(c.ticks) == 0 && c != added {
+			c.removed = true
+			state.candles = removeCandle(state.candles, c)
+		}
+		return []*aggregatorCandle{c, added}
+	}
+	return nil
+}
+
+func classify(t *timeandsale.TimeAndSale) side.Side {
+	switch {
+	case t.AggressorSide() != side.Undefined:
+		return t.AggressorSide()
+	case t.Price() >= t.AskPrice():
+		return side.Buy
+	case t.Price() <= t.BidPrice():
+		return side.Sell
+	default:
+		return side.Undefined
+	}
+}
+
+func newAggregatorCandle(symbol *CandleSymbol, time int64, sequence int64, sessionStart int64) *aggregatorCandle {
+	c := &aggregatorCandle{candle: NewCandle(""), sessionStart: sessionStart}
+	c.candle.SetEventSymbol(symbol)
+	c.candle.SetTime(time)
+	_ = c.candle.SetSequence(sequence)
+	c.reset()
+	return c
+}
+
+func (c *aggregatorCandle) reset() {
+	c.candle.SetCount(0)
+	c.candle.SetOpen(math.NaN())
+	c.candle.SetHigh(math.NaN())
+	c.candle.SetLow(math.NaN())
+	c.candle.SetClose(math.NaN())
+	c.candle.SetVolume(0)
+	c.candle.SetVwap(math.NaN())
+	c.candle.SetBidVolume(0)
+	c.candle.SetAskVolume(0)
+	c.turnover = 0
+	c.vwapVolume = 0
+}
+
+// insert adds the tick keeping ticks ordered by index. Ticks are applied incrementally when they come in order.
+func (c *aggregatorCandle) insert(tick aggregatorTick) {
+	i := sort.Search(len(c.ticks), func(i int) bool {
+		return c.ticks[i].index > tick.index
+	})
+	c.ticks = append(c.ticks, aggregatorTick{})
+	copy(c.ticks[i+1:], c.ticks[i:])
+	c.ticks[i] = tick
+	if i == len(c.ticks)-1 {
+		c.apply(tick)
+	} else {
+		c.recompute()
+	}
+}
+
+func (c *aggregatorCandle) remove(index int64) bool {
+	for i, tick := range c.ticks {
+		if tick.index == index {
+			c.ticks = append(c.ticks[:i], c.ticks[i+1:]...)
+			c.recompute()
+			return true
+		}
+	}
+	return false
+}
+
+func (c *aggregatorCandle) recompute() {
+	c.reset()
+	for _, tick := range c.ticks {
+		c.apply(tick)
+	}
+}
+
+func (c *aggregatorCandle) apply(tick aggregatorTick) {
+	candle := c.candle
+	candle.SetCount(candle.Count() + 1)
+	candle.SetVolume(candle.Volume() + tick.size)
+	switch tick.side {
+	case side.Buy:
+		candle.SetAskVolume(candle.AskVolume() + tick.size)
+	case side.Sell:
+		candle.SetBidVolume(candle.BidVolume() + tick.size)
+	}
+	if tick.valid {
+		if math.IsNaN(candle.Open()) {
+			candle.SetOpen(tick.price)
+			candle.SetHigh(tick.price)
+			candle.SetLow(tick.price)
+		}
+		candle.SetHigh(math.Max(candle.High(), tick.price))
+		candle.SetLow(math.Min(candle.Low(), tick.price))
+		candle.SetClose(tick.price)
+		c.turnover += tick.price * tick.size
+		c.vwapVolume += tick.size
+		candle.SetVwap(c.turnover / c.vwapVolume)
+	}
+	candle.SetEventTime(mathutil.MaxInt(candle.EventTime(), tick.eventTime))
+}
+
+func (c *aggregatorCandle) snapshot() *Candle {
+	result := *c.candle
+	if c.removed {
+		result.SetEventFlags(result.EventFlags() | events.RemoveEvent)
+	}
+	return &result
+}
+
+func removeCandle(candles []*aggregatorCandle, c *aggregatorCandle) []*aggregatorCandle {
+	for i, candidate := range candles {
+		if candidate == c {
+			return append(candles[:i], candles[i+1:]...)
+		}
+	}
+	return candles
+}
+
+func containsCandle(candles []*aggregatorCandle, c *aggregatorCandle) bool {
+	for _, candidate := range candles {
+		if candidate == c {
+			return true
+		}
+	}
+	return false
+}
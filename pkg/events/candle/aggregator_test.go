@@ -0,0 +1,282 @@
+package candle
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/schedule"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// 2024-01-02 00:00:00 UTC
+const baseTime = 1704153600000
+
+func newTick(time int64, sequence int64, price float64, size float64, aggressor side.Side) *timeandsale.TimeAndSale {
+	t := timeandsale.NewTimeAndSale("AAPL")
+	t.SetTime(time)
+	_ = t.SetSequence(sequence)
+	t.SetEventTime(time)
+	t.SetPrice(price)
+	t.SetSize(size)
+	t.SetAggressorSide(aggressor)
+	t.SetIsValidTick(true)
+	return t
+}
+
+func mustAggregator(t *testing.T, period AggregationPeriod, err error) *Aggregator {
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewAggregator(period)
+}
+
+func aggregate(a *Aggregator, ticks ...interface{}) []*Candle {
+	return a.Aggregate(ticks)
+}
+
+func equal(a, b float64) bool {
+	return a == b || math.IsNaN(a) && math.IsNaN(b)
+}
+
+func checkCandle(t *testing.T, c *Candle, time int64, count int64, open, high, low, close, volume float64) {
+	t.Helper()
+	if c.Time() != time || c.Count() != count || !equal(c.Open(), open) || !equal(c.High(), high) ||
+		!equal(c.Low(), low) || !equal(c.Close(), close) || c.Volume() != volume {
+		t.Fatalf(`Unexpected candle %s`, c)
+	}
+}
+
+func TestTimeAggregation(t *testing.T) {
+	period, err := TimeAggregation(7 * timeutil.SECOND)
+	a := mustAggregator(t, period, err)
+	aggregate(a,
+		newTick(baseTime+1000, 1, 10, 100, side.Buy),
+		newTick(baseTime+2999, 2, 12, 50, side.Sell),
+		newTick(baseTime+6999, 3, 9, 150, side.Undefined),
+		newTick(baseTime+7000, 4, 11, 10, side.Buy),
+	)
+	candles := a.Candles("AAPL")
+	if len(candles) != 2 {
+		t.Fatalf(`There should be 2 candles. But there are %d`, len(candles))
+	}
+	checkCandle(t, candles[0], baseTime, 3, 10, 12, 9, 9, 300)
+	checkCandle(t, candles[1], baseTime+7000, 1, 11, 11, 11, 11, 10)
+	if candles[0].AskVolume() != 100 || candles[0].BidVolume() != 50 {
+		t.Fatalf(`Unexpected bid/ask volumes %s`, candles[0])
+	}
+	if vwap := (10*100 + 12*50 + 9*150) / 300.0; math.Abs(candles[0].Vwap()-vwap) > 1e-9 {
+		t.Fatalf(`VWAP should be %v. But it equals %v`, vwap, candles[0].Vwap())
+	}
+	if *candles[0].EventSymbol().Symbol() != "AAPL{=7s}" || a.CandleSymbol("IBM") != "IBM{=7s}" {
+		t.Fatalf(`Unexpected candle symbol %s`, candles[0].EventSymbol())
+	}
+	if candles[0].Sequence() != 0 || candles[0].EventTime() != baseTime+6999 {
+		t.Fatalf(`Unexpected sequence or event time %s`, candles[0])
+	}
+}
+
+func TestSideClassificationByPrice(t *testing.T) {
+	period, err := TimeAggregation(timeutil.MINUTE)
+	a := mustAggregator(t, period, err)
+	atAsk := newTick(baseTime, 1, 10.5, 10, side.Undefined)
+	atAsk.SetBidPrice(10)
+	atAsk.SetAskPrice(10.5)
+	atBid := newTick(baseTime, 2, 10, 20, side.Undefined)
+	atBid.SetBidPrice(10)
+	atBid.SetAskPrice(10.5)
+	inside := newTick(baseTime, 3, 10.25, 30, side.Undefined)
+	inside.SetBidPrice(10)
+	inside.SetAskPrice(10.5)
+	c := aggregate(a, atAsk, atBid, inside)[0]
+	if c.AskVolume() != 10 || c.BidVolume() != 20 || c.Volume() != 60 {
+		t.Fatalf(`Unexpected bid/ask volumes %s`, c)
+	}
+}
+
+func TestInvalidTicksAndCorrections(t *testing.T) {
+	period, err := TimeAggregation(timeutil.MINUTE)
+	a := mustAggregator(t, period, err)
+	invalid := newTick(baseTime+1, 2, 100, 5, side.Buy)
+	invalid.SetIsValidTick(false)
+	aggregate(a, newTick(baseTime, 1, 10, 10, side.Buy), invalid, newTick(baseTime+2, 3, 11, 10, side.Sell))
+	checkCandle(t, a.Candles("AAPL")[0], baseTime, 3, 10, 11, 10, 11, 25)
+	if a.Candles("AAPL")[0].Vwap() != 10.5 {
+		t.Fatalf(`Invalid ticks should not affect VWAP`)
+	}
+
+	correction := newTick(baseTime+2, 3, 12, 20, side.Sell)
+	correction.SetTimeAndSaleType(timeandsale.TypeCorrection)
+	updated := aggregate(a, correction)
+	if len(updated) != 1 {
+		t.Fatalf(`Correction should update 1 candle. But it updated %d`, len(updated))
+	}
+	checkCandle(t, updated[0], baseTime, 3, 10, 12, 10, 12, 35)
+
+	cancel := newTick(baseTime, 1, 10, 10, side.Buy)
+	cancel.SetTimeAndSaleType(timeandsale.TypeCancel)
+	checkCandle(t, aggregate(a, cancel)[0], baseTime, 2, 12, 12, 12, 12, 25)
+}
+
+func TestRemoveCandle(t *testing.T) {
+	period, err := TimeAggregation(timeutil.MINUTE)
+	a := mustAggregator(t, period, err)
+	aggregate(a, newTick(baseTime, 1, 10, 10, side.Buy))
+	removal := newTick(baseTime, 1, 10, 10, side.Buy)
+	removal.SetEventFlags(events.RemoveEvent)
+	updated := aggregate(a, removal)
+	if len(updated) != 1 || updated[0].EventFlags()&events.RemoveEvent == 0 || len(a.Candles("AAPL")) != 0 {
+		t.Fatalf(`Candle without ticks should be removed`)
+	}
+}
+
+func TestRepeatedTicks(t *testing.T) {
+	period, err := TimeAggregation(timeutil.MINUTE)
+	a := mustAggregator(t, period, err)
+	aggregate(a, newTick(baseTime, 1, 10, 5, side.Buy), newTick(baseTime, 1, 10, 5, side.Buy))
+	checkCandle(t, a.Candles("AAPL")[0], baseTime, 1, 10, 10, 10, 10, 5)
+
+	aggregate(a, newTick(baseTime+1, 2, 11, 1, side.Buy), newTick(baseTime, 1, 12, 3, side.Buy))
+	checkCandle(t, a.Candles("AAPL")[0], baseTime, 2, 12, 12, 11, 11, 4)
+
+	removal := newTick(baseTime, 1, 12, 3, side.Buy)
+	removal.SetEventFlags(events.RemoveEvent)
+	checkCandle(t, aggregate(a, removal)[0], baseTime, 1, 11, 11, 11, 11, 1)
+}
+
+func TestTickAggregation(t *testing.T) {
+	period, err := TickAggregation(3)
+	a := mustAggregator(t, period, err)
+	var ticks []interface{}
+	for i := int64(0); i < 7; i++ {
+		ticks = append(ticks, newTick(baseTime+i/4, i, float64(10+i), 1, side.Buy))
+	}
+	a.Aggregate(ticks)
+	candles := a.Candles("AAPL")
+	if len(candles) != 3 {
+		t.Fatalf(`There should be 3 candles. But there are %d`, len(candles))
+	}
+	checkCandle(t, candles[0], baseTime, 3, 10, 12, 10, 12, 3)
+	checkCandle(t, candles[1], baseTime, 3, 13, 15, 13, 15, 3)
+	checkCandle(t, candles[2], baseTime+1, 1, 16, 16, 16, 16, 1)
+	if candles[0].Sequence() != 0 || candles[1].Sequence() != 1 || candles[2].Sequence() != 0 {
+		t.Fatalf(`Candles starting at the same time should have different sequences`)
+	}
+	if candles[1].Index() <= candles[0].Index() || candles[2].Index() <= candles[1].Index() {
+		t.Fatalf(`Candle indices should be ascending`)
+	}
+	if *candles[0].EventSymbol().Symbol() != "AAPL{=3t}" {
+		t.Fatalf(`Unexpected candle symbol %s`, candles[0].EventSymbol())
+	}
+}
+
+func TestVolumeAndPriceRangeAggregation(t *testing.T) {
+	period, err := VolumeAggregation(100)
+	a := mustAggregator(t, period, err)
+	aggregate(a,
+		newTick(baseTime, 1, 10, 60, side.Buy),
+		newTick(baseTime+1, 2, 10, 60, side.Buy),
+		newTick(baseTime+2, 3, 10, 10, side.Buy),
+	)
+	if candles := a.Candles("AAPL"); len(candles) != 2 || candles[0].Volume() != 120 || candles[1].Volume() != 10 {
+		t.Fatalf(`Unexpected volume candles %v`, candles)
+	}
+
+	period, err = PriceRangeAggregation(1)
+	a = mustAggregator(t, period, err)
+	aggregate(a,
+		newTick(baseTime, 1, 10, 1, side.Buy),
+		newTick(baseTime+1, 2, 10.5, 1, side.Buy),
+		newTick(baseTime+2, 3, 9.5, 1, side.Buy),
+		newTick(baseTime+3, 4, 9, 1, side.Buy),
+	)
+	candles := a.Candles("AAPL")
+	if len(candles) != 2 {
+		t.Fatalf(`There should be 2 candles. But there are %d`, len(candles))
+	}
+	checkCandle(t, candles[0], baseTime, 3, 10, 10.5, 9.5, 9.5, 3)
+	checkCandle(t, candles[1], baseTime+3, 1, 9, 9, 9, 9, 1)
+}
+
+func TestSessionAlignment(t *testing.T) {
+	s, err := schedule.NewScheduleWithDefaults("TEST(tz=GMT;0=p08000930r09301600)", schedule.NewDefaults())
+	if err != nil {
+		t.Fatal(err)
+	}
+	period, err := TimeAggregation(timeutil.HOUR)
+	a := mustAggregator(t, period, err)
+	a.SetSchedule(s, true)
+	aggregate(a,
+		newTick(baseTime+8*timeutil.HOUR, 1, 10, 1, side.Buy),
+		newTick(baseTime+9*timeutil.HOUR+45*timeutil.MINUTE, 2, 11, 1, side.Buy),
+		newTick(baseTime+10*timeutil.HOUR+31*timeutil.MINUTE, 3, 12, 1, side.Buy),
+	)
+	candles := a.Candles("AAPL")
+	if len(candles) != 2 {
+		t.Fatalf(`There should be 2 candles. But there are %d`, len(candles))
+	}
+	if candles[0].Time() != baseTime+9*timeutil.HOUR+30*timeutil.MINUTE ||
+		candles[1].Time() != baseTime+10*timeutil.HOUR+30*timeutil.MINUTE {
+		t.Fatalf(`Candles should be aligned to the session start. But they are %s and %s`, candles[0], candles[1])
+	}
+	if *candles[0].EventSymbol().Symbol() != "AAPL{=1h,a=s,tho=true}" {
+		t.Fatalf(`Unexpected candle symbol %s`, candles[0].EventSymbol())
+	}
+}
+
+func TestTradeAggregation(t *testing.T) {
+	period, err := TimeAggregation(timeutil.MINUTE)
+	a := mustAggregator(t, period, err)
+	newTrade := func(time int64, price float64, size float64) *trade.Trade {
+		tr := trade.NewTrade("IBM")
+		tr.SetTime(time)
+		tr.SetPrice(price)
+		tr.SetSize(size)
+		return tr
+	}
+	aggregate(a, newTrade(baseTime+500, 10, 1), newTrade(baseTime+500, 10, 1), newTrade(baseTime+999, 11, 2))
+	candles := a.Candles("IBM")
+	if len(candles) != 1 {
+		t.Fatalf(`There should be 1 candle. But there are %d`, len(candles))
+	}
+	checkCandle(t, candles[0], baseTime, 2, 10, 11, 10, 11, 3)
+}
+
+func TestAggregationPeriod(t *testing.T) {
+	periodOf := func(period AggregationPeriod, err error) AggregationPeriod {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return period
+	}
+	tests := []struct {
+		period   AggregationPeriod
+		expected string
+	}{
+		{periodOf(TimeAggregation(7 * timeutil.SECOND)), "7s"},
+		{periodOf(TimeAggregation(500)), "0.5s"},
+		{periodOf(TimeAggregation(5 * timeutil.MINUTE)), "5m"},
+		{periodOf(TimeAggregation(2 * timeutil.HOUR)), "2h"},
+		{periodOf(TimeAggregation(timeutil.DAY)), "1d"},
+		{periodOf(TickAggregation(3)), "3t"},
+		{periodOf(VolumeAggregation(1000)), "1000v"},
+		{periodOf(PriceRangeAggregation(0.5)), "0.5p"},
+	}
+	for _, test := range tests {
+		if test.period.String() != test.expected {
+			t.Errorf(`Period should be %s. But it is %s`, test.expected, test.period)
+		}
+	}
+	if _, err := TimeAggregation(timeutil.UnlimitedPeriod); err == nil {
+		t.Errorf(`Unlimited time aggregation should fail`)
+	}
+	if _, err := TickAggregation(0); err == nil {
+		t.Errorf(`Zero tick aggregation should fail`)
+	}
+	if _, err := VolumeAggregation(math.NaN()); err == nil {
+		t.Errorf(`NaN volume aggregation should fail`)
+	}
+}
@@ -2,7 +2,9 @@ package candle
 
 import (
 	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
 	"math"
@@ -49,8 +51,16 @@ func (c *Candle) EventSymbol() *CandleSymbol {
 	return c.eventSymbol
 }
 
+// SetEventSymbol sets a copy of the symbol, the candle keeps its own symbol.
 func (c *Candle) SetEventSymbol(eventSymbol *CandleSymbol) {
-	c.eventSymbol = eventSymbol
+	if !c.eventSymbol.Equal(eventSymbol) {
+		c.eventSymbol = eventSymbol.Clone()
+	}
+}
+
+// SetInternedEventSymbol points the candle to the interned symbol without copying it.
+func (c *Candle) SetInternedEventSymbol(_ intern.Access, value *CandleSymbol) {
+	c.eventSymbol = value
 }
 
 func (c *Candle) EventTime() int64 {
@@ -183,7 +193,7 @@ func (q *Candle) Time() int64 {
 
 func (q *Candle) SetTime(value int64) {
 	q.index = (timeutil.GetSecondsFromTime(value) << 32) |
-		int64(timeutil.GetMillisFromTime(value)<<22) |
+		int64(timeutil.GetMillisFromTime(value))<<22 |
 		q.Sequence()
 }
 
@@ -210,3 +220,32 @@ func (c *Candle) String() string {
 func (c *Candle) Type() eventcodes.EventCode {
 	return eventcodes.Candle
 }
+
+// Clone returns a copy of the candle sharing no memory with it.
+func (c *Candle) Clone() *Candle {
+	result := *c
+	result.eventSymbol = c.eventSymbol.Clone()
+	return &result
+}
+
+// Equal returns whether all fields of the candles are equal, NaN values are equal.
+func (c *Candle) Equal(other *Candle) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return c.eventSymbol.Equal(other.eventSymbol) &&
+		c.eventTime == other.eventTime &&
+		c.count == other.count &&
+		eventutil.EqualFloats(c.open, other.open) &&
+		eventutil.EqualFloats(c.high, other.high) &&
+		eventutil.EqualFloats(c.low, other.low) &&
+		eventutil.EqualFloats(c.close, other.close) &&
+		eventutil.EqualFloats(c.volume, other.volume) &&
+		eventutil.EqualFloats(c.vwap, other.vwap) &&
+		eventutil.EqualFloats(c.bidVolume, other.bidVolume) &&
+		eventutil.EqualFloats(c.askVolume, other.askVolume) &&
+		eventutil.EqualFloats(c.impVolatility, other.impVolatility) &&
+		eventutil.EqualFloats(c.openInterest, other.openInterest) &&
+		c.eventFlags == other.eventFlags &&
+		c.index == other.index
+}
@@ -0,0 +1,103 @@
+package candle
+
+import "github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
+
+// Builder sets fields of a candle, invalid values are reported by Build.
+// Build returns a new candle every time, so a builder can be reused as a template.
+type Builder struct {
+	candle  *Candle
+	checker eventutil.Checker
+}
+
+func NewBuilder(eventSymbol string) *Builder {
+	return &Builder{candle: NewCandle(eventSymbol)}
+}
+
+func (b *Builder) EventTime(eventTime int64) *Builder {
+	b.candle.SetEventTime(eventTime)
+	return b
+}
+
+func (b *Builder) EventFlags(eventFlags int32) *Builder {
+	b.candle.SetEventFlags(eventFlags)
+	return b
+}
+
+// Index sets the time and the sequence packed together, like Time and Sequence do separately.
+func (b *Builder) Index(index int64) *Builder {
+	b.candle.SetIndex(index)
+	return b
+}
+
+func (b *Builder) Time(time int64) *Builder {
+	b.candle.SetTime(time)
+	return b
+}
+
+func (b *Builder) Sequence(sequence int64) *Builder {
+	b.checker.Check(b.candle.SetSequence(sequence))
+	return b
+}
+
+func (b *Builder) Count(count int64) *Builder {
+	b.candle.SetCount(count)
+	return b
+}
+
+func (b *Builder) Open(open float64) *Builder {
+	b.candle.SetOpen(open)
+	return b
+}
+
+func (b *Builder) High(high float64) *Builder {
+	b.candle.SetHigh(high)
+	return b
+}
+
+func (b *Builder) Low(low float64) *Builder {
+	b.candle.SetLow(low)
+	return b
+}
+
+func (b *Builder) Close(close float64) *Builder {
+	b.candle.SetClose(close)
+	return b
+}
+
+func (b *Builder) Volume(volume float64) *Builder {
+	b.candle.SetVolume(volume)
+	return b
+}
+
+func (b *Builder) Vwap(vwap float64) *Builder {
+	b.candle.SetVwap(vwap)
+	return b
+}
+
+func (b *Builder) BidVolume(bidVolume float64) *Builder {
+	b.candle.SetBidVolume(bidVolume)
+	return b
+}
+
+func (b *Builder) AskVolume(askVolume float64) *Builder {
+	b.candle.SetAskVolume(askVolume)
+	return b
+}
+
+func (b *Builder) ImpVolatility(impVolatility float64) *Builder {
+	b.candle.SetImpVolatility(impVolatility)
+	return b
+}
+
+func (b *Builder) OpenInterest(openInterest float64) *Builder {
+	b.candle.SetOpenInterest(openInterest)
+	return b
+}
+
+// Build returns the candle, or the first invalid value set.
+func (b *Builder) Build() (*Candle, error) {
+	if err := b.checker.Err(); err != nil {
+		return nil, err
+	}
+	return b.candle.Clone(), nil
+}
@@ -0,0 +1,98 @@
+package candle
+
+import (
+	"encoding/json"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+const eventType = "Candle"
+
+func init() {
+	events.RegisterEventType(eventType, func() json.Unmarshaler {
+		return NewCandle("")
+	})
+}
+
+type candleJSON struct {
+	EventType     string                `json:"eventType"`
+	EventSymbol   *string               `json:"eventSymbol"`
+	EventTime     int64                 `json:"eventTime"`
+	EventFlags    events.JSONEventFlags `json:"eventFlags,omitempty"`
+	Time          int64                 `json:"time"`
+	Sequence      int64                 `json:"sequence"`
+	Count         int64                 `json:"count"`
+	Open          events.JSONFloat      `json:"open"`
+	High          events.JSONFloat      `json:"high"`
+	Low           events.JSONFloat      `json:"low"`
+	Close         events.JSONFloat      `json:"close"`
+	Volume        events.JSONFloat      `json:"volume"`
+	Vwap          events.JSONFloat      `json:"vwap"`
+	BidVolume     events.JSONFloat      `json:"bidVolume"`
+	AskVolume     events.JSONFloat      `json:"askVolume"`
+	ImpVolatility events.JSONFloat      `json:"impVolatility"`
+	OpenInterest  events.JSONFloat      `json:"openInterest"`
+}
+
+func (c *Candle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.toJSON())
+}
+
+func (c *Candle) UnmarshalJSON(data []byte) error {
+	v := NewCandle("").toJSON()
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := events.CheckEventType(v.EventType, eventType); err != nil {
+		return err
+	}
+	symbol := ""
+	if v.EventSymbol != nil {
+		symbol = *v.EventSymbol
+	}
+	*c = *NewCandle(symbol)
+	c.eventTime = v.EventTime
+	c.eventFlags = int32(v.EventFlags)
+	c.SetTime(v.Time)
+	if err := c.SetSequence(v.Sequence); err != nil {
+		return err
+	}
+	c.count = v.Count
+	c.open = float64(v.Open)
+	c.high = float64(v.High)
+	c.low = float64(v.Low)
+	c.close = float64(v.Close)
+	c.volume = float64(v.Volume)
+	c.vwap = float64(v.Vwap)
+	c.bidVolume = float64(v.BidVolume)
+	c.askVolume = float64(v.AskVolume)
+	c.impVolatility = float64(v.ImpVolatility)
+	c.openInterest = float64(v.OpenInterest)
+	return nil
+}
+
+func (c *Candle) toJSON() candleJSON {
+	var symbol *string
+	if c.eventSymbol != nil {
+		symbol = c.eventSymbol.Symbol()
+	}
+	return candleJSON{
+		EventType:     eventType,
+		EventSymbol:   symbol,
+		EventTime:     c.eventTime,
+		EventFlags:    events.JSONEventFlags(c.eventFlags),
+		Time:          c.Time(),
+		Sequence:      c.Sequence(),
+		Count:         c.count,
+		Open:          events.JSONFloat(c.open),
+		High:          events.JSONFloat(c.high),
+		Low:           events.JSONFloat(c.low),
+		Close:         events.JSONFloat(c.close),
+		Volume:        events.JSONFloat(c.volume),
+		Vwap:          events.JSONFloat(c.vwap),
+		BidVolume:     events.JSONFloat(c.bidVolume),
+		AskVolume:     events.JSONFloat(c.askVolume),
+		ImpVolatility: events.JSONFloat(c.impVolatility),
+		OpenInterest:  events.JSONFloat(c.openInterest),
+	}
+}
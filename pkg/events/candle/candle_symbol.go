@@ -1,5 +1,7 @@
 package candle
 
+import "github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
+
 type CandleSymbol struct {
 	symbol *string
 }
@@ -13,9 +15,23 @@ func (c *CandleSymbol) Symbol() *string {
 }
 
 func (c *CandleSymbol) SetSymbol(symbol *string) {
-	c.symbol = symbol
+	eventutil.SetString(&c.symbol, symbol)
 }
 
 func (c *CandleSymbol) String() string {
 	return *c.symbol
 }
+
+func (c *CandleSymbol) Clone() *CandleSymbol {
+	if c == nil {
+		return nil
+	}
+	return &CandleSymbol{symbol: eventutil.CloneString(c.symbol)}
+}
+
+func (c *CandleSymbol) Equal(other *CandleSymbol) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return eventutil.EqualStrings(c.symbol, other.symbol)
+}
@@ -0,0 +1,177 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/greeks"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/profile"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+)
+
+// cloneOf returns a clone of the event and whether the clone equals the event.
+func cloneOf(event interface{}) (interface{}, bool) {
+	switch e := event.(type) {
+	case *quote.Quote:
+		c := e.Clone()
+		return c, e.Equal(c)
+	case *trade.Trade:
+		c := e.Clone()
+		return c, e.Equal(c)
+	case *trade.TradeETH:
+		c := e.Clone()
+		return c, e.Equal(c)
+	case *timeandsale.TimeAndSale:
+		c := e.Clone()
+		return c, e.Equal(c)
+	case *profile.Profile:
+		c := e.Clone()
+		return c, e.Equal(c)
+	case *greeks.Greeks:
+		c := e.Clone()
+		return c, e.Equal(c)
+	case *candle.Candle:
+		c := e.Clone()
+		return c, e.Equal(c)
+	case *order.Order:
+		c := e.Clone()
+		return c, e.Equal(c)
+	case *order.AnalyticOrder:
+		c := e.Clone()
+		return c, e.Equal(c)
+	case *order.SpreadOrder:
+		c := e.Clone()
+		return c, e.Equal(c)
+	case *events.InstrumentProfile:
+		c := e.Clone()
+		return c, e.Equal(c)
+	default:
+		return nil, false
+	}
+}
+
+func TestEventsClone(t *testing.T) {
+	for _, event := range newEvents() {
+		clone, equal := cloneOf(event)
+		if clone == nil {
+			continue
+		}
+		if !equal {
+			t.Errorf(`Clone should equal %v. But it equals %v`, event, clone)
+		}
+		if clone.(events.StringConverter).String() != event.(events.StringConverter).String() {
+			t.Errorf(`Clone should print %v. But it prints %v`, event, clone)
+		}
+	}
+}
+
+func TestCloneIsolation(t *testing.T) {
+	tns := newEvents()[3].(*timeandsale.TimeAndSale)
+	clone := tns.Clone()
+	*tns.EventSymbol() = "MSFT"
+	*tns.Buyer() = "SELLER"
+	tns.SetPrice(1)
+	if *clone.EventSymbol() != "IBM" || *clone.Buyer() != "BUYER" || clone.Price() != 150 {
+		t.Errorf(`Clone should keep %v, %v and %v. But it has %v, %v and %v`,
+			"IBM", "BUYER", 150, *clone.EventSymbol(), *clone.Buyer(), clone.Price())
+	}
+	if tns.Equal(clone) {
+		t.Errorf(`Changed event should not equal its clone %v`, clone)
+	}
+
+	c := candle.NewCandle("AAPL{=1m}")
+	candleClone := c.Clone()
+	*c.EventSymbol().Symbol() = "MSFT{=1m}"
+	if candleClone.EventSymbol().String() != "AAPL{=1m}" {
+		t.Errorf(`Candle clone symbol should be %v. But it equals %v`, "AAPL{=1m}", candleClone.EventSymbol())
+	}
+
+	a := order.NewAnalyticOrder("AAPL")
+	marketMaker := "NSDQ"
+	a.SetMarketMaker(&marketMaker)
+	orderClone := a.Clone()
+	orderClone.SetIcebergPeakSize(5)
+	*a.MarketMaker() = "ARCA"
+	if *orderClone.MarketMaker() != "NSDQ" || a.IcebergPeakSize() == orderClone.IcebergPeakSize() {
+		t.Errorf(`Analytic order clone should be isolated. But it equals %v and the order equals %v`, orderClone, a)
+	}
+}
+
+func TestSettersCopyValues(t *testing.T) {
+	symbol, marketMaker := "AAPL", "NSDQ"
+	o := order.NewOrder("IBM")
+	o.SetEventSymbol(&symbol)
+	o.SetMarketMaker(&marketMaker)
+	symbol, marketMaker = "MSFT", "ARCA"
+	if *o.EventSymbol() != "AAPL" || *o.MarketMaker() != "NSDQ" {
+		t.Errorf(`Order should keep %v and %v. But it has %v and %v`, "AAPL", "NSDQ", *o.EventSymbol(), *o.MarketMaker())
+	}
+
+	p := profile.NewProfile("AAPL")
+	other := profile.NewProfile("MSFT")
+	other.SetEventSymbol(p.EventSymbol())
+	p.SetEventSymbol(&symbol)
+	if *other.EventSymbol() != "AAPL" {
+		t.Errorf(`Profile symbol should be %v. But it equals %v`, "AAPL", *other.EventSymbol())
+	}
+
+	q := quote.NewQuote("AAPL")
+	copied := *q
+	previous := q.EventSymbol()
+	q.SetEventSymbol("MSFT")
+	if *previous != "AAPL" || *copied.EventSymbol() != "AAPL" || *q.EventSymbol() != "MSFT" {
+		t.Errorf(`Quote copies should keep %v. But they have %v and %v`, "AAPL", *previous, *copied.EventSymbol())
+	}
+
+	ip := events.NewInstrumentProfile()
+	ip.SetSymbol(&symbol)
+	ipClone := ip.Clone()
+	symbol = "IBM"
+	*ipClone.Cfi() = "ESXXXX"
+	if *ip.Symbol() != "MSFT" || *ipClone.Symbol() != "MSFT" || *ip.Cfi() != "" {
+		t.Errorf(`Instrument profile should keep %v. But it has %v and its clone has %v`, "MSFT", ip, ipClone)
+	}
+	if ip.Equal(ipClone) {
+		t.Errorf(`Changed instrument profile clone should not equal %v`, ip)
+	}
+
+	candleSymbol := candle.NewCandleSymbol("AAPL{=1d}")
+	c := candle.NewCandle("")
+	c.SetEventSymbol(candleSymbol)
+	candleSymbol.SetSymbol(&symbol)
+	if c.EventSymbol().String() != "AAPL{=1d}" {
+		t.Errorf(`Candle symbol should be %v. But it equals %v`, "AAPL{=1d}", c.EventSymbol())
+	}
+}
+
+func TestEventsEqual(t *testing.T) {
+	if !quote.NewQuote("AAPL").Equal(quote.NewQuote("AAPL")) {
+		t.Errorf(`Quotes with NaN prices should be equal`)
+	}
+	if quote.NewQuote("AAPL").Equal(quote.NewQuote("MSFT")) {
+		t.Errorf(`Quotes of different symbols should not be equal`)
+	}
+	if quote.NewQuote("AAPL").Equal(nil) {
+		t.Errorf(`Quote should not equal nil`)
+	}
+	tns := timeandsale.NewTimeAndSale("IBM")
+	other := tns.Clone()
+	buyer := "BUYER"
+	other.SetBuyer(&buyer)
+	if tns.Equal(other) {
+		t.Errorf(`Time and sales with different buyers should not be equal`)
+	}
+	g := greeks.NewGreeks("AAPL")
+	if !g.Equal(g.Clone()) {
+		t.Errorf(`Greeks should equal their clone`)
+	}
+	g2 := g.Clone()
+	g2.SetDelta(0.5)
+	if g.Equal(g2) {
+		t.Errorf(`Greeks with different deltas should not be equal`)
+	}
+}
@@ -0,0 +1,12 @@
+package events
+
+// Event flags of indexed events, see EventFlags() of the corresponding events.
+const (
+	TxPending     int32 = 0x01
+	RemoveEvent   int32 = 0x02
+	SnapshotBegin int32 = 0x04
+	SnapshotEnd   int32 = 0x08
+	SnapshotSnip  int32 = 0x10
+	SnapshotMode  int32 = 0x40
+	RemoveSymbol  int32 = 0x80
+)
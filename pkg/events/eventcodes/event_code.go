@@ -1,5 +1,7 @@
 package eventcodes
 
+import "fmt"
+
 type EventCode int32
 
 const (
@@ -27,3 +29,70 @@ const (
 func (e EventCode) NativeCode() int32 {
 	return int32(e)
 }
+
+func (e EventCode) String() string {
+	switch e {
+	case Quote:
+		return "Quote"
+	case Profile:
+		return "Profile"
+	case Summary:
+		return "Summary"
+	case Greeks:
+		return "Greeks"
+	case Candle:
+		return "Candle"
+	case DailyCandle:
+		return "DailyCandle"
+	case Underlying:
+		return "Underlying"
+	case TheoPrice:
+		return "TheoPrice"
+	case Trade:
+		return "Trade"
+	case TradeETH:
+		return "TradeETH"
+	case Configuration:
+		return "Configuration"
+	case Message:
+		return "Message"
+	case TimeAndSale:
+		return "TimeAndSale"
+	case OrderBase:
+		return "OrderBase"
+	case Order:
+		return "Order"
+	case AnalyticOrder:
+		return "AnalyticOrder"
+	case SpreadOrder:
+		return "SpreadOrder"
+	case Series:
+		return "Series"
+	case OptionSale:
+		return "OptionSale"
+	default:
+		return fmt.Sprintf("EventCode(%d)", int32(e))
+	}
+}
+
+// IsIndexed returns whether events of the type are indexed events, which have event flags and snapshots.
+func (e EventCode) IsIndexed() bool {
+	switch e {
+	case Greeks, Candle, DailyCandle, Underlying, TheoPrice, TimeAndSale,
+		OrderBase, Order, AnalyticOrder, SpreadOrder, Series, OptionSale:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTimeSeries returns whether events of the type are time series, which are subscribed from a time
+// with Osub.TimeSeriesSubscriptionSymbol.
+func (e EventCode) IsTimeSeries() bool {
+	switch e {
+	case Greeks, Candle, DailyCandle, Underlying, TheoPrice, TimeAndSale:
+		return true
+	default:
+		return false
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"math"
 	"strconv"
 
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 )
 
@@ -44,7 +46,12 @@ func (g *Greeks) EventSymbol() *string {
 }
 
 func (g *Greeks) SetEventSymbol(eventSymbol string) {
-	*g.eventSymbol = eventSymbol
+	eventutil.SetStringValue(&g.eventSymbol, eventSymbol)
+}
+
+// SetInternedEventSymbol points the symbol to the interned value without copying it.
+func (g *Greeks) SetInternedEventSymbol(_ intern.Access, value *string) {
+	g.eventSymbol = value
 }
 
 func (g *Greeks) EventTime() int64 {
@@ -141,3 +148,28 @@ func (g *Greeks) String() string {
 		", vega=" + formatutil.FormatFloat64(g.vega) +
 		"}"
 }
+
+// Clone returns a copy of the greeks sharing no memory with them.
+func (g *Greeks) Clone() *Greeks {
+	result := *g
+	result.eventSymbol = eventutil.CloneString(g.eventSymbol)
+	return &result
+}
+
+// Equal returns whether all fields of the greeks are equal, NaN values are equal.
+func (g *Greeks) Equal(other *Greeks) bool {
+	if g == nil || other == nil {
+		return g == other
+	}
+	return eventutil.EqualStrings(g.eventSymbol, other.eventSymbol) &&
+		g.eventTime == other.eventTime &&
+		g.eventFlags == other.eventFlags &&
+		g.index == other.index &&
+		eventutil.EqualFloats(g.price, other.price) &&
+		eventutil.EqualFloats(g.volatility, other.volatility) &&
+		eventutil.EqualFloats(g.delta, other.delta) &&
+		eventutil.EqualFloats(g.gamma, other.gamma) &&
+		eventutil.EqualFloats(g.theta, other.theta) &&
+		eventutil.EqualFloats(g.rho, other.rho) &&
+		eventutil.EqualFloats(g.vega, other.vega)
+}
@@ -0,0 +1,72 @@
+package greeks
+
+import "github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
+
+// Builder sets fields of greeks, invalid values are reported by Build.
+// Build returns new greeks every time, so a builder can be reused as a template.
+type Builder struct {
+	greeks  *Greeks
+	checker eventutil.Checker
+}
+
+func NewBuilder(eventSymbol string) *Builder {
+	return &Builder{greeks: NewGreeks(eventSymbol)}
+}
+
+func (b *Builder) EventTime(eventTime int64) *Builder {
+	b.greeks.SetEventTime(eventTime)
+	return b
+}
+
+func (b *Builder) EventFlags(eventFlags int32) *Builder {
+	b.greeks.SetEventFlags(eventFlags)
+	return b
+}
+
+func (b *Builder) Index(index int64) *Builder {
+	b.greeks.SetIndex(index)
+	return b
+}
+
+func (b *Builder) Price(price float64) *Builder {
+	b.greeks.SetPrice(price)
+	return b
+}
+
+func (b *Builder) Volatility(volatility float64) *Builder {
+	b.greeks.SetVolatility(volatility)
+	return b
+}
+
+func (b *Builder) Delta(delta float64) *Builder {
+	b.greeks.SetDelta(delta)
+	return b
+}
+
+func (b *Builder) Gamma(gamma float64) *Builder {
+	b.greeks.SetGamma(gamma)
+	return b
+}
+
+func (b *Builder) Theta(theta float64) *Builder {
+	b.greeks.SetTheta(theta)
+	return b
+}
+
+func (b *Builder) Rho(rho float64) *Builder {
+	b.greeks.SetRho(rho)
+	return b
+}
+
+func (b *Builder) Vega(vega float64) *Builder {
+	b.greeks.SetVega(vega)
+	return b
+}
+
+// Build returns the greeks, or the first invalid value set.
+func (b *Builder) Build() (*Greeks, error) {
+	if err := b.checker.Err(); err != nil {
+		return nil, err
+	}
+	return b.greeks.Clone(), nil
+}
@@ -0,0 +1,83 @@
+package greeks
+
+import (
+	"encoding/json"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+const (
+	eventType   = "Greeks"
+	maxSequence = (1 << 22) - 1
+)
+
+func init() {
+	events.RegisterEventType(eventType, func() json.Unmarshaler {
+		return NewGreeks("")
+	})
+}
+
+type greeksJSON struct {
+	EventType   string                `json:"eventType"`
+	EventSymbol *string               `json:"eventSymbol"`
+	EventTime   int64                 `json:"eventTime"`
+	EventFlags  events.JSONEventFlags `json:"eventFlags,omitempty"`
+	Time        int64                 `json:"time"`
+	Sequence    int64                 `json:"sequence"`
+	Price       events.JSONFloat      `json:"price"`
+	Volatility  events.JSONFloat      `json:"volatility"`
+	Delta       events.JSONFloat      `json:"delta"`
+	Gamma       events.JSONFloat      `json:"gamma"`
+	Theta       events.JSONFloat      `json:"theta"`
+	Rho         events.JSONFloat      `json:"rho"`
+	Vega        events.JSONFloat      `json:"vega"`
+}
+
+func (g *Greeks) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.toJSON())
+}
+
+func (g *Greeks) UnmarshalJSON(data []byte) error {
+	v := NewGreeks("").toJSON()
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := events.CheckEventType(v.EventType, eventType); err != nil {
+		return err
+	}
+	symbol := ""
+	if v.EventSymbol != nil {
+		symbol = *v.EventSymbol
+	}
+	*g = *NewGreeks(symbol)
+	g.eventTime = v.EventTime
+	g.eventFlags = int32(v.EventFlags)
+	g.index = timeutil.GetSecondsFromTime(v.Time)<<32 | int64(timeutil.GetMillisFromTime(v.Time))<<22 | v.Sequence&maxSequence
+	g.price = float64(v.Price)
+	g.volatility = float64(v.Volatility)
+	g.delta = float64(v.Delta)
+	g.gamma = float64(v.Gamma)
+	g.theta = float64(v.Theta)
+	g.rho = float64(v.Rho)
+	g.vega = float64(v.Vega)
+	return nil
+}
+
+func (g *Greeks) toJSON() greeksJSON {
+	return greeksJSON{
+		EventType:   eventType,
+		EventSymbol: g.eventSymbol,
+		EventTime:   g.eventTime,
+		EventFlags:  events.JSONEventFlags(g.eventFlags),
+		Time:        (g.index>>32)*1000 + (g.index>>22)&0x3ff,
+		Sequence:    g.index & maxSequence,
+		Price:       events.JSONFloat(g.price),
+		Volatility:  events.JSONFloat(g.volatility),
+		Delta:       events.JSONFloat(g.delta),
+		Gamma:       events.JSONFloat(g.gamma),
+		Theta:       events.JSONFloat(g.theta),
+		Rho:         events.JSONFloat(g.rho),
+		Vega:        events.JSONFloat(g.vega),
+	}
+}
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 )
 
@@ -43,7 +44,7 @@ func (p *InstrumentProfile) InstrumentType() *string {
 }
 
 func (p *InstrumentProfile) SetInstrumentType(instrumentType *string) {
-	p.instrumentType = instrumentType
+	eventutil.SetString(&p.instrumentType, instrumentType)
 }
 
 func (p *InstrumentProfile) Symbol() *string {
@@ -51,7 +52,7 @@ func (p *InstrumentProfile) Symbol() *string {
 }
 
 func (p *InstrumentProfile) SetSymbol(symbol *string) {
-	p.symbol = symbol
+	eventutil.SetString(&p.symbol, symbol)
 }
 
 func (p *InstrumentProfile) Description() *string {
@@ -59,7 +60,7 @@ func (p *InstrumentProfile) Description() *string {
 }
 
 func (p *InstrumentProfile) SetDescription(description *string) {
-	p.description = description
+	eventutil.SetString(&p.description, description)
 }
 
 func (p *InstrumentProfile) LocalSymbol() *string {
@@ -67,7 +68,7 @@ func (p *InstrumentProfile) LocalSymbol() *string {
 }
 
 func (p *InstrumentProfile) SetLocalSymbol(localSymbol *string) {
-	p.localSymbol = localSymbol
+	eventutil.SetString(&p.localSymbol, localSymbol)
 }
 
 func (p *InstrumentProfile) LocalDescription() *string {
@@ -75,7 +76,7 @@ func (p *InstrumentProfile) LocalDescription() *string {
 }
 
 func (p *InstrumentProfile) SetLocalDescription(localDescription *string) {
-	p.localDescription = localDescription
+	eventutil.SetString(&p.localDescription, localDescription)
 }
 
 func (p *InstrumentProfile) Country() *string {
@@ -83,7 +84,7 @@ func (p *InstrumentProfile) Country() *string {
 }
 
 func (p *InstrumentProfile) SetCountry(country *string) {
-	p.country = country
+	eventutil.SetString(&p.country, country)
 }
 
 func (p *InstrumentProfile) Opol() *string {
@@ -91,7 +92,7 @@ func (p *InstrumentProfile) Opol() *string {
 }
 
 func (p *InstrumentProfile) SetOpol(opol *string) {
-	p.opol = opol
+	eventutil.SetString(&p.opol, opol)
 }
 
 func (p *InstrumentProfile) ExchangeData() *string {
@@ -99,7 +100,7 @@ func (p *InstrumentProfile) ExchangeData() *string {
 }
 
 func (p *InstrumentProfile) SetExchangeData(exchangeData *string) {
-	p.exchangeData = exchangeData
+	eventutil.SetString(&p.exchangeData, exchangeData)
 }
 
 func (p *InstrumentProfile) Exchanges() *string {
@@ -107,7 +108,7 @@ func (p *InstrumentProfile) Exchanges() *string {
 }
 
 func (p *InstrumentProfile) SetExchanges(exchanges *string) {
-	p.exchanges = exchanges
+	eventutil.SetString(&p.exchanges, exchanges)
 }
 
 func (p *InstrumentProfile) Currency() *string {
@@ -115,7 +116,7 @@ func (p *InstrumentProfile) Currency() *string {
 }
 
 func (p *InstrumentProfile) SetCurrency(currency *string) {
-	p.currency = currency
+	eventutil.SetString(&p.currency, currency)
 }
 
 func (p *InstrumentProfile) BaseCurrency() *string {
@@ -123,7 +124,7 @@ func (p *InstrumentProfile) BaseCurrency() *string {
 }
 
 func (p *InstrumentProfile) SetBaseCurrency(baseCurrency *string) {
-	p.baseCurrency = baseCurrency
+	eventutil.SetString(&p.baseCurrency, baseCurrency)
 }
 
 func (p *InstrumentProfile) Cfi() *string {
@@ -131,7 +132,7 @@ func (p *InstrumentProfile) Cfi() *string {
 }
 
 func (p *InstrumentProfile) SetCfi(cfi *string) {
-	p.cfi = cfi
+	eventutil.SetString(&p.cfi, cfi)
 }
 
 func (p *InstrumentProfile) Isin() *string {
@@ -139,7 +140,7 @@ func (p *InstrumentProfile) Isin() *string {
 }
 
 func (p *InstrumentProfile) SetIsin(isin *string) {
-	p.isin = isin
+	eventutil.SetString(&p.isin, isin)
 }
 
 func (p *InstrumentProfile) Sedol() *string {
@@ -147,7 +148,7 @@ func (p *InstrumentProfile) Sedol() *string {
 }
 
 func (p *InstrumentProfile) SetSedol(sedol *string) {
-	p.sedol = sedol
+	eventutil.SetString(&p.sedol, sedol)
 }
 
 func (p *InstrumentProfile) Cusip() *string {
@@ -155,7 +156,7 @@ func (p *InstrumentProfile) Cusip() *string {
 }
 
 func (p *InstrumentProfile) SetCusip(cusip *string) {
-	p.cusip = cusip
+	eventutil.SetString(&p.cusip, cusip)
 }
 
 func (p *InstrumentProfile) Icb() int64 {
@@ -187,7 +188,7 @@ func (p *InstrumentProfile) Product() *string {
 }
 
 func (p *InstrumentProfile) SetProduct(product *string) {
-	p.product = product
+	eventutil.SetString(&p.product, product)
 }
 
 func (p *InstrumentProfile) Underlying() *string {
@@ -195,7 +196,7 @@ func (p *InstrumentProfile) Underlying() *string {
 }
 
 func (p *InstrumentProfile) SetUnderlying(underlying *string) {
-	p.underlying = underlying
+	eventutil.SetString(&p.underlying, underlying)
 }
 
 func (p *InstrumentProfile) Spc() float64 {
@@ -211,7 +212,7 @@ func (p *InstrumentProfile) AdditionalUnderlyings() *string {
 }
 
 func (p *InstrumentProfile) SetAdditionalUnderlyings(additionalUnderlyings *string) {
-	p.additionalUnderlyings = additionalUnderlyings
+	eventutil.SetString(&p.additionalUnderlyings, additionalUnderlyings)
 }
 
 func (p *InstrumentProfile) Mmy() *string {
@@ -219,7 +220,7 @@ func (p *InstrumentProfile) Mmy() *string {
 }
 
 func (p *InstrumentProfile) SetMmy(mmy *string) {
-	p.mmy = mmy
+	eventutil.SetString(&p.mmy, mmy)
 }
 
 func (p *InstrumentProfile) Expiration() int64 {
@@ -251,7 +252,7 @@ func (p *InstrumentProfile) OptionType() *string {
 }
 
 func (p *InstrumentProfile) SetOptionType(optionType *string) {
-	p.optionType = optionType
+	eventutil.SetString(&p.optionType, optionType)
 }
 
 func (p *InstrumentProfile) ExpirationStyle() *string {
@@ -259,7 +260,7 @@ func (p *InstrumentProfile) ExpirationStyle() *string {
 }
 
 func (p *InstrumentProfile) SetExpirationStyle(expirationStyle *string) {
-	p.expirationStyle = expirationStyle
+	eventutil.SetString(&p.expirationStyle, expirationStyle)
 }
 
 func (p *InstrumentProfile) SettlementStyle() *string {
@@ -267,7 +268,7 @@ func (p *InstrumentProfile) SettlementStyle() *string {
 }
 
 func (p *InstrumentProfile) SetSettlementStyle(settlementStyle *string) {
-	p.settlementStyle = settlementStyle
+	eventutil.SetString(&p.settlementStyle, settlementStyle)
 }
 
 func (p *InstrumentProfile) PriceIncrements() *string {
@@ -275,7 +276,7 @@ func (p *InstrumentProfile) PriceIncrements() *string {
 }
 
 func (p *InstrumentProfile) SetPriceIncrements(priceIncrements *string) {
-	p.priceIncrements = priceIncrements
+	eventutil.SetString(&p.priceIncrements, priceIncrements)
 }
 
 func (p *InstrumentProfile) TradingHours() *string {
@@ -283,7 +284,7 @@ func (p *InstrumentProfile) TradingHours() *string {
 }
 
 func (p *InstrumentProfile) SetTradingHours(tradingHours *string) {
-	p.tradingHours = tradingHours
+	eventutil.SetString(&p.tradingHours, tradingHours)
 }
 
 func NewInstrumentProfile() *InstrumentProfile {
@@ -331,3 +332,71 @@ func (q *InstrumentProfile) String() string {
 		", type=" + formatutil.FormatString(q.InstrumentType()) +
 		"}"
 }
+
+// Clone returns a copy of the instrument profile sharing no memory with it.
+func (p *InstrumentProfile) Clone() *InstrumentProfile {
+	result := *p
+	result.instrumentType = eventutil.CloneString(p.instrumentType)
+	result.symbol = eventutil.CloneString(p.symbol)
+	result.description = eventutil.CloneString(p.description)
+	result.localSymbol = eventutil.CloneString(p.localSymbol)
+	result.localDescription = eventutil.CloneString(p.localDescription)
+	result.country = eventutil.CloneString(p.country)
+	result.opol = eventutil.CloneString(p.opol)
+	result.exchangeData = eventutil.CloneString(p.exchangeData)
+	result.exchanges = eventutil.CloneString(p.exchanges)
+	result.currency = eventutil.CloneString(p.currency)
+	result.baseCurrency = eventutil.CloneString(p.baseCurrency)
+	result.cfi = eventutil.CloneString(p.cfi)
+	result.isin = eventutil.CloneString(p.isin)
+	result.sedol = eventutil.CloneString(p.sedol)
+	result.cusip = eventutil.CloneString(p.cusip)
+	result.product = eventutil.CloneString(p.product)
+	result.underlying = eventutil.CloneString(p.underlying)
+	result.additionalUnderlyings = eventutil.CloneString(p.additionalUnderlyings)
+	result.mmy = eventutil.CloneString(p.mmy)
+	result.optionType = eventutil.CloneString(p.optionType)
+	result.expirationStyle = eventutil.CloneString(p.expirationStyle)
+	result.settlementStyle = eventutil.CloneString(p.settlementStyle)
+	result.priceIncrements = eventutil.CloneString(p.priceIncrements)
+	result.tradingHours = eventutil.CloneString(p.tradingHours)
+	return &result
+}
+
+// Equal returns whether all fields of the instrument profiles are equal, NaN values are equal.
+func (p *InstrumentProfile) Equal(other *InstrumentProfile) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return eventutil.EqualStrings(p.instrumentType, other.instrumentType) &&
+		eventutil.EqualStrings(p.symbol, other.symbol) &&
+		eventutil.EqualStrings(p.description, other.description) &&
+		eventutil.EqualStrings(p.localSymbol, other.localSymbol) &&
+		eventutil.EqualStrings(p.localDescription, other.localDescription) &&
+		eventutil.EqualStrings(p.country, other.country) &&
+		eventutil.EqualStrings(p.opol, other.opol) &&
+		eventutil.EqualStrings(p.exchangeData, other.exchangeData) &&
+		eventutil.EqualStrings(p.exchanges, other.exchanges) &&
+		eventutil.EqualStrings(p.currency, other.currency) &&
+		eventutil.EqualStrings(p.baseCurrency, other.baseCurrency) &&
+		eventutil.EqualStrings(p.cfi, other.cfi) &&
+		eventutil.EqualStrings(p.isin, other.isin) &&
+		eventutil.EqualStrings(p.sedol, other.sedol) &&
+		eventutil.EqualStrings(p.cusip, other.cusip) &&
+		p.icb == other.icb &&
+		p.sic == other.sic &&
+		eventutil.EqualFloats(p.multiplier, other.multiplier) &&
+		eventutil.EqualStrings(p.product, other.product) &&
+		eventutil.EqualStrings(p.underlying, other.underlying) &&
+		eventutil.EqualFloats(p.spc, other.spc) &&
+		eventutil.EqualStrings(p.additionalUnderlyings, other.additionalUnderlyings) &&
+		eventutil.EqualStrings(p.mmy, other.mmy) &&
+		p.expiration == other.expiration &&
+		p.lastTrade == other.lastTrade &&
+		eventutil.EqualFloats(p.strike, other.strike) &&
+		eventutil.EqualStrings(p.optionType, other.optionType) &&
+		eventutil.EqualStrings(p.expirationStyle, other.expirationStyle) &&
+		eventutil.EqualStrings(p.settlementStyle, other.settlementStyle) &&
+		eventutil.EqualStrings(p.priceIncrements, other.priceIncrements) &&
+		eventutil.EqualStrings(p.tradingHours, other.tradingHours)
+}
@@ -0,0 +1,142 @@
+package events
+
+import (
+	"encoding/json"
+)
+
+const instrumentProfileEventType = "InstrumentProfile"
+
+func init() {
+	RegisterEventType(instrumentProfileEventType, func() json.Unmarshaler {
+		return NewInstrumentProfile()
+	})
+}
+
+type instrumentProfileJSON struct {
+	EventType             string    `json:"eventType"`
+	InstrumentType        *string   `json:"instrumentType"`
+	Symbol                *string   `json:"symbol"`
+	Description           *string   `json:"description"`
+	LocalSymbol           *string   `json:"localSymbol"`
+	LocalDescription      *string   `json:"localDescription"`
+	Country               *string   `json:"country"`
+	Opol                  *string   `json:"opol"`
+	ExchangeData          *string   `json:"exchangeData"`
+	Exchanges             *string   `json:"exchanges"`
+	Currency              *string   `json:"currency"`
+	BaseCurrency          *string   `json:"baseCurrency"`
+	Cfi                   *string   `json:"cfi"`
+	Isin                  *string   `json:"isin"`
+	Sedol                 *string   `json:"sedol"`
+	Cusip                 *string   `json:"cusip"`
+	Icb                   int64     `json:"icb"`
+	Sic                   int64     `json:"sic"`
+	Multiplier            JSONFloat `json:"multiplier"`
+	Product               *string   `json:"product"`
+	Underlying            *string   `json:"underlying"`
+	Spc                   JSONFloat `json:"spc"`
+	AdditionalUnderlyings *string   `json:"additionalUnderlyings"`
+	Mmy                   *string   `json:"mmy"`
+	Expiration            int64     `json:"expiration"`
+	LastTrade             int64     `json:"lastTrade"`
+	Strike                JSONFloat `json:"strike"`
+	OptionType            *string   `json:"optionType"`
+	ExpirationStyle       *string   `json:"expirationStyle"`
+	SettlementStyle       *string   `json:"settlementStyle"`
+	PriceIncrements       *string   `json:"priceIncrements"`
+	TradingHours          *string   `json:"tradingHours"`
+}
+
+func (p *InstrumentProfile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.toJSON())
+}
+
+func (p *InstrumentProfile) UnmarshalJSON(data []byte) error {
+	// NewInstrumentProfile shares one empty string between fields, so strings are decoded into nil pointers
+	v := instrumentProfileJSON{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := CheckEventType(v.EventType, instrumentProfileEventType); err != nil {
+		return err
+	}
+	*p = InstrumentProfile{
+		instrumentType:        orEmpty(v.InstrumentType),
+		symbol:                orEmpty(v.Symbol),
+		description:           orEmpty(v.Description),
+		localSymbol:           orEmpty(v.LocalSymbol),
+		localDescription:      orEmpty(v.LocalDescription),
+		country:               orEmpty(v.Country),
+		opol:                  orEmpty(v.Opol),
+		exchangeData:          orEmpty(v.ExchangeData),
+		exchanges:             orEmpty(v.Exchanges),
+		currency:              orEmpty(v.Currency),
+		baseCurrency:          orEmpty(v.BaseCurrency),
+		cfi:                   orEmpty(v.Cfi),
+		isin:                  orEmpty(v.Isin),
+		sedol:                 orEmpty(v.Sedol),
+		cusip:                 orEmpty(v.Cusip),
+		icb:                   v.Icb,
+		sic:                   v.Sic,
+		multiplier:            float64(v.Multiplier),
+		product:               orEmpty(v.Product),
+		underlying:            orEmpty(v.Underlying),
+		spc:                   float64(v.Spc),
+		additionalUnderlyings: orEmpty(v.AdditionalUnderlyings),
+		mmy:                   orEmpty(v.Mmy),
+		expiration:            v.Expiration,
+		lastTrade:             v.LastTrade,
+		strike:                float64(v.Strike),
+		optionType:            orEmpty(v.OptionType),
+		expirationStyle:       orEmpty(v.ExpirationStyle),
+		settlementStyle:       orEmpty(v.SettlementStyle),
+		priceIncrements:       orEmpty(v.PriceIncrements),
+		tradingHours:          orEmpty(v.TradingHours),
+	}
+	return nil
+}
+
+func (p *InstrumentProfile) toJSON() instrumentProfileJSON {
+	return instrumentProfileJSON{
+		EventType:             instrumentProfileEventType,
+		InstrumentType:        p.instrumentType,
+		Symbol:                p.symbol,
+		Description:           p.description,
+		LocalSymbol:           p.localSymbol,
+		LocalDescription:      p.localDescription,
+		Country:               p.country,
+		Opol:                  p.opol,
+		ExchangeData:          p.exchangeData,
+		Exchanges:             p.exchanges,
+		Currency:              p.currency,
+		BaseCurrency:          p.baseCurrency,
+		Cfi:                   p.cfi,
+		Isin:                  p.isin,
+		Sedol:                 p.sedol,
+		Cusip:                 p.cusip,
+		Icb:                   p.icb,
+		Sic:                   p.sic,
+		Multiplier:            JSONFloat(p.multiplier),
+		Product:               p.product,
+		Underlying:            p.underlying,
+		Spc:                   JSONFloat(p.spc),
+		AdditionalUnderlyings: p.additionalUnderlyings,
+		Mmy:                   p.mmy,
+		Expiration:            p.expiration,
+		LastTrade:             p.lastTrade,
+		Strike:                JSONFloat(p.strike),
+		OptionType:            p.optionType,
+		ExpirationStyle:       p.expirationStyle,
+		SettlementStyle:       p.settlementStyle,
+		PriceIncrements:       p.priceIncrements,
+		TradingHours:          p.tradingHours,
+	}
+}
+
+func orEmpty(value *string) *string {
+	if value == nil {
+		empty := ""
+		return &empty
+	}
+	return value
+}
@@ -0,0 +1,35 @@
+package eventutil
+
+import "fmt"
+
+const maxSequence = (1 << 22) - 1
+
+// Checker keeps the first error found by a builder, Build returns it.
+type Checker struct {
+	err error
+}
+
+// Check keeps the error unless an earlier one is kept.
+func (c *Checker) Check(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+func (c *Checker) Err() error {
+	return c.err
+}
+
+func CheckSequence(sequence int64) error {
+	if sequence < 0 || sequence > maxSequence {
+		return fmt.Errorf("sequence(%d) is < 0 or > MaxSequence(%d)", sequence, maxSequence)
+	}
+	return nil
+}
+
+func CheckTimeNanoPart(timeNanoPart int32) error {
+	if timeNanoPart < 0 || timeNanoPart > 999_999 {
+		return fmt.Errorf("timeNanoPart(%d) is < 0 or > 999999", timeNanoPart)
+	}
+	return nil
+}
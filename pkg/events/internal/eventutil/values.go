@@ -0,0 +1,48 @@
+// Package eventutil keeps string fields of events from sharing memory with callers, compares fields of events
+// and validates fields set by builders.
+//
+// Strings pointed to by fields are never written through, setters point fields to copies instead,
+// so events copied by value do not change when either of them is set.
+package eventutil
+
+import "math"
+
+// SetString points the field to a copy of the value, nil clears it.
+// The field is kept when its value is already equal, so setting the same strings again does not allocate.
+func SetString(field **string, value *string) {
+	if value == nil {
+		*field = nil
+		return
+	}
+	SetStringValue(field, *value)
+}
+
+// SetStringValue points the field to a copy of the value.
+func SetStringValue(field **string, value string) {
+	if *field != nil && **field == value {
+		return
+	}
+	*field = &value
+}
+
+// CloneString returns a copy of the string, nil stays nil.
+func CloneString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	result := *value
+	return &result
+}
+
+// EqualStrings returns whether both strings are nil or have equal values.
+func EqualStrings(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// EqualFloats returns whether the values are equal, NaN equals NaN.
+func EqualFloats(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
@@ -0,0 +1,213 @@
+package events
+
+// JSON schema of events.
+//
+// Every event is encoded as a JSON object with the "eventType" field holding the name of its type
+// ("Quote", "Trade", "TradeETH", "TimeAndSale", "Profile", "Greeks", "Candle", "Order", "AnalyticOrder",
+// "SpreadOrder" or "InstrumentProfile") and other fields named after the getters of the event
+// in lower camel case, e.g. "eventSymbol", "bidPrice" or "exchangeCode".
+//
+//   - Times are milliseconds since epoch, day ids are days since epoch. Time and sequence packed into
+//     the index or the time sequence of an event are encoded as separate "time" and "sequence" fields.
+//   - Bit fields are decoded: "eventFlags" is an array of flag names like ["TxPending", "SnapshotBegin"]
+//     and is omitted when there are no flags, enums like side, scope, action, direction or trading status
+//     are encoded by their names as returned by String, boolean flags are booleans.
+//   - Characters like exchange codes are one-character strings, an empty string for no character.
+//   - NaN is encoded as null, infinities as "Infinity" and "-Infinity".
+//
+// Decoding starts from the defaults of the event constructor, so missing fields keep their default values.
+// Unknown fields are ignored, while unknown enum names or flags and a mismatching "eventType" are errors.
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var eventFlagNames = []struct {
+	flag int32
+	name string
+}{
+	{TxPending, "TxPending"},
+	{RemoveEvent, "RemoveEvent"},
+	{SnapshotBegin, "SnapshotBegin"},
+	{SnapshotEnd, "SnapshotEnd"},
+	{SnapshotSnip, "SnapshotSnip"},
+	{SnapshotMode, "SnapshotMode"},
+	{RemoveSymbol, "RemoveSymbol"},
+}
+
+var (
+	eventTypesMu sync.RWMutex
+	eventTypes   = map[string]func() json.Unmarshaler{}
+)
+
+// RegisterEventType registers a constructor of events of the type for UnmarshalEvent.
+// Packages of events register their types on initialization.
+func RegisterEventType(eventType string, newEvent func() json.Unmarshaler) {
+	eventTypesMu.Lock()
+	defer eventTypesMu.Unlock()
+	eventTypes[eventType] = newEvent
+}
+
+// UnmarshalEvent decodes an event of any type by its "eventType" field. The package of the event
+// type must be imported, e.g. UnmarshalEvent returns *quote.Quote for quotes only if package quote is imported.
+func UnmarshalEvent(data []byte) (interface{}, error) {
+	var header struct {
+		EventType string `json:"eventType"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	eventTypesMu.RLock()
+	newEvent, ok := eventTypes[header.EventType]
+	eventTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", header.EventType)
+	}
+	event := newEvent()
+	if err := event.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// CheckEventType returns an error if the decoded event type is set and differs from the expected one.
+func CheckEventType(eventType string, expected string) error {
+	if eventType != "" && eventType != expected {
+		return fmt.Errorf("can't decode %s as %s", eventType, expected)
+	}
+	return nil
+}
+
+// JSONFloat is a float encoded as null for NaN and as "Infinity" or "-Infinity" for infinities.
+type JSONFloat float64
+
+func (f JSONFloat) MarshalJSON() ([]byte, error) {
+	value := float64(f)
+	switch {
+	case math.IsNaN(value):
+		return []byte("null"), nil
+	case math.IsInf(value, 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(value, -1):
+		return []byte(`"-Infinity"`), nil
+	}
+	return json.Marshal(value)
+}
+
+func (f *JSONFloat) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "null", `"NaN"`:
+		*f = JSONFloat(math.NaN())
+	case `"Infinity"`:
+		*f = JSONFloat(math.Inf(1))
+	case `"-Infinity"`:
+		*f = JSONFloat(math.Inf(-1))
+	default:
+		var value float64
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*f = JSONFloat(value)
+	}
+	return nil
+}
+
+// JSONChar is a character encoded as a one-character string, an empty string for zero.
+type JSONChar rune
+
+func (c JSONChar) MarshalJSON() ([]byte, error) {
+	if c == 0 {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(string(rune(c)))
+}
+
+func (c *JSONChar) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*c = 0
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == "" {
+		*c = 0
+		return nil
+	}
+	r, size := utf8.DecodeRuneInString(value)
+	if size != len(value) {
+		return fmt.Errorf("invalid character %q", value)
+	}
+	*c = JSONChar(r)
+	return nil
+}
+
+// JSONEventFlags are event flags encoded as an array of flag names, unknown flags are encoded in hex like "0x20".
+type JSONEventFlags int32
+
+func (f JSONEventFlags) MarshalJSON() ([]byte, error) {
+	names := []string{}
+	rest := int32(f)
+	for _, n := range eventFlagNames {
+		if rest&n.flag != 0 {
+			names = append(names, n.name)
+			rest &^= n.flag
+		}
+	}
+	if rest != 0 {
+		names = append(names, "0x"+strconv.FormatInt(int64(rest), 16))
+	}
+	return json.Marshal(names)
+}
+
+func (f *JSONEventFlags) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	var flags int32
+	for _, name := range names {
+		flag, ok := eventFlagByName(name)
+		if !ok {
+			return fmt.Errorf("unknown event flag %q", name)
+		}
+		flags |= flag
+	}
+	*f = JSONEventFlags(flags)
+	return nil
+}
+
+func eventFlagByName(name string) (int32, bool) {
+	for _, n := range eventFlagNames {
+		if n.name == name {
+			return n.flag, true
+		}
+	}
+	if strings.HasPrefix(name, "0x") {
+		flag, err := strconv.ParseInt(name[2:], 16, 32)
+		return int32(flag), err == nil
+	}
+	return 0, false
+}
+
+// ParseEnum returns the value whose String is the name.
+func ParseEnum[T fmt.Stringer](kind string, name string, values ...T) (T, error) {
+	for _, value := range values {
+		if value.String() == name {
+			return value, nil
+		}
+	}
+	var zero T
+	names := make([]string, len(values))
+	for i, value := range values {
+		names[i] = value.String()
+	}
+	return zero, fmt.Errorf("unknown %s %q, expected one of %s", kind, name, strings.Join(names, ", "))
+}
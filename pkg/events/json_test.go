@@ -0,0 +1,216 @@
+package events_test
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/greeks"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/profile"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+)
+
+const testTime = int64(1700000000123)
+
+func newEvents() []interface{} {
+	q := quote.NewQuote("AAPL")
+	q.SetEventTime(testTime + 5)
+	q.SetBidTime(testTime - 123)
+	q.SetAskTime(testTime)
+	q.SetSequence(7)
+	q.SetBidExchangeCode('Q')
+	q.SetBidPrice(100.5)
+	q.SetBidSize(10)
+	q.SetAskPrice(math.Inf(1))
+
+	tr := trade.NewTrade("MSFT")
+	tr.SetTime(testTime)
+	_ = tr.SetSequence(3)
+	tr.SetExchangeCode('X')
+	tr.SetPrice(300.25)
+	tr.SetSize(math.NaN())
+	tr.SetDayId(19676)
+	tr.SetTickDirection(trade.ZeroUp)
+	tr.SetIsExtendedTradingHours(true)
+
+	eth := trade.NewTradeETH("MSFT")
+	eth.SetPrice(301)
+
+	tns := timeandsale.NewTimeAndSale("IBM")
+	tns.SetEventFlags(events.SnapshotBegin | events.TxPending)
+	tns.SetTime(testTime)
+	_ = tns.SetSequence(11)
+	tns.SetTimeNanoPart(456)
+	tns.SetExchangeCode('N')
+	tns.SetPrice(150)
+	tns.SetSize(200)
+	conditions, buyer := "@T", "BUYER"
+	tns.SetExchangeSaleConditions(&conditions)
+	tns.SetBuyer(&buyer)
+	tns.SetTradeThroughExempt('X')
+	tns.SetAggressorSide(side.Sell)
+	tns.SetIsValidTick(true)
+	tns.SetIsSpreadLeg(true)
+	tns.SetTimeAndSaleType(timeandsale.TypeCorrection)
+
+	p := profile.NewProfile("GOOG")
+	description := "Alphabet Inc."
+	p.SetDescription(&description)
+	p.SetShortSaleRestriction(profile.ActiveShortSaleRestriction)
+	p.SetTradingStatus(profile.HaltedTradingStatus)
+	p.SetBeta(1.1)
+	p.SetExDividendDayId(19000)
+
+	g := greeks.NewGreeks(".AAPL240119C150")
+	g.SetIndex(testTime / 1000 << 32)
+	g.SetPrice(2.5)
+	g.SetDelta(-0.5)
+
+	c := candle.NewCandle("AAPL{=1m}")
+	c.SetEventFlags(events.RemoveEvent)
+	c.SetTime(testTime)
+	_ = c.SetSequence(1)
+	c.SetCount(5)
+	c.SetOpen(1)
+	c.SetClose(2)
+
+	o := order.NewOrder("AAPL")
+	o.SetOrderSource(order.NtvL3())
+	o.SetTime(testTime)
+	o.SetAction(order.ActionReplace)
+	o.SetOrderId(42)
+	o.SetPrice(99.5)
+	o.SetSize(100)
+	_ = o.SetExchangeCode('Q')
+	o.SetSide(side.Buy)
+	o.SetScope(order.ScopeOrder)
+	marketMaker := "NSDQ"
+	o.SetMarketMaker(&marketMaker)
+
+	a := order.NewAnalyticOrder("AAPL")
+	a.SetIcebergPeakSize(10)
+	a.SetIcebergType(order.Synthetic)
+	a.SetScope(order.ScopeAggregate)
+
+	s := order.NewSpreadOrder("AAPL")
+	spreadSymbol := "=AAPL-MSFT"
+	s.SetSpreadSymbol(&spreadSymbol)
+
+	ip := events.NewInstrumentProfile()
+	symbol, cfi := "AAPL", "ESXXXX"
+	ip.SetSymbol(&symbol)
+	ip.SetCfi(&cfi)
+	ip.SetMultiplier(100)
+
+	return []interface{}{q, tr, eth, tns, p, g, c, o, a, s, ip}
+}
+
+func TestEventsJSONRoundTrip(t *testing.T) {
+	for _, event := range newEvents() {
+		data, err := json.Marshal(event)
+		if err != nil {
+			t.Fatalf(`Unexpected error %v for %v`, err, event)
+		}
+		decoded, err := events.UnmarshalEvent(data)
+		if err != nil {
+			t.Fatalf(`Unexpected error %v for %s`, err, data)
+		}
+		if reflect.TypeOf(decoded) != reflect.TypeOf(event) {
+			t.Fatalf(`Type should be %T. But it equals %T`, event, decoded)
+		}
+		again, _ := json.Marshal(decoded)
+		if string(again) != string(data) {
+			t.Errorf(`JSON should be %s. But it equals %s`, data, again)
+		}
+	}
+}
+
+func TestEventsJSONSchema(t *testing.T) {
+	all := newEvents()
+	cases := []struct {
+		event    interface{}
+		contains []string
+	}{
+		{all[0], []string{`"eventType":"Quote"`, `"time":1700000000123`, `"sequence":7`, `"bidExchangeCode":"Q"`,
+			`"askExchangeCode":""`, `"askPrice":"Infinity"`, `"askSize":null`}},
+		{all[1], []string{`"eventType":"Trade"`, `"tickDirection":"ZeroUp"`, `"size":null`, `"extendedTradingHours":true`}},
+		{all[3], []string{`"eventFlags":["TxPending","SnapshotBegin"]`, `"aggressorSide":"Sell"`,
+			`"type":"Correction"`, `"tradeThroughExempt":"X"`, `"seller":null`}},
+		{all[4], []string{`"shortSaleRestriction":"Active"`, `"tradingStatus":"Halted"`}},
+		{all[5], []string{`"time":1700000000000`, `"price":2.5`}},
+		{all[6], []string{`"eventSymbol":"AAPL{=1m}"`, `"eventFlags":["RemoveEvent"]`}},
+		{all[7], []string{`"source":"NTV"`, `"action":"Replace"`, `"side":"Buy"`, `"scope":"Order"`, `"marketMaker":"NSDQ"`}},
+		{all[8], []string{`"eventType":"AnalyticOrder"`, `"icebergType":"Synthetic"`}},
+		{all[10], []string{`"eventType":"InstrumentProfile"`, `"cfi":"ESXXXX"`, `"multiplier":100`}},
+	}
+	for _, c := range cases {
+		data, _ := json.Marshal(c.event)
+		for _, s := range c.contains {
+			if !strings.Contains(string(data), s) {
+				t.Errorf(`JSON should contain %s. But it equals %s`, s, data)
+			}
+		}
+	}
+	data, _ := json.Marshal(all[1])
+	if strings.Contains(string(data), "eventFlags") {
+		t.Errorf(`JSON should not contain empty event flags. But it equals %s`, data)
+	}
+}
+
+func TestEventsJSONDefaults(t *testing.T) {
+	q := &quote.Quote{}
+	if err := json.Unmarshal([]byte(`{"eventSymbol":"AAPL","bidPrice":1.5}`), q); err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	if *q.EventSymbol() != "AAPL" || q.BidPrice() != 1.5 || !math.IsNaN(q.AskPrice()) {
+		t.Errorf(`Missing fields should keep defaults. But quote equals %v`, q)
+	}
+
+	ip := &events.InstrumentProfile{}
+	if err := json.Unmarshal([]byte(`{"symbol":"AAPL"}`), ip); err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	if *ip.Symbol() != "AAPL" || *ip.Description() != "" || *ip.Cfi() != "" {
+		t.Errorf(`Missing fields should be empty. But profile equals %v`, ip)
+	}
+}
+
+func TestEventsJSONErrors(t *testing.T) {
+	for _, data := range []string{
+		`{"eventType":"Unknown"}`,
+		`{}`,
+		`[1]`,
+		`{"eventType":"TimeAndSale","aggressorSide":"Both"}`,
+		`{"eventType":"Order","scope":"Global"}`,
+		`{"eventType":"Candle","eventFlags":["Pending"]}`,
+		`{"eventType":"Quote","bidExchangeCode":"QQ"}`,
+		`{"eventType":"Quote","bidPrice":"1"}`,
+	} {
+		if _, err := events.UnmarshalEvent([]byte(data)); err == nil {
+			t.Errorf(`Decoding of %s should fail`, data)
+		}
+	}
+	if err := json.Unmarshal([]byte(`{"eventType":"Trade"}`), quote.NewQuote("")); err == nil {
+		t.Errorf(`Decoding of Trade as Quote should fail`)
+	}
+}
+
+func TestJSONEventFlagsKeepsUnknownFlags(t *testing.T) {
+	flags := events.JSONEventFlags(events.SnapshotEnd | 0x20)
+	data, _ := json.Marshal(flags)
+	if string(data) != `["SnapshotEnd","0x20"]` {
+		t.Errorf(`JSON should be %v. But it equals %s`, `["SnapshotEnd","0x20"]`, data)
+	}
+	var decoded events.JSONEventFlags
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != flags {
+		t.Errorf(`Flags should be %v. But they equal %v (%v)`, flags, decoded, err)
+	}
+}
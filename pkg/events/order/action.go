@@ -1,9 +1,37 @@
 package order
 
-import "github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+import (
+	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+)
 
 type Action int32
 
+func (a Action) String() string {
+	switch a {
+	case ActionUndefined:
+		return "Undefined"
+	case ActionNew:
+		return "New"
+	case ActionReplace:
+		return "Replace"
+	case ActionModify:
+		return "Modify"
+	case ActionDelete:
+		return "Delete"
+	case ActionPartial:
+		return "Partial"
+	case ActionExecute:
+		return "Execute"
+	case ActionTrade:
+		return "Trade"
+	case ActionBurst:
+		return "Burst"
+	default:
+		return fmt.Sprintf("Action: Wrong value %d", a)
+	}
+}
+
 const (
 	ActionUndefined = iota
 	ActionNew
@@ -2,6 +2,7 @@ package order
 
 import (
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
 )
@@ -75,3 +76,20 @@ func (o *AnalyticOrder) String() string {
 		", icebergType=" + formatutil.FormatInt64(int64((o.IcebergType()))) +
 		"}"
 }
+
+func (o *AnalyticOrder) Clone() *AnalyticOrder {
+	result := *o
+	result.Order = *o.Order.Clone()
+	return &result
+}
+
+func (o *AnalyticOrder) Equal(other *AnalyticOrder) bool {
+	if o == nil || other == nil {
+		return o == other
+	}
+	return o.Order.Equal(&other.Order) &&
+		eventutil.EqualFloats(o.icebergPeakSize, other.icebergPeakSize) &&
+		eventutil.EqualFloats(o.icebergHiddenSize, other.icebergHiddenSize) &&
+		eventutil.EqualFloats(o.icebergExecutedSize, other.icebergExecutedSize) &&
+		o.icebergFlags == other.icebergFlags
+}
@@ -1,9 +1,25 @@
 package order
 
-import "github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+import (
+	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+)
 
 type IcebergType int32
 
+func (t IcebergType) String() string {
+	switch t {
+	case Undefined:
+		return "Undefined"
+	case Native:
+		return "Native"
+	case Synthetic:
+		return "Synthetic"
+	default:
+		return fmt.Sprintf("IcebergType: Wrong value %d", t)
+	}
+}
+
 const (
 	Undefined = iota
 	Native
@@ -1,7 +1,9 @@
 package order
 
 import (
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 )
 
@@ -19,7 +21,12 @@ func (o *Order) MarketMaker() *string {
 }
 
 func (o *Order) SetMarketMaker(marketMaker *string) {
-	o.marketMaker = marketMaker
+	eventutil.SetString(&o.marketMaker, marketMaker)
+}
+
+// SetInternedMarketMaker points the market maker to the interned value without copying it.
+func (o *Order) SetInternedMarketMaker(_ intern.Access, value *string) {
+	o.marketMaker = value
 }
 
 func (o *Order) Type() eventcodes.EventCode {
@@ -31,3 +38,14 @@ func (o *Order) String() string {
 		", marketMaker=" + formatutil.FormatString(o.MarketMaker()) +
 		"}"
 }
+
+func (o *Order) Clone() *Order {
+	return &Order{Base: *o.Base.Clone(), marketMaker: eventutil.CloneString(o.marketMaker)}
+}
+
+func (o *Order) Equal(other *Order) bool {
+	if o == nil || other == nil {
+		return o == other
+	}
+	return o.Base.Equal(&other.Base) && eventutil.EqualStrings(o.marketMaker, other.marketMaker)
+}
@@ -2,6 +2,8 @@ package order
 
 import (
 	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
@@ -65,7 +67,12 @@ func (b *Base) EventSymbol() *string {
 }
 
 func (b *Base) SetEventSymbol(eventSymbol *string) {
-	b.eventSymbol = eventSymbol
+	eventutil.SetString(&b.eventSymbol, eventSymbol)
+}
+
+// SetInternedEventSymbol points the symbol of the order to the interned value without copying it.
+func (b *Base) SetInternedEventSymbol(_ intern.Access, value *string) {
+	b.eventSymbol = value
 }
 
 func (b *Base) EventTime() int64 {
@@ -227,17 +234,17 @@ func (b *Base) SetSide(value side.Side) {
 }
 
 func (b *Base) Time() int64 {
-	return ((b.index >> 32) * 1000) + ((b.index >> 22) & 0x3ff)
+	return ((b.timeSequence >> 32) * 1000) + ((b.timeSequence >> 22) & 0x3ff)
 }
 
 func (b *Base) SetTime(value int64) {
-	b.index = (timeutil.GetSecondsFromTime(value) << 32) |
-		int64(timeutil.GetMillisFromTime(value)<<22) |
+	b.timeSequence = (timeutil.GetSecondsFromTime(value) << 32) |
+		int64(timeutil.GetMillisFromTime(value))<<22 |
 		b.Sequence()
 }
 
 func (b *Base) Sequence() int64 {
-	return b.index & maxSequence
+	return b.timeSequence & maxSequence
 }
 
 func (b *Base) SetSequence(value int64) error {
@@ -245,7 +252,7 @@ func (b *Base) SetSequence(value int64) error {
 		return fmt.Errorf("Sequence(%d) is < 0 or > MaxSequence(%d)", value, maxSequence)
 	}
 
-	b.index = (b.index & ^maxSequence) | value
+	b.timeSequence = (b.timeSequence & ^maxSequence) | value
 	return nil
 }
 
@@ -338,3 +345,34 @@ func (b *Base) baseFieldsToString() string {
 		", tradePrice=" + formatutil.FormatFloat64(b.TradePrice()) +
 		", tradeSize=" + formatutil.FormatFloat64(b.TradeSize())
 }
+
+// Clone returns a copy of the order sharing no memory with it.
+func (b *Base) Clone() *Base {
+	result := *b
+	result.eventSymbol = eventutil.CloneString(b.eventSymbol)
+	return &result
+}
+
+// Equal returns whether all fields of the orders are equal, NaN prices and sizes are equal.
+func (b *Base) Equal(other *Base) bool {
+	if b == nil || other == nil {
+		return b == other
+	}
+	return eventutil.EqualStrings(b.eventSymbol, other.eventSymbol) &&
+		b.eventTime == other.eventTime &&
+		b.eventFlags == other.eventFlags &&
+		b.index == other.index &&
+		b.timeSequence == other.timeSequence &&
+		b.timeNanoPart == other.timeNanoPart &&
+		b.actionTime == other.actionTime &&
+		b.orderId == other.orderId &&
+		b.auxOrderId == other.auxOrderId &&
+		eventutil.EqualFloats(b.price, other.price) &&
+		eventutil.EqualFloats(b.size, other.size) &&
+		eventutil.EqualFloats(b.executedSize, other.executedSize) &&
+		b.count == other.count &&
+		b.flags == other.flags &&
+		b.tradeId == other.tradeId &&
+		eventutil.EqualFloats(b.tradePrice, other.tradePrice) &&
+		eventutil.EqualFloats(b.tradeSize, other.tradeSize)
+}
@@ -0,0 +1,260 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+)
+
+// maxSourceIndex bounds indices of orders built with a source, the source takes the highest bits.
+const maxSourceIndex = (1 << 32) - 1
+
+// baseBuilder sets fields of Base, its methods return the builder B embedding it for chaining.
+// The source is packed into the index of built orders, so Source and Index may be called in any order.
+type baseBuilder[B any] struct {
+	self    B
+	order   *Base
+	source  *Source
+	checker eventutil.Checker
+}
+
+func (b *baseBuilder[B]) EventTime(eventTime int64) B {
+	b.order.SetEventTime(eventTime)
+	return b.self
+}
+
+func (b *baseBuilder[B]) EventFlags(eventFlags int32) B {
+	b.order.SetEventFlags(eventFlags)
+	return b.self
+}
+
+// Source sets the source of orders, it must be publishable for the type of built orders.
+func (b *baseBuilder[B]) Source(source *Source) B {
+	if source == nil {
+		b.checker.Check(errors.New("nil source"))
+	}
+	b.source = source
+	return b.self
+}
+
+// Index sets the index of orders, without Source the highest bits of the index are the source.
+func (b *baseBuilder[B]) Index(index int64) B {
+	b.checker.Check(b.order.SetIndex(index))
+	return b.self
+}
+
+func (b *baseBuilder[B]) Time(time int64) B {
+	b.order.SetTime(time)
+	return b.self
+}
+
+// TimeNanos sets the time and the time nano part.
+func (b *baseBuilder[B]) TimeNanos(timeNanos int64) B {
+	b.order.SetTimeNanos(timeNanos)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Sequence(sequence int64) B {
+	b.checker.Check(b.order.SetSequence(sequence))
+	return b.self
+}
+
+func (b *baseBuilder[B]) TimeNanoPart(timeNanoPart int32) B {
+	b.checker.Check(eventutil.CheckTimeNanoPart(timeNanoPart))
+	b.order.SetTimeNanoPart(timeNanoPart)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Action(action Action) B {
+	b.order.SetAction(action)
+	return b.self
+}
+
+func (b *baseBuilder[B]) ActionTime(actionTime int64) B {
+	b.order.SetActionTime(actionTime)
+	return b.self
+}
+
+func (b *baseBuilder[B]) OrderId(orderId int64) B {
+	b.order.SetOrderId(orderId)
+	return b.self
+}
+
+func (b *baseBuilder[B]) AuxOrderId(auxOrderId int64) B {
+	b.order.SetAuxOrderId(auxOrderId)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Price(price float64) B {
+	b.order.SetPrice(price)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Size(size float64) B {
+	b.order.SetSize(size)
+	return b.self
+}
+
+func (b *baseBuilder[B]) ExecutedSize(executedSize float64) B {
+	b.order.SetExecutedSize(executedSize)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Count(count int64) B {
+	b.order.SetCount(count)
+	return b.self
+}
+
+func (b *baseBuilder[B]) ExchangeCode(exchangeCode rune) B {
+	b.checker.Check(b.order.SetExchangeCode(exchangeCode))
+	return b.self
+}
+
+func (b *baseBuilder[B]) Side(side side.Side) B {
+	b.order.SetSide(side)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Scope(scope Scope) B {
+	b.order.SetScope(scope)
+	return b.self
+}
+
+func (b *baseBuilder[B]) TradeId(tradeId int64) B {
+	b.order.SetTradeId(tradeId)
+	return b.self
+}
+
+func (b *baseBuilder[B]) TradePrice(tradePrice float64) B {
+	b.order.SetTradePrice(tradePrice)
+	return b.self
+}
+
+func (b *baseBuilder[B]) TradeSize(tradeSize float64) B {
+	b.order.SetTradeSize(tradeSize)
+	return b.self
+}
+
+// build packs the source into the index of the built order and checks that it is publishable.
+func (b *baseBuilder[B]) build(order *Base, eventType eventcodes.EventCode) error {
+	if err := b.checker.Err(); err != nil {
+		return err
+	}
+	source := b.source
+	if source != nil {
+		if order.Index() > maxSourceIndex {
+			return fmt.Errorf("index %d overlaps the source %s", order.Index(), *source.Name())
+		}
+		order.SetOrderSource(source)
+	} else {
+		var err error
+		if source, err = order.OrderSource(); err != nil {
+			return err
+		}
+	}
+	if !source.IsPublishable(eventType) {
+		return fmt.Errorf("source %s is not publishable for %s events", *source.Name(), eventType)
+	}
+	return nil
+}
+
+// Builder sets fields of an order, invalid values are reported by Build.
+// Build returns a new order every time, so a builder can be reused as a template.
+type Builder struct {
+	baseBuilder[*Builder]
+	event *Order
+}
+
+func NewBuilder(eventSymbol string) *Builder {
+	b := &Builder{event: NewOrder(eventSymbol)}
+	b.baseBuilder = baseBuilder[*Builder]{self: b, order: &b.event.Base}
+	return b
+}
+
+func (b *Builder) MarketMaker(marketMaker string) *Builder {
+	b.event.SetMarketMaker(&marketMaker)
+	return b
+}
+
+// Build returns the order, or the first invalid value set.
+func (b *Builder) Build() (*Order, error) {
+	result := b.event.Clone()
+	if err := b.build(&result.Base, eventcodes.Order); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AnalyticOrderBuilder sets fields of an analytic order, invalid values are reported by Build.
+type AnalyticOrderBuilder struct {
+	baseBuilder[*AnalyticOrderBuilder]
+	event *AnalyticOrder
+}
+
+func NewAnalyticOrderBuilder(eventSymbol string) *AnalyticOrderBuilder {
+	b := &AnalyticOrderBuilder{event: NewAnalyticOrder(eventSymbol)}
+	b.baseBuilder = baseBuilder[*AnalyticOrderBuilder]{self: b, order: &b.event.Base}
+	return b
+}
+
+func (b *AnalyticOrderBuilder) MarketMaker(marketMaker string) *AnalyticOrderBuilder {
+	b.event.SetMarketMaker(&marketMaker)
+	return b
+}
+
+func (b *AnalyticOrderBuilder) IcebergPeakSize(icebergPeakSize float64) *AnalyticOrderBuilder {
+	b.event.SetIcebergPeakSize(icebergPeakSize)
+	return b
+}
+
+func (b *AnalyticOrderBuilder) IcebergHiddenSize(icebergHiddenSize float64) *AnalyticOrderBuilder {
+	b.event.SetIcebergHiddenSize(icebergHiddenSize)
+	return b
+}
+
+func (b *AnalyticOrderBuilder) IcebergExecutedSize(icebergExecutedSize float64) *AnalyticOrderBuilder {
+	b.event.SetIcebergExecutedSize(icebergExecutedSize)
+	return b
+}
+
+func (b *AnalyticOrderBuilder) IcebergType(icebergType IcebergType) *AnalyticOrderBuilder {
+	b.event.SetIcebergType(icebergType)
+	return b
+}
+
+// Build returns the analytic order, or the first invalid value set.
+func (b *AnalyticOrderBuilder) Build() (*AnalyticOrder, error) {
+	result := b.event.Clone()
+	if err := b.build(&result.Base, eventcodes.AnalyticOrder); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SpreadOrderBuilder sets fields of a spread order, invalid values are reported by Build.
+type SpreadOrderBuilder struct {
+	baseBuilder[*SpreadOrderBuilder]
+	event *SpreadOrder
+}
+
+func NewSpreadOrderBuilder(eventSymbol string) *SpreadOrderBuilder {
+	b := &SpreadOrderBuilder{event: NewSpreadOrder(eventSymbol)}
+	b.baseBuilder = baseBuilder[*SpreadOrderBuilder]{self: b, order: &b.event.Base}
+	return b
+}
+
+func (b *SpreadOrderBuilder) SpreadSymbol(spreadSymbol string) *SpreadOrderBuilder {
+	b.event.SetSpreadSymbol(&spreadSymbol)
+	return b
+}
+
+// Build returns the spread order, or the first invalid value set.
+func (b *SpreadOrderBuilder) Build() (*SpreadOrder, error) {
+	result := b.event.Clone()
+	if err := b.build(&result.Base, eventcodes.SpreadOrder); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
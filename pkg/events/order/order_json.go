@@ -0,0 +1,248 @@
+package order
+
+import (
+	"encoding/json"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+)
+
+const (
+	orderEventType         = "Order"
+	analyticOrderEventType = "AnalyticOrder"
+	spreadOrderEventType   = "SpreadOrder"
+)
+
+func init() {
+	events.RegisterEventType(orderEventType, func() json.Unmarshaler {
+		return NewOrder("")
+	})
+	events.RegisterEventType(analyticOrderEventType, func() json.Unmarshaler {
+		return NewAnalyticOrder("")
+	})
+	events.RegisterEventType(spreadOrderEventType, func() json.Unmarshaler {
+		return NewSpreadOrder("")
+	})
+}
+
+// baseJSON is the common part of order events. The source is derived from the index,
+// when it is decoded it replaces the source in the index.
+type baseJSON struct {
+	EventType    string                `json:"eventType"`
+	EventSymbol  *string               `json:"eventSymbol"`
+	EventTime    int64                 `json:"eventTime"`
+	EventFlags   events.JSONEventFlags `json:"eventFlags,omitempty"`
+	Index        int64                 `json:"index"`
+	Source       string                `json:"source"`
+	Time         int64                 `json:"time"`
+	Sequence     int64                 `json:"sequence"`
+	TimeNanoPart int32                 `json:"timeNanoPart"`
+	Action       string                `json:"action"`
+	ActionTime   int64                 `json:"actionTime"`
+	OrderId      int64                 `json:"orderId"`
+	AuxOrderId   int64                 `json:"auxOrderId"`
+	Price        events.JSONFloat      `json:"price"`
+	Size         events.JSONFloat      `json:"size"`
+	ExecutedSize events.JSONFloat      `json:"executedSize"`
+	Count        int64                 `json:"count"`
+	ExchangeCode events.JSONChar       `json:"exchangeCode"`
+	Side         string                `json:"side"`
+	Scope        string                `json:"scope"`
+	TradeId      int64                 `json:"tradeId"`
+	TradePrice   events.JSONFloat      `json:"tradePrice"`
+	TradeSize    events.JSONFloat      `json:"tradeSize"`
+}
+
+type orderJSON struct {
+	baseJSON
+	MarketMaker *string `json:"marketMaker"`
+}
+
+type analyticOrderJSON struct {
+	orderJSON
+	IcebergPeakSize     events.JSONFloat `json:"icebergPeakSize"`
+	IcebergHiddenSize   events.JSONFloat `json:"icebergHiddenSize"`
+	IcebergExecutedSize events.JSONFloat `json:"icebergExecutedSize"`
+	IcebergType         string           `json:"icebergType"`
+}
+
+type spreadOrderJSON struct {
+	baseJSON
+	SpreadSymbol *string `json:"spreadSymbol"`
+}
+
+func (o *Order) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.toJSON(orderEventType))
+}
+
+func (o *Order) UnmarshalJSON(data []byte) error {
+	v := NewOrder("").toJSON(orderEventType)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	result := NewOrder("")
+	if err := result.fromJSON(&v, orderEventType); err != nil {
+		return err
+	}
+	*o = *result
+	return nil
+}
+
+func (o *AnalyticOrder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(analyticOrderJSON{
+		orderJSON:           o.toJSON(analyticOrderEventType),
+		IcebergPeakSize:     events.JSONFloat(o.icebergPeakSize),
+		IcebergHiddenSize:   events.JSONFloat(o.icebergHiddenSize),
+		IcebergExecutedSize: events.JSONFloat(o.icebergExecutedSize),
+		IcebergType:         o.IcebergType().String(),
+	})
+}
+
+func (o *AnalyticOrder) UnmarshalJSON(data []byte) error {
+	defaults := NewAnalyticOrder("")
+	v := analyticOrderJSON{
+		orderJSON:           defaults.toJSON(analyticOrderEventType),
+		IcebergPeakSize:     events.JSONFloat(defaults.icebergPeakSize),
+		IcebergHiddenSize:   events.JSONFloat(defaults.icebergHiddenSize),
+		IcebergExecutedSize: events.JSONFloat(defaults.icebergExecutedSize),
+		IcebergType:         defaults.IcebergType().String(),
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	icebergType, err := events.ParseEnum("iceberg type", v.IcebergType, IcebergType(Undefined), Native, Synthetic)
+	if err != nil {
+		return err
+	}
+	result := NewAnalyticOrder("")
+	if err := result.fromJSON(&v.orderJSON, analyticOrderEventType); err != nil {
+		return err
+	}
+	result.icebergPeakSize = float64(v.IcebergPeakSize)
+	result.icebergHiddenSize = float64(v.IcebergHiddenSize)
+	result.icebergExecutedSize = float64(v.IcebergExecutedSize)
+	result.SetIcebergType(icebergType)
+	*o = *result
+	return nil
+}
+
+func (o *SpreadOrder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(spreadOrderJSON{
+		baseJSON:     o.toJSON(spreadOrderEventType),
+		SpreadSymbol: o.spreadSymbol,
+	})
+}
+
+func (o *SpreadOrder) UnmarshalJSON(data []byte) error {
+	v := spreadOrderJSON{baseJSON: NewSpreadOrder("").toJSON(spreadOrderEventType)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	result := NewSpreadOrder("")
+	if err := result.fromJSON(&v.baseJSON, spreadOrderEventType); err != nil {
+		return err
+	}
+	result.spreadSymbol = v.SpreadSymbol
+	*o = *result
+	return nil
+}
+
+func (o *Order) toJSON(eventType string) orderJSON {
+	return orderJSON{
+		baseJSON:    o.Base.toJSON(eventType),
+		MarketMaker: o.marketMaker,
+	}
+}
+
+func (o *Order) fromJSON(v *orderJSON, eventType string) error {
+	if err := o.Base.fromJSON(&v.baseJSON, eventType); err != nil {
+		return err
+	}
+	o.marketMaker = v.MarketMaker
+	return nil
+}
+
+func (b *Base) toJSON(eventType string) baseJSON {
+	return baseJSON{
+		EventType:    eventType,
+		EventSymbol:  b.eventSymbol,
+		EventTime:    b.eventTime,
+		EventFlags:   events.JSONEventFlags(b.eventFlags),
+		Index:        b.index,
+		Source:       *b.OrderSourceName(),
+		Time:         b.Time(),
+		Sequence:     b.Sequence(),
+		TimeNanoPart: b.timeNanoPart,
+		Action:       b.Action().String(),
+		ActionTime:   b.actionTime,
+		OrderId:      b.orderId,
+		AuxOrderId:   b.auxOrderId,
+		Price:        events.JSONFloat(b.price),
+		Size:         events.JSONFloat(b.size),
+		ExecutedSize: events.JSONFloat(b.executedSize),
+		Count:        b.count,
+		ExchangeCode: events.JSONChar(b.ExchangeCode()),
+		Side:         b.Side().String(),
+		Scope:        b.Scope().String(),
+		TradeId:      b.tradeId,
+		TradePrice:   events.JSONFloat(b.tradePrice),
+		TradeSize:    events.JSONFloat(b.tradeSize),
+	}
+}
+
+func (b *Base) fromJSON(v *baseJSON, eventType string) error {
+	if err := events.CheckEventType(v.EventType, eventType); err != nil {
+		return err
+	}
+	action, err := events.ParseEnum("action", v.Action, Action(ActionUndefined), ActionNew, ActionReplace,
+		ActionModify, ActionDelete, ActionPartial, ActionExecute, ActionTrade, ActionBurst)
+	if err != nil {
+		return err
+	}
+	orderSide, err := events.ParseEnum("side", v.Side, side.Side(side.Undefined), side.Buy, side.Sell)
+	if err != nil {
+		return err
+	}
+	scope, err := events.ParseEnum("scope", v.Scope, Scope(ScopeComposite), ScopeRegional, ScopeAggregate, ScopeOrder)
+	if err != nil {
+		return err
+	}
+	if v.EventSymbol != nil {
+		symbol := *v.EventSymbol
+		b.eventSymbol = &symbol
+	}
+	b.eventTime = v.EventTime
+	b.eventFlags = int32(v.EventFlags)
+	if err := b.SetIndex(v.Index); err != nil {
+		return err
+	}
+	if v.Source != "" {
+		source, err := ValueOfName(v.Source)
+		if err != nil {
+			return err
+		}
+		b.SetOrderSource(source)
+	}
+	b.SetTime(v.Time)
+	if err := b.SetSequence(v.Sequence); err != nil {
+		return err
+	}
+	b.timeNanoPart = v.TimeNanoPart
+	b.SetAction(action)
+	b.actionTime = v.ActionTime
+	b.orderId = v.OrderId
+	b.auxOrderId = v.AuxOrderId
+	b.price = float64(v.Price)
+	b.size = float64(v.Size)
+	b.executedSize = float64(v.ExecutedSize)
+	b.count = v.Count
+	if err := b.SetExchangeCode(rune(v.ExchangeCode)); err != nil {
+		return err
+	}
+	b.SetSide(orderSide)
+	b.SetScope(scope)
+	b.tradeId = v.TradeId
+	b.tradePrice = float64(v.TradePrice)
+	b.tradeSize = float64(v.TradeSize)
+	return nil
+}
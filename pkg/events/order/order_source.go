@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
 	"regexp"
 	"sync"
 )
@@ -165,3 +166,18 @@ func ValueOfName(name string) (*Source, error) {
 func (source Source) Type() events.EventSourceType {
 	return events.OrderSourceType
 }
+
+// IsPublishable returns whether events of the type can be published with the source,
+// sources that are not built-in are not publishable.
+func (source Source) IsPublishable(eventType eventcodes.EventCode) bool {
+	switch eventType {
+	case eventcodes.Order:
+		return source.pubFlags&pubOrder != 0
+	case eventcodes.AnalyticOrder:
+		return source.pubFlags&pubAnalyticOrder != 0
+	case eventcodes.SpreadOrder:
+		return source.pubFlags&pubSpreadOrder != 0
+	default:
+		return false
+	}
+}
@@ -1,7 +1,9 @@
 package order
 
 import (
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 )
 
@@ -19,7 +21,12 @@ func (o *SpreadOrder) SpreadSymbol() *string {
 }
 
 func (o *SpreadOrder) SetSpreadSymbol(spreadSymbol *string) {
-	o.spreadSymbol = spreadSymbol
+	eventutil.SetString(&o.spreadSymbol, spreadSymbol)
+}
+
+// SetInternedSpreadSymbol points the spread symbol to the interned value without copying it.
+func (o *SpreadOrder) SetInternedSpreadSymbol(_ intern.Access, value *string) {
+	o.spreadSymbol = value
 }
 
 func (o *SpreadOrder) Type() eventcodes.EventCode {
@@ -31,3 +38,14 @@ func (o *SpreadOrder) String() string {
 		", spreadSymbol=" + formatutil.FormatString(o.SpreadSymbol()) +
 		"}"
 }
+
+func (o *SpreadOrder) Clone() *SpreadOrder {
+	return &SpreadOrder{Base: *o.Base.Clone(), spreadSymbol: eventutil.CloneString(o.spreadSymbol)}
+}
+
+func (o *SpreadOrder) Equal(other *SpreadOrder) bool {
+	if o == nil || other == nil {
+		return o == other
+	}
+	return o.Base.Equal(&other.Base) && eventutil.EqualStrings(o.spreadSymbol, other.spreadSymbol)
+}
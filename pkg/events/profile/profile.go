@@ -1,7 +1,9 @@
 package profile
 
 import (
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
 	"math"
@@ -69,7 +71,12 @@ func (p *Profile) EventSymbol() *string {
 }
 
 func (p *Profile) SetEventSymbol(eventSymbol *string) {
-	p.eventSymbol = eventSymbol
+	eventutil.SetString(&p.eventSymbol, eventSymbol)
+}
+
+// SetInternedEventSymbol points the symbol to the interned value without copying it.
+func (p *Profile) SetInternedEventSymbol(_ intern.Access, value *string) {
+	p.eventSymbol = value
 }
 
 func (p *Profile) EventTime() int64 {
@@ -85,7 +92,7 @@ func (p *Profile) Description() *string {
 }
 
 func (p *Profile) SetDescription(description *string) {
-	p.description = description
+	eventutil.SetString(&p.description, description)
 }
 
 func (p *Profile) StatusReason() *string {
@@ -93,6 +100,12 @@ func (p *Profile) StatusReason() *string {
 }
 
 func (p *Profile) SetStatusReason(statusReason *string) {
+	eventutil.SetString(&p.statusReason, statusReason)
+}
+
+// SetInternedStrings points the description and the status reason to interned values without copying them.
+func (p *Profile) SetInternedStrings(_ intern.Access, description, statusReason *string) {
+	p.description = description
 	p.statusReason = statusReason
 }
 
@@ -245,3 +258,37 @@ func (p *Profile) String() string {
 		", freeFloat=" + formatutil.FormatFloat64(p.FreeFloat()) +
 		"}"
 }
+
+// Clone returns a copy of the profile sharing no memory with it.
+func (p *Profile) Clone() *Profile {
+	result := *p
+	result.eventSymbol = eventutil.CloneString(p.eventSymbol)
+	result.description = eventutil.CloneString(p.description)
+	result.statusReason = eventutil.CloneString(p.statusReason)
+	return &result
+}
+
+// Equal returns whether all fields of the profiles are equal, NaN values are equal.
+func (p *Profile) Equal(other *Profile) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return eventutil.EqualStrings(p.eventSymbol, other.eventSymbol) &&
+		p.eventTime == other.eventTime &&
+		eventutil.EqualStrings(p.description, other.description) &&
+		eventutil.EqualStrings(p.statusReason, other.statusReason) &&
+		p.haltStartTime == other.haltStartTime &&
+		p.haltEndTime == other.haltEndTime &&
+		eventutil.EqualFloats(p.highLimitPrice, other.highLimitPrice) &&
+		eventutil.EqualFloats(p.lowLimitPrice, other.lowLimitPrice) &&
+		eventutil.EqualFloats(p.high52WeekPrice, other.high52WeekPrice) &&
+		eventutil.EqualFloats(p.low52WeekPrice, other.low52WeekPrice) &&
+		eventutil.EqualFloats(p.beta, other.beta) &&
+		eventutil.EqualFloats(p.earningsPerShare, other.earningsPerShare) &&
+		eventutil.EqualFloats(p.dividendFrequency, other.dividendFrequency) &&
+		eventutil.EqualFloats(p.exDividendAmount, other.exDividendAmount) &&
+		p.exDividendDayId == other.exDividendDayId &&
+		eventutil.EqualFloats(p.shares, other.shares) &&
+		eventutil.EqualFloats(p.freeFloat, other.freeFloat) &&
+		p.flags == other.flags
+}
@@ -0,0 +1,106 @@
+package profile
+
+// Builder sets fields of a profile. Build returns a new profile every time,
+// so a builder can be reused as a template.
+type Builder struct {
+	profile *Profile
+}
+
+func NewBuilder(eventSymbol string) *Builder {
+	return &Builder{profile: NewProfile(eventSymbol)}
+}
+
+func (b *Builder) EventTime(eventTime int64) *Builder {
+	b.profile.SetEventTime(eventTime)
+	return b
+}
+
+func (b *Builder) Description(description string) *Builder {
+	b.profile.SetDescription(&description)
+	return b
+}
+
+func (b *Builder) StatusReason(statusReason string) *Builder {
+	b.profile.SetStatusReason(&statusReason)
+	return b
+}
+
+func (b *Builder) ShortSaleRestriction(shortSaleRestriction ShortSaleRestriction) *Builder {
+	b.profile.SetShortSaleRestriction(shortSaleRestriction)
+	return b
+}
+
+func (b *Builder) TradingStatus(tradingStatus TradingStatus) *Builder {
+	b.profile.SetTradingStatus(tradingStatus)
+	return b
+}
+
+func (b *Builder) HaltStartTime(haltStartTime int64) *Builder {
+	b.profile.SetHaltStartTime(haltStartTime)
+	return b
+}
+
+func (b *Builder) HaltEndTime(haltEndTime int64) *Builder {
+	b.profile.SetHaltEndTime(haltEndTime)
+	return b
+}
+
+func (b *Builder) HighLimitPrice(highLimitPrice float64) *Builder {
+	b.profile.SetHighLimitPrice(highLimitPrice)
+	return b
+}
+
+func (b *Builder) LowLimitPrice(lowLimitPrice float64) *Builder {
+	b.profile.SetLowLimitPrice(lowLimitPrice)
+	return b
+}
+
+func (b *Builder) High52WeekPrice(high52WeekPrice float64) *Builder {
+	b.profile.SetHigh52WeekPrice(high52WeekPrice)
+	return b
+}
+
+func (b *Builder) Low52WeekPrice(low52WeekPrice float64) *Builder {
+	b.profile.SetLow52WeekPrice(low52WeekPrice)
+	return b
+}
+
+func (b *Builder) Beta(beta float64) *Builder {
+	b.profile.SetBeta(beta)
+	return b
+}
+
+func (b *Builder) EarningsPerShare(earningsPerShare float64) *Builder {
+	b.profile.SetEarningsPerShare(earningsPerShare)
+	return b
+}
+
+func (b *Builder) DividendFrequency(dividendFrequency float64) *Builder {
+	b.profile.SetDividendFrequency(dividendFrequency)
+	return b
+}
+
+func (b *Builder) ExDividendAmount(exDividendAmount float64) *Builder {
+	b.profile.SetExDividendAmount(exDividendAmount)
+	return b
+}
+
+func (b *Builder) ExDividendDayId(exDividendDayId int32) *Builder {
+	b.profile.SetExDividendDayId(exDividendDayId)
+	return b
+}
+
+func (b *Builder) Shares(shares float64) *Builder {
+	b.profile.SetShares(shares)
+	return b
+}
+
+func (b *Builder) FreeFloat(freeFloat float64) *Builder {
+	b.profile.SetFreeFloat(freeFloat)
+	return b
+}
+
+// Build returns the profile, the error is always nil and kept for symmetry with builders of other events.
+func (b *Builder) Build() (*Profile, error) {
+	return b.profile.Clone(), nil
+}
@@ -0,0 +1,112 @@
+package profile
+
+import (
+	"encoding/json"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+const eventType = "Profile"
+
+func init() {
+	events.RegisterEventType(eventType, func() json.Unmarshaler {
+		return NewProfile("")
+	})
+}
+
+type profileJSON struct {
+	EventType            string           `json:"eventType"`
+	EventSymbol          *string          `json:"eventSymbol"`
+	EventTime            int64            `json:"eventTime"`
+	Description          *string          `json:"description"`
+	ShortSaleRestriction string           `json:"shortSaleRestriction"`
+	TradingStatus        string           `json:"tradingStatus"`
+	StatusReason         *string          `json:"statusReason"`
+	HaltStartTime        int64            `json:"haltStartTime"`
+	HaltEndTime          int64            `json:"haltEndTime"`
+	HighLimitPrice       events.JSONFloat `json:"highLimitPrice"`
+	LowLimitPrice        events.JSONFloat `json:"lowLimitPrice"`
+	High52WeekPrice      events.JSONFloat `json:"high52WeekPrice"`
+	Low52WeekPrice       events.JSONFloat `json:"low52WeekPrice"`
+	Beta                 events.JSONFloat `json:"beta"`
+	EarningsPerShare     events.JSONFloat `json:"earningsPerShare"`
+	DividendFrequency    events.JSONFloat `json:"dividendFrequency"`
+	ExDividendAmount     events.JSONFloat `json:"exDividendAmount"`
+	ExDividendDayId      int32            `json:"exDividendDayId"`
+	Shares               events.JSONFloat `json:"shares"`
+	FreeFloat            events.JSONFloat `json:"freeFloat"`
+}
+
+func (p *Profile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.toJSON())
+}
+
+func (p *Profile) UnmarshalJSON(data []byte) error {
+	v := NewProfile("").toJSON()
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := events.CheckEventType(v.EventType, eventType); err != nil {
+		return err
+	}
+	ssr, err := events.ParseEnum("short sale restriction", v.ShortSaleRestriction,
+		ShortSaleRestriction(UndefinedShortSaleRestriction), ActiveShortSaleRestriction, InactiveShortSaleRestriction)
+	if err != nil {
+		return err
+	}
+	status, err := events.ParseEnum("trading status", v.TradingStatus,
+		TradingStatus(UndefinedTradingStatus), HaltedTradingStatus, ActiveTradingStatus)
+	if err != nil {
+		return err
+	}
+	symbol := ""
+	if v.EventSymbol != nil {
+		symbol = *v.EventSymbol
+	}
+	result := NewProfile(symbol)
+	result.eventTime = v.EventTime
+	result.description = v.Description
+	result.SetShortSaleRestriction(ssr)
+	result.SetTradingStatus(status)
+	result.statusReason = v.StatusReason
+	result.haltStartTime = v.HaltStartTime
+	result.haltEndTime = v.HaltEndTime
+	result.highLimitPrice = float64(v.HighLimitPrice)
+	result.lowLimitPrice = float64(v.LowLimitPrice)
+	result.high52WeekPrice = float64(v.High52WeekPrice)
+	result.low52WeekPrice = float64(v.Low52WeekPrice)
+	result.beta = float64(v.Beta)
+	result.earningsPerShare = float64(v.EarningsPerShare)
+	result.dividendFrequency = float64(v.DividendFrequency)
+	result.exDividendAmount = float64(v.ExDividendAmount)
+	result.exDividendDayId = v.ExDividendDayId
+	result.shares = float64(v.Shares)
+	result.freeFloat = float64(v.FreeFloat)
+	*p = *result
+	return nil
+}
+
+func (p *Profile) toJSON() profileJSON {
+	return profileJSON{
+		EventType:            eventType,
+		EventSymbol:          p.eventSymbol,
+		EventTime:            p.eventTime,
+		Description:          p.description,
+		ShortSaleRestriction: p.ShortSaleRestriction().String(),
+		TradingStatus:        p.TradingStatus().String(),
+		StatusReason:         p.statusReason,
+		HaltStartTime:        p.haltStartTime,
+		HaltEndTime:          p.haltEndTime,
+		HighLimitPrice:       events.JSONFloat(p.highLimitPrice),
+		LowLimitPrice:        events.JSONFloat(p.lowLimitPrice),
+		High52WeekPrice:      events.JSONFloat(p.high52WeekPrice),
+		Low52WeekPrice:       events.JSONFloat(p.low52WeekPrice),
+		Beta:                 events.JSONFloat(p.beta),
+		EarningsPerShare:     events.JSONFloat(p.earningsPerShare),
+		DividendFrequency:    events.JSONFloat(p.dividendFrequency),
+		ExDividendAmount:     events.JSONFloat(p.exDividendAmount),
+		ExDividendDayId:      p.exDividendDayId,
+		Shares:               events.JSONFloat(p.shares),
+		FreeFloat:            events.JSONFloat(p.freeFloat),
+	}
+}
@@ -1,9 +1,25 @@
 package profile
 
-import "github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+import (
+	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+)
 
 type ShortSaleRestriction int64
 
+func (s ShortSaleRestriction) String() string {
+	switch s {
+	case UndefinedShortSaleRestriction:
+		return "Undefined"
+	case ActiveShortSaleRestriction:
+		return "Active"
+	case InactiveShortSaleRestriction:
+		return "Inactive"
+	default:
+		return fmt.Sprintf("ShortSaleRestriction: Wrong value %d", s)
+	}
+}
+
 const (
 	UndefinedShortSaleRestriction = 0
 	ActiveShortSaleRestriction    = 1
@@ -1,9 +1,25 @@
 package profile
 
-import "github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+import (
+	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+)
 
 type TradingStatus int64
 
+func (s TradingStatus) String() string {
+	switch s {
+	case UndefinedTradingStatus:
+		return "Undefined"
+	case HaltedTradingStatus:
+		return "Halted"
+	case ActiveTradingStatus:
+		return "Active"
+	default:
+		return fmt.Sprintf("TradingStatus: Wrong value %d", s)
+	}
+}
+
 const (
 	UndefinedTradingStatus = 0
 	HaltedTradingStatus    = 1
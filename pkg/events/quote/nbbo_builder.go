@@ -0,0 +1,334 @@
+package quote
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/common"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/symbols"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+type MarketState int32
+
+const (
+	MarketNormal MarketState = iota
+	// MarketLocked means that the best bid price equals the best ask price.
+	MarketLocked
+	// MarketCrossed means that the best bid price is greater than the best ask price.
+	MarketCrossed
+)
+
+func (s MarketState) String() string {
+	switch s {
+	case MarketNormal:
+		return "Normal"
+	case MarketLocked:
+		return "Locked"
+	case MarketCrossed:
+		return "Crossed"
+	default:
+		return fmt.Sprintf("MarketState: Wrong value %d", s)
+	}
+}
+
+// MarketStateOf returns the state of the market of the quote. One-sided quotes are always normal.
+func MarketStateOf(q *Quote) MarketState {
+	switch {
+	case math.IsNaN(q.bidPrice) || math.IsNaN(q.askPrice) || q.bidPrice < q.askPrice:
+		return MarketNormal
+	case q.bidPrice == q.askPrice:
+		return MarketLocked
+	default:
+		return MarketCrossed
+	}
+}
+
+type nbboSide struct {
+	price float64
+	size  float64
+	time  int64
+}
+
+type nbboExchange struct {
+	quote *Quote
+	bid   nbboSide
+	ask   nbboSide
+}
+
+type nbboState struct {
+	symbol    string
+	exchanges map[rune]*nbboExchange
+	reference int64
+	eventTime int64
+	nbbo      *Quote
+}
+
+// NBBOBuilder builds composite quotes with the national best bid and offer from regional quotes.
+//
+// Regional quotes are stored per exchange of the regional symbol (like "AAPL&Q"), and quotes with NaN price
+// clear the side of the exchange. Composite quotes (like "AAPL") are ignored, since their exchange codes
+// refer to the best exchanges of the composite quote rather than to the exchange that sent them. The composite quote has the best prices, the total size of all exchanges at the best price
+// and the exchange that was the first to quote the best price. Its time is the latest time of those quotes and
+// its sequence distinguishes composite quotes within the same millisecond.
+//
+// When a stale timeout is set, sides of exchanges which were not updated for longer than the timeout comparing
+// to the latest regional time of the symbol (or the time passed to Expire) are excluded from the composite quote.
+type NBBOBuilder struct {
+	staleTimeout timeutil.TimePeriod
+	listeners    []common.EventListener
+
+	mu     sync.Mutex
+	states map[string]*nbboState
+}
+
+func NewNBBOBuilder() *NBBOBuilder {
+	return &NBBOBuilder{
+		staleTimeout: timeutil.UnlimitedPeriod,
+		states:       map[string]*nbboState{},
+	}
+}
+
+// SetStaleTimeout sets the time after which a side of an exchange is considered stale.
+// timeutil.UnlimitedPeriod (the default) disables staleness.
+func (b *NBBOBuilder) SetStaleTimeout(timeout timeutil.TimePeriod) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.staleTimeout = timeout
+}
+
+func (b *NBBOBuilder) AddListener(listener common.EventListener) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.listeners = append(b.listeners, listener)
+}
+
+// Update processes the quotes and notifies the listeners about changed composite quotes.
+// It allows to attach the builder to a subscription as a listener.
+func (b *NBBOBuilder) Update(eventsList []interface{}) {
+	quotes := b.Process(eventsList)
+	if len(quotes) == 0 {
+		return
+	}
+	result := make([]interface{}, len(quotes))
+	for i, q := range quotes {
+		result[i] = q
+	}
+	b.mu.Lock()
+	listeners := b.listeners
+	b.mu.Unlock()
+	for _, listener := range listeners {
+		listener.Update(result)
+	}
+}
+
+// Process processes the regional quotes and returns changed composite quotes.
+// Events of other types and quotes with non-regional symbols are ignored.
+func (b *NBBOBuilder) Process(eventsList []interface{}) []*Quote {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var changed []*nbboState
+	for _, event := range eventsList {
+		q, ok := event.(*Quote)
+		if !ok {
+			continue
+		}
+		if state := b.process(q); state != nil && b.rebuild(state) && !containsState(changed, state) {
+			changed = append(changed, state)
+		}
+	}
+	return snapshots(changed)
+}
+
+// Expire excludes sides of exchanges which are stale at the time and returns changed composite quotes.
+func (b *NBBOBuilder) Expire(timeMillis int64) []*Quote {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var changed []*nbboState
+	for _, state := range b.states {
+		state.reference = mathutil.MaxInt(state.reference, timeMillis)
+		if b.rebuild(state) {
+			changed = append(changed, state)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		return changed[i].symbol < changed[j].symbol
+	})
+	return snapshots(changed)
+}
+
+// NBBO returns a copy of the current composite quote of the symbol or nil if there is no quote.
+func (b *NBBOBuilder) NBBO(symbol string) *Quote {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.states[symbol]
+	if !ok || state.nbbo == nil {
+		return nil
+	}
+	return state.nbbo.Clone()
+}
+
+// MarketState returns the state of the market of the symbol.
+func (b *NBBOBuilder) MarketState(symbol string) MarketState {
+	q := b.NBBO(symbol)
+	if q == nil {
+		return MarketNormal
+	}
+	return MarketStateOf(q)
+}
+
+// Regional returns copies of the latest regional quotes of the symbol ordered by exchange.
+func (b *NBBOBuilder) Regional(symbol string) []*Quote {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.states[symbol]
+	if !ok {
+		return nil
+	}
+	codes := make([]rune, 0, len(state.exchanges))
+	for code := range state.exchanges {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i] < codes[j]
+	})
+	result := make([]*Quote, len(codes))
+	for i, code := range codes {
+		result[i] = state.exchanges[code].quote.Clone()
+	}
+	return result
+}
+
+func (b *NBBOBuilder) process(q *Quote) *nbboState {
+	if q.eventSymbol == nil {
+		return nil
+	}
+	regional, err := symbols.ParseRegional(*q.eventSymbol)
+	if err != nil {
+		return nil
+	}
+	symbol := regional.Base()
+	state, ok := b.states[symbol]
+	if !ok {
+		state = &nbboState{symbol: symbol, exchanges: map[rune]*nbboExchange{}}
+		b.states[symbol] = state
+	}
+	ex := state.exchange(regional.Exchange())
+	ex.quote = q.Clone()
+	ex.bid = nbboSide{price: q.bidPrice, size: q.bidSize, time: sideTime(q.bidTime, q.eventTime)}
+	ex.ask = nbboSide{price: q.askPrice, size: q.askSize, time: sideTime(q.askTime, q.eventTime)}
+	state.reference = mathutil.MaxInt(state.reference, mathutil.MaxInt(ex.bid.time, ex.ask.time))
+	state.eventTime = mathutil.MaxInt(state.eventTime, q.eventTime)
+	return state
+}
+
+// rebuild recomputes the composite quote of the state and returns whether it has changed.
+func (b *NBBOBuilder) rebuild(state *nbboState) bool {
+	bid, bidExchange := b.best(state, true)
+	ask, askExchange := b.best(state, false)
+	previous := state.nbbo
+	if previous == nil && math.IsNaN(bid.price) && math.IsNaN(ask.price) {
+		return false
+	}
+	if previous != nil && sameSide(bid, previous.bidPrice, previous.bidSize) && bidExchange == previous.bidExchangeCode &&
+		sameSide(ask, previous.askPrice, previous.askSize) && askExchange == previous.askExchangeCode {
+		return false
+	}
+	nbbo := NewQuote(state.symbol)
+	nbbo.eventTime = state.eventTime
+	nbbo.bidExchangeCode, nbbo.bidPrice, nbbo.bidSize = bidExchange, bid.price, bid.size
+	nbbo.askExchangeCode, nbbo.askPrice, nbbo.askSize = askExchange, ask.price, ask.size
+	nbbo.SetBidTime(bid.time)
+	nbbo.SetAskTime(ask.time)
+	if previous != nil && previous.Time() == nbbo.Time() {
+		nbbo.SetSequence(mathutil.MinInt(previous.Sequence()+1, maxSequence))
+	}
+	state.nbbo = nbbo
+	return true
+}
+
+// best returns the best side of all exchanges with the total size at the best price, the latest time of
+// the best price and the exchange with the earliest time of the best price.
+func (b *NBBOBuilder) best(state *nbboState, bid bool) (nbboSide, rune) {
+	result, exchange, exchangeTime := nbboSide{price: math.NaN(), size: math.NaN()}, rune(0), int64(0)
+	for code, ex := range state.exchanges {
+		side := ex.ask
+		if bid {
+			side = ex.bid
+		}
+		if math.IsNaN(side.price) || b.isStale(state, side) {
+			continue
+		}
+		better := math.IsNaN(result.price) || bid && side.price > result.price || !bid && side.price < result.price
+		if better {
+			result = nbboSide{price: side.price, size: math.NaN()}
+			exchange, exchangeTime = code, side.time
+		} else if side.price != result.price {
+			continue
+		} else if side.time < exchangeTime || side.time == exchangeTime && code < exchange {
+			exchange, exchangeTime = code, side.time
+		}
+		if !math.IsNaN(side.size) {
+			if math.IsNaN(result.size) {
+				result.size = 0
+			}
+			result.size += side.size
+		}
+		result.time = mathutil.MaxInt(result.time, side.time)
+	}
+	return result, exchange
+}
+
+func (b *NBBOBuilder) isStale(state *nbboState, side nbboSide) bool {
+	if b.staleTimeout.IsUnlimited() || side.time == 0 {
+		return false
+	}
+	return side.time < state.reference-b.staleTimeout.Millis()
+}
+
+func (s *nbboState) exchange(code rune) *nbboExchange {
+	ex, ok := s.exchanges[code]
+	if !ok {
+		ex = &nbboExchange{
+			bid: nbboSide{price: math.NaN(), size: math.NaN()},
+			ask: nbboSide{price: math.NaN(), size: math.NaN()},
+		}
+		s.exchanges[code] = ex
+	}
+	return ex
+}
+
+func sideTime(time, eventTime int64) int64 {
+	if time != 0 {
+		return time
+	}
+	return eventTime
+}
+
+func sameSide(side nbboSide, price, size float64) bool {
+	return sameValue(side.price, price) && sameValue(side.size, size)
+}
+
+func sameValue(a, b float64) bool {
+	return a == b || math.IsNaN(a) && math.IsNaN(b)
+}
+
+func containsState(states []*nbboState, state *nbboState) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+func snapshots(states []*nbboState) []*Quote {
+	result := make([]*Quote, len(states))
+	for i, s := range states {
+		result[i] = s.nbbo.Clone()
+	}
+	return result
+}
@@ -0,0 +1,191 @@
+package quote
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+const baseTime = int64(1700000000000)
+
+func regional(symbol string, exchange rune, time int64, bidPrice, bidSize, askPrice, askSize float64) *Quote {
+	q := NewQuote(symbol + "&" + string(exchange))
+	q.SetBidExchangeCode(exchange)
+	q.SetAskExchangeCode(exchange)
+	q.SetBidTime(time)
+	q.SetAskTime(time)
+	q.SetBidPrice(bidPrice)
+	q.SetBidSize(bidSize)
+	q.SetAskPrice(askPrice)
+	q.SetAskSize(askSize)
+	return q
+}
+
+func checkNBBO(t *testing.T, q *Quote, bidExchange rune, bidPrice, bidSize float64, askExchange rune, askPrice, askSize float64) {
+	t.Helper()
+	if q == nil {
+		t.Fatalf(`NBBO should not be nil`)
+	}
+	if q.BidExchangeCode() != bidExchange || !sameValue(q.BidPrice(), bidPrice) || !sameValue(q.BidSize(), bidSize) {
+		t.Errorf(`Bid should be %c %v x %v. But it equals %c %v x %v`,
+			bidExchange, bidPrice, bidSize, q.BidExchangeCode(), q.BidPrice(), q.BidSize())
+	}
+	if q.AskExchangeCode() != askExchange || !sameValue(q.AskPrice(), askPrice) || !sameValue(q.AskSize(), askSize) {
+		t.Errorf(`Ask should be %c %v x %v. But it equals %c %v x %v`,
+			askExchange, askPrice, askSize, q.AskExchangeCode(), q.AskPrice(), q.AskSize())
+	}
+}
+
+func TestNBBOBestPricesAndSizes(t *testing.T) {
+	b := NewNBBOBuilder()
+	result := b.Process([]interface{}{
+		regional("AAPL", 'Q', baseTime, 100.0, 10, 100.2, 5),
+		regional("AAPL", 'X', baseTime+1, 100.1, 3, 100.2, 7),
+		regional("AAPL", 'Z', baseTime+2, 100.1, 4, 100.3, 1),
+	})
+	if len(result) != 1 {
+		t.Fatalf(`Result length should be %v. But it equals %v`, 1, len(result))
+	}
+	checkNBBO(t, result[0], 'X', 100.1, 7, 'Q', 100.2, 12)
+	if *result[0].EventSymbol() != "AAPL" {
+		t.Errorf(`EventSymbol should be %v. But it equals %v`, "AAPL", *result[0].EventSymbol())
+	}
+	if result[0].BidTime() != baseTime+2 || result[0].AskTime() != baseTime+1 || result[0].Time() != baseTime+2 {
+		t.Errorf(`Times should be %v and %v. But they equal %v and %v`,
+			baseTime+2, baseTime+1, result[0].BidTime(), result[0].AskTime())
+	}
+	if len(b.Regional("AAPL")) != 3 {
+		t.Errorf(`Regional quotes count should be %v. But it equals %v`, 3, len(b.Regional("AAPL")))
+	}
+
+	// the best exchange leaves the bid
+	result = b.Process([]interface{}{regional("AAPL", 'X', baseTime+3, math.NaN(), math.NaN(), 100.2, 7)})
+	checkNBBO(t, result[0], 'Z', 100.1, 4, 'Q', 100.2, 12)
+
+	// the same composite quote is not emitted again
+	result = b.Process([]interface{}{regional("AAPL", 'Z', baseTime+4, 100.1, 4, 100.4, 1)})
+	if len(result) != 0 {
+		t.Errorf(`Result length should be %v. But it equals %v`, 0, len(result))
+	}
+	if b.NBBO("MSFT") != nil {
+		t.Errorf(`NBBO of unknown symbol should be nil`)
+	}
+}
+
+func TestNBBOIgnoresQuotesWithoutExchange(t *testing.T) {
+	b := NewNBBOBuilder()
+	q := NewQuote("AAPL")
+	q.SetBidPrice(100)
+	q.SetAskPrice(101)
+	if result := b.Process([]interface{}{q, "text"}); len(result) != 0 {
+		t.Errorf(`Result length should be %v. But it equals %v`, 0, len(result))
+	}
+	// the exchange code is taken from the symbol
+	q = NewQuote("AAPL&K")
+	q.SetBidPrice(100)
+	q.SetAskPrice(101)
+	result := b.Process([]interface{}{q})
+	checkNBBO(t, result[0], 'K', 100, math.NaN(), 'K', 101, math.NaN())
+}
+
+func TestNBBOIgnoresCompositeQuotes(t *testing.T) {
+	b := NewNBBOBuilder()
+	composite := NewQuote("AAPL")
+	composite.SetBidExchangeCode('X')
+	composite.SetAskExchangeCode('Q')
+	composite.SetBidTime(baseTime + 1)
+	composite.SetAskTime(baseTime + 1)
+	composite.SetBidPrice(99)
+	composite.SetBidSize(100)
+	composite.SetAskPrice(102)
+	composite.SetAskSize(100)
+	result := b.Process([]interface{}{
+		regional("AAPL", 'Q', baseTime, 100.0, 10, 100.2, 5),
+		composite,
+		regional("AAPL", 'X', baseTime+2, 100.1, 3, 100.3, 7),
+	})
+	if len(result) != 1 {
+		t.Fatalf(`Result length should be %v. But it equals %v`, 1, len(result))
+	}
+	checkNBBO(t, result[0], 'X', 100.1, 3, 'Q', 100.2, 5)
+	if len(b.Regional("AAPL")) != 2 {
+		t.Errorf(`Regional quotes count should be %v. But it equals %v`, 2, len(b.Regional("AAPL")))
+	}
+	if result = b.Process([]interface{}{composite}); len(result) != 0 {
+		t.Errorf(`Result length should be %v. But it equals %v`, 0, len(result))
+	}
+}
+
+func TestNBBOSequence(t *testing.T) {
+	b := NewNBBOBuilder()
+	first := b.Process([]interface{}{regional("AAPL", 'Q', baseTime, 100, 1, 101, 1)})[0]
+	second := b.Process([]interface{}{regional("AAPL", 'X', baseTime, 100.5, 1, 101, 1)})[0]
+	third := b.Process([]interface{}{regional("AAPL", 'X', baseTime+1, 100.6, 1, 101, 1)})[0]
+	if first.Sequence() != 0 || second.Sequence() != 1 || third.Sequence() != 0 {
+		t.Errorf(`Sequences should be 0, 1, 0. But they equal %v, %v, %v`,
+			first.Sequence(), second.Sequence(), third.Sequence())
+	}
+	if second.Time() != baseTime || third.Time() != baseTime+1 {
+		t.Errorf(`Times should be %v and %v. But they equal %v and %v`, baseTime, baseTime+1, second.Time(), third.Time())
+	}
+}
+
+func TestNBBOStaleTimeout(t *testing.T) {
+	b := NewNBBOBuilder()
+	b.SetStaleTimeout(5 * timeutil.SECOND)
+	b.Process([]interface{}{
+		regional("AAPL", 'Q', baseTime, 100.5, 1, 101, 1),
+		regional("AAPL", 'X', baseTime+1000, 100, 2, 101.5, 2),
+	})
+	// Q becomes stale when X is updated after the timeout
+	result := b.Process([]interface{}{regional("AAPL", 'X', baseTime+6000, 100, 3, 101.5, 3)})
+	checkNBBO(t, result[0], 'X', 100, 3, 'X', 101.5, 3)
+
+	result = b.Expire(baseTime + 12000)
+	checkNBBO(t, result[0], 0, math.NaN(), math.NaN(), 0, math.NaN(), math.NaN())
+	if len(b.Expire(baseTime+13000)) != 0 {
+		t.Errorf(`Expired NBBO should not be emitted again`)
+	}
+}
+
+func TestNBBOMarketState(t *testing.T) {
+	b := NewNBBOBuilder()
+	b.Process([]interface{}{regional("AAPL", 'Q', baseTime, 100, 1, 101, 1)})
+	if b.MarketState("AAPL") != MarketNormal {
+		t.Errorf(`MarketState should be %v. But it equals %v`, MarketNormal, b.MarketState("AAPL"))
+	}
+	b.Process([]interface{}{regional("AAPL", 'X', baseTime, 101, 1, 102, 1)})
+	if b.MarketState("AAPL") != MarketLocked {
+		t.Errorf(`MarketState should be %v. But it equals %v`, MarketLocked, b.MarketState("AAPL"))
+	}
+	b.Process([]interface{}{regional("AAPL", 'Z', baseTime, 101.5, 1, 103, 1)})
+	if b.MarketState("AAPL") != MarketCrossed {
+		t.Errorf(`MarketState should be %v. But it equals %v`, MarketCrossed, b.MarketState("AAPL"))
+	}
+}
+
+type quoteListener struct {
+	quotes []*Quote
+}
+
+func (l *quoteListener) Update(eventsList []interface{}) {
+	for _, event := range eventsList {
+		l.quotes = append(l.quotes, event.(*Quote))
+	}
+}
+
+func TestNBBOListener(t *testing.T) {
+	b := NewNBBOBuilder()
+	listener := &quoteListener{}
+	b.AddListener(listener)
+	b.Update([]interface{}{
+		regional("AAPL", 'Q', baseTime, 100, 1, 101, 1),
+		regional("MSFT", 'Q', baseTime, 300, 1, 301, 1),
+		regional("AAPL", 'X', baseTime, 100, 1, 101, 1),
+	})
+	if len(listener.quotes) != 2 {
+		t.Fatalf(`Quotes count should be %v. But it equals %v`, 2, len(listener.quotes))
+	}
+	checkNBBO(t, listener.quotes[0], 'Q', 100, 2, 'Q', 101, 2)
+}
@@ -1,7 +1,9 @@
 package quote
 
 import (
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
@@ -45,7 +47,12 @@ func (q *Quote) EventSymbol() *string {
 }
 
 func (q *Quote) SetEventSymbol(eventSymbol string) {
-	*q.eventSymbol = eventSymbol
+	eventutil.SetStringValue(&q.eventSymbol, eventSymbol)
+}
+
+// SetInternedEventSymbol points the symbol to the interned value without copying it.
+func (q *Quote) SetInternedEventSymbol(_ intern.Access, value *string) {
+	q.eventSymbol = value
 }
 
 func (q *Quote) EventTime() int64 {
@@ -174,3 +181,29 @@ func (q *Quote) SetTimeMillisSequence(timeMillisSequence int32) {
 func (q *Quote) recomputeTimeMillisPart() {
 	q.timeMillisSequence = timeutil.GetMillisFromTime(mathutil.MaxInt(q.askTime, q.bidTime))<<22 | q.Sequence()
 }
+
+// Clone returns a copy of the quote sharing no memory with it.
+func (q *Quote) Clone() *Quote {
+	result := *q
+	result.eventSymbol = eventutil.CloneString(q.eventSymbol)
+	return &result
+}
+
+// Equal returns whether all fields of the quotes are equal, NaN prices and sizes are equal.
+func (q *Quote) Equal(other *Quote) bool {
+	if q == nil || other == nil {
+		return q == other
+	}
+	return eventutil.EqualStrings(q.eventSymbol, other.eventSymbol) &&
+		q.eventTime == other.eventTime &&
+		q.timeMillisSequence == other.timeMillisSequence &&
+		q.timeNanoPart == other.timeNanoPart &&
+		q.bidTime == other.bidTime &&
+		q.bidExchangeCode == other.bidExchangeCode &&
+		eventutil.EqualFloats(q.bidPrice, other.bidPrice) &&
+		eventutil.EqualFloats(q.bidSize, other.bidSize) &&
+		q.askTime == other.askTime &&
+		q.askExchangeCode == other.askExchangeCode &&
+		eventutil.EqualFloats(q.askPrice, other.askPrice) &&
+		eventutil.EqualFloats(q.askSize, other.askSize)
+}
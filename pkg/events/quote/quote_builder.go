@@ -0,0 +1,87 @@
+package quote
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+)
+
+// exchangeMask limits exchange codes to ASCII chars.
+const exchangeMask = 0x7f
+
+// Builder sets fields of a quote, invalid values are reported by Build.
+// Build returns a new quote every time, so a builder can be reused as a template.
+type Builder struct {
+	quote   *Quote
+	checker eventutil.Checker
+}
+
+func NewBuilder(eventSymbol string) *Builder {
+	return &Builder{quote: NewQuote(eventSymbol)}
+}
+
+func (b *Builder) EventTime(eventTime int64) *Builder {
+	b.quote.SetEventTime(eventTime)
+	return b
+}
+
+func (b *Builder) Sequence(sequence int32) *Builder {
+	b.checker.Check(eventutil.CheckSequence(int64(sequence)))
+	b.quote.SetSequence(sequence)
+	return b
+}
+
+func (b *Builder) TimeNanoPart(timeNanoPart int32) *Builder {
+	b.checker.Check(eventutil.CheckTimeNanoPart(timeNanoPart))
+	b.quote.SetTimeNanoPart(timeNanoPart)
+	return b
+}
+
+func (b *Builder) BidTime(bidTime int64) *Builder {
+	b.quote.SetBidTime(bidTime)
+	return b
+}
+
+func (b *Builder) BidExchangeCode(bidExchangeCode rune) *Builder {
+	b.checker.Check(mathutil.CheckChar(int64(bidExchangeCode), exchangeMask, "bidExchangeCode"))
+	b.quote.SetBidExchangeCode(bidExchangeCode)
+	return b
+}
+
+func (b *Builder) BidPrice(bidPrice float64) *Builder {
+	b.quote.SetBidPrice(bidPrice)
+	return b
+}
+
+func (b *Builder) BidSize(bidSize float64) *Builder {
+	b.quote.SetBidSize(bidSize)
+	return b
+}
+
+func (b *Builder) AskTime(askTime int64) *Builder {
+	b.quote.SetAskTime(askTime)
+	return b
+}
+
+func (b *Builder) AskExchangeCode(askExchangeCode rune) *Builder {
+	b.checker.Check(mathutil.CheckChar(int64(askExchangeCode), exchangeMask, "askExchangeCode"))
+	b.quote.SetAskExchangeCode(askExchangeCode)
+	return b
+}
+
+func (b *Builder) AskPrice(askPrice float64) *Builder {
+	b.quote.SetAskPrice(askPrice)
+	return b
+}
+
+func (b *Builder) AskSize(askSize float64) *Builder {
+	b.quote.SetAskSize(askSize)
+	return b
+}
+
+// Build returns the quote, or the first invalid value set.
+func (b *Builder) Build() (*Quote, error) {
+	if err := b.checker.Err(); err != nil {
+		return nil, err
+	}
+	return b.quote.Clone(), nil
+}
@@ -0,0 +1,83 @@
+package quote
+
+import (
+	"encoding/json"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+const eventType = "Quote"
+
+func init() {
+	events.RegisterEventType(eventType, func() json.Unmarshaler {
+		return NewQuote("")
+	})
+}
+
+type quoteJSON struct {
+	EventType       string           `json:"eventType"`
+	EventSymbol     *string          `json:"eventSymbol"`
+	EventTime       int64            `json:"eventTime"`
+	Time            int64            `json:"time"`
+	Sequence        int32            `json:"sequence"`
+	TimeNanoPart    int32            `json:"timeNanoPart"`
+	BidTime         int64            `json:"bidTime"`
+	BidExchangeCode events.JSONChar  `json:"bidExchangeCode"`
+	BidPrice        events.JSONFloat `json:"bidPrice"`
+	BidSize         events.JSONFloat `json:"bidSize"`
+	AskTime         int64            `json:"askTime"`
+	AskExchangeCode events.JSONChar  `json:"askExchangeCode"`
+	AskPrice        events.JSONFloat `json:"askPrice"`
+	AskSize         events.JSONFloat `json:"askSize"`
+}
+
+func (q *Quote) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.toJSON())
+}
+
+func (q *Quote) UnmarshalJSON(data []byte) error {
+	v := NewQuote("").toJSON()
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := events.CheckEventType(v.EventType, eventType); err != nil {
+		return err
+	}
+	symbol := ""
+	if v.EventSymbol != nil {
+		symbol = *v.EventSymbol
+	}
+	*q = *NewQuote(symbol)
+	q.eventTime = v.EventTime
+	q.timeNanoPart = v.TimeNanoPart
+	q.bidTime = v.BidTime
+	q.bidExchangeCode = rune(v.BidExchangeCode)
+	q.bidPrice = float64(v.BidPrice)
+	q.bidSize = float64(v.BidSize)
+	q.askTime = v.AskTime
+	q.askExchangeCode = rune(v.AskExchangeCode)
+	q.askPrice = float64(v.AskPrice)
+	q.askSize = float64(v.AskSize)
+	q.timeMillisSequence = timeutil.GetMillisFromTime(v.Time)<<22 | v.Sequence&maxSequence
+	return nil
+}
+
+func (q *Quote) toJSON() quoteJSON {
+	return quoteJSON{
+		EventType:       eventType,
+		EventSymbol:     q.eventSymbol,
+		EventTime:       q.eventTime,
+		Time:            q.Time(),
+		Sequence:        q.Sequence(),
+		TimeNanoPart:    q.timeNanoPart,
+		BidTime:         q.bidTime,
+		BidExchangeCode: events.JSONChar(q.bidExchangeCode),
+		BidPrice:        events.JSONFloat(q.bidPrice),
+		BidSize:         events.JSONFloat(q.bidSize),
+		AskTime:         q.askTime,
+		AskExchangeCode: events.JSONChar(q.askExchangeCode),
+		AskPrice:        events.JSONFloat(q.askPrice),
+		AskSize:         events.JSONFloat(q.askSize),
+	}
+}
@@ -0,0 +1,63 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+)
+
+// timeSequenceEvent packs the time in seconds, the milliseconds and the sequence into one int64.
+type timeSequenceEvent interface {
+	Time() int64
+	SetTime(value int64)
+	Sequence() int64
+	SetSequence(value int64) error
+}
+
+// packedTime has 999 milliseconds, which overflow int32 when shifted by 22 bits.
+const packedTime = int64(1700000000999)
+
+func checkTimeSequence(t *testing.T, name string, event timeSequenceEvent, packed func() int64) {
+	t.Helper()
+	if err := event.SetSequence(12345); err != nil {
+		t.Fatalf(`%s SetSequence should not fail. But it returns %v`, name, err)
+	}
+	event.SetTime(packedTime)
+	if event.Time() != packedTime || event.Sequence() != 12345 {
+		t.Errorf(`%s time and sequence should be %v and %v. But they equal %v and %v`,
+			name, packedTime, 12345, event.Time(), event.Sequence())
+	}
+	expected := (packedTime/1000)<<32 | (packedTime%1000)<<22 | 12345
+	if packed() != expected {
+		t.Errorf(`%s packed time and sequence should be %#x. But it equals %#x`, name, expected, packed())
+	}
+	if err := event.SetSequence(7); err != nil || event.Time() != packedTime || event.Sequence() != 7 {
+		t.Errorf(`%s SetSequence should keep the time %v. But it equals %v`, name, packedTime, event.Time())
+	}
+}
+
+func TestSetTimePacksMillisAndKeepsSequence(t *testing.T) {
+	c := candle.NewCandle("AAPL{=1m}")
+	checkTimeSequence(t, "Candle", c, c.Index)
+	tns := timeandsale.NewTimeAndSale("AAPL")
+	checkTimeSequence(t, "TimeAndSale", tns, tns.Index)
+	tr := trade.NewTrade("AAPL")
+	checkTimeSequence(t, "Trade", tr, tr.TimeSequence)
+}
+
+func TestOrderTimeAndSequenceArePackedIntoTimeSequence(t *testing.T) {
+	o := order.NewOrder("AAPL")
+	o.SetOrderSource(order.NtvL3())
+	_ = o.SetIndex(o.Index() | 42)
+	index := o.Index()
+	checkTimeSequence(t, "Order", o, o.TimeSequence)
+	if o.Index() != index {
+		t.Errorf(`Order index should stay %#x. But it equals %#x`, index, o.Index())
+	}
+	if source, err := o.OrderSource(); err != nil || source.Id() != order.NtvL3().Id() {
+		t.Errorf(`Order source should stay %v. But it equals %v (%v)`, *order.NtvL3().Name(), source, err)
+	}
+}
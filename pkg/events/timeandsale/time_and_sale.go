@@ -2,7 +2,9 @@ package timeandsale
 
 import (
 	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
@@ -67,7 +69,12 @@ func (t *TimeAndSale) EventSymbol() *string {
 }
 
 func (t *TimeAndSale) SetEventSymbol(value string) {
-	*t.eventSymbol = value
+	eventutil.SetStringValue(&t.eventSymbol, value)
+}
+
+// SetInternedEventSymbol points the symbol to the interned value without copying it.
+func (t *TimeAndSale) SetInternedEventSymbol(_ intern.Access, value *string) {
+	t.eventSymbol = value
 }
 
 func (t *TimeAndSale) EventTime() int64 {
@@ -131,7 +138,7 @@ func (t *TimeAndSale) ExchangeSaleConditions() *string {
 }
 
 func (t *TimeAndSale) SetExchangeSaleConditions(value *string) {
-	t.exchangeSaleConditions = value
+	eventutil.SetString(&t.exchangeSaleConditions, value)
 }
 
 func (t *TimeAndSale) Buyer() *string {
@@ -139,7 +146,7 @@ func (t *TimeAndSale) Buyer() *string {
 }
 
 func (t *TimeAndSale) SetBuyer(value *string) {
-	t.buyer = value
+	eventutil.SetString(&t.buyer, value)
 }
 
 func (t *TimeAndSale) Seller() *string {
@@ -147,7 +154,14 @@ func (t *TimeAndSale) Seller() *string {
 }
 
 func (t *TimeAndSale) SetSeller(value *string) {
-	t.seller = value
+	eventutil.SetString(&t.seller, value)
+}
+
+// SetInternedStrings points the sale conditions, the buyer and the seller to interned values without copying them.
+func (t *TimeAndSale) SetInternedStrings(_ intern.Access, exchangeSaleConditions, buyer, seller *string) {
+	t.exchangeSaleConditions = exchangeSaleConditions
+	t.buyer = buyer
+	t.seller = seller
 }
 
 func (t *TimeAndSale) EventFlags() int32 {
@@ -185,7 +199,7 @@ func (t *TimeAndSale) Time() int64 {
 
 func (t *TimeAndSale) SetTime(value int64) {
 	t.index = (timeutil.GetSecondsFromTime(value) << 32) |
-		int64(timeutil.GetMillisFromTime(value)<<22) |
+		int64(timeutil.GetMillisFromTime(value))<<22 |
 		t.Sequence()
 }
 
@@ -302,3 +316,34 @@ func (t *TimeAndSale) String() string {
 		formatutil.FormatNullableString(", seller=%s", t.Seller(), "") +
 		"}"
 }
+
+// Clone returns a copy of the time and sale sharing no memory with it.
+func (t *TimeAndSale) Clone() *TimeAndSale {
+	result := *t
+	result.eventSymbol = eventutil.CloneString(t.eventSymbol)
+	result.exchangeSaleConditions = eventutil.CloneString(t.exchangeSaleConditions)
+	result.buyer = eventutil.CloneString(t.buyer)
+	result.seller = eventutil.CloneString(t.seller)
+	return &result
+}
+
+// Equal returns whether all fields of the time and sales are equal, NaN prices and sizes are equal.
+func (t *TimeAndSale) Equal(other *TimeAndSale) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return eventutil.EqualStrings(t.eventSymbol, other.eventSymbol) &&
+		t.eventTime == other.eventTime &&
+		t.timeNanoPart == other.timeNanoPart &&
+		t.exchangeCode == other.exchangeCode &&
+		eventutil.EqualFloats(t.price, other.price) &&
+		eventutil.EqualFloats(t.size, other.size) &&
+		eventutil.EqualFloats(t.bidPrice, other.bidPrice) &&
+		eventutil.EqualFloats(t.askPrice, other.askPrice) &&
+		eventutil.EqualStrings(t.exchangeSaleConditions, other.exchangeSaleConditions) &&
+		t.flags == other.flags &&
+		eventutil.EqualStrings(t.buyer, other.buyer) &&
+		eventutil.EqualStrings(t.seller, other.seller) &&
+		t.eventFlags == other.eventFlags &&
+		t.index == other.index
+}
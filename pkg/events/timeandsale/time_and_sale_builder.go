@@ -0,0 +1,139 @@
+package timeandsale
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+)
+
+// exchangeMask limits exchange codes to ASCII chars.
+const exchangeMask = 0x7f
+
+// Builder sets fields of a time and sale, invalid values are reported by Build.
+// Build returns a new time and sale every time, so a builder can be reused as a template.
+type Builder struct {
+	timeAndSale *TimeAndSale
+	checker     eventutil.Checker
+}
+
+func NewBuilder(eventSymbol string) *Builder {
+	return &Builder{timeAndSale: NewTimeAndSale(eventSymbol)}
+}
+
+func (b *Builder) EventTime(eventTime int64) *Builder {
+	b.timeAndSale.SetEventTime(eventTime)
+	return b
+}
+
+func (b *Builder) EventFlags(eventFlags int32) *Builder {
+	b.timeAndSale.SetEventFlags(eventFlags)
+	return b
+}
+
+// Index sets the time and the sequence packed together, like Time and Sequence do separately.
+func (b *Builder) Index(index int64) *Builder {
+	b.timeAndSale.SetIndex(index)
+	return b
+}
+
+func (b *Builder) Time(time int64) *Builder {
+	b.timeAndSale.SetTime(time)
+	return b
+}
+
+// TimeNanos sets the time and the time nano part.
+func (b *Builder) TimeNanos(timeNanos int64) *Builder {
+	b.timeAndSale.SetTimeNanos(timeNanos)
+	return b
+}
+
+func (b *Builder) Sequence(sequence int64) *Builder {
+	b.checker.Check(b.timeAndSale.SetSequence(sequence))
+	return b
+}
+
+func (b *Builder) TimeNanoPart(timeNanoPart int32) *Builder {
+	b.checker.Check(eventutil.CheckTimeNanoPart(timeNanoPart))
+	b.timeAndSale.SetTimeNanoPart(timeNanoPart)
+	return b
+}
+
+func (b *Builder) ExchangeCode(exchangeCode int16) *Builder {
+	b.checker.Check(mathutil.CheckChar(int64(exchangeCode), exchangeMask, "exchangeCode"))
+	b.timeAndSale.SetExchangeCode(exchangeCode)
+	return b
+}
+
+func (b *Builder) Price(price float64) *Builder {
+	b.timeAndSale.SetPrice(price)
+	return b
+}
+
+func (b *Builder) Size(size float64) *Builder {
+	b.timeAndSale.SetSize(size)
+	return b
+}
+
+func (b *Builder) BidPrice(bidPrice float64) *Builder {
+	b.timeAndSale.SetBidPrice(bidPrice)
+	return b
+}
+
+func (b *Builder) AskPrice(askPrice float64) *Builder {
+	b.timeAndSale.SetAskPrice(askPrice)
+	return b
+}
+
+func (b *Builder) ExchangeSaleConditions(exchangeSaleConditions string) *Builder {
+	b.timeAndSale.SetExchangeSaleConditions(&exchangeSaleConditions)
+	return b
+}
+
+func (b *Builder) TradeThroughExempt(tradeThroughExempt rune) *Builder {
+	b.checker.Check(mathutil.CheckChar(int64(tradeThroughExempt), tteMask, "tradeThroughExempt"))
+	b.timeAndSale.SetTradeThroughExempt(tradeThroughExempt)
+	return b
+}
+
+func (b *Builder) AggressorSide(aggressorSide side.Side) *Builder {
+	b.timeAndSale.SetAggressorSide(aggressorSide)
+	return b
+}
+
+func (b *Builder) SpreadLeg(spreadLeg bool) *Builder {
+	b.timeAndSale.SetIsSpreadLeg(spreadLeg)
+	return b
+}
+
+func (b *Builder) ExtendedTradingHours(extendedTradingHours bool) *Builder {
+	b.timeAndSale.SetIsExtendedTradingHours(extendedTradingHours)
+	return b
+}
+
+func (b *Builder) ValidTick(validTick bool) *Builder {
+	b.timeAndSale.SetIsValidTick(validTick)
+	return b
+}
+
+func (b *Builder) Type(timeAndSaleType Type) *Builder {
+	b.timeAndSale.SetTimeAndSaleType(timeAndSaleType)
+	return b
+}
+
+func (b *Builder) Buyer(buyer string) *Builder {
+	b.timeAndSale.SetBuyer(&buyer)
+	return b
+}
+
+func (b *Builder) Seller(seller string) *Builder {
+	b.timeAndSale.SetSeller(&seller)
+	return b
+}
+
+// Build returns the time and sale, or the first invalid value set.
+func (b *Builder) Build() (*TimeAndSale, error) {
+	if err := b.checker.Err(); err != nil {
+		return nil, err
+	}
+	return b.timeAndSale.Clone(), nil
+}
@@ -0,0 +1,116 @@
+package timeandsale
+
+import (
+	"encoding/json"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+)
+
+const eventType = "TimeAndSale"
+
+func init() {
+	events.RegisterEventType(eventType, func() json.Unmarshaler {
+		return NewTimeAndSale("")
+	})
+}
+
+type timeAndSaleJSON struct {
+	EventType              string                `json:"eventType"`
+	EventSymbol            *string               `json:"eventSymbol"`
+	EventTime              int64                 `json:"eventTime"`
+	EventFlags             events.JSONEventFlags `json:"eventFlags,omitempty"`
+	Time                   int64                 `json:"time"`
+	Sequence               int64                 `json:"sequence"`
+	TimeNanoPart           int32                 `json:"timeNanoPart"`
+	ExchangeCode           events.JSONChar       `json:"exchangeCode"`
+	Price                  events.JSONFloat      `json:"price"`
+	Size                   events.JSONFloat      `json:"size"`
+	BidPrice               events.JSONFloat      `json:"bidPrice"`
+	AskPrice               events.JSONFloat      `json:"askPrice"`
+	ExchangeSaleConditions *string               `json:"exchangeSaleConditions"`
+	TradeThroughExempt     events.JSONChar       `json:"tradeThroughExempt"`
+	AggressorSide          string                `json:"aggressorSide"`
+	SpreadLeg              bool                  `json:"spreadLeg"`
+	ExtendedTradingHours   bool                  `json:"extendedTradingHours"`
+	ValidTick              bool                  `json:"validTick"`
+	Type                   string                `json:"type"`
+	Buyer                  *string               `json:"buyer"`
+	Seller                 *string               `json:"seller"`
+}
+
+func (t *TimeAndSale) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.toJSON())
+}
+
+func (t *TimeAndSale) UnmarshalJSON(data []byte) error {
+	v := NewTimeAndSale("").toJSON()
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := events.CheckEventType(v.EventType, eventType); err != nil {
+		return err
+	}
+	aggressorSide, err := events.ParseEnum("side", v.AggressorSide, side.Side(side.Undefined), side.Buy, side.Sell)
+	if err != nil {
+		return err
+	}
+	typ, err := events.ParseEnum("time and sale type", v.Type, Type(TypeNew), TypeCorrection, TypeCancel)
+	if err != nil {
+		return err
+	}
+	symbol := ""
+	if v.EventSymbol != nil {
+		symbol = *v.EventSymbol
+	}
+	result := NewTimeAndSale(symbol)
+	result.eventTime = v.EventTime
+	result.eventFlags = int32(v.EventFlags)
+	result.SetTime(v.Time)
+	if err := result.SetSequence(v.Sequence); err != nil {
+		return err
+	}
+	result.timeNanoPart = v.TimeNanoPart
+	result.exchangeCode = int16(v.ExchangeCode)
+	result.price = float64(v.Price)
+	result.size = float64(v.Size)
+	result.bidPrice = float64(v.BidPrice)
+	result.askPrice = float64(v.AskPrice)
+	result.exchangeSaleConditions = v.ExchangeSaleConditions
+	result.SetTradeThroughExempt(rune(v.TradeThroughExempt))
+	result.SetAggressorSide(aggressorSide)
+	result.SetIsSpreadLeg(v.SpreadLeg)
+	result.SetIsExtendedTradingHours(v.ExtendedTradingHours)
+	result.SetIsValidTick(v.ValidTick)
+	result.SetTimeAndSaleType(typ)
+	result.buyer = v.Buyer
+	result.seller = v.Seller
+	*t = *result
+	return nil
+}
+
+func (t *TimeAndSale) toJSON() timeAndSaleJSON {
+	return timeAndSaleJSON{
+		EventType:              eventType,
+		EventSymbol:            t.eventSymbol,
+		EventTime:              t.eventTime,
+		EventFlags:             events.JSONEventFlags(t.eventFlags),
+		Time:                   t.Time(),
+		Sequence:               t.Sequence(),
+		TimeNanoPart:           t.timeNanoPart,
+		ExchangeCode:           events.JSONChar(t.exchangeCode),
+		Price:                  events.JSONFloat(t.price),
+		Size:                   events.JSONFloat(t.size),
+		BidPrice:               events.JSONFloat(t.bidPrice),
+		AskPrice:               events.JSONFloat(t.askPrice),
+		ExchangeSaleConditions: t.exchangeSaleConditions,
+		TradeThroughExempt:     events.JSONChar(t.TradeThroughExempt()),
+		AggressorSide:          t.AggressorSide().String(),
+		SpreadLeg:              t.IsSpreadLeg(),
+		ExtendedTradingHours:   t.IsExtendedTradingHours(),
+		ValidTick:              t.IsValidTick(),
+		Type:                   t.TimeAndSaleType().String(),
+		Buyer:                  t.buyer,
+		Seller:                 t.seller,
+	}
+}
@@ -1,9 +1,25 @@
 package timeandsale
 
-import "github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+import (
+	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+)
 
 type Type int64
 
+func (t Type) String() string {
+	switch t {
+	case TypeNew:
+		return "New"
+	case TypeCorrection:
+		return "Correction"
+	case TypeCancel:
+		return "Cancel"
+	default:
+		return fmt.Sprintf("Type: Wrong value %d", t)
+	}
+}
+
 const (
 	TypeNew        = 0
 	TypeCorrection = 1
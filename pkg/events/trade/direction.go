@@ -1,9 +1,31 @@
 package trade
 
-import "github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+import (
+	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+)
 
 type Direction int32
 
+func (d Direction) String() string {
+	switch d {
+	case Undefined:
+		return "Undefined"
+	case Down:
+		return "Down"
+	case ZeroDown:
+		return "ZeroDown"
+	case Zero:
+		return "Zero"
+	case ZeroUp:
+		return "ZeroUp"
+	case Up:
+		return "Up"
+	default:
+		return fmt.Sprintf("Direction: Wrong value %d", d)
+	}
+}
+
 const (
 	Undefined = iota
 	Down
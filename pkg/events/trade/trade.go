@@ -21,3 +21,14 @@ func (t *Trade) String() string {
 		t.TradeBase.String() +
 		"}"
 }
+
+func (t *Trade) Clone() *Trade {
+	return &Trade{TradeBase: *t.TradeBase.Clone()}
+}
+
+func (t *Trade) Equal(other *Trade) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return t.TradeBase.Equal(&other.TradeBase)
+}
@@ -2,7 +2,9 @@ package trade
 
 import (
 	"fmt"
+	"github.com/dxfeed/dxfeed-graal-go-api/internal/intern"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
@@ -43,7 +45,12 @@ func (t *TradeBase) EventSymbol() *string {
 }
 
 func (t *TradeBase) SetEventSymbol(eventSymbol *string) {
-	t.eventSymbol = eventSymbol
+	eventutil.SetString(&t.eventSymbol, eventSymbol)
+}
+
+// SetInternedEventSymbol points the symbol of the trade to the interned value without copying it.
+func (t *TradeBase) SetInternedEventSymbol(_ intern.Access, value *string) {
+	t.eventSymbol = value
 }
 
 func (t *TradeBase) TimeSequence() int64 {
@@ -172,8 +179,8 @@ func (t *TradeBase) Time() int64 {
 
 func (t *TradeBase) SetTime(value int64) {
 	t.timeSequence = timeutil.GetSecondsFromTime(value)<<32 |
-		int64(timeutil.GetMillisFromTime(value)<<22) |
-		value
+		int64(timeutil.GetMillisFromTime(value))<<22 |
+		t.Sequence()
 }
 
 func (t *TradeBase) TimeNanos() int64 {
@@ -205,3 +212,29 @@ func (t *TradeBase) String() string {
 		", direction=" + formatutil.FormatInt64(int64(t.TickDirection())) +
 		", ETH=" + formatutil.FormatBool(t.IsExtendedTradingHours())
 }
+
+// Clone returns a copy of the trade sharing no memory with it.
+func (t *TradeBase) Clone() *TradeBase {
+	result := *t
+	result.eventSymbol = eventutil.CloneString(t.eventSymbol)
+	return &result
+}
+
+// Equal returns whether all fields of the trades are equal, NaN values are equal.
+func (t *TradeBase) Equal(other *TradeBase) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return eventutil.EqualStrings(t.eventSymbol, other.eventSymbol) &&
+		t.eventTime == other.eventTime &&
+		t.timeSequence == other.timeSequence &&
+		t.timeNanoPart == other.timeNanoPart &&
+		t.exchangeCode == other.exchangeCode &&
+		eventutil.EqualFloats(t.price, other.price) &&
+		eventutil.EqualFloats(t.change, other.change) &&
+		eventutil.EqualFloats(t.size, other.size) &&
+		t.dayId == other.dayId &&
+		eventutil.EqualFloats(t.dayVolume, other.dayVolume) &&
+		eventutil.EqualFloats(t.dayTurnover, other.dayTurnover) &&
+		t.flags == other.flags
+}
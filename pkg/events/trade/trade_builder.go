@@ -0,0 +1,130 @@
+package trade
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/internal/eventutil"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/mathutil"
+)
+
+// exchangeMask limits exchange codes to ASCII chars.
+const exchangeMask = 0x7f
+
+// baseBuilder sets fields of TradeBase, its methods return the builder B embedding it for chaining.
+type baseBuilder[B any] struct {
+	self    B
+	trade   *TradeBase
+	checker eventutil.Checker
+}
+
+func (b *baseBuilder[B]) EventTime(eventTime int64) B {
+	b.trade.SetEventTime(eventTime)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Time(time int64) B {
+	b.trade.SetTime(time)
+	return b.self
+}
+
+// TimeNanos sets the time and the time nano part.
+func (b *baseBuilder[B]) TimeNanos(timeNanos int64) B {
+	b.trade.SetTimeNanos(timeNanos)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Sequence(sequence int64) B {
+	b.checker.Check(b.trade.SetSequence(sequence))
+	return b.self
+}
+
+func (b *baseBuilder[B]) TimeNanoPart(timeNanoPart int32) B {
+	b.checker.Check(eventutil.CheckTimeNanoPart(timeNanoPart))
+	b.trade.SetTimeNanoPart(timeNanoPart)
+	return b.self
+}
+
+func (b *baseBuilder[B]) ExchangeCode(exchangeCode int16) B {
+	b.checker.Check(mathutil.CheckChar(int64(exchangeCode), exchangeMask, "exchangeCode"))
+	b.trade.SetExchangeCode(exchangeCode)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Price(price float64) B {
+	b.trade.SetPrice(price)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Change(change float64) B {
+	b.trade.SetChange(change)
+	return b.self
+}
+
+func (b *baseBuilder[B]) Size(size float64) B {
+	b.trade.SetSize(size)
+	return b.self
+}
+
+func (b *baseBuilder[B]) DayId(dayId int32) B {
+	b.trade.SetDayId(dayId)
+	return b.self
+}
+
+func (b *baseBuilder[B]) DayVolume(dayVolume float64) B {
+	b.trade.SetDayVolume(dayVolume)
+	return b.self
+}
+
+func (b *baseBuilder[B]) DayTurnover(dayTurnover float64) B {
+	b.trade.SetDayTurnover(dayTurnover)
+	return b.self
+}
+
+func (b *baseBuilder[B]) TickDirection(tickDirection Direction) B {
+	b.trade.SetTickDirection(tickDirection)
+	return b.self
+}
+
+func (b *baseBuilder[B]) ExtendedTradingHours(extendedTradingHours bool) B {
+	b.trade.SetIsExtendedTradingHours(extendedTradingHours)
+	return b.self
+}
+
+// Builder sets fields of a trade, invalid values are reported by Build.
+// Build returns a new trade every time, so a builder can be reused as a template.
+type Builder struct {
+	baseBuilder[*Builder]
+	event *Trade
+}
+
+func NewBuilder(eventSymbol string) *Builder {
+	b := &Builder{event: NewTrade(eventSymbol)}
+	b.baseBuilder = baseBuilder[*Builder]{self: b, trade: &b.event.TradeBase}
+	return b
+}
+
+// Build returns the trade, or the first invalid value set.
+func (b *Builder) Build() (*Trade, error) {
+	if err := b.checker.Err(); err != nil {
+		return nil, err
+	}
+	return b.event.Clone(), nil
+}
+
+// ETHBuilder sets fields of a trade in extended trading hours, invalid values are reported by Build.
+type ETHBuilder struct {
+	baseBuilder[*ETHBuilder]
+	event *TradeETH
+}
+
+func NewETHBuilder(eventSymbol string) *ETHBuilder {
+	b := &ETHBuilder{event: NewTradeETH(eventSymbol)}
+	b.baseBuilder = baseBuilder[*ETHBuilder]{self: b, trade: &b.event.TradeBase}
+	return b
+}
+
+// Build returns the trade, or the first invalid value set.
+func (b *ETHBuilder) Build() (*TradeETH, error) {
+	if err := b.checker.Err(); err != nil {
+		return nil, err
+	}
+	return b.event.Clone(), nil
+}
@@ -19,3 +19,14 @@ func (t *TradeETH) String() string {
 		t.TradeBase.String() +
 		"}"
 }
+
+func (t *TradeETH) Clone() *TradeETH {
+	return &TradeETH{TradeBase: *t.TradeBase.Clone()}
+}
+
+func (t *TradeETH) Equal(other *TradeETH) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return t.TradeBase.Equal(&other.TradeBase)
+}
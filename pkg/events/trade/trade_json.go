@@ -0,0 +1,112 @@
+package trade
+
+import (
+	"encoding/json"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+const (
+	tradeEventType    = "Trade"
+	tradeETHEventType = "TradeETH"
+)
+
+func init() {
+	events.RegisterEventType(tradeEventType, func() json.Unmarshaler {
+		return NewTrade("")
+	})
+	events.RegisterEventType(tradeETHEventType, func() json.Unmarshaler {
+		return NewTradeETH("")
+	})
+}
+
+type tradeJSON struct {
+	EventType            string           `json:"eventType"`
+	EventSymbol          *string          `json:"eventSymbol"`
+	EventTime            int64            `json:"eventTime"`
+	Time                 int64            `json:"time"`
+	Sequence             int64            `json:"sequence"`
+	TimeNanoPart         int32            `json:"timeNanoPart"`
+	ExchangeCode         events.JSONChar  `json:"exchangeCode"`
+	Price                events.JSONFloat `json:"price"`
+	Change               events.JSONFloat `json:"change"`
+	Size                 events.JSONFloat `json:"size"`
+	DayId                int32            `json:"dayId"`
+	DayVolume            events.JSONFloat `json:"dayVolume"`
+	DayTurnover          events.JSONFloat `json:"dayTurnover"`
+	TickDirection        string           `json:"tickDirection"`
+	ExtendedTradingHours bool             `json:"extendedTradingHours"`
+}
+
+func (t *Trade) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.toJSON(tradeEventType))
+}
+
+func (t *Trade) UnmarshalJSON(data []byte) error {
+	return t.fromJSON(data, tradeEventType)
+}
+
+func (t *TradeETH) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.toJSON(tradeETHEventType))
+}
+
+func (t *TradeETH) UnmarshalJSON(data []byte) error {
+	return t.fromJSON(data, tradeETHEventType)
+}
+
+func (t *TradeBase) fromJSON(data []byte, expectedType string) error {
+	v := NewTradeBase("").toJSON(expectedType)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := events.CheckEventType(v.EventType, expectedType); err != nil {
+		return err
+	}
+	direction, err := events.ParseEnum("direction", v.TickDirection,
+		Direction(Undefined), Down, ZeroDown, Zero, ZeroUp, Up)
+	if err != nil {
+		return err
+	}
+	symbol := ""
+	if v.EventSymbol != nil {
+		symbol = *v.EventSymbol
+	}
+	result := NewTradeBase(symbol)
+	result.eventTime = v.EventTime
+	result.SetTime(v.Time)
+	if err := result.SetSequence(v.Sequence); err != nil {
+		return err
+	}
+	result.timeNanoPart = v.TimeNanoPart
+	result.exchangeCode = int16(v.ExchangeCode)
+	result.price = float64(v.Price)
+	result.change = float64(v.Change)
+	result.size = float64(v.Size)
+	result.dayId = v.DayId
+	result.dayVolume = float64(v.DayVolume)
+	result.dayTurnover = float64(v.DayTurnover)
+	result.SetTickDirection(direction)
+	result.SetIsExtendedTradingHours(v.ExtendedTradingHours)
+	*t = *result
+	return nil
+}
+
+func (t *TradeBase) toJSON(eventType string) tradeJSON {
+	return tradeJSON{
+		EventType:            eventType,
+		EventSymbol:          t.eventSymbol,
+		EventTime:            t.eventTime,
+		Time:                 t.Time(),
+		Sequence:             t.Sequence(),
+		TimeNanoPart:         t.timeNanoPart,
+		ExchangeCode:         events.JSONChar(t.exchangeCode),
+		Price:                events.JSONFloat(t.price),
+		Change:               events.JSONFloat(t.change),
+		Size:                 events.JSONFloat(t.size),
+		DayId:                t.dayId,
+		DayVolume:            events.JSONFloat(t.dayVolume),
+		DayTurnover:          events.JSONFloat(t.dayTurnover),
+		TickDirection:        t.TickDirection().String(),
+		ExtendedTradingHours: t.IsExtendedTradingHours(),
+	}
+}
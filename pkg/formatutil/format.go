@@ -3,7 +3,8 @@ package formatutil
 import (
 	"fmt"
 	"strconv"
-	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
 )
 
 func FormatChar(c rune) string {
@@ -27,15 +28,13 @@ func FormatNullableString(format string, s *string, defaultValue string) string
 	if s == nil {
 		return defaultValue
 	}
-	return fmt.Sprintf(format, s)
+	return fmt.Sprintf(format, *s)
 }
 
+var defaultTimeFormat = timeutil.DefaultTimeFormat.WithMillis()
+
 func FormatTime(timeMillis int64) string {
-	const defaultTimeFormat = "20060102-150405.000-07:00"
-	if timeMillis == 0 {
-		return "0"
-	}
-	return time.UnixMilli(timeMillis).Format(defaultTimeFormat)
+	return defaultTimeFormat.Format(timeMillis)
 }
 
 func FormatFloat64(f float64) string {
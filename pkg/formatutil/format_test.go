@@ -0,0 +1,13 @@
+package formatutil
+
+import "testing"
+
+func TestFormatNullableString(t *testing.T) {
+	buyer := "BUYER"
+	if result := FormatNullableString(", buyer=%s", &buyer, ""); result != ", buyer=BUYER" {
+		t.Errorf(`Result should be ", buyer=BUYER". But it equals %q`, result)
+	}
+	if result := FormatNullableString(", buyer=%s", nil, "-"); result != "-" {
+		t.Errorf(`Result should be "-". But it equals %q`, result)
+	}
+}
@@ -0,0 +1,53 @@
+package profileio
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+// CSVWriter writes instrument profiles as CSV rows preceded by a header row of field names.
+// All fields are written by default. It is not safe for concurrent use.
+type CSVWriter struct {
+	writer        *csv.Writer
+	fields        []Field
+	headerWritten bool
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(w), fields: Fields()}
+}
+
+func (w *CSVWriter) SetFields(fields ...Field) {
+	w.fields = fields
+}
+
+func (w *CSVWriter) Write(profiles []*events.InstrumentProfile) error {
+	if !w.headerWritten {
+		names := make([]string, len(w.fields))
+		for i, f := range w.fields {
+			names[i] = f.String()
+		}
+		if err := w.writer.Write(names); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	record := make([]string, len(w.fields))
+	for _, p := range profiles {
+		for i, f := range w.fields {
+			record[i] = f.Get(p)
+		}
+		if err := w.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *CSVWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
@@ -0,0 +1,197 @@
+package profileio
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+// Field is a field of instrument profiles, it is named like in the IPF format (e.g. UNDERLYING, LAST_TRADE).
+type Field int32
+
+const (
+	FieldType Field = iota
+	FieldSymbol
+	FieldDescription
+	FieldLocalSymbol
+	FieldLocalDescription
+	FieldCountry
+	FieldOpol
+	FieldExchangeData
+	FieldExchanges
+	FieldCurrency
+	FieldBaseCurrency
+	FieldCfi
+	FieldIsin
+	FieldSedol
+	FieldCusip
+	FieldIcb
+	FieldSic
+	FieldMultiplier
+	FieldProduct
+	FieldUnderlying
+	FieldSpc
+	FieldAdditionalUnderlyings
+	FieldMmy
+	FieldExpiration
+	FieldLastTrade
+	FieldStrike
+	FieldOptionType
+	FieldExpirationStyle
+	FieldSettlementStyle
+	FieldPriceIncrements
+	FieldTradingHours
+)
+
+type fieldKind int32
+
+const (
+	textField fieldKind = iota
+	intField
+	// dateField holds a day id (days since epoch) written as yyyy-MM-dd
+	dateField
+	floatField
+)
+
+const dateLayout = "2006-01-02"
+
+type fieldInfo struct {
+	name     string
+	jsonName string
+	kind     fieldKind
+	text     func(p *events.InstrumentProfile) *string
+	int      func(p *events.InstrumentProfile) int64
+	float    func(p *events.InstrumentProfile) float64
+}
+
+type profile = events.InstrumentProfile
+
+func text(name, jsonName string, get func(*profile) *string) fieldInfo {
+	return fieldInfo{name: name, jsonName: jsonName, kind: textField, text: get}
+}
+
+func integer(name, jsonName string, kind fieldKind, get func(*profile) int64) fieldInfo {
+	return fieldInfo{name: name, jsonName: jsonName, kind: kind, int: get}
+}
+
+func float(name, jsonName string, get func(*profile) float64) fieldInfo {
+	return fieldInfo{name: name, jsonName: jsonName, kind: floatField, float: get}
+}
+
+// fields are indexed by Field
+var fields = []fieldInfo{
+	text("TYPE", "instrumentType", (*profile).InstrumentType),
+	text("SYMBOL", "symbol", (*profile).Symbol),
+	text("DESCRIPTION", "description", (*profile).Description),
+	text("LOCAL_SYMBOL", "localSymbol", (*profile).LocalSymbol),
+	text("LOCAL_DESCRIPTION", "localDescription", (*profile).LocalDescription),
+	text("COUNTRY", "country", (*profile).Country),
+	text("OPOL", "opol", (*profile).Opol),
+	text("EXCHANGE_DATA", "exchangeData", (*profile).ExchangeData),
+	text("EXCHANGES", "exchanges", (*profile).Exchanges),
+	text("CURRENCY", "currency", (*profile).Currency),
+	text("BASE_CURRENCY", "baseCurrency", (*profile).BaseCurrency),
+	text("CFI", "cfi", (*profile).Cfi),
+	text("ISIN", "isin", (*profile).Isin),
+	text("SEDOL", "sedol", (*profile).Sedol),
+	text("CUSIP", "cusip", (*profile).Cusip),
+	integer("ICB", "icb", intField, (*profile).Icb),
+	integer("SIC", "sic", intField, (*profile).Sic),
+	float("MULTIPLIER", "multiplier", (*profile).Multiplier),
+	text("PRODUCT", "product", (*profile).Product),
+	text("UNDERLYING", "underlying", (*profile).Underlying),
+	float("SPC", "spc", (*profile).Spc),
+	text("ADDITIONAL_UNDERLYINGS", "additionalUnderlyings", (*profile).AdditionalUnderlyings),
+	text("MMY", "mmy", (*profile).Mmy),
+	integer("EXPIRATION", "expiration", dateField, (*profile).Expiration),
+	integer("LAST_TRADE", "lastTrade", dateField, (*profile).LastTrade),
+	float("STRIKE", "strike", (*profile).Strike),
+	text("OPTION_TYPE", "optionType", (*profile).OptionType),
+	text("EXPIRATION_STYLE", "expirationStyle", (*profile).ExpirationStyle),
+	text("SETTLEMENT_STYLE", "settlementStyle", (*profile).SettlementStyle),
+	text("PRICE_INCREMENTS", "priceIncrements", (*profile).PriceIncrements),
+	text("TRADING_HOURS", "tradingHours", (*profile).TradingHours),
+}
+
+// Fields returns all fields in the order they are written.
+func Fields() []Field {
+	result := make([]Field, len(fields))
+	for i := range fields {
+		result[i] = Field(i)
+	}
+	return result
+}
+
+func (f Field) String() string {
+	if f < 0 || int(f) >= len(fields) {
+		return fmt.Sprintf("Field: Wrong value %d", f)
+	}
+	return fields[f].name
+}
+
+// JSONName returns the name of the field in the JSON encoding of instrument profiles.
+func (f Field) JSONName() string {
+	return fields[f].jsonName
+}
+
+// ParseField returns the field by its IPF name or JSON name ignoring the case,
+// so UNDERLYING, underlying, LAST_TRADE and lastTrade are all valid names.
+func ParseField(name string) (Field, error) {
+	normalized := normalizeName(name)
+	for i, info := range fields {
+		if normalizeName(info.name) == normalized || normalizeName(info.jsonName) == normalized {
+			return Field(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown instrument profile field %q", name)
+}
+
+// ParseFields parses the names of fields, see ParseField.
+func ParseFields(names []string) ([]Field, error) {
+	result := make([]Field, 0, len(names))
+	for _, name := range names {
+		f, err := ParseField(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "_", ""))
+}
+
+// Get returns the value of the field formatted like in the IPF format: zero numbers and dates are empty,
+// dates are formatted as yyyy-MM-dd.
+func (f Field) Get(p *events.InstrumentProfile) string {
+	info := fields[f]
+	switch info.kind {
+	case intField:
+		if v := info.int(p); v != 0 {
+			return strconv.FormatInt(v, 10)
+		}
+	case dateField:
+		if v := info.int(p); v != 0 {
+			return time.Unix(v*24*60*60, 0).UTC().Format(dateLayout)
+		}
+	case floatField:
+		if v := info.float(p); v != 0 {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	default:
+		if v := info.text(p); v != nil {
+			return *v
+		}
+	}
+	return ""
+}
+
+// IsEmpty returns whether the field has the default value.
+func (f Field) IsEmpty(p *events.InstrumentProfile) bool {
+	return f.Get(p) == ""
+}
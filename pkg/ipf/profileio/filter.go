@@ -0,0 +1,103 @@
+package profileio
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+// Filter selects instrument profiles matching all of its conditions.
+type Filter struct {
+	conditions []condition
+}
+
+type condition struct {
+	field    Field
+	negated  bool
+	patterns []string
+}
+
+// ParseFilter parses conditions like FIELD=VALUE or FIELD!=VALUE. The value may list alternatives
+// separated by '|' and contain '*' matching any characters, values are compared ignoring the case
+// with the field formatted like in the IPF format. For instance, TYPE=OPTION|FUTURE, UNDERLYING=AAPL,
+// EXPIRATION=2024-01-* or EXCHANGES!= (which selects profiles having exchanges).
+func ParseFilter(conditions ...string) (*Filter, error) {
+	f := &Filter{}
+	for _, s := range conditions {
+		separator := strings.Index(s, "=")
+		if separator < 0 {
+			return nil, fmt.Errorf("no '=' in the condition %q", s)
+		}
+		name := s[:separator]
+		negated := strings.HasSuffix(name, "!")
+		if negated {
+			name = name[:len(name)-1]
+		}
+		field, err := ParseField(name)
+		if err != nil {
+			return nil, err
+		}
+		f.conditions = append(f.conditions, condition{
+			field:    field,
+			negated:  negated,
+			patterns: strings.Split(strings.ToLower(s[separator+1:]), "|"),
+		})
+	}
+	return f, nil
+}
+
+// Match returns whether the profile matches all conditions, an empty filter matches any profile.
+func (f *Filter) Match(p *events.InstrumentProfile) bool {
+	for _, c := range f.conditions {
+		if c.match(p) == c.negated {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the matching profiles.
+func (f *Filter) Apply(profiles []*events.InstrumentProfile) []*events.InstrumentProfile {
+	if len(f.conditions) == 0 {
+		return profiles
+	}
+	var result []*events.InstrumentProfile
+	for _, p := range profiles {
+		if f.Match(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func (c condition) match(p *events.InstrumentProfile) bool {
+	value := strings.ToLower(c.field.Get(p))
+	for _, pattern := range c.patterns {
+		if matchPattern(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern matches the value against the pattern where '*' matches any characters.
+func matchPattern(pattern string, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return len(value) >= len(last) && strings.HasSuffix(value, last)
+}
@@ -0,0 +1,78 @@
+package profileio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+// JSONWriter writes instrument profiles as a JSON array of their JSON encodings (see package events),
+// one profile per line. It is not safe for concurrent use.
+type JSONWriter struct {
+	writer   *bufio.Writer
+	selected []Field
+	count    int
+}
+
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{writer: bufio.NewWriter(w)}
+}
+
+// SetFields selects the fields of the objects, they are named by Field.JSONName.
+func (w *JSONWriter) SetFields(fields ...Field) {
+	w.selected = fields
+}
+
+func (w *JSONWriter) Write(profiles []*events.InstrumentProfile) error {
+	for _, p := range profiles {
+		data, err := w.encode(p)
+		if err != nil {
+			return err
+		}
+		separator := ",\n"
+		if w.count == 0 {
+			separator = "[\n"
+		}
+		w.writer.WriteString(separator)
+		w.writer.Write(data)
+		w.count++
+	}
+	return w.writer.Flush()
+}
+
+// Close closes the array, an empty array is written if there are no profiles.
+func (w *JSONWriter) Close() error {
+	if w.count == 0 {
+		w.writer.WriteString("[")
+	}
+	w.writer.WriteString("\n]\n")
+	return w.writer.Flush()
+}
+
+func (w *JSONWriter) encode(p *events.InstrumentProfile) ([]byte, error) {
+	data, err := json.Marshal(p)
+	if err != nil || w.selected == nil {
+		return data, err
+	}
+	values := map[string]json.RawMessage{}
+	if err = json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	// maps are encoded with sorted keys, so the object is built to keep the order of selected fields
+	b := &bytes.Buffer{}
+	b.WriteString("{")
+	for i, f := range w.selected {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		name, _ := json.Marshal(f.JSONName())
+		b.Write(name)
+		b.WriteString(":")
+		b.Write(values[f.JSONName()])
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
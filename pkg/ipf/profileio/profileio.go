@@ -0,0 +1,89 @@
+// Package profileio writes instrument profiles in the IPF text format, CSV and JSON, and filters and
+// summarizes them.
+//
+// An IPF file consists of header lines defining the fields of each instrument type, data rows
+// whose first cell is the type of the instrument, and the final ##COMPLETE line:
+//
+//	#STOCK::=TYPE,SYMBOL,DESCRIPTION,EXCHANGES,CURRENCY
+//	STOCK,AAPL,"Apple Inc.",ARCX;BATS;XNAS,USD
+//	##COMPLETE
+//
+// Cells are quoted like in CSV, zero numbers and dates are empty, dates are formatted as yyyy-MM-dd.
+// Other lines starting with '#' are comments.
+package profileio
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+const (
+	headerPrefix    = "#"
+	headerSeparator = "::="
+	completeLine    = "##COMPLETE"
+)
+
+type Format int32
+
+const (
+	FormatIPF Format = iota
+	FormatCSV
+	FormatJSON
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatIPF:
+		return "ipf"
+	case FormatCSV:
+		return "csv"
+	case FormatJSON:
+		return "json"
+	default:
+		return fmt.Sprintf("Format: Wrong value %d", f)
+	}
+}
+
+// ParseFormat returns the format by its name: ipf, csv or json.
+func ParseFormat(name string) (Format, error) {
+	for _, f := range []Format{FormatIPF, FormatCSV, FormatJSON} {
+		if strings.EqualFold(name, f.String()) {
+			return f, nil
+		}
+	}
+	return FormatIPF, fmt.Errorf("unknown format %q", name)
+}
+
+// FormatOfPath returns the format by the extension of the path, a trailing .gz is ignored.
+func FormatOfPath(path string) (Format, error) {
+	ext := filepath.Ext(strings.TrimSuffix(strings.ToLower(path), ".gz"))
+	if ext == "" {
+		return FormatIPF, fmt.Errorf("no format extension in %q", path)
+	}
+	return ParseFormat(ext[1:])
+}
+
+// ProfileWriter is implemented by writers of all formats.
+type ProfileWriter interface {
+	// SetFields selects the fields to write in the given order, all non-empty fields are written by default.
+	SetFields(fields ...Field)
+	Write(profiles []*events.InstrumentProfile) error
+	// Close completes the output and flushes it, the underlying writer is not closed.
+	Close() error
+}
+
+// NewProfileWriter returns the writer of the format.
+func NewProfileWriter(format Format, w io.Writer) ProfileWriter {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w)
+	case FormatJSON:
+		return NewJSONWriter(w)
+	default:
+		return NewWriter(w)
+	}
+}
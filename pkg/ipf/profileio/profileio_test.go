@@ -0,0 +1,225 @@
+package profileio
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+// testProfiles returns two stocks and two options expiring on 2024-01-19.
+func testProfiles() []*events.InstrumentProfile {
+	text := func(value string) *string {
+		return &value
+	}
+	newProfile := func(instrumentType, symbol, exchanges string) *events.InstrumentProfile {
+		p := events.NewInstrumentProfile()
+		p.SetInstrumentType(&instrumentType)
+		p.SetSymbol(&symbol)
+		p.SetExchanges(&exchanges)
+		return p
+	}
+	aapl := newProfile("STOCK", "AAPL", "ARCX;BATS;XNAS")
+	aapl.SetDescription(text("Apple, Inc."))
+	aapl.SetCurrency(text("USD"))
+	aapl.SetIcb(9572)
+	msft := newProfile("STOCK", "MSFT", "XNAS")
+	msft.SetDescription(text("Microsoft"))
+	msft.SetCurrency(text("USD"))
+	profiles := []*events.InstrumentProfile{aapl, msft}
+	for _, o := range []struct {
+		symbol, underlying, exchanges string
+		strike                        float64
+	}{
+		{".AAPL240119C150", "AAPL", "XCBO", 150.5},
+		{".MSFT240119P300", "MSFT", "XCBO;XISX", 300},
+	} {
+		p := newProfile("OPTION", o.symbol, o.exchanges)
+		p.SetUnderlying(&o.underlying)
+		p.SetMultiplier(100)
+		p.SetExpiration(19741)
+		p.SetStrike(o.strike)
+		p.SetOptionType(text("STAN"))
+		profiles = append(profiles, p)
+	}
+	return profiles
+}
+
+func TestFieldGet(t *testing.T) {
+	profiles := testProfiles()
+	if v := FieldDescription.Get(profiles[0]); v != "Apple, Inc." {
+		t.Fatalf(`DESCRIPTION should be "Apple, Inc.". But it equals %q`, v)
+	}
+	if v := FieldIcb.Get(profiles[0]); v != "9572" {
+		t.Fatalf(`ICB should be 9572. But it equals %q`, v)
+	}
+	if v := FieldExpiration.Get(profiles[2]); v != "2024-01-19" {
+		t.Fatalf(`EXPIRATION should be 2024-01-19. But it equals %v`, v)
+	}
+	if v := FieldStrike.Get(profiles[2]); v != "150.5" {
+		t.Fatalf(`STRIKE should be 150.5. But it equals %v`, v)
+	}
+	if v := FieldIcb.Get(profiles[1]); v != "" || !FieldIcb.IsEmpty(profiles[1]) {
+		t.Fatalf(`Zero ICB should be empty. But it equals %q`, v)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	profiles := testProfiles()
+	b := &bytes.Buffer{}
+	w := NewWriter(b)
+	if err := w.Write(profiles); err != nil {
+		t.Fatalf(`Write should not fail. But it fails with %v`, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf(`Close should not fail. But it fails with %v`, err)
+	}
+	expected := `#STOCK::=TYPE,SYMBOL,DESCRIPTION,EXCHANGES,CURRENCY,ICB
+STOCK,AAPL,"Apple, Inc.",ARCX;BATS;XNAS,USD,9572
+STOCK,MSFT,Microsoft,XNAS,USD,
+#OPTION::=TYPE,SYMBOL,EXCHANGES,MULTIPLIER,UNDERLYING,EXPIRATION,STRIKE,OPTION_TYPE
+OPTION,.AAPL240119C150,XCBO,100,AAPL,2024-01-19,150.5,STAN
+OPTION,.MSFT240119P300,XCBO;XISX,100,MSFT,2024-01-19,300,STAN
+##COMPLETE
+`
+	if b.String() != expected {
+		t.Fatalf(`Output should be %q. But it equals %q`, expected, b.String())
+	}
+}
+
+func TestWriterSelectedFields(t *testing.T) {
+	profiles := testProfiles()
+	b := &bytes.Buffer{}
+	w := NewWriter(b)
+	w.SetFields(FieldSymbol, FieldUnderlying)
+	_ = w.Write(profiles[1:3])
+	_ = w.Write(profiles[3:])
+	_ = w.Close()
+	expected := "#STOCK::=TYPE,SYMBOL,UNDERLYING\nSTOCK,MSFT,\n#OPTION::=TYPE,SYMBOL,UNDERLYING\n" +
+		"OPTION,.AAPL240119C150,AAPL\nOPTION,.MSFT240119P300,MSFT\n##COMPLETE\n"
+	if b.String() != expected {
+		t.Fatalf(`Output should be %q. But it equals %q`, expected, b.String())
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	profiles := testProfiles()
+	b := &bytes.Buffer{}
+	w := NewCSVWriter(b)
+	w.SetFields(FieldSymbol, FieldDescription, FieldStrike)
+	_ = w.Write(profiles[:3])
+	_ = w.Close()
+	expected := "SYMBOL,DESCRIPTION,STRIKE\nAAPL,\"Apple, Inc.\",\nMSFT,Microsoft,\n.AAPL240119C150,,150.5\n"
+	if b.String() != expected {
+		t.Fatalf(`Output should be %q. But it equals %q`, expected, b.String())
+	}
+}
+
+func TestJSONWriter(t *testing.T) {
+	profiles := testProfiles()
+	b := &bytes.Buffer{}
+	w := NewJSONWriter(b)
+	w.SetFields(FieldSymbol, FieldStrike)
+	_ = w.Write(profiles[2:])
+	_ = w.Close()
+	expected := "[\n{\"symbol\":\".AAPL240119C150\",\"strike\":150.5},\n{\"symbol\":\".MSFT240119P300\",\"strike\":300}\n]\n"
+	if b.String() != expected {
+		t.Fatalf(`Output should be %q. But it equals %q`, expected, b.String())
+	}
+
+	b.Reset()
+	w = NewJSONWriter(b)
+	_ = w.Write(profiles[:1])
+	_ = w.Close()
+	var decoded []*events.InstrumentProfile
+	if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatalf(`Unmarshal should not fail. But it fails with %v`, err)
+	}
+	if v := *decoded[0].Description(); v != "Apple, Inc." {
+		t.Fatalf(`Description should be "Apple, Inc.". But it equals %q`, v)
+	}
+
+	b.Reset()
+	w = NewJSONWriter(b)
+	_ = w.Close()
+	if b.String() != "[\n]\n" {
+		t.Fatalf(`Empty output should be "[\n]\n". But it equals %q`, b.String())
+	}
+}
+
+func TestParseField(t *testing.T) {
+	for name, expected := range map[string]Field{
+		"TYPE":           FieldType,
+		"instrumentType": FieldType,
+		"underlying":     FieldUnderlying,
+		"LAST_TRADE":     FieldLastTrade,
+		"lastTrade":      FieldLastTrade,
+	} {
+		f, err := ParseField(name)
+		if err != nil || f != expected {
+			t.Errorf(`Field %q should be %v. But it equals %v (%v)`, name, expected, f, err)
+		}
+	}
+	if _, err := ParseField("unknown"); err == nil {
+		t.Errorf(`ParseField of unknown should fail. But it succeeds`)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	profiles := testProfiles()
+	for _, test := range []struct {
+		conditions []string
+		symbols    string
+	}{
+		{nil, "AAPL,MSFT,.AAPL240119C150,.MSFT240119P300"},
+		{[]string{"TYPE=OPTION", "underlying=AAPL"}, ".AAPL240119C150"},
+		{[]string{"type=stock|option", "symbol=*msft*"}, "MSFT,.MSFT240119P300"},
+		{[]string{"EXPIRATION=2024-01-*"}, ".AAPL240119C150,.MSFT240119P300"},
+		{[]string{"STRIKE!="}, ".AAPL240119C150,.MSFT240119P300"},
+		{[]string{"EXCHANGES=*XNAS*", "ICB!=9572"}, "MSFT"},
+		{[]string{"SYMBOL=A*L"}, "AAPL"},
+	} {
+		filter, err := ParseFilter(test.conditions...)
+		if err != nil {
+			t.Fatalf(`ParseFilter should not fail. But it fails with %v`, err)
+		}
+		var symbols []string
+		for _, p := range filter.Apply(profiles) {
+			symbols = append(symbols, *p.Symbol())
+		}
+		if v := strings.Join(symbols, ","); v != test.symbols {
+			t.Errorf(`Filter %v should select %s. But it selects %s`, test.conditions, test.symbols, v)
+		}
+	}
+	for _, condition := range []string{"TYPE", "unknown=1"} {
+		if _, err := ParseFilter(condition); err == nil {
+			t.Errorf(`ParseFilter of %q should fail. But it succeeds`, condition)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize(testProfiles())
+	if s.Total != 4 {
+		t.Fatalf(`Total should be 4. But it equals %d`, s.Total)
+	}
+	if v := s.Types; len(v) != 2 || v[0] != (Count{"OPTION", 2}) || v[1] != (Count{"STOCK", 2}) {
+		t.Fatalf(`Types should be [{OPTION 2} {STOCK 2}]. But it equals %v`, v)
+	}
+	if v := s.Exchanges; len(v) != 5 || v[0] != (Count{"XCBO", 2}) || v[1] != (Count{"XNAS", 2}) || v[4] != (Count{"XISX", 1}) {
+		t.Fatalf(`Exchanges should be [{XCBO 2} {XNAS 2} {ARCX 1} {BATS 1} {XISX 1}]. But it equals %v`, v)
+	}
+}
+
+func TestFormatOfPath(t *testing.T) {
+	for path, expected := range map[string]Format{"a.ipf": FormatIPF, "a.IPF.gz": FormatIPF, "dir/a.csv": FormatCSV, "a.json": FormatJSON} {
+		if f, err := FormatOfPath(path); err != nil || f != expected {
+			t.Errorf(`Format of %s should be %v. But it equals %v (%v)`, path, expected, f, err)
+		}
+	}
+	if _, err := FormatOfPath("a.txt"); err == nil {
+		t.Errorf(`FormatOfPath of a.txt should fail. But it succeeds`)
+	}
+}
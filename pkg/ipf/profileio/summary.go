@@ -0,0 +1,53 @@
+package profileio
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+const exchangesSeparator = ";"
+
+// Count is the number of profiles having the key.
+type Count struct {
+	Key   string
+	Count int
+}
+
+// Summary counts instrument profiles by type and by exchange.
+type Summary struct {
+	Total int
+	// Types and Exchanges are sorted by count in descending order, then by key.
+	// A profile is counted for each of its exchanges, profiles without exchanges are not counted.
+	Types     []Count
+	Exchanges []Count
+}
+
+func Summarize(profiles []*events.InstrumentProfile) Summary {
+	types := map[string]int{}
+	exchanges := map[string]int{}
+	for _, p := range profiles {
+		types[FieldType.Get(p)]++
+		for _, exchange := range strings.Split(FieldExchanges.Get(p), exchangesSeparator) {
+			if exchange != "" {
+				exchanges[exchange]++
+			}
+		}
+	}
+	return Summary{Total: len(profiles), Types: sortedCounts(types), Exchanges: sortedCounts(exchanges)}
+}
+
+func sortedCounts(counts map[string]int) []Count {
+	result := make([]Count, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, Count{Key: key, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,133 @@
+package profileio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+// Writer writes instrument profiles in the IPF format. It is not safe for concurrent use.
+type Writer struct {
+	buffer   *bufio.Writer
+	writer   *csv.Writer
+	selected []Field
+	headers  map[string][]Field
+}
+
+func NewWriter(w io.Writer) *Writer {
+	buffer := bufio.NewWriter(w)
+	return &Writer{buffer: buffer, writer: csv.NewWriter(buffer), headers: map[string][]Field{}}
+}
+
+func (w *Writer) SetFields(fields ...Field) {
+	w.selected = fields
+}
+
+// Write writes the profiles. The header of a type is written before its first profile and again
+// when profiles of the type have other non-empty fields than the previous header declared.
+func (w *Writer) Write(profiles []*events.InstrumentProfile) error {
+	fieldsByType := w.fieldsByType(profiles)
+	for _, p := range profiles {
+		instrumentType := FieldType.Get(p)
+		fields := fieldsByType[instrumentType]
+		if header, ok := w.headers[instrumentType]; !ok || !equalFields(header, fields) {
+			if err := w.writeHeader(instrumentType, fields); err != nil {
+				return err
+			}
+		}
+		record := make([]string, len(fields))
+		for i, f := range fields {
+			record[i] = f.Get(p)
+		}
+		if err := w.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// Close writes ##COMPLETE and flushes the output.
+func (w *Writer) Close() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return err
+	}
+	if _, err := w.buffer.WriteString(completeLine + "\n"); err != nil {
+		return err
+	}
+	return w.buffer.Flush()
+}
+
+func (w *Writer) writeHeader(instrumentType string, fields []Field) error {
+	w.writer.Flush()
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.String()
+	}
+	if _, err := w.buffer.WriteString(headerPrefix + instrumentType + headerSeparator + strings.Join(names, ",") + "\n"); err != nil {
+		return err
+	}
+	w.headers[instrumentType] = fields
+	return nil
+}
+
+// fieldsByType returns the fields to write for each type: TYPE, then the selected fields or
+// the fields that are not empty in any profile of the type. SYMBOL is always written by default.
+func (w *Writer) fieldsByType(profiles []*events.InstrumentProfile) map[string][]Field {
+	result := map[string][]Field{}
+	if w.selected != nil {
+		fields := []Field{FieldType}
+		for _, f := range w.selected {
+			if f != FieldType {
+				fields = append(fields, f)
+			}
+		}
+		for _, p := range profiles {
+			result[FieldType.Get(p)] = fields
+		}
+		return result
+	}
+
+	used := map[string][]bool{}
+	for _, p := range profiles {
+		instrumentType := FieldType.Get(p)
+		flags, ok := used[instrumentType]
+		if !ok {
+			flags = make([]bool, len(fields))
+			flags[FieldType] = true
+			flags[FieldSymbol] = true
+			used[instrumentType] = flags
+		}
+		for i := range fields {
+			if !flags[i] && !Field(i).IsEmpty(p) {
+				flags[i] = true
+			}
+		}
+	}
+	for instrumentType, flags := range used {
+		var fields []Field
+		for i, isUsed := range flags {
+			if isUsed {
+				fields = append(fields, Field(i))
+			}
+		}
+		result[instrumentType] = fields
+	}
+	return result
+}
+
+func equalFields(a, b []Field) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package latency
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// JSONWriter writes reports as JSON, one report per line.
+type JSONWriter struct {
+	encoder *json.Encoder
+}
+
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{encoder: json.NewEncoder(w)}
+}
+
+func (w *JSONWriter) Write(report Report) error {
+	return w.encoder.Encode(report)
+}
+
+// CSVWriter writes reports as CSV rows, one row per scope and window:
+//
+//	time,scope,key,window,count,negative,min,mean,p50,p90,p99,p999,max
+//
+// The scope is all, symbol or exchange, the window is interval or total. Latencies are in nanoseconds.
+type CSVWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *CSVWriter) Write(report Report) error {
+	if !w.headerWritten {
+		w.headerWritten = true
+		if err := w.writer.Write([]string{
+			"time", "scope", "key", "window",
+			"count", "negative", "min", "mean", "p50", "p90", "p99", "p999", "max",
+		}); err != nil {
+			return err
+		}
+	}
+	timestamp := report.Time.UTC().Format(time.RFC3339Nano)
+	if err := w.writeWindow(timestamp, "all", "", report.All); err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(report.Symbols) {
+		if err := w.writeWindow(timestamp, "symbol", key, report.Symbols[key]); err != nil {
+			return err
+		}
+	}
+	for _, key := range sortedKeys(report.Exchanges) {
+		if err := w.writeWindow(timestamp, "exchange", key, report.Exchanges[key]); err != nil {
+			return err
+		}
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *CSVWriter) writeWindow(timestamp string, scope string, key string, window Window) error {
+	if err := w.writer.Write(summaryRow(timestamp, scope, key, "interval", window.Interval)); err != nil {
+		return err
+	}
+	return w.writer.Write(summaryRow(timestamp, scope, key, "total", window.Total))
+}
+
+func summaryRow(timestamp string, scope string, key string, window string, s Summary) []string {
+	return []string{
+		timestamp, scope, key, window,
+		strconv.FormatUint(s.Count, 10),
+		strconv.FormatUint(s.Negative, 10),
+		strconv.FormatInt(s.Min, 10),
+		strconv.FormatFloat(s.Mean, 'f', 0, 64),
+		strconv.FormatInt(s.P50, 10),
+		strconv.FormatInt(s.P90, 10),
+		strconv.FormatInt(s.P99, 10),
+		strconv.FormatInt(s.P999, 10),
+		strconv.FormatInt(s.Max, 10),
+	}
+}
@@ -0,0 +1,181 @@
+// Package latency measures latencies with log-bucketed histograms of bounded size.
+//
+// A Histogram keeps 2^precisionBits linear buckets for each power of two, so the relative error
+// of reported percentiles is below 2^-precisionBits regardless of the number of recorded values,
+// in the manner of HdrHistogram. A Tracker keeps histograms per interval and cumulatively,
+// overall and by symbol and exchange.
+package latency
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	// DefaultHighestValue is the largest latency a histogram tells apart, one hour in nanoseconds.
+	DefaultHighestValue = int64(3600_000_000_000)
+
+	// DefaultPrecisionBits gives the relative error below 1%.
+	DefaultPrecisionBits = 7
+)
+
+// Histogram counts non-negative values, typically nanoseconds. Values above the highest value are counted
+// in the last bucket, negative values are counted as 0. The minimum, maximum and mean are exact.
+type Histogram struct {
+	precisionBits int
+	subBuckets    int64
+	highestValue  int64
+	counts        []uint64
+	count         uint64
+	negative      uint64
+	sum           float64
+	min           int64
+	max           int64
+}
+
+// NewHistogram creates a histogram of values up to highestValue with the relative error below 2^-precisionBits.
+// It takes about (bits.Len(highestValue) - precisionBits + 1) * 2^precisionBits * 8 bytes.
+func NewHistogram(highestValue int64, precisionBits int) *Histogram {
+	if precisionBits < 1 {
+		precisionBits = 1
+	} else if precisionBits > 16 {
+		precisionBits = 16
+	}
+	subBuckets := int64(1) << precisionBits
+	if highestValue < subBuckets {
+		highestValue = subBuckets
+	}
+	h := &Histogram{
+		precisionBits: precisionBits,
+		subBuckets:    subBuckets,
+		highestValue:  highestValue,
+	}
+	h.counts = make([]uint64, h.indexOf(highestValue)+1)
+	h.Reset()
+	return h
+}
+
+func (h *Histogram) indexOf(value int64) int {
+	if value < h.subBuckets {
+		return int(value)
+	}
+	shift := bits.Len64(uint64(value)) - h.precisionBits - 1
+	return shift*int(h.subBuckets) + int(value>>shift)
+}
+
+// highestEquivalentValue returns the largest value counted in the bucket with the index.
+func (h *Histogram) highestEquivalentValue(index int) int64 {
+	if int64(index) < h.subBuckets {
+		return int64(index)
+	}
+	shift := index/int(h.subBuckets) - 1
+	mantissa := int64(index) - int64(shift)*h.subBuckets
+	return mantissa<<shift + (int64(1)<<shift - 1)
+}
+
+func (h *Histogram) Record(value int64) {
+	if value < 0 {
+		h.negative++
+		value = 0
+	}
+	if value < h.min {
+		h.min = value
+	}
+	if value > h.max {
+		h.max = value
+	}
+	h.sum += float64(value)
+	h.count++
+	if value > h.highestValue {
+		value = h.highestValue
+	}
+	h.counts[h.indexOf(value)]++
+}
+
+// Merge adds the values of the other histogram, which must have the same highest value and precision.
+func (h *Histogram) Merge(other *Histogram) {
+	if other.count == 0 {
+		return
+	}
+	for i, count := range other.counts {
+		h.counts[i] += count
+	}
+	h.count += other.count
+	h.negative += other.negative
+	h.sum += other.sum
+	if other.min < h.min {
+		h.min = other.min
+	}
+	if other.max > h.max {
+		h.max = other.max
+	}
+}
+
+func (h *Histogram) Reset() {
+	for i := range h.counts {
+		h.counts[i] = 0
+	}
+	h.count = 0
+	h.negative = 0
+	h.sum = 0
+	h.min = math.MaxInt64
+	h.max = 0
+}
+
+func (h *Histogram) Count() uint64 {
+	return h.count
+}
+
+// Negative returns the number of recorded negative values, e.g. latencies of events from the future
+// because of unsynchronized clocks.
+func (h *Histogram) Negative() uint64 {
+	return h.negative
+}
+
+// Min returns the smallest recorded value, 0 if there are none.
+func (h *Histogram) Min() int64 {
+	if h.count == 0 {
+		return 0
+	}
+	return h.min
+}
+
+func (h *Histogram) Max() int64 {
+	return h.max
+}
+
+// Mean returns the mean of recorded values, NaN if there are none.
+func (h *Histogram) Mean() float64 {
+	if h.count == 0 {
+		return math.NaN()
+	}
+	return h.sum / float64(h.count)
+}
+
+// Percentile returns the value below or equal to which percentile percents of recorded values fall,
+// up to the precision of the histogram. It returns 0 if there are no values.
+func (h *Histogram) Percentile(percentile float64) int64 {
+	if h.count == 0 {
+		return 0
+	}
+	target := uint64(math.Ceil(percentile / 100 * float64(h.count)))
+	if target < 1 {
+		target = 1
+	}
+	var cumulative uint64
+	for i, count := range h.counts {
+		cumulative += count
+		if cumulative >= target {
+			value := h.highestEquivalentValue(i)
+			// the last bucket also counts values above the highest value
+			if value > h.max || i == len(h.counts)-1 {
+				return h.max
+			}
+			if value < h.min {
+				return h.min
+			}
+			return value
+		}
+	}
+	return h.max
+}
@@ -0,0 +1,161 @@
+package latency
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestBucketsAreContiguous(t *testing.T) {
+	h := NewHistogram(DefaultHighestValue, 3)
+	previous := int64(-1)
+	for i := range h.counts {
+		value := h.highestEquivalentValue(i)
+		if value <= previous {
+			t.Fatalf(`Bucket %v should end after %v. But it ends at %v`, i, previous, value)
+		}
+		if h.indexOf(value) != i || h.indexOf(previous+1) != i {
+			t.Fatalf(`Values %v..%v should fall into bucket %v`, previous+1, value, i)
+		}
+		previous = value
+	}
+	if previous < DefaultHighestValue {
+		t.Errorf(`The last bucket should end after %v. But it ends at %v`, DefaultHighestValue, previous)
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	h := NewHistogram(DefaultHighestValue, DefaultPrecisionBits)
+	for i := int64(1); i <= 10000; i++ {
+		h.Record(i * 1000)
+	}
+	for _, test := range []struct {
+		percentile float64
+		expected   int64
+	}{
+		{50, 5_000_000},
+		{90, 9_000_000},
+		{99, 9_900_000},
+		{99.9, 9_990_000},
+		{100, 10_000_000},
+	} {
+		actual := h.Percentile(test.percentile)
+		if math.Abs(float64(actual-test.expected))/float64(test.expected) > 1.0/128 {
+			t.Errorf(`Percentile %v should be about %v. But it equals %v`, test.percentile, test.expected, actual)
+		}
+	}
+	if h.Min() != 1000 || h.Max() != 10_000_000 || h.Mean() != 5_000_500 {
+		t.Errorf(`Min, max and mean should be exact. But they equal %v, %v, %v`, h.Min(), h.Max(), h.Mean())
+	}
+}
+
+func TestPercentilesOfRandomValues(t *testing.T) {
+	h := NewHistogram(DefaultHighestValue, DefaultPrecisionBits)
+	random := rand.New(rand.NewSource(1))
+	values := make([]int64, 100000)
+	for i := range values {
+		values[i] = int64(random.ExpFloat64() * 2_000_000)
+		h.Record(values[i])
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	for _, percentile := range []float64{50, 90, 99, 99.9} {
+		expected := values[int(math.Ceil(percentile/100*float64(len(values))))-1]
+		actual := h.Percentile(percentile)
+		if actual < expected || float64(actual-expected) > float64(expected)/128 {
+			t.Errorf(`Percentile %v should be about %v. But it equals %v`, percentile, expected, actual)
+		}
+	}
+}
+
+func TestOutOfRangeValues(t *testing.T) {
+	h := NewHistogram(1_000_000, DefaultPrecisionBits)
+	h.Record(-5)
+	h.Record(5_000_000)
+	if h.Negative() != 1 || h.Min() != 0 || h.Max() != 5_000_000 {
+		t.Errorf(`Out of range values should be clamped. But min and max equal %v, %v`, h.Min(), h.Max())
+	}
+	if h.Percentile(100) != 5_000_000 || h.Percentile(50) != 0 {
+		t.Errorf(`Percentiles should be 0 and 5000000. But they equal %v, %v`, h.Percentile(50), h.Percentile(100))
+	}
+
+	empty := NewHistogram(DefaultHighestValue, DefaultPrecisionBits)
+	if empty.Percentile(99) != 0 || empty.Min() != 0 || !math.IsNaN(empty.Mean()) {
+		t.Errorf(`Empty histogram should have zero percentiles`)
+	}
+}
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker(true, true)
+	tracker.Record("AAPL", "Q", 1000)
+	tracker.Record("AAPL", "Z", 3000)
+	tracker.Record("IBM", "", 2000)
+	first := tracker.Report()
+	if first.All.Interval.Count != 3 || first.All.Total.Count != 3 || first.All.Interval.Max != 3000 {
+		t.Errorf(`Report should count 3 events. But it equals %+v`, first.All)
+	}
+	if first.Symbols["AAPL"].Interval.Count != 2 || len(first.Exchanges) != 2 {
+		t.Errorf(`Report should have breakdowns. But it equals %+v`, first)
+	}
+
+	tracker.Record("IBM", "Q", 5000)
+	second := tracker.Report()
+	if second.All.Interval.Count != 1 || second.All.Total.Count != 4 || second.All.Total.Max != 5000 {
+		t.Errorf(`Total should include all intervals. But it equals %+v`, second.All)
+	}
+	if second.Symbols["AAPL"].Interval.Count != 0 || second.Symbols["AAPL"].Total.Count != 2 {
+		t.Errorf(`AAPL should have no events in the interval. But it equals %+v`, second.Symbols["AAPL"])
+	}
+}
+
+func TestTrackerLimitsBreakdowns(t *testing.T) {
+	tracker := NewTracker(true, true)
+	tracker.SetMaxBreakdowns(2)
+	for i, symbol := range []string{"AAPL", "IBM", "MSFT", "AAPL", "GOOG"} {
+		tracker.Record(symbol, string(rune('A'+i)), 1000)
+	}
+	report := tracker.Report()
+	symbols := report.Symbols
+	if len(symbols) != 3 || symbols["AAPL"].Interval.Count != 2 || symbols["IBM"].Interval.Count != 1 {
+		t.Errorf(`Symbols should be AAPL, IBM and other. But they equal %v`, sortedKeys(report.Symbols))
+	}
+	if report.Symbols[OtherBreakdown].Interval.Count != 2 {
+		t.Errorf(`Other should count MSFT and GOOG. But it equals %+v`, report.Symbols[OtherBreakdown])
+	}
+	if len(report.Exchanges) != 3 || report.Exchanges[OtherBreakdown].Interval.Count != 3 {
+		t.Errorf(`Exchanges should be A, B and other. But they equal %v`, sortedKeys(report.Exchanges))
+	}
+	if report.All.Interval.Count != 5 {
+		t.Errorf(`All should count 5 events. But it equals %+v`, report.All.Interval)
+	}
+}
+
+func TestExport(t *testing.T) {
+	tracker := NewTracker(true, false)
+	tracker.Record("AAPL", "Q", 1000)
+	report := tracker.Report()
+
+	buffer := &bytes.Buffer{}
+	if err := NewJSONWriter(buffer).Write(report); err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(buffer.Bytes(), &decoded); err != nil || decoded.Symbols["AAPL"].Total.P99 != 1000 {
+		t.Errorf(`JSON should be decoded back. But it equals %v`, buffer.String())
+	}
+
+	buffer.Reset()
+	writer := NewCSVWriter(buffer)
+	_ = writer.Write(report)
+	_ = writer.Write(tracker.Report())
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 9 || lines[0] != "time,scope,key,window,count,negative,min,mean,p50,p90,p99,p999,max" {
+		t.Fatalf(`CSV should have a header and 8 rows. But it equals %v`, buffer.String())
+	}
+	if !strings.HasSuffix(lines[3], ",symbol,AAPL,interval,1,0,1000,1000,1000,1000,1000,1000,1000") {
+		t.Errorf(`Row should describe AAPL. But it equals %v`, lines[3])
+	}
+}
@@ -0,0 +1,182 @@
+package latency
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxBreakdowns is the default number of symbols and of exchanges that have their own histograms.
+	DefaultMaxBreakdowns = 1000
+	// OtherBreakdown is the key of the histogram of symbols and exchanges over the limit.
+	OtherBreakdown = "other"
+
+	// breakdownPrecisionBits keeps histograms by symbol and exchange small, the relative error is below 4%.
+	breakdownPrecisionBits = 5
+)
+
+// Summary describes the values of a histogram, in nanoseconds.
+type Summary struct {
+	Count    uint64  `json:"count"`
+	Negative uint64  `json:"negative,omitempty"`
+	Min      int64   `json:"min"`
+	Mean     float64 `json:"mean"`
+	P50      int64   `json:"p50"`
+	P90      int64   `json:"p90"`
+	P99      int64   `json:"p99"`
+	P999     int64   `json:"p999"`
+	Max      int64   `json:"max"`
+}
+
+func (h *Histogram) Summary() Summary {
+	mean := h.Mean()
+	if h.count == 0 {
+		mean = 0
+	}
+	return Summary{
+		Count:    h.count,
+		Negative: h.negative,
+		Min:      h.Min(),
+		Mean:     mean,
+		P50:      h.Percentile(50),
+		P90:      h.Percentile(90),
+		P99:      h.Percentile(99),
+		P999:     h.Percentile(99.9),
+		Max:      h.Max(),
+	}
+}
+
+// Window holds the summaries of the last interval and of all intervals so far.
+type Window struct {
+	Interval Summary `json:"interval"`
+	Total    Summary `json:"total"`
+}
+
+// Report is the state of a tracker at the end of an interval.
+type Report struct {
+	Time      time.Time         `json:"time"`
+	Interval  time.Duration     `json:"interval"`
+	All       Window            `json:"all"`
+	Symbols   map[string]Window `json:"symbols,omitempty"`
+	Exchanges map[string]Window `json:"exchanges,omitempty"`
+}
+
+type pair struct {
+	interval *Histogram
+	total    *Histogram
+}
+
+func newPair(precisionBits int) *pair {
+	return &pair{
+		interval: NewHistogram(DefaultHighestValue, precisionBits),
+		total:    NewHistogram(DefaultHighestValue, precisionBits),
+	}
+}
+
+// window merges the interval into the total and resets the interval.
+func (p *pair) window() Window {
+	p.total.Merge(p.interval)
+	w := Window{Interval: p.interval.Summary(), Total: p.total.Summary()}
+	p.interval.Reset()
+	return w
+}
+
+// Tracker records latencies by symbol and exchange. It is safe for concurrent use.
+type Tracker struct {
+	mu            sync.Mutex
+	all           *pair
+	symbols       map[string]*pair
+	exchanges     map[string]*pair
+	bySymbol      bool
+	byExchange    bool
+	maxBreakdowns int
+	intervalStart time.Time
+}
+
+// NewTracker creates a tracker with breakdowns by symbol and by exchange if requested.
+func NewTracker(bySymbol bool, byExchange bool) *Tracker {
+	return &Tracker{
+		all:           newPair(DefaultPrecisionBits),
+		symbols:       map[string]*pair{},
+		exchanges:     map[string]*pair{},
+		bySymbol:      bySymbol,
+		byExchange:    byExchange,
+		maxBreakdowns: DefaultMaxBreakdowns,
+		intervalStart: time.Now(),
+	}
+}
+
+// SetMaxBreakdowns limits the number of symbols and of exchanges that have their own histograms,
+// DefaultMaxBreakdowns by default. Latencies of other symbols and exchanges are recorded under OtherBreakdown.
+func (t *Tracker) SetMaxBreakdowns(max int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.maxBreakdowns = max
+}
+
+// Record records the latency in nanoseconds. The exchange may be empty for events without one.
+func (t *Tracker) Record(symbol string, exchange string, latency int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.all.interval.Record(latency)
+	if t.bySymbol {
+		t.breakdown(t.symbols, symbol).interval.Record(latency)
+	}
+	if t.byExchange && exchange != "" {
+		t.breakdown(t.exchanges, exchange).interval.Record(latency)
+	}
+}
+
+func (t *Tracker) breakdown(pairs map[string]*pair, key string) *pair {
+	p, ok := pairs[key]
+	if ok {
+		return p
+	}
+	count := len(pairs)
+	if _, ok := pairs[OtherBreakdown]; ok {
+		count--
+	}
+	if count >= t.maxBreakdowns {
+		key = OtherBreakdown
+		if p, ok := pairs[key]; ok {
+			return p
+		}
+	}
+	p = newPair(breakdownPrecisionBits)
+	pairs[key] = p
+	return p
+}
+
+// Report ends the interval and returns the summaries of it and of all intervals so far.
+func (t *Tracker) Report() Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	r := Report{Time: now, Interval: now.Sub(t.intervalStart), All: t.all.window()}
+	if t.bySymbol {
+		r.Symbols = windows(t.symbols)
+	}
+	if t.byExchange {
+		r.Exchanges = windows(t.exchanges)
+	}
+	t.intervalStart = now
+	return r
+}
+
+func windows(pairs map[string]*pair) map[string]Window {
+	result := make(map[string]Window, len(pairs))
+	for key, p := range pairs {
+		result[key] = p.window()
+	}
+	return result
+}
+
+func sortedKeys(windows map[string]Window) []string {
+	keys := make([]string, 0, len(windows))
+	for key := range windows {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
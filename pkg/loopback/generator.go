@@ -0,0 +1,102 @@
+package loopback
+
+import (
+	"fmt"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/batch"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/order"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/side"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/timeandsale"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// maxSequence is the largest sequence of events packing it with the time, (1 << 22) - 1.
+const maxSequence = (1 << 22) - 1
+
+// EventTypes are the event types a loopback run can generate.
+var EventTypes = []eventcodes.EventCode{eventcodes.Quote, eventcodes.TimeAndSale, eventcodes.Order}
+
+// generator creates the n-th synthetic event of a symbol carrying the time it is published in nanoseconds.
+type generator func(symbol string, n int64, timeNanos int64) interface{}
+
+func generatorOf(eventType eventcodes.EventCode) (generator, error) {
+	switch eventType {
+	case eventcodes.Quote:
+		return newQuote, nil
+	case eventcodes.TimeAndSale:
+		return newTimeAndSale, nil
+	case eventcodes.Order:
+		return newOrder, nil
+	default:
+		return nil, fmt.Errorf("loopback of %s events is not supported", eventType)
+	}
+}
+
+// newQuote keeps the time in the bid and ask times, the sequence keeps their milliseconds.
+func newQuote(symbol string, n int64, timeNanos int64) interface{} {
+	q := quote.NewQuote(symbol)
+	timeMillis := timeutil.GetMillisFromNanos(timeNanos)
+	q.SetBidTime(timeMillis)
+	q.SetAskTime(timeMillis)
+	q.SetTimeNanoPart(int32(timeutil.GetNanoPartFromNanos(timeNanos)))
+	q.SetSequence(int32(n & maxSequence))
+	q.SetBidPrice(price(n))
+	q.SetBidSize(100)
+	q.SetAskPrice(price(n) + 0.01)
+	q.SetAskSize(100)
+	return q
+}
+
+func newTimeAndSale(symbol string, n int64, timeNanos int64) interface{} {
+	t := timeandsale.NewTimeAndSale(symbol)
+	t.SetTimeNanos(timeNanos)
+	_ = t.SetSequence(n & maxSequence)
+	t.SetPrice(price(n))
+	t.SetSize(100)
+	t.SetAggressorSide(side.Buy)
+	return t
+}
+
+func newOrder(symbol string, n int64, timeNanos int64) interface{} {
+	o := order.NewOrder(symbol)
+	_ = o.SetIndex(n)
+	o.SetTimeNanos(timeNanos)
+	_ = o.SetSequence(n & maxSequence)
+	o.SetPrice(price(n))
+	o.SetSize(100)
+	o.SetSide(side.Buy)
+	return o
+}
+
+func price(n int64) float64 {
+	return 100 + float64(n%100)/100
+}
+
+// timeNanosOf returns the time in nanoseconds a generated event was published at.
+func timeNanosOf(event interface{}) (int64, bool) {
+	switch e := event.(type) {
+	case *quote.Quote:
+		return e.TimeNanos(), true
+	case *timeandsale.TimeAndSale:
+		return e.TimeNanos(), true
+	case *order.Order:
+		return e.TimeNanos(), true
+	default:
+		return 0, false
+	}
+}
+
+func timeNanosAt(b batch.Batch, i int) (int64, bool) {
+	switch b := b.(type) {
+	case *batch.QuoteBatch:
+		return b.TimeNanos(i), true
+	case *batch.TimeAndSaleBatch:
+		return b.TimeNanos(i), true
+	case *batch.OrderBatch:
+		return b.TimeNanos(i), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,250 @@
+// Package loopback measures the overhead of publishing and receiving events without a server.
+//
+// A Run publishes synthetic events carrying the time they are published at and records the latency
+// of every event it receives back, typically from a subscription of the same LocalHub endpoint,
+// along with the throughput and the allocations per event.
+package loopback
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/batch"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/latency"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultDrainTimeout = time.Second
+)
+
+// Publisher is the part of api.DXPublisher used by a Run.
+type Publisher interface {
+	Publish(events []interface{}) error
+}
+
+type Config struct {
+	EventType eventcodes.EventCode
+	// Symbols events are published for in turn.
+	Symbols []string
+	// Rate is the number of events published per second, 0 publishes as fast as possible.
+	Rate float64
+	// BatchSize is the number of events published by one call, 100 by default.
+	BatchSize int
+	// Duration and Count limit the publishing time and the number of published events,
+	// at least one of them must be set.
+	Duration time.Duration
+	Count    int64
+	// DrainTimeout is how long a run waits for the next event once everything is published,
+	// 1 second by default. Events not received by then, like conflated quotes, are lost.
+	DrainTimeout time.Duration
+}
+
+// Symbols returns n synthetic symbols, LOOP0, LOOP1 and so on.
+func Symbols(n int) []string {
+	symbols := make([]string, n)
+	for i := range symbols {
+		symbols[i] = "LOOP" + strconv.Itoa(i)
+	}
+	return symbols
+}
+
+type Result struct {
+	EventType string  `json:"eventType"`
+	Published int64   `json:"published"`
+	Received  int64   `json:"received"`
+	Seconds   float64 `json:"seconds"`
+	// Throughput is the number of events received per second.
+	Throughput float64 `json:"eventsPerSecond"`
+	// AllocsPerEvent and BytesPerEvent are the heap allocations of the whole process per published event.
+	AllocsPerEvent float64         `json:"allocsPerEvent"`
+	BytesPerEvent  float64         `json:"bytesPerEvent"`
+	Latency        latency.Summary `json:"latencyNanos"`
+}
+
+// Lost returns the number of published events that were not received.
+func (r Result) Lost() int64 {
+	if r.Received > r.Published {
+		return 0
+	}
+	return r.Published - r.Received
+}
+
+// Run publishes events once by Execute. Receive and ReceiveBatch are the listeners of received events
+// and may be called concurrently.
+type Run struct {
+	config    Config
+	publisher Publisher
+	generate  generator
+
+	mu           sync.Mutex
+	histogram    *latency.Histogram
+	received     int64
+	lastReceived time.Time
+	arrived      chan struct{}
+}
+
+func NewRun(config Config, publisher Publisher) (*Run, error) {
+	generate, err := generatorOf(config.EventType)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Symbols) == 0 {
+		return nil, errors.New("no symbols to publish")
+	}
+	if config.Duration <= 0 && config.Count <= 0 {
+		return nil, errors.New("either duration or count must be positive")
+	}
+	if config.Rate < 0 {
+		return nil, fmt.Errorf("negative rate: %v", config.Rate)
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.DrainTimeout <= 0 {
+		config.DrainTimeout = defaultDrainTimeout
+	}
+	return &Run{
+		config:    config,
+		publisher: publisher,
+		generate:  generate,
+		histogram: latency.NewHistogram(latency.DefaultHighestValue, latency.DefaultPrecisionBits),
+		arrived:   make(chan struct{}, 1),
+	}, nil
+}
+
+// Receive records the latencies of generated events, other events are ignored.
+func (r *Run) Receive(events []interface{}) {
+	now := time.Now()
+	r.mu.Lock()
+	for _, event := range events {
+		if timeNanos, ok := timeNanosOf(event); ok {
+			r.record(now, timeNanos)
+		}
+	}
+	r.mu.Unlock()
+	r.notify()
+}
+
+// ReceiveBatch records the latencies of generated events received as columns.
+func (r *Run) ReceiveBatch(b batch.Batch) {
+	now := time.Now()
+	r.mu.Lock()
+	for i := 0; i < b.Len(); i++ {
+		if timeNanos, ok := timeNanosAt(b, i); ok {
+			r.record(now, timeNanos)
+		}
+	}
+	r.mu.Unlock()
+	r.notify()
+}
+
+func (r *Run) record(now time.Time, timeNanos int64) {
+	r.histogram.Record(now.UnixNano() - timeNanos)
+	r.received++
+	r.lastReceived = now
+}
+
+func (r *Run) notify() {
+	select {
+	case r.arrived <- struct{}{}:
+	default:
+	}
+}
+
+// Execute publishes events until the count or the duration is reached and waits for them to be received.
+func (r *Run) Execute() (Result, error) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	published, err := r.publish(start)
+	if err != nil {
+		return Result{}, err
+	}
+	r.drain(published)
+	runtime.ReadMemStats(&after)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := Result{
+		EventType: r.config.EventType.String(),
+		Published: published,
+		Received:  r.received,
+		Latency:   r.histogram.Summary(),
+	}
+	end := time.Now()
+	if r.received > 0 {
+		end = r.lastReceived
+	}
+	result.Seconds = end.Sub(start).Seconds()
+	if result.Seconds > 0 {
+		result.Throughput = float64(result.Received) / result.Seconds
+	}
+	if published > 0 {
+		result.AllocsPerEvent = float64(after.Mallocs-before.Mallocs) / float64(published)
+		result.BytesPerEvent = float64(after.TotalAlloc-before.TotalAlloc) / float64(published)
+	}
+	return result, nil
+}
+
+func (r *Run) publish(start time.Time) (int64, error) {
+	events := make([]interface{}, 0, r.config.BatchSize)
+	published := int64(0)
+	for r.config.Count <= 0 || published < r.config.Count {
+		if r.config.Rate > 0 {
+			due := time.Duration(float64(published) / r.config.Rate * float64(time.Second))
+			if r.config.Duration > 0 && due >= r.config.Duration {
+				break
+			}
+			if wait := time.Until(start.Add(due)); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		if r.config.Duration > 0 && time.Since(start) >= r.config.Duration {
+			break
+		}
+		size := int64(r.config.BatchSize)
+		if r.config.Count > 0 && r.config.Count-published < size {
+			size = r.config.Count - published
+		}
+		events = events[:0]
+		timeNanos := time.Now().UnixNano()
+		for i := published; i < published+size; i++ {
+			events = append(events, r.generate(r.config.Symbols[i%int64(len(r.config.Symbols))], i, timeNanos))
+		}
+		if err := r.publisher.Publish(events); err != nil {
+			return published, fmt.Errorf("Publish: %w", err)
+		}
+		published += size
+	}
+	return published, nil
+}
+
+// drain waits until all published events are received or none arrives within the drain timeout.
+func (r *Run) drain(published int64) {
+	timer := time.NewTimer(r.config.DrainTimeout)
+	defer timer.Stop()
+	for {
+		r.mu.Lock()
+		received := r.received
+		r.mu.Unlock()
+		if received >= published {
+			return
+		}
+		select {
+		case <-r.arrived:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(r.config.DrainTimeout)
+		case <-timer.C:
+			return
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package loopback
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/batch"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+)
+
+// hub delivers published events to the run asynchronously, dropping every skip-th event when skip is set.
+// With batches events are delivered as columns.
+type hub struct {
+	run     *Run
+	skip    int
+	batches bool
+	events  chan []interface{}
+	n       int
+}
+
+func newHub(skip int, batches bool) *hub {
+	h := &hub{skip: skip, batches: batches, events: make(chan []interface{}, 100)}
+	go func() {
+		collector := &batch.Collector{}
+		for events := range h.events {
+			if !h.batches {
+				h.run.Receive(events)
+				continue
+			}
+			for _, b := range collector.Collect(events) {
+				h.run.ReceiveBatch(b)
+			}
+		}
+	}()
+	return h
+}
+
+func (h *hub) Publish(events []interface{}) error {
+	delivered := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		h.n++
+		if h.skip > 0 && h.n%h.skip == 0 {
+			continue
+		}
+		delivered = append(delivered, event)
+	}
+	h.events <- delivered
+	return nil
+}
+
+func execute(t *testing.T, config Config, skip int) Result {
+	return executeOn(t, newHub(skip, false), config)
+}
+
+func executeOn(t *testing.T, h *hub, config Config) Result {
+	defer close(h.events)
+	run, err := NewRun(config, h)
+	if err != nil {
+		t.Fatalf(`NewRun should not fail. But it returns %v`, err)
+	}
+	h.run = run
+	result, err := run.Execute()
+	if err != nil {
+		t.Fatalf(`Execute should not fail. But it returns %v`, err)
+	}
+	return result
+}
+
+func TestGeneratedEventsKeepTime(t *testing.T) {
+	timeNanos := time.Date(2024, 5, 17, 10, 30, 15, 123456789, time.UTC).UnixNano()
+	for _, eventType := range EventTypes {
+		generate, err := generatorOf(eventType)
+		if err != nil {
+			t.Fatalf(`generatorOf(%v) should not fail. But it returns %v`, eventType, err)
+		}
+		event := generate("AAPL", 5_000_000, timeNanos)
+		value, ok := timeNanosOf(event)
+		if !ok || value != timeNanos {
+			t.Errorf(`Time of %v should be %v. But it equals %v`, eventType, timeNanos, value)
+		}
+	}
+	if _, err := generatorOf(eventcodes.Candle); err == nil {
+		t.Errorf(`generatorOf(Candle) should fail`)
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, eventType := range EventTypes {
+		result := execute(t, Config{EventType: eventType, Symbols: Symbols(3), Count: 1050}, 0)
+		if result.Published != 1050 || result.Received != 1050 || result.Lost() != 0 {
+			t.Errorf(`Published and received %v events should be 1050. But they equal %v and %v`,
+				eventType, result.Published, result.Received)
+		}
+		if result.EventType != eventType.String() {
+			t.Errorf(`EventType should be %v. But it equals %v`, eventType, result.EventType)
+		}
+		if result.Latency.Count != 1050 || result.Latency.Negative != 0 {
+			t.Errorf(`Latency count should be 1050. But it equals %v with %v negative`,
+				result.Latency.Count, result.Latency.Negative)
+		}
+		if result.Throughput <= 0 || result.AllocsPerEvent <= 0 {
+			t.Errorf(`Throughput and allocations should be positive. But they equal %v and %v`,
+				result.Throughput, result.AllocsPerEvent)
+		}
+	}
+}
+
+func TestRunBatches(t *testing.T) {
+	for _, eventType := range EventTypes {
+		result := executeOn(t, newHub(0, true), Config{EventType: eventType, Symbols: Symbols(3), Count: 500})
+		if result.Received != 500 || result.Latency.Count != 500 || result.Latency.Negative != 0 {
+			t.Errorf(`Received %v events should be 500. But it equals %v with %v negative latencies`,
+				eventType, result.Received, result.Latency.Negative)
+		}
+	}
+}
+
+func TestRunRate(t *testing.T) {
+	result := execute(t, Config{EventType: eventcodes.Quote, Symbols: Symbols(1), Rate: 2000, BatchSize: 10,
+		Count: 200}, 0)
+	if result.Seconds < 0.09 {
+		t.Errorf(`200 events at 2000 per second should take 0.1 seconds. But it takes %v`, result.Seconds)
+	}
+	result = execute(t, Config{EventType: eventcodes.Quote, Symbols: Symbols(1), Rate: 1000, BatchSize: 10,
+		Duration: 100 * time.Millisecond}, 0)
+	if result.Published < 80 || result.Published > 100 {
+		t.Errorf(`Published events should be about 100. But it equals %v`, result.Published)
+	}
+}
+
+func TestRunLost(t *testing.T) {
+	result := execute(t, Config{EventType: eventcodes.Quote, Symbols: Symbols(2), Count: 100,
+		DrainTimeout: 50 * time.Millisecond}, 10)
+	if result.Received != 90 || result.Lost() != 10 {
+		t.Errorf(`Received and lost events should be 90 and 10. But they equal %v and %v`,
+			result.Received, result.Lost())
+	}
+}
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish([]interface{}) error {
+	return errors.New("closed")
+}
+
+func TestRunErrors(t *testing.T) {
+	configs := []Config{
+		{EventType: eventcodes.Trade, Symbols: Symbols(1), Count: 1},
+		{EventType: eventcodes.Quote, Count: 1},
+		{EventType: eventcodes.Quote, Symbols: Symbols(1)},
+		{EventType: eventcodes.Quote, Symbols: Symbols(1), Count: 1, Rate: -1},
+	}
+	for _, config := range configs {
+		if _, err := NewRun(config, failingPublisher{}); err == nil {
+			t.Errorf(`NewRun(%+v) should fail`, config)
+		}
+	}
+	run, _ := NewRun(Config{EventType: eventcodes.Quote, Symbols: Symbols(1), Count: 1}, failingPublisher{})
+	if _, err := run.Execute(); err == nil {
+		t.Errorf(`Execute should fail when publishing fails`)
+	}
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// DefaultBuckets are the bounds of latency buckets in seconds, from 1µs to 4s.
+	DefaultBuckets = ExponentialBuckets(0.000001, 4, 12)
+
+	// SizeBuckets are the bounds of size buckets, from 1 to 65536.
+	SizeBuckets = ExponentialBuckets(1, 4, 9)
+)
+
+// ExponentialBuckets returns count bounds starting with start and multiplied by factor.
+func ExponentialBuckets(start float64, factor float64, count int) []float64 {
+	if start <= 0 || factor <= 1 || count < 1 {
+		panic(fmt.Sprintf("metrics: invalid exponential buckets %v, %v, %v", start, factor, count))
+	}
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start
+		start *= factor
+	}
+	return buckets
+}
+
+// Histogram counts observed values in buckets. A value falls into the first bucket with the bound not less than it.
+type Histogram struct {
+	bounds  []float64
+	buckets []atomic.Uint64
+	count   atomic.Uint64
+	sum     atomic.Uint64
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	buckets = checkBuckets(buckets)
+	return &Histogram{bounds: buckets, buckets: make([]atomic.Uint64, len(buckets)+1)}
+}
+
+func checkBuckets(buckets []float64) []float64 {
+	if len(buckets) == 0 {
+		return DefaultBuckets
+	}
+	if !sort.Float64sAreSorted(buckets) {
+		panic(fmt.Sprintf("metrics: buckets %v are not sorted", buckets))
+	}
+	if math.IsInf(buckets[len(buckets)-1], 1) {
+		buckets = buckets[:len(buckets)-1]
+	}
+	return buckets
+}
+
+func (h *Histogram) Observe(value float64) {
+	h.buckets[sort.SearchFloat64s(h.bounds, value)].Add(1)
+	h.count.Add(1)
+	for {
+		old := h.sum.Load()
+		if h.sum.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+value)) {
+			return
+		}
+	}
+}
+
+// ObserveDuration observes the duration in seconds.
+func (h *Histogram) ObserveDuration(duration time.Duration) {
+	h.Observe(duration.Seconds())
+}
+
+// ObserveSince observes the time elapsed since start in seconds.
+func (h *Histogram) ObserveSince(start time.Time) {
+	h.ObserveDuration(time.Since(start))
+}
+
+func (h *Histogram) Count() uint64 {
+	return h.count.Load()
+}
+
+func (h *Histogram) Sum() float64 {
+	return math.Float64frombits(h.sum.Load())
+}
+
+func (h *Histogram) write(w *bufio.Writer, name string) {
+	h.writeSamples(w, name, nil, nil)
+}
+
+func (h *Histogram) writeSamples(w *bufio.Writer, name string, labels []string, values []string) {
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.buckets[i].Load()
+		writeSample(w, name+"_bucket", labels, values, "le", formatFloat(bound), strconv.FormatUint(cumulative, 10))
+	}
+	cumulative += h.buckets[len(h.bounds)].Load()
+	writeSample(w, name+"_bucket", labels, values, "le", "+Inf", strconv.FormatUint(cumulative, 10))
+	writeSample(w, name+"_sum", labels, values, "", "", formatFloat(h.Sum()))
+	writeSample(w, name+"_count", labels, values, "", "", strconv.FormatUint(cumulative, 10))
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a monotonically increasing count.
+type Counter struct {
+	value atomic.Uint64
+}
+
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+func (c *Counter) Add(n uint64) {
+	c.value.Add(n)
+}
+
+func (c *Counter) Value() uint64 {
+	return c.value.Load()
+}
+
+func (c *Counter) write(w *bufio.Writer, name string) {
+	writeSample(w, name, nil, nil, "", "", strconv.FormatUint(c.Value(), 10))
+}
+
+// Gauge is a value that can go up and down.
+type Gauge struct {
+	bits  atomic.Uint64
+	value atomic.Pointer[func() float64]
+}
+
+func (g *Gauge) Set(value float64) {
+	g.bits.Store(math.Float64bits(value))
+}
+
+func (g *Gauge) Add(delta float64) {
+	for {
+		old := g.bits.Load()
+		if g.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+func (g *Gauge) Inc() {
+	g.Add(1)
+}
+
+func (g *Gauge) Dec() {
+	g.Add(-1)
+}
+
+// SetFunc makes the gauge return the value of the function, which is called on each collection.
+func (g *Gauge) SetFunc(value func() float64) {
+	g.value.Store(&value)
+}
+
+func (g *Gauge) Value() float64 {
+	if value := g.value.Load(); value != nil {
+		return (*value)()
+	}
+	return math.Float64frombits(g.bits.Load())
+}
+
+func (g *Gauge) write(w *bufio.Writer, name string) {
+	writeSample(w, name, nil, nil, "", "", formatFloat(g.Value()))
+}
+
+type child[T any] struct {
+	values []string
+	metric *T
+}
+
+// vec keeps the metrics of a family by the values of their labels.
+type vec[T any] struct {
+	labels   []string
+	newChild func() *T
+	children sync.Map
+}
+
+func (v *vec[T]) with(values []string) *T {
+	if len(values) != len(v.labels) {
+		panic(fmt.Sprintf("metrics: %d label values for labels %v", len(values), v.labels))
+	}
+	key := strings.Join(values, "\xff")
+	if c, ok := v.children.Load(key); ok {
+		return c.(*child[T]).metric
+	}
+	c, _ := v.children.LoadOrStore(key, &child[T]{values: append([]string(nil), values...), metric: v.newChild()})
+	return c.(*child[T]).metric
+}
+
+func (v *vec[T]) sorted() []*child[T] {
+	var children []*child[T]
+	v.children.Range(func(_, c any) bool {
+		children = append(children, c.(*child[T]))
+		return true
+	})
+	sort.Slice(children, func(i, j int) bool {
+		return strings.Join(children[i].values, "\xff") < strings.Join(children[j].values, "\xff")
+	})
+	return children
+}
+
+// CounterVec is a family of counters with the same labels.
+type CounterVec struct {
+	vec[Counter]
+}
+
+// WithLabelValues returns the counter with the label values, creating it on the first use.
+// Callers on hot paths should keep the returned counter instead of looking it up for each update.
+func (v *CounterVec) WithLabelValues(values ...string) *Counter {
+	return v.with(values)
+}
+
+func (v *CounterVec) write(w *bufio.Writer, name string) {
+	for _, c := range v.sorted() {
+		writeSample(w, name, v.labels, c.values, "", "", strconv.FormatUint(c.metric.Value(), 10))
+	}
+}
+
+// GaugeVec is a family of gauges with the same labels.
+type GaugeVec struct {
+	vec[Gauge]
+}
+
+func (v *GaugeVec) WithLabelValues(values ...string) *Gauge {
+	return v.with(values)
+}
+
+func (v *GaugeVec) write(w *bufio.Writer, name string) {
+	for _, c := range v.sorted() {
+		writeSample(w, name, v.labels, c.values, "", "", formatFloat(c.metric.Value()))
+	}
+}
+
+// HistogramVec is a family of histograms with the same labels and buckets.
+type HistogramVec struct {
+	vec[Histogram]
+}
+
+func (v *HistogramVec) WithLabelValues(values ...string) *Histogram {
+	return v.with(values)
+}
+
+func (v *HistogramVec) write(w *bufio.Writer, name string) {
+	for _, c := range v.sorted() {
+		c.metric.writeSamples(w, name, v.labels, c.values)
+	}
+}
@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func render(t *testing.T, r *Registry) string {
+	buffer := &bytes.Buffer{}
+	n, err := r.WriteTo(buffer)
+	if err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	if n != int64(buffer.Len()) {
+		t.Errorf(`WriteTo should return %v. But it returns %v`, buffer.Len(), n)
+	}
+	return buffer.String()
+}
+
+func TestExpositionFormat(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("requests_total", "Number of requests.").Add(3)
+	received := r.NewCounterVec("received_total", "Received\nevents.", "type")
+	received.WithLabelValues("Trade").Inc()
+	received.WithLabelValues(`Quote "x"`).Add(2)
+	r.NewGauge("queue", "").Set(1.5)
+	r.NewGaugeFunc("workers", "Number of workers.", func() float64 { return 2 })
+	h := r.NewHistogram("latency_seconds", "Latency.", []float64{0.1, 1})
+	h.Observe(0.05)
+	h.Observe(0.1)
+	h.Observe(0.5)
+	h.Observe(5)
+
+	expected := `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.1"} 2
+latency_seconds_bucket{le="1"} 3
+latency_seconds_bucket{le="+Inf"} 4
+latency_seconds_sum 5.65
+latency_seconds_count 4
+# TYPE queue gauge
+queue 1.5
+# HELP received_total Received\nevents.
+# TYPE received_total counter
+received_total{type="Quote \"x\""} 2
+received_total{type="Trade"} 1
+# HELP requests_total Number of requests.
+# TYPE requests_total counter
+requests_total 3
+# HELP workers Number of workers.
+# TYPE workers gauge
+workers 2
+`
+	if actual := render(t, r); actual != expected {
+		t.Errorf("Output should be\n%v\nBut it equals\n%v", expected, actual)
+	}
+}
+
+func TestHistogramVec(t *testing.T) {
+	r := NewRegistry()
+	v := r.NewHistogramVec("size", "", []float64{10}, "endpoint", "role")
+	v.WithLabelValues("a", "Feed").Observe(1)
+	v.WithLabelValues("a", "Feed").Observe(20)
+	if v.WithLabelValues("a", "Feed").Count() != 2 {
+		t.Errorf(`Count should be 2. But it equals %v`, v.WithLabelValues("a", "Feed").Count())
+	}
+	actual := render(t, r)
+	if !strings.Contains(actual, `size_bucket{endpoint="a",role="Feed",le="10"} 1`) ||
+		!strings.Contains(actual, `size_bucket{endpoint="a",role="Feed",le="+Inf"} 2`) ||
+		!strings.Contains(actual, `size_sum{endpoint="a",role="Feed"} 21`) {
+		t.Errorf(`Output should contain the samples of the histogram. But it equals %v`, actual)
+	}
+}
+
+func TestGaugeAdd(t *testing.T) {
+	g := &Gauge{}
+	g.Inc()
+	g.Add(2.5)
+	g.Dec()
+	if g.Value() != 2.5 {
+		t.Errorf(`Value should be 2.5. But it equals %v`, g.Value())
+	}
+}
+
+func TestRegistrationErrors(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("events_total", "")
+	for name, register := range map[string]func(){
+		"duplicate":     func() { r.NewGauge("events_total", "") },
+		"invalid name":  func() { r.NewCounter("events-total", "") },
+		"invalid label": func() { r.NewCounterVec("events", "", "le") },
+		"label values":  func() { r.NewCounterVec("events_by_type", "", "type").WithLabelValues() },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf(`Registration should panic for %v`, name)
+				}
+			}()
+			register()
+		}()
+	}
+	if !r.Unregister("events_total") || r.Unregister("events_total") {
+		t.Errorf(`Unregister should remove the metric once`)
+	}
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	r := NewRegistry()
+	counters := r.NewCounterVec("events_total", "", "type")
+	h := r.NewHistogram("latency_seconds", "", nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				counters.WithLabelValues("Quote").Inc()
+				h.Observe(0.001)
+			}
+		}()
+	}
+	wg.Wait()
+	if counters.WithLabelValues("Quote").Value() != 8000 || h.Count() != 8000 {
+		t.Errorf(`Count should be 8000. But it equals %v`, counters.WithLabelValues("Quote").Value())
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("events_total", "").Inc()
+	recorder := httptest.NewRecorder()
+	r.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(recorder.Body)
+	if !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf(`Content type should be text/plain. But it equals %v`, recorder.Header().Get("Content-Type"))
+	}
+	if string(body) != "# TYPE events_total counter\nevents_total 1\n" {
+		t.Errorf(`Body should contain the counter. But it equals %v`, string(body))
+	}
+}
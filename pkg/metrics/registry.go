@@ -0,0 +1,225 @@
+// Package metrics provides counters, gauges and histograms rendered in the Prometheus text exposition format.
+//
+// Updates are lock-free atomic operations, so metrics can be left on in production. The API instruments
+// itself into DefaultRegistry: received and published events, listener and isolate call latencies,
+// isolate queue depths and connection state transitions. Expose them with
+//
+//	http.Handle("/metrics", metrics.Handler())
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultRegistry is the registry of the metrics of the API.
+var DefaultRegistry = NewRegistry()
+
+var (
+	namePattern  = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+type metric interface {
+	write(w *bufio.Writer, name string)
+}
+
+type family struct {
+	name   string
+	help   string
+	kind   string
+	metric metric
+}
+
+// Registry is a set of named metrics. Creating a metric with a name that is already registered panics.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+func NewRegistry() *Registry {
+	return &Registry{families: map[string]*family{}}
+}
+
+func (r *Registry) register(name string, help string, kind string, labels []string, m metric) {
+	if !namePattern.MatchString(name) {
+		panic(fmt.Sprintf("metrics: invalid metric name %q", name))
+	}
+	for _, label := range labels {
+		if !labelPattern.MatchString(label) || label == "le" {
+			panic(fmt.Sprintf("metrics: invalid label name %q of metric %s", label, name))
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.families[name]; ok {
+		panic(fmt.Sprintf("metrics: metric %s is already registered", name))
+	}
+	r.families[name] = &family{name: name, help: help, kind: kind, metric: m}
+}
+
+func (r *Registry) NewCounter(name string, help string) *Counter {
+	c := &Counter{}
+	r.register(name, help, "counter", nil, c)
+	return c
+}
+
+func (r *Registry) NewCounterVec(name string, help string, labels ...string) *CounterVec {
+	v := &CounterVec{vec[Counter]{labels: labels, newChild: func() *Counter { return &Counter{} }}}
+	r.register(name, help, "counter", labels, v)
+	return v
+}
+
+func (r *Registry) NewGauge(name string, help string) *Gauge {
+	g := &Gauge{}
+	r.register(name, help, "gauge", nil, g)
+	return g
+}
+
+// NewGaugeFunc registers a gauge whose value is returned by the function on each collection.
+func (r *Registry) NewGaugeFunc(name string, help string, value func() float64) {
+	g := &Gauge{}
+	g.SetFunc(value)
+	r.register(name, help, "gauge", nil, g)
+}
+
+func (r *Registry) NewGaugeVec(name string, help string, labels ...string) *GaugeVec {
+	v := &GaugeVec{vec[Gauge]{labels: labels, newChild: func() *Gauge { return &Gauge{} }}}
+	r.register(name, help, "gauge", labels, v)
+	return v
+}
+
+// NewHistogram registers a histogram with the upper bounds of buckets, DefaultBuckets if there are none.
+func (r *Registry) NewHistogram(name string, help string, buckets []float64) *Histogram {
+	h := newHistogram(buckets)
+	r.register(name, help, "histogram", nil, h)
+	return h
+}
+
+func (r *Registry) NewHistogramVec(name string, help string, buckets []float64, labels ...string) *HistogramVec {
+	buckets = checkBuckets(buckets)
+	v := &HistogramVec{vec[Histogram]{labels: labels, newChild: func() *Histogram { return newHistogram(buckets) }}}
+	r.register(name, help, "histogram", labels, v)
+	return v
+}
+
+// Unregister removes the metric with the name and returns whether it was registered.
+func (r *Registry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.families[name]
+	delete(r.families, name)
+	return ok
+}
+
+// WriteTo writes all metrics sorted by name in the Prometheus text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].name < families[j].name
+	})
+
+	counter := &countingWriter{writer: w}
+	buffer := bufio.NewWriter(counter)
+	for _, f := range families {
+		if f.help != "" {
+			_, _ = fmt.Fprintf(buffer, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		}
+		_, _ = fmt.Fprintf(buffer, "# TYPE %s %s\n", f.name, f.kind)
+		f.metric.write(buffer, f.name)
+	}
+	err := buffer.Flush()
+	return counter.count, err
+}
+
+// Handler returns a handler serving the metrics of the registry.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = r.WriteTo(w)
+	})
+}
+
+// Handler returns a handler serving the metrics of DefaultRegistry.
+func Handler() http.Handler {
+	return DefaultRegistry.Handler()
+}
+
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count += int64(n)
+	return n, err
+}
+
+func writeSample(w *bufio.Writer, name string, labels []string, values []string, extraLabel string, extraValue string, value string) {
+	_, _ = w.WriteString(name)
+	if len(labels) > 0 || extraLabel != "" {
+		_ = w.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				_ = w.WriteByte(',')
+			}
+			writeLabel(w, label, values[i])
+		}
+		if extraLabel != "" {
+			if len(labels) > 0 {
+				_ = w.WriteByte(',')
+			}
+			writeLabel(w, extraLabel, extraValue)
+		}
+		_ = w.WriteByte('}')
+	}
+	_ = w.WriteByte(' ')
+	_, _ = w.WriteString(value)
+	_ = w.WriteByte('\n')
+}
+
+func writeLabel(w *bufio.Writer, label string, value string) {
+	_, _ = w.WriteString(label)
+	_, _ = w.WriteString(`="`)
+	_, _ = w.WriteString(escapeLabelValue(value))
+	_ = w.WriteByte('"')
+}
+
+var (
+	helpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelReplacer.Replace(value)
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,75 @@
+package pricing
+
+import (
+	"math"
+)
+
+// Greeks are the price of the option and its sensitivities.
+type Greeks struct {
+	Price float64
+
+	// Delta is the first derivative of the price by the underlying price.
+	Delta float64
+	// Vega is the first derivative of the price by the volatility.
+	Vega float64
+	// Theta is the negative first derivative of the price by the time to expiration.
+	Theta float64
+	// Rho is the first derivative of the price by the rate.
+	Rho float64
+	// Epsilon is the first derivative of the price by the dividend yield, always 0 for Black76.
+	Epsilon float64
+
+	// Gamma is the second derivative of the price by the underlying price.
+	Gamma float64
+	// Vanna is the second derivative of the price by the underlying price and the volatility.
+	Vanna float64
+	// Charm is the negative derivative of Delta by the time to expiration.
+	Charm float64
+	// Vomma is the second derivative of the price by the volatility.
+	Vomma float64
+	// Veta is the negative derivative of Vega by the time to expiration.
+	Veta float64
+}
+
+// ComputeGreeks returns the theoretical price of the option and its Greeks.
+func ComputeGreeks(p Parameters) (*Greeks, error) {
+	if err := p.validate(true); err != nil {
+		return nil, err
+	}
+	d1, d2 := d(&p)
+	s, k, t, r, b, v := p.Underlying, p.Strike, p.Time, p.Rate, p.carry(), p.Volatility
+	sqrtTime := math.Sqrt(t)
+	carryDiscount := math.Exp((b - r) * t)
+	discount := math.Exp(-r * t)
+	density := pdf(d1)
+
+	g := &Greeks{Price: price(&p)}
+	g.Gamma = carryDiscount * density / (s * v * sqrtTime)
+	g.Vega = s * carryDiscount * density * sqrtTime
+	g.Vanna = -carryDiscount * density * d2 / v
+	g.Vomma = g.Vega * d1 * d2 / v
+	g.Veta = -g.Vega * ((b - r) - b*d1/(v*sqrtTime) + (1+d1*d2)/(2*t))
+	decay := -s * carryDiscount * density * v / (2 * sqrtTime)
+	charm := density * (b/(v*sqrtTime) - d2/(2*t))
+	if p.OptionType == Call {
+		g.Delta = carryDiscount * cdf(d1)
+		g.Theta = decay - (b-r)*s*carryDiscount*cdf(d1) - r*k*discount*cdf(d2)
+		g.Charm = -carryDiscount * (charm + (b-r)*cdf(d1))
+		if p.Model == BlackScholesMerton {
+			g.Rho = t * k * discount * cdf(d2)
+			g.Epsilon = -t * s * carryDiscount * cdf(d1)
+		}
+	} else {
+		g.Delta = carryDiscount * (cdf(d1) - 1)
+		g.Theta = decay + (b-r)*s*carryDiscount*cdf(-d1) + r*k*discount*cdf(-d2)
+		g.Charm = -carryDiscount * (charm - (b-r)*cdf(-d1))
+		if p.Model == BlackScholesMerton {
+			g.Rho = -t * k * discount * cdf(-d2)
+			g.Epsilon = t * s * carryDiscount * cdf(-d1)
+		}
+	}
+	if p.Model == Black76 {
+		g.Rho = -t * g.Price
+	}
+	return g, nil
+}
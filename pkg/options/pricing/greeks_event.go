@@ -0,0 +1,114 @@
+package pricing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/greeks"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/symbols"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+const daysInYear = 365
+
+// Market is the market state used to compute Greeks for a quote.
+type Market struct {
+	Rate     float64
+	Dividend float64
+	// Time is the valuation time in milliseconds since epoch. When it is zero the time of the quote is used,
+	// the later of its bid and ask times.
+	Time int64
+	// PerContract scales the price and Greeks by the multiplier of the instrument.
+	PerContract bool
+}
+
+// GreeksFromQuote computes a Greeks event for the option quote using the middle of the bid and ask prices.
+// The option type, strike, expiration and multiplier are taken from the instrument profile. Options on
+// futures are priced with Black76, all others with BlackScholesMerton. The option is assumed to expire at
+// the end of its expiration day in UTC.
+func GreeksFromQuote(q *quote.Quote, profile *events.InstrumentProfile, underlyingPrice float64, market Market) (*greeks.Greeks, error) {
+	if q == nil || profile == nil {
+		return nil, fmt.Errorf("quote and instrument profile should not be nil")
+	}
+	optionType, err := profileOptionType(profile)
+	if err != nil {
+		return nil, err
+	}
+	timeMillis := market.Time
+	if timeMillis == 0 {
+		timeMillis = q.Time()
+	}
+	if timeMillis <= 0 {
+		return nil, fmt.Errorf("valuation time of %s is unknown, quote has no bid and ask times", stringValue(q.EventSymbol()))
+	}
+	expiration := (profile.Expiration()+1)*timeutil.DAY - timeMillis
+	if expiration <= 0 {
+		return nil, fmt.Errorf("option %s is expired", stringValue(profile.Symbol()))
+	}
+	p := Parameters{
+		Model:      BlackScholesMerton,
+		OptionType: optionType,
+		Underlying: underlyingPrice,
+		Strike:     profile.Strike(),
+		Time:       float64(expiration) / (daysInYear * timeutil.DAY),
+		Rate:       market.Rate,
+		Dividend:   market.Dividend,
+	}
+	if symbols.KindOf(stringValue(profile.Underlying())) == symbols.KindFuture {
+		p.Model = Black76
+	}
+	optionPrice := (q.BidPrice() + q.AskPrice()) / 2
+	p.Volatility, err = ImpliedVolatility(p, optionPrice)
+	if err != nil {
+		return nil, err
+	}
+	g, err := ComputeGreeks(p)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := 1.0
+	if market.PerContract && isPositive(profile.Multiplier()) {
+		scale = profile.Multiplier()
+	}
+	result := greeks.NewGreeks(stringValue(q.EventSymbol()))
+	result.SetEventTime(q.EventTime())
+	result.SetIndex(timeutil.GetSecondsFromTime(timeMillis)<<32 | int64(timeutil.GetMillisFromTime(timeMillis))<<22)
+	result.SetPrice(optionPrice * scale)
+	result.SetVolatility(p.Volatility)
+	result.SetDelta(g.Delta * scale)
+	result.SetGamma(g.Gamma * scale)
+	result.SetTheta(g.Theta * scale)
+	result.SetRho(g.Rho * scale)
+	result.SetVega(g.Vega * scale)
+	return result, nil
+}
+
+// profileOptionType returns the option type from the CFI code of the profile
+// or from its symbol when the CFI code is not an option one.
+func profileOptionType(profile *events.InstrumentProfile) (OptionType, error) {
+	cfi := stringValue(profile.Cfi())
+	switch {
+	case strings.HasPrefix(cfi, "OC"):
+		return Call, nil
+	case strings.HasPrefix(cfi, "OP"):
+		return Put, nil
+	}
+	option, err := symbols.ParseOption(stringValue(profile.Symbol()))
+	if err != nil {
+		return Call, fmt.Errorf("unknown option type of %s: %w", stringValue(profile.Symbol()), err)
+	}
+	if option.OptionType() == symbols.Put {
+		return Put, nil
+	}
+	return Call, nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
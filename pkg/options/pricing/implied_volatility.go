@@ -0,0 +1,80 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	minVolatility          = 1e-6
+	maxVolatility          = 10.0
+	volatilityTolerance    = 1e-10
+	priceTolerance         = 1e-12
+	maxVolatilityIteration = 100
+)
+
+// ImpliedVolatility finds the volatility at which the theoretical price of the option equals the price.
+// The volatility of the parameters is ignored. It fails if the price violates no-arbitrage bounds or
+// the implied volatility is outside of [0.000001, 10].
+//
+// The solver uses Newton's method safeguarded by bisection, so it converges even for deep in or out
+// of the money options where vega is close to zero.
+func ImpliedVolatility(p Parameters, optionPrice float64) (float64, error) {
+	if err := p.validate(false); err != nil {
+		return math.NaN(), err
+	}
+	if !isPositive(optionPrice) {
+		return math.NaN(), fmt.Errorf("option price %v should be positive", optionPrice)
+	}
+	forward := p.Underlying * math.Exp((p.carry()-p.Rate)*p.Time)
+	strike := p.Strike * math.Exp(-p.Rate*p.Time)
+	lower, upper := math.Max(forward-strike, 0), forward
+	if p.OptionType == Put {
+		lower, upper = math.Max(strike-forward, 0), strike
+	}
+	if optionPrice <= lower || optionPrice >= upper {
+		return math.NaN(), fmt.Errorf("option price %v is outside of no-arbitrage bounds (%v, %v)", optionPrice, lower, upper)
+	}
+
+	low, high := minVolatility, maxVolatility
+	if priceAt(&p, low) > optionPrice || priceAt(&p, high) < optionPrice {
+		return math.NaN(), fmt.Errorf("implied volatility for price %v is outside of [%v, %v]", optionPrice, low, high)
+	}
+	// initial guess of Brenner and Subrahmanyam, exact for at the money options
+	volatility := math.Sqrt(2*math.Pi/p.Time) * optionPrice / forward
+	if !(volatility > low && volatility < high) {
+		volatility = (low + high) / 2
+	}
+	step := high - low
+	for i := 0; i < maxVolatilityIteration; i++ {
+		p.Volatility = volatility
+		diff := price(&p) - optionPrice
+		if math.Abs(diff) <= priceTolerance*optionPrice {
+			return volatility, nil
+		}
+		if diff > 0 {
+			high = volatility
+		} else {
+			low = volatility
+		}
+		d1, _ := d(&p)
+		vega := p.Underlying * math.Exp((p.carry()-p.Rate)*p.Time) * pdf(d1) * math.Sqrt(p.Time)
+		next := volatility - diff/vega
+		// fall back to bisection when Newton's step leaves the bracket or converges slower than bisection
+		if !(next > low && next < high) || math.Abs(next-volatility) > step/2 {
+			next = (low + high) / 2
+		}
+		step = math.Abs(next - volatility)
+		if step < volatilityTolerance {
+			return next, nil
+		}
+		volatility = next
+	}
+	return math.NaN(), fmt.Errorf("implied volatility for price %v did not converge", optionPrice)
+}
+
+func priceAt(p *Parameters, volatility float64) float64 {
+	q := *p
+	q.Volatility = volatility
+	return price(&q)
+}
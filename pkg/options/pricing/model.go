@@ -0,0 +1,137 @@
+// Package pricing implements Black-Scholes-Merton and Black-76 option pricing models,
+// their first- and second-order Greeks and an implied volatility solver.
+//
+// All values are annualized and use unit changes: Theta is the change of the price per year
+// as time passes, Vega, Rho and Epsilon are changes per 1.0 (that is, 100%) of volatility,
+// rate and dividend yield respectively.
+package pricing
+
+import (
+	"fmt"
+	"math"
+)
+
+type OptionType int32
+
+const (
+	Call OptionType = iota
+	Put
+)
+
+func (t OptionType) String() string {
+	switch t {
+	case Call:
+		return "Call"
+	case Put:
+		return "Put"
+	default:
+		return fmt.Sprintf("OptionType: Wrong value %d", t)
+	}
+}
+
+type Model int32
+
+const (
+	// BlackScholesMerton prices options on a spot underlying with a continuous dividend yield.
+	BlackScholesMerton Model = iota
+	// Black76 prices options on futures or forwards, Underlying is the futures price and Dividend is ignored.
+	Black76
+)
+
+func (m Model) String() string {
+	switch m {
+	case BlackScholesMerton:
+		return "BlackScholesMerton"
+	case Black76:
+		return "Black76"
+	default:
+		return fmt.Sprintf("Model: Wrong value %d", m)
+	}
+}
+
+type Parameters struct {
+	Model      Model
+	OptionType OptionType
+	// Underlying is the spot price for BlackScholesMerton or the futures price for Black76.
+	Underlying float64
+	Strike     float64
+	// Time is the time to expiration in years.
+	Time float64
+	// Rate is the continuously compounded risk-free interest rate.
+	Rate float64
+	// Dividend is the continuously compounded dividend yield.
+	Dividend   float64
+	Volatility float64
+}
+
+// carry returns the cost of carry of the underlying.
+func (p *Parameters) carry() float64 {
+	if p.Model == Black76 {
+		return 0
+	}
+	return p.Rate - p.Dividend
+}
+
+func (p *Parameters) validate(checkVolatility bool) error {
+	switch {
+	case p.Model != BlackScholesMerton && p.Model != Black76:
+		return fmt.Errorf("unknown model %s", p.Model)
+	case p.OptionType != Call && p.OptionType != Put:
+		return fmt.Errorf("unknown option type %s", p.OptionType)
+	case !isPositive(p.Underlying):
+		return fmt.Errorf("underlying price %v should be positive", p.Underlying)
+	case !isPositive(p.Strike):
+		return fmt.Errorf("strike %v should be positive", p.Strike)
+	case !isPositive(p.Time):
+		return fmt.Errorf("time to expiration %v should be positive", p.Time)
+	case !isFinite(p.Rate):
+		return fmt.Errorf("invalid rate %v", p.Rate)
+	case !isFinite(p.Dividend):
+		return fmt.Errorf("invalid dividend yield %v", p.Dividend)
+	case checkVolatility && !isPositive(p.Volatility):
+		return fmt.Errorf("volatility %v should be positive", p.Volatility)
+	}
+	return nil
+}
+
+// Price returns the theoretical price of the option.
+func Price(p Parameters) (float64, error) {
+	if err := p.validate(true); err != nil {
+		return math.NaN(), err
+	}
+	return price(&p), nil
+}
+
+func price(p *Parameters) float64 {
+	d1, d2 := d(p)
+	carryDiscount := math.Exp((p.carry() - p.Rate) * p.Time)
+	discount := math.Exp(-p.Rate * p.Time)
+	if p.OptionType == Call {
+		return p.Underlying*carryDiscount*cdf(d1) - p.Strike*discount*cdf(d2)
+	}
+	return p.Strike*discount*cdf(-d2) - p.Underlying*carryDiscount*cdf(-d1)
+}
+
+func d(p *Parameters) (float64, float64) {
+	sqrtTime := math.Sqrt(p.Time)
+	d1 := (math.Log(p.Underlying/p.Strike) + (p.carry()+p.Volatility*p.Volatility/2)*p.Time) / (p.Volatility * sqrtTime)
+	return d1, d1 - p.Volatility*sqrtTime
+}
+
+// cdf is the cumulative distribution function of the standard normal distribution.
+func cdf(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// pdf is the probability density function of the standard normal distribution.
+func pdf(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+func isPositive(value float64) bool {
+	return value > 0 && !math.IsInf(value, 0)
+}
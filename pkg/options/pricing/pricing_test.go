@@ -0,0 +1,217 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+func parameters(model Model, optionType OptionType) Parameters {
+	return Parameters{
+		Model:      model,
+		OptionType: optionType,
+		Underlying: 100,
+		Strike:     100,
+		Time:       1,
+		Rate:       0.05,
+		Volatility: 0.2,
+	}
+}
+
+func checkClose(t *testing.T, name string, expected, actual, tolerance float64) {
+	t.Helper()
+	if math.Abs(expected-actual) > tolerance {
+		t.Errorf(`%s should be %v. But it equals %v`, name, expected, actual)
+	}
+}
+
+func TestPrice(t *testing.T) {
+	cases := []struct {
+		model      Model
+		optionType OptionType
+		price      float64
+	}{
+		{BlackScholesMerton, Call, 10.450584},
+		{BlackScholesMerton, Put, 5.573526},
+		{Black76, Call, 7.577082},
+		{Black76, Put, 7.577082},
+	}
+	for _, c := range cases {
+		price, err := Price(parameters(c.model, c.optionType))
+		if err != nil {
+			t.Fatalf(`Unexpected error %v`, err)
+		}
+		checkClose(t, c.model.String()+" "+c.optionType.String(), c.price, price, 1e-6)
+	}
+}
+
+func TestPutCallParity(t *testing.T) {
+	p := parameters(BlackScholesMerton, Call)
+	p.Strike, p.Dividend = 110, 0.02
+	call, _ := Price(p)
+	p.OptionType = Put
+	put, _ := Price(p)
+	forward := p.Underlying*math.Exp(-p.Dividend*p.Time) - p.Strike*math.Exp(-p.Rate*p.Time)
+	checkClose(t, "Call minus put", forward, call-put, 1e-9)
+}
+
+func TestInvalidParameters(t *testing.T) {
+	for _, modify := range []func(p *Parameters){
+		func(p *Parameters) { p.Underlying = 0 },
+		func(p *Parameters) { p.Strike = -1 },
+		func(p *Parameters) { p.Time = 0 },
+		func(p *Parameters) { p.Volatility = math.NaN() },
+		func(p *Parameters) { p.Rate = math.Inf(1) },
+		func(p *Parameters) { p.Model = 5 },
+	} {
+		p := parameters(BlackScholesMerton, Call)
+		modify(&p)
+		if _, err := Price(p); err == nil {
+			t.Errorf(`Price of %+v should fail`, p)
+		}
+		if _, err := ComputeGreeks(p); err == nil {
+			t.Errorf(`Greeks of %+v should fail`, p)
+		}
+	}
+}
+
+func TestGreeksMatchFiniteDifferences(t *testing.T) {
+	const h = 1e-4
+	priceOf := func(p Parameters) float64 {
+		price, _ := Price(p)
+		return price
+	}
+	bump := func(p Parameters, modify func(p *Parameters, h float64), h float64) Parameters {
+		modify(&p, h)
+		return p
+	}
+	derivative := func(f func(p Parameters) float64, p Parameters, modify func(p *Parameters, h float64)) float64 {
+		return (f(bump(p, modify, h)) - f(bump(p, modify, -h))) / (2 * h)
+	}
+	underlying := func(p *Parameters, h float64) { p.Underlying += h }
+	volatility := func(p *Parameters, h float64) { p.Volatility += h }
+	time := func(p *Parameters, h float64) { p.Time -= h }
+	rate := func(p *Parameters, h float64) { p.Rate += h }
+	dividend := func(p *Parameters, h float64) { p.Dividend += h }
+	greek := func(get func(g *Greeks) float64) func(p Parameters) float64 {
+		return func(p Parameters) float64 {
+			g, _ := ComputeGreeks(p)
+			return get(g)
+		}
+	}
+	delta := greek(func(g *Greeks) float64 { return g.Delta })
+	vega := greek(func(g *Greeks) float64 { return g.Vega })
+
+	for _, model := range []Model{BlackScholesMerton, Black76} {
+		for _, optionType := range []OptionType{Call, Put} {
+			p := parameters(model, optionType)
+			p.Strike, p.Time, p.Dividend = 95, 0.5, 0.03
+			g, err := ComputeGreeks(p)
+			if err != nil {
+				t.Fatalf(`Unexpected error %v`, err)
+			}
+			name := model.String() + " " + optionType.String() + " "
+			checkClose(t, name+"Price", priceOf(p), g.Price, 1e-12)
+			checkClose(t, name+"Delta", derivative(priceOf, p, underlying), g.Delta, 1e-6)
+			checkClose(t, name+"Vega", derivative(priceOf, p, volatility), g.Vega, 1e-5)
+			checkClose(t, name+"Theta", derivative(priceOf, p, time), g.Theta, 1e-5)
+			checkClose(t, name+"Rho", derivative(priceOf, p, rate), g.Rho, 1e-5)
+			checkClose(t, name+"Epsilon", derivative(priceOf, p, dividend), g.Epsilon, 1e-5)
+			checkClose(t, name+"Gamma", derivative(delta, p, underlying), g.Gamma, 1e-6)
+			checkClose(t, name+"Vanna", derivative(delta, p, volatility), g.Vanna, 1e-5)
+			checkClose(t, name+"Charm", derivative(delta, p, time), g.Charm, 1e-5)
+			checkClose(t, name+"Vomma", derivative(vega, p, volatility), g.Vomma, 1e-4)
+			checkClose(t, name+"Veta", derivative(vega, p, time), g.Veta, 1e-4)
+		}
+	}
+}
+
+func TestImpliedVolatility(t *testing.T) {
+	for _, model := range []Model{BlackScholesMerton, Black76} {
+		for _, optionType := range []OptionType{Call, Put} {
+			for _, strike := range []float64{40, 90, 100, 120, 250} {
+				for _, volatility := range []float64{0.05, 0.3, 1.5} {
+					p := parameters(model, optionType)
+					p.Strike, p.Volatility = strike, volatility
+					price, _ := Price(p)
+					intrinsic := p.Underlying*math.Exp((p.carry()-p.Rate)*p.Time) - p.Strike*math.Exp(-p.Rate*p.Time)
+					if optionType == Put {
+						intrinsic = -intrinsic
+					}
+					intrinsic = math.Max(intrinsic, 0)
+					// without time value the volatility can't be recovered
+					if price-intrinsic < 1e-9*price || price < 1e-200 {
+						continue
+					}
+					p.Volatility = 0
+					implied, err := ImpliedVolatility(p, price)
+					if err != nil {
+						t.Fatalf(`Unexpected error %v for %+v and price %v`, err, p, price)
+					}
+					checkClose(t, "Implied volatility", volatility, implied, 1e-6)
+				}
+			}
+		}
+	}
+}
+
+func TestImpliedVolatilityOutOfBounds(t *testing.T) {
+	p := parameters(BlackScholesMerton, Call)
+	for _, price := range []float64{0, -1, math.NaN(), 100, 4} {
+		if _, err := ImpliedVolatility(p, price); err == nil {
+			t.Errorf(`Implied volatility for price %v should fail`, price)
+		}
+	}
+}
+
+func TestGreeksFromQuote(t *testing.T) {
+	now := int64(19723)*timeutil.DAY + 12*timeutil.HOUR + 345
+	p := parameters(BlackScholesMerton, Put)
+	p.Time = 30.5 / daysInYear
+	price, _ := Price(p)
+
+	q := quote.NewQuote(".AAPL240217P100")
+	q.SetEventTime(now)
+	q.SetBidPrice(price - 0.05)
+	q.SetAskPrice(price + 0.05)
+	profile := events.NewInstrumentProfile()
+	symbol, underlying := ".AAPL240217P100", "AAPL"
+	profile.SetSymbol(&symbol)
+	profile.SetUnderlying(&underlying)
+	profile.SetStrike(100)
+	profile.SetExpiration(19723 + 30)
+	profile.SetMultiplier(100)
+	if _, err := GreeksFromQuote(q, profile, 100, Market{}); err == nil {
+		t.Errorf(`Greeks for a quote without bid and ask times should fail`)
+	}
+	q.SetBidTime(now - timeutil.MINUTE)
+	q.SetAskTime(now)
+
+	g, err := GreeksFromQuote(q, profile, 100, Market{Rate: 0.05, PerContract: true})
+	if err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	expected, _ := ComputeGreeks(p)
+	checkClose(t, "Price", price*100, g.Price(), 1e-9)
+	checkClose(t, "Volatility", 0.2, g.Volatility(), 1e-6)
+	checkClose(t, "Delta", expected.Delta*100, g.Delta(), 1e-4)
+	checkClose(t, "Gamma", expected.Gamma*100, g.Gamma(), 1e-4)
+	if *g.EventSymbol() != symbol {
+		t.Errorf(`EventSymbol should be %v. But it equals %v`, symbol, *g.EventSymbol())
+	}
+	if g.Index() != timeutil.GetSecondsFromTime(now)<<32|345<<22 {
+		t.Errorf(`Index should contain time %v. But it equals %v`, now, g.Index())
+	}
+
+	profile.SetExpiration(19722)
+	if _, err := GreeksFromQuote(q, profile, 100, Market{}); err == nil {
+		t.Errorf(`Greeks for expired option should fail`)
+	}
+	symbol = "AAPL"
+	if _, err := GreeksFromQuote(q, profile, 100, Market{}); err == nil {
+		t.Errorf(`Greeks for unknown option type should fail`)
+	}
+}
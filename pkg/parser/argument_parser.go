@@ -4,6 +4,7 @@ import (
 	"github.com/dxfeed/dxfeed-graal-go-api/internal/native"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/api/Osub"
 	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
 	"strings"
 )
 
@@ -49,6 +50,5 @@ func ParseEventTypes(value string) []eventcodes.EventCode {
 }
 
 func ParseTime(time string) (int64, error) {
-	value, err := native.ParseTime(time)
-	return value, err
+	return timeutil.DefaultTimeFormat.Parse(time)
 }
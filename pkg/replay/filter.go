@@ -0,0 +1,91 @@
+package replay
+
+import (
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+)
+
+// Filter selects events by symbol, type and time, an empty filter accepts all events.
+// It is not safe for concurrent use.
+type Filter struct {
+	symbols  map[string]struct{}
+	types    map[eventcodes.EventCode]struct{}
+	fromTime int64
+	toTime   int64
+}
+
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// SetSymbols accepts only events of the symbols, events of all symbols are accepted if there are no symbols.
+func (f *Filter) SetSymbols(symbols ...string) {
+	f.symbols = nil
+	if len(symbols) > 0 {
+		f.symbols = map[string]struct{}{}
+		for _, symbol := range symbols {
+			f.symbols[symbol] = struct{}{}
+		}
+	}
+}
+
+// SetEventTypes accepts only events of the types, events of all types are accepted if there are no types.
+func (f *Filter) SetEventTypes(types ...eventcodes.EventCode) {
+	f.types = nil
+	if len(types) > 0 {
+		f.types = map[eventcodes.EventCode]struct{}{}
+		for _, eventType := range types {
+			f.types[eventType] = struct{}{}
+		}
+	}
+}
+
+// SetTimeWindow accepts only events with time in milliseconds at or after fromTime and before toTime,
+// zero bounds are not checked. Events without time, like profiles, are accepted by any window.
+func (f *Filter) SetTimeWindow(fromTime int64, toTime int64) {
+	f.fromTime = fromTime
+	f.toTime = toTime
+}
+
+func (f *Filter) Accepts(event interface{}) bool {
+	if f.types != nil {
+		e, ok := event.(events.EventType)
+		if !ok {
+			return false
+		}
+		if _, ok := f.types[e.Type()]; !ok {
+			return false
+		}
+	}
+	if f.symbols != nil {
+		symbol, ok := symbolOf(event)
+		if !ok {
+			return false
+		}
+		if _, ok := f.symbols[symbol]; !ok {
+			return false
+		}
+	}
+	if f.fromTime != 0 || f.toTime != 0 {
+		if t, ok := timeOf(event); ok && (t < f.fromTime || f.toTime != 0 && t >= f.toTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the accepted events, the slice is reused when all events are accepted.
+func (f *Filter) Apply(eventsList []interface{}) []interface{} {
+	for i, event := range eventsList {
+		if !f.Accepts(event) {
+			result := append([]interface{}{}, eventsList[:i]...)
+			for _, e := range eventsList[i+1:] {
+				if f.Accepts(e) {
+					result = append(result, e)
+				}
+			}
+			return result
+		}
+	}
+	return eventsList
+}
@@ -0,0 +1,342 @@
+package replay
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/greeks"
+)
+
+// Publisher is the part of api.DXPublisher used by the Player, e.g. the publisher of a LocalHub or Publisher endpoint.
+type Publisher interface {
+	Publish(events []interface{}) error
+}
+
+const (
+	// MaxSpeed replays events without delays.
+	MaxSpeed = 0.0
+
+	defaultBatchSize = 1000
+)
+
+// Player publishes events read from a source. With a speed other than MaxSpeed the delays between events
+// follow their original times divided by the speed: the speed 10 replays an hour of events in 6 minutes.
+// Events without time, like instrument profiles, are published without delay.
+//
+// Play blocks until the source is exhausted or the player is stopped, other methods may be called
+// concurrently to control the replay.
+type Player struct {
+	source    Source
+	publisher Publisher
+
+	mu        sync.Mutex
+	speed     float64
+	batchSize int
+	filter    *Filter
+	paused    bool
+	stopped   bool
+	seeking   bool
+	seekTime  int64
+	position  int64
+	published int64
+	wake      chan struct{}
+}
+
+func NewPlayer(source Source, publisher Publisher) *Player {
+	return &Player{
+		source:    source,
+		publisher: publisher,
+		speed:     1,
+		batchSize: defaultBatchSize,
+		filter:    NewFilter(),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// SetSpeed sets the replay speed, 1 by default. MaxSpeed or a negative speed replays events without delays.
+func (p *Player) SetSpeed(speed float64) {
+	if speed < 0 {
+		speed = MaxSpeed
+	}
+	p.mu.Lock()
+	p.speed = speed
+	p.mu.Unlock()
+	p.signal()
+}
+
+// SetBatchSize sets the maximal number of events published at once.
+func (p *Player) SetBatchSize(batchSize int) {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.batchSize = batchSize
+}
+
+// SetSymbols replays only events of the symbols, all events are replayed if there are no symbols.
+func (p *Player) SetSymbols(symbols ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.filter.SetSymbols(symbols...)
+}
+
+// SetEventTypes replays only events of the types, events of all types are replayed if there are no types.
+func (p *Player) SetEventTypes(types ...eventcodes.EventCode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.filter.SetEventTypes(types...)
+}
+
+func (p *Player) Pause() {
+	p.mu.Lock()
+	p.paused = true
+	p.mu.Unlock()
+	p.signal()
+}
+
+func (p *Player) Resume() {
+	p.mu.Lock()
+	p.paused = false
+	p.mu.Unlock()
+	p.signal()
+}
+
+func (p *Player) IsPaused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+// Stop stops the replay, Play returns after publishing the events read so far.
+func (p *Player) Stop() {
+	p.mu.Lock()
+	p.stopped = true
+	p.mu.Unlock()
+	p.signal()
+}
+
+// SeekTo continues the replay from the first event with time at or after the given time in milliseconds.
+// Seeking backwards requires a source that implements Rewinder.
+func (p *Player) SeekTo(timeMillis int64) error {
+	p.mu.Lock()
+	if _, ok := p.source.(Rewinder); !ok && timeMillis < p.position {
+		p.mu.Unlock()
+		return errors.New("source can't be rewound")
+	}
+	p.seeking = true
+	p.seekTime = timeMillis
+	p.mu.Unlock()
+	p.signal()
+	return nil
+}
+
+// Position returns the time of the last read event in milliseconds.
+func (p *Player) Position() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.position
+}
+
+// Published returns the number of published events.
+func (p *Player) Published() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.published
+}
+
+// Play replays events until the source is exhausted or the player is stopped.
+func (p *Player) Play() error {
+	var batch []interface{}
+	var next interface{}
+	var skipUntil int64
+	// the replay clock: anchorTime of events corresponds to anchorWall
+	anchored := false
+	var anchorTime int64
+	var anchorWall time.Time
+	var speed float64
+	for {
+		p.mu.Lock()
+		if p.stopped {
+			p.mu.Unlock()
+			return p.publish(batch)
+		}
+		if p.paused {
+			p.mu.Unlock()
+			if err := p.publish(batch); err != nil {
+				return err
+			}
+			batch = nil
+			if anchored {
+				anchorTime = replayTime(anchorTime, anchorWall, speed)
+			}
+			p.awaitResume()
+			anchorWall = time.Now()
+			continue
+		}
+		if p.seeking {
+			p.seeking = false
+			if p.seekTime < p.position {
+				if err := p.source.(Rewinder).Rewind(); err != nil {
+					p.mu.Unlock()
+					return err
+				}
+				next = nil
+			}
+			skipUntil = p.seekTime
+			p.position = p.seekTime
+			anchored = false
+		}
+		speed = p.speed
+		batchSize := p.batchSize
+		p.mu.Unlock()
+		if speed == MaxSpeed {
+			anchored = false
+		}
+
+		event := next
+		next = nil
+		if event == nil {
+			var err error
+			if event, err = p.source.Read(); err != nil {
+				if publishErr := p.publish(batch); publishErr != nil || err == io.EOF {
+					return publishErr
+				}
+				return err
+			}
+		}
+		eventTime, hasTime := timeOf(event)
+		if hasTime {
+			p.mu.Lock()
+			p.position = eventTime
+			p.mu.Unlock()
+		}
+		if skipUntil != 0 {
+			// events before the first one at or after the seek time are skipped, including events without time
+			if !hasTime || eventTime < skipUntil {
+				continue
+			}
+			skipUntil = 0
+		}
+		if !p.accepts(event) {
+			continue
+		}
+		if speed != MaxSpeed && hasTime {
+			if !anchored {
+				anchorTime, anchorWall, anchored = eventTime, time.Now(), true
+			}
+			due := anchorWall.Add(time.Duration(float64(eventTime-anchorTime) * float64(time.Millisecond) / speed))
+			if wait := time.Until(due); wait > 0 {
+				if err := p.publish(batch); err != nil {
+					return err
+				}
+				batch = nil
+				if !p.sleep(wait) {
+					// the state has changed, the clock continues from the current replay time
+					anchorTime, anchorWall = replayTime(anchorTime, anchorWall, speed), time.Now()
+					next = event
+					continue
+				}
+			}
+		}
+		batch = append(batch, event)
+		if len(batch) >= batchSize {
+			if err := p.publish(batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+	}
+}
+
+func (p *Player) publish(batch []interface{}) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := p.publisher.Publish(batch); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.published += int64(len(batch))
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *Player) accepts(event interface{}) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.filter.Accepts(event)
+}
+
+func (p *Player) signal() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// sleep waits for the duration and returns false if it was interrupted by a change of the state.
+func (p *Player) sleep(duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-p.wake:
+		return false
+	}
+}
+
+func (p *Player) awaitResume() {
+	for {
+		p.mu.Lock()
+		waiting := p.paused && !p.stopped
+		p.mu.Unlock()
+		if !waiting {
+			return
+		}
+		<-p.wake
+	}
+}
+
+// replayTime returns the current time of the replay clock.
+func replayTime(anchorTime int64, anchorWall time.Time, speed float64) int64 {
+	return anchorTime + int64(float64(time.Since(anchorWall))*speed/float64(time.Millisecond))
+}
+
+func symbolOf(event interface{}) (string, bool) {
+	switch e := event.(type) {
+	case *candle.Candle:
+		if e.EventSymbol() == nil {
+			return "", false
+		}
+		return e.EventSymbol().String(), true
+	case interface{ EventSymbol() *string }:
+		if e.EventSymbol() == nil {
+			return "", false
+		}
+		return *e.EventSymbol(), true
+	}
+	return "", false
+}
+
+// timeOf returns the time of the event in milliseconds, or the event time if the event has no time.
+func timeOf(event interface{}) (int64, bool) {
+	var result int64
+	switch e := event.(type) {
+	case *greeks.Greeks:
+		result = (e.Index()>>32)*1000 + (e.Index()>>22)&0x3ff
+	case interface{ Time() int64 }:
+		result = e.Time()
+	}
+	if result == 0 {
+		if e, ok := event.(interface{ EventTime() int64 }); ok {
+			result = e.EventTime()
+		}
+	}
+	return result, result != 0
+}
@@ -0,0 +1,80 @@
+package replay
+
+import (
+	"sync"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/common"
+)
+
+// Subscription is the part of api.DXFeedSubscription used by the Recorder.
+type Subscription interface {
+	AddListener(listener common.EventListener) error
+	RemoveListener(listener common.EventListener)
+}
+
+// Recorder writes events received from a subscription into a sink. Writing stops at the first error,
+// which is returned by Err and Close.
+type Recorder struct {
+	subscription Subscription
+
+	mu     sync.Mutex
+	sink   Sink
+	count  int64
+	err    error
+	closed bool
+}
+
+// NewRecorder creates a recorder and attaches it to the subscription.
+func NewRecorder(subscription Subscription, sink Sink) (*Recorder, error) {
+	r := &Recorder{subscription: subscription, sink: sink}
+	if err := subscription.AddListener(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Recorder) Update(events []interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil || r.closed {
+		return
+	}
+	for _, event := range events {
+		if r.err = r.sink.Write(event); r.err != nil {
+			return
+		}
+		r.count++
+	}
+}
+
+// Count returns the number of recorded events.
+func (r *Recorder) Count() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Flush flushes the sink.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	return r.sink.Flush()
+}
+
+// Close detaches the recorder from the subscription and flushes the sink.
+func (r *Recorder) Close() error {
+	r.subscription.RemoveListener(r)
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+	return r.Flush()
+}
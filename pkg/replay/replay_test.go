@@ -0,0 +1,340 @@
+package replay
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/common"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/eventio/csv"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/eventio/tape"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/eventcodes"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/profile"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/quote"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/trade"
+)
+
+var (
+	_ Source = (*tape.Reader)(nil)
+	_ Source = (*csv.Reader)(nil)
+	_ Sink   = (*tape.Writer)(nil)
+	_ Sink   = (*csv.Writer)(nil)
+)
+
+const startTime = int64(1700000000000)
+
+type testPublisher struct {
+	mu      sync.Mutex
+	batches [][]interface{}
+	times   []time.Time
+}
+
+func (p *testPublisher) Publish(events []interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.batches = append(p.batches, append([]interface{}(nil), events...))
+	p.times = append(p.times, time.Now())
+	return nil
+}
+
+func (p *testPublisher) symbols() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var result []string
+	for _, batch := range p.batches {
+		for _, event := range batch {
+			symbol, _ := symbolOf(event)
+			result = append(result, symbol)
+		}
+	}
+	return strings.Join(result, ",")
+}
+
+func newQuote(symbol string, timeMillis int64) *quote.Quote {
+	q := quote.NewQuote(symbol)
+	q.SetBidTime(timeMillis)
+	return q
+}
+
+func newTrade(symbol string, timeMillis int64) *trade.Trade {
+	t := trade.NewTrade(symbol)
+	t.SetTime(timeMillis)
+	return t
+}
+
+// timeline returns a profile without time followed by quotes and trades of AAPL and IBM 100 ms apart.
+func timeline() []interface{} {
+	return []interface{}{
+		profile.NewProfile("AAPL"),
+		newQuote("AAPL", startTime),
+		newTrade("IBM", startTime+100),
+		newQuote("IBM", startTime+200),
+		newTrade("AAPL", startTime+300),
+	}
+}
+
+func TestPlayAtMaxSpeed(t *testing.T) {
+	publisher := &testPublisher{}
+	player := NewPlayer(NewSliceSource(timeline()), publisher)
+	player.SetSpeed(MaxSpeed)
+	player.SetBatchSize(2)
+	if err := player.Play(); err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	if publisher.symbols() != "AAPL,AAPL,IBM,IBM,AAPL" {
+		t.Errorf(`Symbols should be AAPL,AAPL,IBM,IBM,AAPL. But they equal %v`, publisher.symbols())
+	}
+	if len(publisher.batches) != 3 || player.Published() != 5 {
+		t.Errorf(`Events should be published in 3 batches. But there are %v`, len(publisher.batches))
+	}
+	if player.Position() != startTime+300 {
+		t.Errorf(`Position should be %v. But it equals %v`, startTime+300, player.Position())
+	}
+}
+
+func TestPlayFilters(t *testing.T) {
+	publisher := &testPublisher{}
+	player := NewPlayer(NewSliceSource(timeline()), publisher)
+	player.SetSpeed(MaxSpeed)
+	player.SetSymbols("AAPL")
+	player.SetEventTypes(eventcodes.Quote, eventcodes.Trade)
+	_ = player.Play()
+	if publisher.symbols() != "AAPL,AAPL" || player.Published() != 2 {
+		t.Errorf(`Symbols should be AAPL,AAPL. But they equal %v`, publisher.symbols())
+	}
+}
+
+func TestPlayFollowsTimes(t *testing.T) {
+	publisher := &testPublisher{}
+	player := NewPlayer(NewSliceSource(timeline()), publisher)
+	player.SetSpeed(10)
+	start := time.Now()
+	_ = player.Play()
+	elapsed := time.Since(start)
+	if elapsed < 25*time.Millisecond || elapsed > time.Second {
+		t.Errorf(`Replay of 300ms at 10x should take about 30ms. But it took %v`, elapsed)
+	}
+	if publisher.symbols() != "AAPL,AAPL,IBM,IBM,AAPL" {
+		t.Errorf(`Symbols should be AAPL,AAPL,IBM,IBM,AAPL. But they equal %v`, publisher.symbols())
+	}
+}
+
+func TestPauseResumeAndStop(t *testing.T) {
+	publisher := &testPublisher{}
+	player := NewPlayer(NewSliceSource([]interface{}{
+		newQuote("AAPL", startTime),
+		newQuote("IBM", startTime+50),
+		newQuote("MSFT", startTime+10_000),
+	}), publisher)
+	done := make(chan error)
+	go func() {
+		done <- player.Play()
+	}()
+	time.Sleep(20 * time.Millisecond)
+	player.Pause()
+	time.Sleep(100 * time.Millisecond)
+	if publisher.symbols() != "AAPL" || !player.IsPaused() {
+		t.Errorf(`Only AAPL should be published before pause. But there are %v`, publisher.symbols())
+	}
+	player.Resume()
+	time.Sleep(100 * time.Millisecond)
+	if publisher.symbols() != "AAPL,IBM" {
+		t.Errorf(`IBM should be published after resume. But there are %v`, publisher.symbols())
+	}
+	player.Stop()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf(`Unexpected error %v`, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf(`Play should return after stop`)
+	}
+	if publisher.symbols() != "AAPL,IBM" {
+		t.Errorf(`MSFT should not be published after stop. But there are %v`, publisher.symbols())
+	}
+}
+
+func TestSeek(t *testing.T) {
+	publisher := &testPublisher{}
+	player := NewPlayer(NewSliceSource(timeline()), publisher)
+	player.SetSpeed(MaxSpeed)
+	_ = player.SeekTo(startTime + 200)
+	_ = player.Play()
+	if publisher.symbols() != "IBM,AAPL" {
+		t.Errorf(`Symbols should be IBM,AAPL. But they equal %v`, publisher.symbols())
+	}
+
+	_ = player.SeekTo(startTime + 100)
+	_ = player.Play()
+	if publisher.symbols() != "IBM,AAPL,IBM,IBM,AAPL" {
+		t.Errorf(`Seek backwards should rewind the source. But symbols equal %v`, publisher.symbols())
+	}
+
+	player = NewPlayer(NewJSONSource(strings.NewReader("")), publisher)
+	player.position = startTime
+	if err := player.SeekTo(startTime - 1); err == nil {
+		t.Errorf(`Seek backwards should fail for a source that can't be rewound`)
+	}
+}
+
+type testSubscription struct {
+	listeners []common.EventListener
+}
+
+func (s *testSubscription) AddListener(listener common.EventListener) error {
+	s.listeners = append(s.listeners, listener)
+	return nil
+}
+
+func (s *testSubscription) RemoveListener(listener common.EventListener) {
+	for i, l := range s.listeners {
+		if l == listener {
+			s.listeners = append(s.listeners[:i], s.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *testSubscription) publish(events []interface{}) {
+	for _, listener := range s.listeners {
+		listener.Update(events)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	subscription := &testSubscription{}
+	buffer := &bytes.Buffer{}
+	recorder, err := NewRecorder(subscription, NewJSONSink(buffer))
+	if err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	subscription.publish(timeline()[:3])
+	subscription.publish(timeline()[3:])
+	if err := recorder.Close(); err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	subscription.publish(timeline())
+	if recorder.Count() != 5 || len(subscription.listeners) != 0 {
+		t.Errorf(`Recorder should record 5 events and detach. But it recorded %v`, recorder.Count())
+	}
+
+	publisher := &testPublisher{}
+	player := NewPlayer(NewJSONSource(buffer), publisher)
+	player.SetSpeed(MaxSpeed)
+	if err := player.Play(); err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	if publisher.symbols() != "AAPL,AAPL,IBM,IBM,AAPL" {
+		t.Errorf(`Symbols should be AAPL,AAPL,IBM,IBM,AAPL. But they equal %v`, publisher.symbols())
+	}
+	if _, ok := publisher.batches[0][2].(*trade.Trade); !ok {
+		t.Errorf(`Event should be *trade.Trade. But it is %T`, publisher.batches[0][2])
+	}
+}
+
+func TestFeedSource(t *testing.T) {
+	subscription := &testSubscription{}
+	source, err := NewFeedSource(subscription, 2)
+	if err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// the listener waits while the buffer is full
+		subscription.publish(timeline()[:3])
+		subscription.publish(timeline()[3:])
+		_ = source.Close()
+	}()
+	publisher := &testPublisher{}
+	player := NewPlayer(source, publisher)
+	player.SetSpeed(MaxSpeed)
+	if err := player.Play(); err != nil {
+		t.Fatalf(`Unexpected error %v`, err)
+	}
+	<-done
+	if publisher.symbols() != "AAPL,AAPL,IBM,IBM,AAPL" || len(subscription.listeners) != 0 {
+		t.Errorf(`Symbols should be AAPL,AAPL,IBM,IBM,AAPL. But they equal %v`, publisher.symbols())
+	}
+	subscription.publish(timeline())
+	if event, err := source.Read(); err != io.EOF {
+		t.Errorf(`Read after Close should return io.EOF. But it returns %v, %v`, event, err)
+	}
+}
+
+func TestMemorySink(t *testing.T) {
+	sink := NewMemorySink()
+	for _, event := range timeline() {
+		_ = sink.Write(event)
+	}
+	source := sink.Source()
+	count := 0
+	for {
+		if _, err := source.Read(); err == io.EOF {
+			break
+		}
+		count++
+	}
+	if count != 5 {
+		t.Errorf(`Count should be 5. But it equals %v`, count)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	filter := NewFilter()
+	if v := filter.Apply(timeline()); len(v) != 5 {
+		t.Fatalf(`Empty filter should accept 5 events. But it accepts %d`, len(v))
+	}
+	filter.SetTimeWindow(startTime+100, startTime+300)
+	if v := symbolsOf(filter.Apply(timeline())); v != "AAPL,IBM,IBM" {
+		t.Errorf(`Symbols in the window should be AAPL,IBM,IBM. But they equal %v`, v)
+	}
+	filter.SetEventTypes(eventcodes.Quote)
+	if v := symbolsOf(filter.Apply(timeline())); v != "IBM" {
+		t.Errorf(`Quotes in the window should be IBM. But they equal %v`, v)
+	}
+	filter.SetTimeWindow(0, 0)
+	filter.SetSymbols("AAPL")
+	if v := symbolsOf(filter.Apply(timeline())); v != "AAPL" {
+		t.Errorf(`AAPL quotes should be AAPL. But they equal %v`, v)
+	}
+}
+
+func symbolsOf(eventsList []interface{}) string {
+	var result []string
+	for _, event := range eventsList {
+		symbol, _ := symbolOf(event)
+		result = append(result, symbol)
+	}
+	return strings.Join(result, ",")
+}
+
+func TestStats(t *testing.T) {
+	stats := NewStats(1000)
+	stats.Add(timeline())
+	stats.Add([]interface{}{newTrade("MSFT", startTime+5000), newQuote("MSFT", startTime+50)})
+	if v := stats.Count(); v != 7 {
+		t.Fatalf(`Count should be 7. But it equals %d`, v)
+	}
+	if from, to := stats.TimeRange(); from != startTime || to != startTime+5000 {
+		t.Errorf(`Time range should be %d-%d. But it equals %d-%d`, startTime, startTime+5000, from, to)
+	}
+	if v := stats.OutOfOrder(); v != 1 {
+		t.Errorf(`OutOfOrder should be 1. But it equals %d`, v)
+	}
+	if count, gaps := stats.Gaps(); count != 1 || gaps[0] != (Gap{From: startTime + 300, To: startTime + 5000}) {
+		t.Errorf(`Gaps should be 1 from %d to %d. But they equal %d %v`, startTime+300, startTime+5000, count, gaps)
+	}
+	types := stats.ByType()
+	if len(types) != 3 || types[0] != (Count{"Quote", 3}) || types[1] != (Count{"Trade", 3}) || types[2] != (Count{"Profile", 1}) {
+		t.Errorf(`Types should be [{Quote 3} {Trade 3} {Profile 1}]. But they equal %v`, types)
+	}
+	symbols := stats.BySymbol()
+	if len(symbols) != 3 || symbols[0] != (Count{"AAPL", 3}) || symbols[2] != (Count{"MSFT", 2}) {
+		t.Errorf(`Symbols should be [{AAPL 3} {IBM 2} {MSFT 2}]. But they equal %v`, symbols)
+	}
+}
@@ -0,0 +1,184 @@
+// Package replay records events from subscriptions and replays them into publishers.
+//
+// Events are read from a Source: the readers of packages eventio/tape and eventio/csv, a JSON stream,
+// a slice of events or a subscription. Native tapes are read by the native endpoint: subscribe a FeedSource
+// to a StreamFeed endpoint and connect the endpoint to the tape.
+package replay
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+// Source reads recorded events in the order they were recorded. Read returns io.EOF when there are no more events.
+type Source interface {
+	Read() (interface{}, error)
+}
+
+// Rewinder is a Source that can be read again from the beginning, which allows the Player to seek backwards.
+type Rewinder interface {
+	Rewind() error
+}
+
+// Sink writes recorded events. The writers of packages eventio/tape and eventio/csv are sinks.
+type Sink interface {
+	Write(event interface{}) error
+	Flush() error
+}
+
+// FeedSource is a Source of events received from a subscription, e.g. of a StreamFeed endpoint connected
+// to a native tape. Read waits for events until the source is closed, then it returns the remaining events
+// and io.EOF. At most capacity events are buffered, the listener of the subscription waits while the buffer
+// is full, so the endpoint reads the tape at the pace of the Player. Events of pooled listeners are reused
+// by the subscription, the source must be added with AddListener.
+type FeedSource struct {
+	subscription Subscription
+	events       chan interface{}
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+// NewFeedSource creates a source buffering at most capacity events and attaches it to the subscription.
+func NewFeedSource(subscription Subscription, capacity int) (*FeedSource, error) {
+	s := &FeedSource{
+		subscription: subscription,
+		events:       make(chan interface{}, capacity),
+		done:         make(chan struct{}),
+	}
+	if err := subscription.AddListener(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FeedSource) Update(events []interface{}) {
+	for _, event := range events {
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *FeedSource) Read() (interface{}, error) {
+	select {
+	case event := <-s.events:
+		return event, nil
+	case <-s.done:
+	}
+	select {
+	case event := <-s.events:
+		return event, nil
+	default:
+		return nil, io.EOF
+	}
+}
+
+// Close detaches the source from the subscription, e.g. after the endpoint has read the tape
+// (see DXEndpoint.AwaitNotConnected). Events received after Close are dropped.
+func (s *FeedSource) Close() error {
+	s.subscription.RemoveListener(s)
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
+
+type jsonSource struct {
+	decoder *json.Decoder
+}
+
+// NewJSONSource returns a source reading a stream of JSON events like the one written by the sink of NewJSONSink.
+// The packages of event types must be imported (see events.UnmarshalEvent).
+func NewJSONSource(r io.Reader) Source {
+	return &jsonSource{decoder: json.NewDecoder(r)}
+}
+
+func (s *jsonSource) Read() (interface{}, error) {
+	var data json.RawMessage
+	if err := s.decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	return events.UnmarshalEvent(data)
+}
+
+type jsonSink struct {
+	writer  io.Writer
+	encoder *json.Encoder
+}
+
+// NewJSONSink returns a sink writing events as JSON, one event per line.
+func NewJSONSink(w io.Writer) Sink {
+	return &jsonSink{writer: w, encoder: json.NewEncoder(w)}
+}
+
+func (s *jsonSink) Write(event interface{}) error {
+	return s.encoder.Encode(event)
+}
+
+func (s *jsonSink) Flush() error {
+	if flusher, ok := s.writer.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// SliceSource is a rewindable source of events kept in memory.
+type SliceSource struct {
+	events   []interface{}
+	position int
+}
+
+func NewSliceSource(events []interface{}) *SliceSource {
+	return &SliceSource{events: events}
+}
+
+func (s *SliceSource) Read() (interface{}, error) {
+	if s.position >= len(s.events) {
+		return nil, io.EOF
+	}
+	s.position++
+	return s.events[s.position-1], nil
+}
+
+func (s *SliceSource) Rewind() error {
+	s.position = 0
+	return nil
+}
+
+// MemorySink keeps written events in memory. It is safe for concurrent use.
+type MemorySink struct {
+	mu     sync.Mutex
+	events []interface{}
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Write(event interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *MemorySink) Flush() error {
+	return nil
+}
+
+// Events returns a copy of the written events.
+func (s *MemorySink) Events() []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]interface{}(nil), s.events...)
+}
+
+// Source returns a source of the events written so far.
+func (s *MemorySink) Source() *SliceSource {
+	return NewSliceSource(s.Events())
+}
@@ -0,0 +1,134 @@
+package replay
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+// maxGaps limits the number of gaps kept by Stats, further gaps are only counted
+const maxGaps = 1000
+
+// Count is the number of events having the key, like an event type or a symbol.
+type Count struct {
+	Key   string
+	Count int64
+}
+
+// Gap is a period in milliseconds without events.
+type Gap struct {
+	From int64
+	To   int64
+}
+
+// Stats collects statistics of a stream of recorded events: counts by type and by symbol, the time range,
+// gaps between events and events that are earlier than the events before them.
+// Events without time are only counted. It is safe for concurrent use.
+type Stats struct {
+	mu           sync.Mutex
+	gapThreshold int64
+	count        int64
+	byType       map[string]int64
+	bySymbol     map[string]int64
+	firstTime    int64
+	lastTime     int64
+	outOfOrder   int64
+	gapCount     int64
+	gaps         []Gap
+}
+
+// NewStats returns stats detecting gaps longer than the threshold in milliseconds, zero disables gaps.
+func NewStats(gapThreshold int64) *Stats {
+	return &Stats{gapThreshold: gapThreshold, byType: map[string]int64{}, bySymbol: map[string]int64{}}
+}
+
+// Add adds the events in the order they were recorded.
+func (s *Stats) Add(eventsList []interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, event := range eventsList {
+		s.count++
+		if e, ok := event.(events.EventType); ok {
+			s.byType[e.Type().String()]++
+		}
+		if symbol, ok := symbolOf(event); ok {
+			s.bySymbol[symbol]++
+		}
+		t, ok := timeOf(event)
+		switch {
+		case !ok:
+		case s.firstTime == 0:
+			s.firstTime = t
+			s.lastTime = t
+		case t < s.lastTime:
+			s.outOfOrder++
+			if t < s.firstTime {
+				s.firstTime = t
+			}
+		default:
+			if s.gapThreshold > 0 && t-s.lastTime > s.gapThreshold {
+				s.gapCount++
+				if len(s.gaps) < maxGaps {
+					s.gaps = append(s.gaps, Gap{From: s.lastTime, To: t})
+				}
+			}
+			s.lastTime = t
+		}
+	}
+}
+
+func (s *Stats) Count() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+// TimeRange returns the earliest and the latest times of events in milliseconds, zeros if no event has time.
+func (s *Stats) TimeRange() (int64, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.firstTime, s.lastTime
+}
+
+// OutOfOrder returns the number of events that are earlier than the latest event before them.
+func (s *Stats) OutOfOrder() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.outOfOrder
+}
+
+// Gaps returns the number of gaps and the first of them in the order they were found.
+func (s *Stats) Gaps() (int64, []Gap) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gapCount, append([]Gap(nil), s.gaps...)
+}
+
+// ByType returns the counts of events by type sorted by count in descending order, then by type.
+func (s *Stats) ByType() []Count {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedCounts(s.byType)
+}
+
+// BySymbol returns the counts of events by symbol sorted by count in descending order, then by symbol.
+func (s *Stats) BySymbol() []Count {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedCounts(s.bySymbol)
+}
+
+func sortedCounts(counts map[string]int64) []Count {
+	result := make([]Count, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, Count{Key: key, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
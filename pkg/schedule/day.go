@@ -0,0 +1,207 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+// Day represents a continuous period of time approximately 24 hours long. The day is aligned
+// to the start and the end of business activities of a certain business entity or business process.
+type Day struct {
+	schedule     *Schedule
+	dayId        int32
+	yearMonthDay int32
+	holiday      bool
+	shortDay     bool
+	startTime    int64
+	endTime      int64
+	sessions     []*Session
+}
+
+func newDay(s *Schedule, dayId int32) *Day {
+	d := s.definition
+	day := &Day{
+		schedule:     s,
+		dayId:        dayId,
+		yearMonthDay: timeutil.GetYearMonthDayByDayId(dayId),
+		holiday:      d.holidays[dayId],
+		shortDay:     d.shortDays[dayId],
+	}
+	day.startTime = s.millis(day.yearMonthDay, d.dayStart())
+	day.endTime = s.millis(day.yearMonthDay, d.dayEnd)
+
+	var definitions []sessionDefinition
+	if !day.holiday && d.tradingDays[day.DayOfWeek()] {
+		if day.shortDay {
+			definitions = d.short
+		} else {
+			definitions = d.regular
+		}
+	}
+	current := day.startTime
+	for _, definition := range definitions {
+		start := s.millis(day.yearMonthDay, definition.start)
+		end := s.millis(day.yearMonthDay, definition.end)
+		if start > current {
+			day.addSession(NoTrading, current, start)
+		}
+		day.addSession(definition.sessionType, start, end)
+		current = end
+	}
+	if current < day.endTime || len(day.sessions) == 0 {
+		day.addSession(NoTrading, current, day.endTime)
+	}
+	return day
+}
+
+func (d *Day) addSession(sessionType SessionType, start int64, end int64) {
+	d.sessions = append(d.sessions, &Session{
+		day:         d,
+		index:       len(d.sessions),
+		sessionType: sessionType,
+		startTime:   start,
+		endTime:     end,
+	})
+}
+
+func (d *Day) Schedule() *Schedule {
+	return d.schedule
+}
+
+// DayId returns the number of this day since January 1, 1970.
+func (d *Day) DayId() int32 {
+	return d.dayId
+}
+
+// YearMonthDay returns the date of this day in yyyymmdd format.
+func (d *Day) YearMonthDay() int32 {
+	return d.yearMonthDay
+}
+
+func (d *Day) Year() int32 {
+	return d.yearMonthDay / 10000
+}
+
+func (d *Day) MonthOfYear() int32 {
+	return d.yearMonthDay / 100 % 100
+}
+
+func (d *Day) DayOfMonth() int32 {
+	return d.yearMonthDay % 100
+}
+
+// DayOfWeek returns the day of week from 1 (Monday) to 7 (Sunday).
+func (d *Day) DayOfWeek() int32 {
+	// January 1, 1970 was Thursday.
+	return int32((int64(d.dayId)%7+7+3)%7) + 1
+}
+
+func (d *Day) IsHoliday() bool {
+	return d.holiday
+}
+
+func (d *Day) IsShortDay() bool {
+	return d.shortDay
+}
+
+// IsTrading returns true if the day has at least one trading session.
+func (d *Day) IsTrading() bool {
+	for _, session := range d.sessions {
+		if session.IsTrading() {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Day) StartTime() int64 {
+	return d.startTime
+}
+
+func (d *Day) EndTime() int64 {
+	return d.endTime
+}
+
+func (d *Day) ContainsTime(timeMillis int64) bool {
+	return timeMillis >= d.startTime && timeMillis < d.endTime
+}
+
+// ResetTime returns the time when the day is considered to start for the purpose of daily statistics,
+// that is the start of the first trading session or the start of the day if there are no trading sessions.
+func (d *Day) ResetTime() int64 {
+	if session := d.FirstSession(TradingSession); session != nil {
+		return session.StartTime()
+	}
+	return d.startTime
+}
+
+// Sessions returns all sessions of the day. Sessions are ordered by time and cover the whole day.
+func (d *Day) Sessions() []*Session {
+	return append([]*Session(nil), d.sessions...)
+}
+
+func (d *Day) SessionByTime(timeMillis int64) *Session {
+	for _, session := range d.sessions {
+		if session.ContainsTime(timeMillis) {
+			return session
+		}
+	}
+	return nil
+}
+
+// FirstSession returns the first session of the day matching the filter or nil if there is none.
+func (d *Day) FirstSession(filter SessionFilter) *Session {
+	for _, session := range d.sessions {
+		if filter(session) {
+			return session
+		}
+	}
+	return nil
+}
+
+// LastSession returns the last session of the day matching the filter or nil if there is none.
+func (d *Day) LastSession(filter SessionFilter) *Session {
+	for i := len(d.sessions) - 1; i >= 0; i-- {
+		if filter(d.sessions[i]) {
+			return d.sessions[i]
+		}
+	}
+	return nil
+}
+
+// PrevDay returns the closest previous day matching the filter or nil if there is none.
+func (d *Day) PrevDay(filter DayFilter) *Day {
+	for i := int32(1); i <= maxDaySearch; i++ {
+		day := d.schedule.DayById(d.dayId - i)
+		if filter(day) {
+			return day
+		}
+	}
+	return nil
+}
+
+// NextDay returns the closest following day matching the filter or nil if there is none.
+func (d *Day) NextDay(filter DayFilter) *Day {
+	for i := int32(1); i <= maxDaySearch; i++ {
+		day := d.schedule.DayById(d.dayId + i)
+		if filter(day) {
+			return day
+		}
+	}
+	return nil
+}
+
+func (d *Day) String() string {
+	return "Day{" + formatutil.FormatInt64(int64(d.yearMonthDay)) +
+		", holiday=" + formatutil.FormatBool(d.holiday) +
+		", shortDay=" + formatutil.FormatBool(d.shortDay) +
+		", start=" + formatTime(d.startTime, d.schedule.TimeZone()) +
+		", end=" + formatTime(d.endTime, d.schedule.TimeZone()) +
+		"}"
+}
+
+func formatTime(timeMillis int64, location *time.Location) string {
+	return time.UnixMilli(timeMillis).In(location).Format("20060102-150405-07:00")
+}
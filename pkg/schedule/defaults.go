@@ -0,0 +1,137 @@
+package schedule
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Defaults holds named holiday and short day lists and named schedule definitions
+// that trading hours strings can refer to.
+//
+// The defaults file is a properties file with the following keys:
+//
+//	hd.<name>=<yyyymmdd>,...     list of holidays that can be referenced as hd=<name>
+//	sd.<name>=<yyyymmdd>,...     list of short days that can be referenced as sd=<name>
+//	def.<name>=<key>=<value>;... default properties of the schedule with the given name
+//
+// Empty lines and lines starting with '#' are ignored.
+type Defaults struct {
+	holidays    map[string][]int32
+	shortDays   map[string][]int32
+	definitions map[string]string
+}
+
+var (
+	defaultsMu      sync.RWMutex
+	currentDefaults = NewDefaults()
+)
+
+func NewDefaults() *Defaults {
+	return &Defaults{
+		holidays:    map[string][]int32{},
+		shortDays:   map[string][]int32{},
+		definitions: map[string]string{},
+	}
+}
+
+func ParseDefaults(reader io.Reader) (*Defaults, error) {
+	d := NewDefaults()
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: missing value", lineNumber)
+		}
+		kind, name, found := strings.Cut(strings.TrimSpace(key), ".")
+		if !found || name == "" {
+			return nil, fmt.Errorf("line %d: invalid key %q", lineNumber, key)
+		}
+		value = strings.TrimSpace(value)
+		var err error
+		switch kind {
+		case "hd":
+			d.holidays[name], err = parseDayList(value)
+		case "sd":
+			d.shortDays[name], err = parseDayList(value)
+		case "def":
+			d.definitions[name] = value
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func LoadDefaults(path string) (*Defaults, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseDefaults(file)
+}
+
+// SetDefaults replaces the defaults used by NewSchedule and NewScheduleFromProfile.
+func SetDefaults(defaults *Defaults) {
+	if defaults == nil {
+		defaults = NewDefaults()
+	}
+	defaultsMu.Lock()
+	currentDefaults = defaults
+	defaultsMu.Unlock()
+}
+
+func GetDefaults() *Defaults {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+	return currentDefaults
+}
+
+func (d *Defaults) SetHolidays(name string, yyyymmdd ...int32) {
+	d.holidays[name] = yyyymmdd
+}
+
+func (d *Defaults) SetShortDays(name string, yyyymmdd ...int32) {
+	d.shortDays[name] = yyyymmdd
+}
+
+func (d *Defaults) SetDefinition(name string, properties string) {
+	d.definitions[name] = properties
+}
+
+func (d *Defaults) definition(name string) (string, bool) {
+	value, ok := d.definitions[name]
+	return value, ok
+}
+
+func parseDayList(value string) ([]int32, error) {
+	var result []int32
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		yyyymmdd, err := parseYearMonthDay(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, yyyymmdd)
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package schedule
+
+type SessionFilter func(session *Session) bool
+
+type DayFilter func(day *Day) bool
+
+var (
+	AnySession SessionFilter = func(session *Session) bool {
+		return true
+	}
+	TradingSession SessionFilter = func(session *Session) bool {
+		return session.IsTrading()
+	}
+	NonTradingSession SessionFilter = func(session *Session) bool {
+		return !session.IsTrading()
+	}
+	NoTradingSession   = SessionTypeFilter(NoTrading)
+	PreMarketSession   = SessionTypeFilter(PreMarket)
+	RegularSession     = SessionTypeFilter(Regular)
+	AfterMarketSession = SessionTypeFilter(AfterMarket)
+)
+
+var (
+	AnyDay DayFilter = func(day *Day) bool {
+		return true
+	}
+	TradingDay DayFilter = func(day *Day) bool {
+		return day.IsTrading()
+	}
+	NonTradingDay DayFilter = func(day *Day) bool {
+		return !day.IsTrading()
+	}
+	HolidayDay DayFilter = func(day *Day) bool {
+		return day.IsHoliday()
+	}
+	ShortDay DayFilter = func(day *Day) bool {
+		return day.IsShortDay()
+	}
+	WeekDay DayFilter = func(day *Day) bool {
+		return day.DayOfWeek() <= 5
+	}
+	Weekend DayFilter = func(day *Day) bool {
+		return day.DayOfWeek() > 5
+	}
+	// BusinessDay accepts weekdays that are not holidays.
+	BusinessDay DayFilter = func(day *Day) bool {
+		return day.DayOfWeek() <= 5 && !day.IsHoliday()
+	}
+)
+
+func SessionTypeFilter(sessionType SessionType) SessionFilter {
+	return func(session *Session) bool {
+		return session.Type() == sessionType
+	}
+}
@@ -0,0 +1,310 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+const secondsInDay = 24 * 60 * 60
+
+// offset is a wall clock time relative to the midnight of a calendar day.
+type offset struct {
+	days    int32
+	seconds int32
+}
+
+func (o offset) value() int64 {
+	return int64(o.days)*secondsInDay + int64(o.seconds)
+}
+
+type sessionDefinition struct {
+	sessionType SessionType
+	start       offset
+	end         offset
+}
+
+type definition struct {
+	name        string
+	location    *time.Location
+	holidays    map[int32]bool
+	shortDays   map[int32]bool
+	tradingDays [8]bool
+	dayEnd      offset
+	regular     []sessionDefinition
+	short       []sessionDefinition
+}
+
+func (d *definition) dayStart() offset {
+	return offset{days: d.dayEnd.days - 1, seconds: d.dayEnd.seconds}
+}
+
+// parseDefinition parses trading hours in the form "<name>(<key>=<value>;...)".
+// Properties of the named definition from defaults are used for the keys that are not specified explicitly.
+func parseDefinition(tradingHours string, defaults *Defaults) (*definition, error) {
+	tradingHours = strings.TrimSpace(tradingHours)
+	if tradingHours == "" {
+		return nil, fmt.Errorf("empty trading hours")
+	}
+	name := tradingHours
+	body := ""
+	if open := strings.IndexByte(tradingHours, '('); open >= 0 {
+		if !strings.HasSuffix(tradingHours, ")") {
+			return nil, fmt.Errorf("missing closing bracket in %q", tradingHours)
+		}
+		name = tradingHours[:open]
+		body = tradingHours[open+1 : len(tradingHours)-1]
+	}
+	for _, ch := range name {
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '_') {
+			return nil, fmt.Errorf("invalid schedule name %q", name)
+		}
+	}
+
+	properties := map[string]string{}
+	if base, ok := defaults.definition(name); ok {
+		if err := parseProperties(base, properties); err != nil {
+			return nil, fmt.Errorf("defaults for %s: %w", name, err)
+		}
+	} else if body == "" && !strings.Contains(tradingHours, "(") {
+		return nil, fmt.Errorf("unknown schedule %q", name)
+	}
+	if err := parseProperties(body, properties); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	d := &definition{
+		name:      name,
+		location:  time.UTC,
+		holidays:  map[int32]bool{},
+		shortDays: map[int32]bool{},
+		dayEnd:    offset{days: 1},
+	}
+	if err := d.apply(properties, defaults); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return d, nil
+}
+
+func parseProperties(body string, properties map[string]string) error {
+	for _, property := range strings.Split(body, ";") {
+		property = strings.TrimSpace(property)
+		if property == "" {
+			continue
+		}
+		key, value, found := strings.Cut(property, "=")
+		if !found {
+			return fmt.Errorf("missing value for %q", property)
+		}
+		properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return nil
+}
+
+func (d *definition) apply(properties map[string]string, defaults *Defaults) error {
+	tradingDays := "12345"
+	for key, value := range properties {
+		var err error
+		switch key {
+		case "tz":
+			d.location, err = loadLocation(value)
+		case "hd":
+			err = parseDays(value, defaults.holidays, d.holidays)
+		case "sd":
+			err = parseDays(value, defaults.shortDays, d.shortDays)
+		case "td":
+			tradingDays = value
+		case "de":
+			d.dayEnd, err = parseDayEnd(value)
+		case "0":
+			d.regular, err = parseSessions(value)
+		case "s":
+			d.short, err = parseSessions(value)
+		default:
+			err = fmt.Errorf("unknown property %q", key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, ch := range tradingDays {
+		if ch < '1' || ch > '7' {
+			return fmt.Errorf("invalid trading days %q", tradingDays)
+		}
+		d.tradingDays[ch-'0'] = true
+	}
+	if d.short == nil {
+		d.short = d.regular
+	}
+	if err := d.checkSessions(d.regular); err != nil {
+		return err
+	}
+	return d.checkSessions(d.short)
+}
+
+func (d *definition) checkSessions(sessions []sessionDefinition) error {
+	previous := d.dayStart().value()
+	for _, s := range sessions {
+		if s.start.value() < previous {
+			return fmt.Errorf("session %s starts before the end of the previous session or the day start", s.sessionType)
+		}
+		if s.end.value() <= s.start.value() {
+			return fmt.Errorf("session %s ends before it starts", s.sessionType)
+		}
+		previous = s.end.value()
+	}
+	if previous > d.dayEnd.value() {
+		return fmt.Errorf("sessions end after the day end")
+	}
+	return nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	switch name {
+	case "GMT", "UTC", "":
+		return time.UTC, nil
+	default:
+		return time.LoadLocation(name)
+	}
+}
+
+func parseDays(value string, named map[string][]int32, result map[int32]bool) error {
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if item[0] >= '0' && item[0] <= '9' {
+			yyyymmdd, err := parseYearMonthDay(item)
+			if err != nil {
+				return err
+			}
+			result[timeutil.GetDayIdByYearMonthDay(yyyymmdd)] = true
+			continue
+		}
+		days, ok := named[item]
+		if !ok {
+			return fmt.Errorf("unknown day list %q", item)
+		}
+		for _, yyyymmdd := range days {
+			result[timeutil.GetDayIdByYearMonthDay(yyyymmdd)] = true
+		}
+	}
+	return nil
+}
+
+func parseYearMonthDay(value string) (int32, error) {
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid date %q, expected yyyymmdd", value)
+	}
+	yyyymmdd, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date %q, expected yyyymmdd", value)
+	}
+	month := yyyymmdd / 100 % 100
+	day := yyyymmdd % 100
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return 0, fmt.Errorf("invalid date %q, expected yyyymmdd", value)
+	}
+	return int32(yyyymmdd), nil
+}
+
+// parseDayEnd parses "HHMM[SS]" as the end of the day on the same calendar date
+// and "+HHMM[SS]" as the end of the day on the next calendar date.
+func parseDayEnd(value string) (offset, error) {
+	days := int32(0)
+	if strings.HasPrefix(value, "+") {
+		days = 1
+		value = value[1:]
+	}
+	seconds, err := parseTimeOfDay(value)
+	if err != nil {
+		return offset{}, err
+	}
+	return offset{days: days, seconds: seconds}, nil
+}
+
+// parseSessions parses a sequence of "<type><start><end>" items, e.g. "p04000930r09301600a16002000".
+// Each time is HHMM or HHMMSS and may be prefixed with '-' or '+' to refer to the previous or the next day.
+func parseSessions(value string) ([]sessionDefinition, error) {
+	var result []sessionDefinition
+	for i := 0; i < len(value); {
+		sessionType, err := sessionTypeByCode(value[i])
+		if err != nil {
+			return nil, err
+		}
+		j := i + 1
+		for j < len(value) && (value[j] >= '0' && value[j] <= '9' || value[j] == '-' || value[j] == '+') {
+			j++
+		}
+		start, end, err := parseSessionTimes(value[i+1 : j])
+		if err != nil {
+			return nil, fmt.Errorf("session %q: %w", value[i:j], err)
+		}
+		if sessionType != NoTrading {
+			result = append(result, sessionDefinition{sessionType: sessionType, start: start, end: end})
+		}
+		i = j
+	}
+	return result, nil
+}
+
+func parseSessionTimes(value string) (offset, offset, error) {
+	digits := 0
+	for k := 0; k < len(value); k++ {
+		if value[k] >= '0' && value[k] <= '9' {
+			digits++
+		}
+	}
+	var width int
+	switch digits {
+	case 8:
+		width = 4
+	case 12:
+		width = 6
+	default:
+		return offset{}, offset{}, fmt.Errorf("expected two times in HHMM or HHMMSS format")
+	}
+	var times [2]offset
+	for k := range times {
+		days := int32(0)
+		if value != "" && (value[0] == '-' || value[0] == '+') {
+			days = 1
+			if value[0] == '-' {
+				days = -1
+			}
+			value = value[1:]
+		}
+		if len(value) < width {
+			return offset{}, offset{}, fmt.Errorf("expected two times in HHMM or HHMMSS format")
+		}
+		seconds, err := parseTimeOfDay(value[:width])
+		if err != nil {
+			return offset{}, offset{}, err
+		}
+		times[k] = offset{days: days, seconds: seconds}
+		value = value[width:]
+	}
+	return times[0], times[1], nil
+}
+
+func parseTimeOfDay(value string) (int32, error) {
+	if len(value) != 4 && len(value) != 6 {
+		return 0, fmt.Errorf("invalid time %q, expected HHMM or HHMMSS", value)
+	}
+	var parts [3]int32
+	for k := 0; k*2 < len(value); k++ {
+		part, err := strconv.ParseInt(value[k*2:k*2+2], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time %q, expected HHMM or HHMMSS", value)
+		}
+		parts[k] = int32(part)
+	}
+	if parts[0] > 24 || parts[1] > 59 || parts[2] > 59 || parts[0] == 24 && parts[1]+parts[2] != 0 {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	return parts[0]*3600 + parts[1]*60 + parts[2], nil
+}
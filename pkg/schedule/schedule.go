@@ -0,0 +1,150 @@
+// Package schedule provides API to retrieve and explore trading schedules of different exchanges
+// and different classes of financial instruments.
+//
+// A schedule is built from trading hours in the form "<name>(<key>=<value>;...)", for example
+//
+//	NYSE(tz=America/New_York;hd=US;sd=US;td=12345;de=+0000;0=p04000930r09301600a16002000;s=p04000930r09301300)
+//
+// with the following keys:
+//
+//	tz  time zone of the schedule, GMT by default
+//	hd  comma-separated holidays in yyyymmdd format or names of holiday lists from Defaults
+//	sd  comma-separated short days in yyyymmdd format or names of short day lists from Defaults
+//	td  trading days of week, digits from 1 (Monday) to 7 (Sunday), 12345 by default
+//	de  day end time as HHMM[SS] on the same date or +HHMM[SS] on the next date, +0000 by default
+//	0   sessions of a regular trading day
+//	s   sessions of a short day, the regular sessions by default
+//
+// Sessions are written as "<type><start><end>" where type is one of 'p' (pre-market), 'r' (regular),
+// 'a' (after-market), and each time is HHMM or HHMMSS optionally prefixed with '-' or '+' to refer
+// to the previous or the next calendar date. Gaps between sessions are filled with NoTrading sessions.
+package schedule
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/formatutil"
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/timeutil"
+)
+
+const (
+	// maxDaySearch limits the number of days scanned when looking for a day or a session matching a filter.
+	maxDaySearch = 20000
+	maxCachedDay = 10000
+)
+
+type Schedule struct {
+	definition *definition
+
+	mu   sync.Mutex
+	days map[int32]*Day
+}
+
+// NewSchedule creates a schedule from the trading hours using the current defaults.
+func NewSchedule(tradingHours string) (*Schedule, error) {
+	return NewScheduleWithDefaults(tradingHours, GetDefaults())
+}
+
+func NewScheduleWithDefaults(tradingHours string, defaults *Defaults) (*Schedule, error) {
+	if defaults == nil {
+		defaults = NewDefaults()
+	}
+	d, err := parseDefinition(tradingHours, defaults)
+	if err != nil {
+		return nil, err
+	}
+	return &Schedule{definition: d, days: map[int32]*Day{}}, nil
+}
+
+// NewScheduleFromProfile creates a schedule from the trading hours of the instrument profile.
+func NewScheduleFromProfile(profile *events.InstrumentProfile) (*Schedule, error) {
+	tradingHours := profile.TradingHours()
+	if tradingHours == nil || *tradingHours == "" {
+		return nil, fmt.Errorf("instrument profile %s has no trading hours", formatutil.FormatString(profile.Symbol()))
+	}
+	return NewSchedule(*tradingHours)
+}
+
+func (s *Schedule) Name() string {
+	return s.definition.name
+}
+
+func (s *Schedule) TimeZone() *time.Location {
+	return s.definition.location
+}
+
+// DayById returns the day for the specified number of days since January 1, 1970.
+func (s *Schedule) DayById(dayId int32) *Day {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if day, ok := s.days[dayId]; ok {
+		return day
+	}
+	if len(s.days) >= maxCachedDay {
+		s.days = map[int32]*Day{}
+	}
+	day := newDay(s, dayId)
+	s.days[dayId] = day
+	return day
+}
+
+// DayByYearMonthDay returns the day for the date in yyyymmdd format.
+func (s *Schedule) DayByYearMonthDay(yyyymmdd int32) *Day {
+	return s.DayById(timeutil.GetDayIdByYearMonthDay(yyyymmdd))
+}
+
+// DayByTime returns the day that contains the specified time in milliseconds.
+func (s *Schedule) DayByTime(timeMillis int64) *Day {
+	local := time.UnixMilli(timeMillis).In(s.definition.location)
+	dayId := timeutil.GetDayIdByDate(int32(local.Year()), int32(local.Month()), int32(local.Day()))
+	for _, candidate := range []int32{dayId, dayId + 1, dayId - 1, dayId + 2} {
+		day := s.DayById(candidate)
+		if day.ContainsTime(timeMillis) {
+			return day
+		}
+	}
+	return s.DayById(dayId)
+}
+
+// SessionByTime returns the session that contains the specified time in milliseconds.
+func (s *Schedule) SessionByTime(timeMillis int64) *Session {
+	return s.DayByTime(timeMillis).SessionByTime(timeMillis)
+}
+
+// NearestSessionByTime returns the session that matches the filter and is nearest to the specified time:
+// either the one containing the time or the one with the smallest distance from the time.
+func (s *Schedule) NearestSessionByTime(timeMillis int64, filter SessionFilter) (*Session, error) {
+	session := s.SessionByTime(timeMillis)
+	if filter(session) {
+		return session, nil
+	}
+	prev := session.PrevSession(filter)
+	next := session.NextSession(filter)
+	switch {
+	case prev == nil && next == nil:
+		return nil, fmt.Errorf("no session matching the filter near %s", formatutil.FormatTime(timeMillis))
+	case prev == nil:
+		return next, nil
+	case next == nil:
+		return prev, nil
+	case timeMillis-prev.EndTime() < next.StartTime()-timeMillis:
+		return prev, nil
+	default:
+		return next, nil
+	}
+}
+
+func (s *Schedule) String() string {
+	return "Schedule{" + s.definition.name + ", tz=" + s.definition.location.String() + "}"
+}
+
+// millis converts the wall clock offset relative to the midnight of the day to the time in milliseconds.
+func (s *Schedule) millis(yyyymmdd int32, o offset) int64 {
+	year := yyyymmdd / 10000
+	month := time.Month(yyyymmdd / 100 % 100)
+	day := int(yyyymmdd%100) + int(o.days)
+	return time.Date(int(year), month, day, 0, 0, int(o.seconds), 0, s.definition.location).UnixMilli()
+}
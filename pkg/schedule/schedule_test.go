@@ -0,0 +1,244 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events"
+)
+
+const nyse = "NYSE(tz=America/New_York;hd=20240101,20240704;sd=20240703;0=p04000930r09301600a16002000;s=p04000930r09301300)"
+
+func newYork(t *testing.T, value string) int64 {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := time.ParseInLocation("20060102-1504", value, location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed.UnixMilli()
+}
+
+func mustSchedule(t *testing.T, tradingHours string) *Schedule {
+	s, err := NewSchedule(tradingHours)
+	if err != nil {
+		t.Fatalf(`NewSchedule(%q) failed: %v`, tradingHours, err)
+	}
+	return s
+}
+
+func TestSessionByTime(t *testing.T) {
+	s := mustSchedule(t, nyse)
+	tests := []struct {
+		time     string
+		expected SessionType
+		day      int32
+	}{
+		{"20240102-0000", NoTrading, 20240102},
+		{"20240102-0400", PreMarket, 20240102},
+		{"20240102-0929", PreMarket, 20240102},
+		{"20240102-0930", Regular, 20240102},
+		{"20240102-1559", Regular, 20240102},
+		{"20240102-1600", AfterMarket, 20240102},
+		{"20240102-2000", NoTrading, 20240102},
+		{"20240101-1000", NoTrading, 20240101},
+		{"20240106-1000", NoTrading, 20240106},
+		{"20240703-1200", Regular, 20240703},
+		{"20240703-1300", NoTrading, 20240703},
+		{"20240704-1000", NoTrading, 20240704},
+		{"20240311-1000", Regular, 20240311},
+	}
+	for _, test := range tests {
+		session := s.SessionByTime(newYork(t, test.time))
+		if session.Type() != test.expected {
+			t.Errorf(`SessionByTime(%s) should be %s. But it equals %s`, test.time, test.expected, session.Type())
+		}
+		if session.Day().YearMonthDay() != test.day {
+			t.Errorf(`SessionByTime(%s) day should be %d. But it equals %d`, test.time, test.day, session.Day().YearMonthDay())
+		}
+	}
+}
+
+func TestDayAttributes(t *testing.T) {
+	s := mustSchedule(t, nyse)
+	tests := []struct {
+		yyyymmdd  int32
+		dayOfWeek int32
+		holiday   bool
+		short     bool
+		trading   bool
+	}{
+		{20240101, 1, true, false, false},
+		{20240102, 2, false, false, true},
+		{20240106, 6, false, false, false},
+		{20240107, 7, false, false, false},
+		{20240703, 3, false, true, true},
+		{20240704, 4, true, false, false},
+	}
+	for _, test := range tests {
+		day := s.DayByYearMonthDay(test.yyyymmdd)
+		if day.DayOfWeek() != test.dayOfWeek || day.IsHoliday() != test.holiday ||
+			day.IsShortDay() != test.short || day.IsTrading() != test.trading {
+			t.Errorf(`Wrong attributes of %s`, day)
+		}
+	}
+}
+
+func TestSessionsCoverDay(t *testing.T) {
+	s := mustSchedule(t, nyse)
+	for _, yyyymmdd := range []int32{20240102, 20240310, 20240311, 20241103, 20240703, 20240101} {
+		day := s.DayByYearMonthDay(yyyymmdd)
+		current := day.StartTime()
+		for _, session := range day.Sessions() {
+			if session.StartTime() != current {
+				t.Fatalf(`Session %s of %s doesn't start at the end of the previous one`, session, day)
+			}
+			current = session.EndTime()
+		}
+		if current != day.EndTime() {
+			t.Fatalf(`Sessions of %s don't cover the whole day`, day)
+		}
+	}
+	if hours := (s.DayByYearMonthDay(20240310).EndTime() - s.DayByYearMonthDay(20240310).StartTime()) / 3600_000; hours != 23 {
+		t.Fatalf(`DST day should be 23 hours long. But it is %d`, hours)
+	}
+}
+
+func TestNavigation(t *testing.T) {
+	s := mustSchedule(t, nyse)
+	friday := s.DayByYearMonthDay(20231229)
+	next := friday.NextDay(TradingDay)
+	if next.YearMonthDay() != 20240102 {
+		t.Fatalf(`NextDay(TradingDay) should be 20240102. But it equals %d`, next.YearMonthDay())
+	}
+	if prev := next.PrevDay(TradingDay); prev.YearMonthDay() != 20231229 {
+		t.Fatalf(`PrevDay(TradingDay) should be 20231229. But it equals %d`, prev.YearMonthDay())
+	}
+	if holiday := friday.NextDay(HolidayDay); holiday.YearMonthDay() != 20240101 {
+		t.Fatalf(`NextDay(HolidayDay) should be 20240101. But it equals %d`, holiday.YearMonthDay())
+	}
+
+	session := s.SessionByTime(newYork(t, "20231229-1700"))
+	nextRegular := session.NextSession(RegularSession)
+	if nextRegular.StartTime() != newYork(t, "20240102-0930") {
+		t.Fatalf(`NextSession(RegularSession) should start on 20240102-0930. But it is %s`, nextRegular)
+	}
+	prevRegular := nextRegular.PrevSession(RegularSession)
+	if prevRegular.EndTime() != newYork(t, "20231229-1600") {
+		t.Fatalf(`PrevSession(RegularSession) should end on 20231229-1600. But it is %s`, prevRegular)
+	}
+	if first := nextRegular.Day().FirstSession(TradingSession); first.Type() != PreMarket {
+		t.Fatalf(`FirstSession(TradingSession) should be PreMarket. But it is %s`, first)
+	}
+	if last := nextRegular.Day().LastSession(TradingSession); last.Type() != AfterMarket {
+		t.Fatalf(`LastSession(TradingSession) should be AfterMarket. But it is %s`, last)
+	}
+}
+
+func TestNearestSessionByTime(t *testing.T) {
+	s := mustSchedule(t, nyse)
+	tests := []struct {
+		time     string
+		expected string
+	}{
+		{"20240102-1000", "20240102-0930"},
+		{"20240102-1700", "20240102-0930"},
+		{"20240103-0800", "20240103-0930"},
+		{"20240106-1200", "20240105-0930"},
+		{"20240107-1200", "20240108-0930"},
+	}
+	for _, test := range tests {
+		session, err := s.NearestSessionByTime(newYork(t, test.time), RegularSession)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if session.StartTime() != newYork(t, test.expected) {
+			t.Errorf(`NearestSessionByTime(%s) should start at %s. But it is %s`, test.time, test.expected, session)
+		}
+	}
+
+	noSessions := mustSchedule(t, "NONE(td=)")
+	if _, err := noSessions.NearestSessionByTime(0, TradingSession); err == nil {
+		t.Fatalf(`NearestSessionByTime should fail for a schedule without trading sessions`)
+	}
+}
+
+func TestOvernightSchedule(t *testing.T) {
+	s := mustSchedule(t, "CME(tz=America/Chicago;de=1700;td=12345;0=r-17001600)")
+	monday := s.DayByYearMonthDay(20240108)
+	chicago, _ := time.LoadLocation("America/Chicago")
+	expectedStart := time.Date(2024, 1, 7, 17, 0, 0, 0, chicago).UnixMilli()
+	if monday.StartTime() != expectedStart {
+		t.Fatalf(`Day should start on the previous evening. But it is %s`, monday)
+	}
+	sessions := monday.Sessions()
+	if len(sessions) != 2 || sessions[0].Type() != Regular || sessions[1].Type() != NoTrading {
+		t.Fatalf(`Unexpected sessions %v`, sessions)
+	}
+	sundayEvening := time.Date(2024, 1, 7, 18, 0, 0, 0, chicago).UnixMilli()
+	if day := s.DayByTime(sundayEvening); day.YearMonthDay() != 20240108 {
+		t.Fatalf(`DayByTime should return 20240108. But it equals %d`, day.YearMonthDay())
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	defaults, err := ParseDefaults(strings.NewReader(`
+# US holidays
+hd.US=20240101,20240704
+sd.US=20240703
+def.NYSE=tz=America/New_York;hd=US;sd=US;0=r09301600;s=r09301300
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewScheduleWithDefaults("NYSE(0=p04000930r09301600)", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.DayByYearMonthDay(20240704).IsHoliday() || !s.DayByYearMonthDay(20240703).IsShortDay() {
+		t.Fatalf(`Holidays and short days should be taken from defaults`)
+	}
+	if s.SessionByTime(newYork(t, "20240102-0800")).Type() != PreMarket {
+		t.Fatalf(`Explicit sessions should override sessions from defaults`)
+	}
+	if _, err := NewScheduleWithDefaults("NYSE", defaults); err != nil {
+		t.Fatalf(`Schedule should be created by name only: %v`, err)
+	}
+
+	SetDefaults(defaults)
+	defer SetDefaults(nil)
+	profile := events.NewInstrumentProfile()
+	tradingHours := "NYSE()"
+	profile.SetTradingHours(&tradingHours)
+	if _, err := NewScheduleFromProfile(profile); err != nil {
+		t.Fatalf(`NewScheduleFromProfile failed: %v`, err)
+	}
+}
+
+func TestInvalidTradingHours(t *testing.T) {
+	tests := []string{
+		"",
+		"NYSE",
+		"NYSE(tz=America/New_York",
+		"NYSE(0=r09301600r09001700)",
+		"NYSE(0=r16000930)",
+		"NYSE(0=x09301600)",
+		"NYSE(0=r0930160)",
+		"NYSE(0=r25001600)",
+		"NYSE(hd=2024011)",
+		"NYSE(hd=UNKNOWN)",
+		"NYSE(td=0)",
+		"NYSE(foo=bar)",
+		"NYSE(tz=Unknown/Zone)",
+		"NYSE(de=1600;0=r09301700)",
+	}
+	for _, test := range tests {
+		if _, err := NewScheduleWithDefaults(test, NewDefaults()); err == nil {
+			t.Errorf(`NewSchedule(%q) should fail`, test)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package schedule
+
+// Session represents a continuous period of time during which the same rules apply to the trading activity.
+type Session struct {
+	day         *Day
+	index       int
+	sessionType SessionType
+	startTime   int64
+	endTime     int64
+}
+
+func (s *Session) Day() *Day {
+	return s.day
+}
+
+func (s *Session) Type() SessionType {
+	return s.sessionType
+}
+
+func (s *Session) IsTrading() bool {
+	return s.sessionType.IsTrading()
+}
+
+// IsEmpty returns true if the session has zero duration.
+func (s *Session) IsEmpty() bool {
+	return s.startTime >= s.endTime
+}
+
+func (s *Session) StartTime() int64 {
+	return s.startTime
+}
+
+func (s *Session) EndTime() int64 {
+	return s.endTime
+}
+
+func (s *Session) ContainsTime(timeMillis int64) bool {
+	return timeMillis >= s.startTime && timeMillis < s.endTime
+}
+
+// PrevSession returns the closest previous session matching the filter or nil if there is none.
+func (s *Session) PrevSession(filter SessionFilter) *Session {
+	for i := s.index - 1; i >= 0; i-- {
+		if filter(s.day.sessions[i]) {
+			return s.day.sessions[i]
+		}
+	}
+	day := s.day.PrevDay(func(day *Day) bool {
+		return day.LastSession(filter) != nil
+	})
+	if day == nil {
+		return nil
+	}
+	return day.LastSession(filter)
+}
+
+// NextSession returns the closest following session matching the filter or nil if there is none.
+func (s *Session) NextSession(filter SessionFilter) *Session {
+	for i := s.index + 1; i < len(s.day.sessions); i++ {
+		if filter(s.day.sessions[i]) {
+			return s.day.sessions[i]
+		}
+	}
+	day := s.day.NextDay(func(day *Day) bool {
+		return day.FirstSession(filter) != nil
+	})
+	if day == nil {
+		return nil
+	}
+	return day.FirstSession(filter)
+}
+
+func (s *Session) String() string {
+	location := s.day.schedule.TimeZone()
+	return "Session{" + s.sessionType.String() +
+		", start=" + formatTime(s.startTime, location) +
+		", end=" + formatTime(s.endTime, location) +
+		"}"
+}
@@ -0,0 +1,47 @@
+package schedule
+
+import "fmt"
+
+type SessionType int32
+
+const (
+	NoTrading SessionType = iota
+	PreMarket
+	Regular
+	AfterMarket
+)
+
+func (t SessionType) String() string {
+	switch t {
+	case NoTrading:
+		return "NoTrading"
+	case PreMarket:
+		return "PreMarket"
+	case Regular:
+		return "Regular"
+	case AfterMarket:
+		return "AfterMarket"
+	default:
+		return fmt.Sprintf("SessionType: Wrong value %d", t)
+	}
+}
+
+// IsTrading returns true if trading activity is allowed for sessions of this type.
+func (t SessionType) IsTrading() bool {
+	return t != NoTrading
+}
+
+func sessionTypeByCode(code byte) (SessionType, error) {
+	switch code {
+	case 'n':
+		return NoTrading, nil
+	case 'p':
+		return PreMarket, nil
+	case 'r':
+		return Regular, nil
+	case 'a':
+		return AfterMarket, nil
+	default:
+		return NoTrading, fmt.Errorf("unknown session type '%c'", code)
+	}
+}
@@ -0,0 +1,146 @@
+package symbols
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dxfeed/dxfeed-graal-go-api/pkg/events/candle"
+)
+
+const (
+	attributesOpen      = '{'
+	attributesClose     = '}'
+	attributesSeparator = ","
+	attributeValueSign  = "="
+)
+
+// CandleSymbol is a structured representation of a candle symbol like "AAPL&Q{=5m,price=mark}".
+// Attributes are kept sorted by key, the period is stored under the empty key.
+type CandleSymbol struct {
+	base       string
+	attributes map[string]string
+}
+
+// NewCandleSymbol creates a candle symbol without attributes. The base symbol may be a regional symbol.
+func NewCandleSymbol(base string) (*CandleSymbol, error) {
+	if strings.ContainsAny(base, "{}") {
+		return nil, fmt.Errorf("invalid candle base symbol %q", base)
+	}
+	if err := Validate(base); err != nil {
+		return nil, fmt.Errorf("invalid candle base symbol: %w", err)
+	}
+	return &CandleSymbol{base: base, attributes: map[string]string{}}, nil
+}
+
+// ParseCandle parses a candle symbol "<base>[{[=<period>][,<key>=<value>...]}]".
+func ParseCandle(symbol string) (*CandleSymbol, error) {
+	base, attributes := symbol, ""
+	if index := strings.IndexByte(symbol, attributesOpen); index >= 0 {
+		if symbol[len(symbol)-1] != attributesClose {
+			return nil, fmt.Errorf("invalid candle symbol %q: missing '}'", symbol)
+		}
+		base, attributes = symbol[:index], symbol[index+1:len(symbol)-1]
+	}
+	result, err := NewCandleSymbol(base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid candle symbol %q: %w", symbol, err)
+	}
+	if attributes == "" {
+		return result, nil
+	}
+	for _, attribute := range strings.Split(attributes, attributesSeparator) {
+		key, value, found := strings.Cut(attribute, attributeValueSign)
+		if !found {
+			return nil, fmt.Errorf("invalid candle symbol %q: attribute %q has no value", symbol, attribute)
+		}
+		if _, duplicate := result.attributes[key]; duplicate {
+			return nil, fmt.Errorf("invalid candle symbol %q: duplicate attribute %q", symbol, key)
+		}
+		if result, err = result.WithAttribute(key, value); err != nil {
+			return nil, fmt.Errorf("invalid candle symbol %q: %w", symbol, err)
+		}
+	}
+	return result, nil
+}
+
+// Base returns the symbol without attributes.
+func (c *CandleSymbol) Base() string {
+	return c.base
+}
+
+// Exchange returns the exchange code of a regional base symbol or 0 for a composite one.
+func (c *CandleSymbol) Exchange() rune {
+	if regional, err := ParseRegional(c.base); err == nil {
+		return regional.Exchange()
+	}
+	return 0
+}
+
+// Period returns the value of the period attribute, e.g. "5m", or an empty string if it is not set.
+func (c *CandleSymbol) Period() string {
+	return c.attributes[""]
+}
+
+func (c *CandleSymbol) Attribute(key string) (string, bool) {
+	value, ok := c.attributes[key]
+	return value, ok
+}
+
+// Attributes returns a copy of all attributes.
+func (c *CandleSymbol) Attributes() map[string]string {
+	result := make(map[string]string, len(c.attributes))
+	for key, value := range c.attributes {
+		result[key] = value
+	}
+	return result
+}
+
+// WithAttribute returns a copy of the symbol with the attribute set. An empty key sets the period.
+func (c *CandleSymbol) WithAttribute(key string, value string) (*CandleSymbol, error) {
+	if strings.ContainsAny(key, "{},= ") {
+		return nil, fmt.Errorf("invalid candle attribute key %q", key)
+	}
+	if value == "" || strings.ContainsAny(value, "{},= ") {
+		return nil, fmt.Errorf("invalid value %q of candle attribute %q", value, key)
+	}
+	result := &CandleSymbol{base: c.base, attributes: c.Attributes()}
+	result.attributes[key] = value
+	return result, nil
+}
+
+// WithoutAttribute returns a copy of the symbol without the attribute.
+func (c *CandleSymbol) WithoutAttribute(key string) *CandleSymbol {
+	result := &CandleSymbol{base: c.base, attributes: c.Attributes()}
+	delete(result.attributes, key)
+	return result
+}
+
+// EventSymbol returns the symbol that can be used to subscribe to candles.
+func (c *CandleSymbol) EventSymbol() *candle.CandleSymbol {
+	return candle.NewCandleSymbol(c.String())
+}
+
+func (c *CandleSymbol) String() string {
+	if len(c.attributes) == 0 {
+		return c.base
+	}
+	keys := make([]string, 0, len(c.attributes))
+	for key := range c.attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteString(c.base)
+	sb.WriteByte(attributesOpen)
+	for i, key := range keys {
+		if i > 0 {
+			sb.WriteString(attributesSeparator)
+		}
+		sb.WriteString(key)
+		sb.WriteString(attributeValueSign)
+		sb.WriteString(c.attributes[key])
+	}
+	sb.WriteByte(attributesClose)
+	return sb.String()
+}
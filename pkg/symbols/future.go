@@ -0,0 +1,104 @@
+package symbols
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// monthCodes are the standard futures delivery month codes from January to December.
+const monthCodes = "FGHJKMNQUVXZ"
+
+var futurePattern = regexp.MustCompile(`^/([A-Z0-9]+)([FGHJKMNQUVXZ])(\d{2})(?::([A-Z0-9]+))?$`)
+
+// FutureSymbol is a structured representation of a future symbol like "/ESZ24" or "/ESZ24:XCME".
+type FutureSymbol struct {
+	root     string
+	year     int32
+	month    int32
+	exchange string
+}
+
+// NewFutureSymbol creates a future symbol. Year is a full year in [2000, 2099], month is in [1, 12],
+// exchange is an optional MIC code and may be empty.
+func NewFutureSymbol(root string, year int32, month int32, exchange string) (*FutureSymbol, error) {
+	if !isAlphanumeric(root) {
+		return nil, fmt.Errorf("invalid future root %q", root)
+	}
+	if year < 2000 || year > 2099 {
+		return nil, fmt.Errorf("future year %d is out of range [2000, 2099]", year)
+	}
+	if month < 1 || month > 12 {
+		return nil, fmt.Errorf("future month %d is out of range [1, 12]", month)
+	}
+	if exchange != "" && !isAlphanumeric(exchange) {
+		return nil, fmt.Errorf("invalid future exchange %q", exchange)
+	}
+	return &FutureSymbol{root: root, year: year, month: month, exchange: exchange}, nil
+}
+
+// ParseFuture parses a future symbol "/<root><month code><yy>[:<exchange>]".
+func ParseFuture(symbol string) (*FutureSymbol, error) {
+	match := futurePattern.FindStringSubmatch(symbol)
+	if match == nil {
+		return nil, fmt.Errorf("invalid future symbol %q", symbol)
+	}
+	year, _ := strconv.ParseInt(match[3], 10, 32)
+	month, _ := MonthByCode(match[2][0])
+	return NewFutureSymbol(match[1], 2000+int32(year), month, match[4])
+}
+
+// MonthCode returns the futures month code for the month in [1, 12].
+func MonthCode(month int32) (byte, error) {
+	if month < 1 || month > 12 {
+		return 0, fmt.Errorf("month %d is out of range [1, 12]", month)
+	}
+	return monthCodes[month-1], nil
+}
+
+// MonthByCode returns the month in [1, 12] for the futures month code.
+func MonthByCode(code byte) (int32, error) {
+	index := strings.IndexByte(monthCodes, code)
+	if index < 0 {
+		return 0, fmt.Errorf("invalid month code %q", code)
+	}
+	return int32(index) + 1, nil
+}
+
+func (f *FutureSymbol) Root() string {
+	return f.root
+}
+
+func (f *FutureSymbol) Year() int32 {
+	return f.year
+}
+
+func (f *FutureSymbol) Month() int32 {
+	return f.month
+}
+
+func (f *FutureSymbol) Exchange() string {
+	return f.exchange
+}
+
+func (f *FutureSymbol) String() string {
+	result := fmt.Sprintf("/%s%c%02d", f.root, monthCodes[f.month-1], f.year%100)
+	if f.exchange != "" {
+		result += ":" + f.exchange
+	}
+	return result
+}
+
+func isAlphanumeric(value string) bool {
+	if value == "" {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
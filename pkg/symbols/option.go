@@ -0,0 +1,154 @@
+package symbols
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type OptionType rune
+
+const (
+	Call OptionType = 'C'
+	Put  OptionType = 'P'
+)
+
+func (t OptionType) String() string {
+	switch t {
+	case Call:
+		return "Call"
+	case Put:
+		return "Put"
+	default:
+		return fmt.Sprintf("OptionType: Wrong value %d", t)
+	}
+}
+
+const (
+	osiLength      = 21
+	osiRootLength  = 6
+	osiStrikeScale = 1000
+	maxOsiStrike   = 99999999
+)
+
+var (
+	optionPattern = regexp.MustCompile(`^\.(.+)(\d{6})([CP])(\d+(?:\.\d+)?)$`)
+	osiPattern    = regexp.MustCompile(`^([A-Z0-9]{1,6}) *(\d{6})([CP])(\d{8})$`)
+)
+
+// OptionSymbol is a structured representation of an option symbol like ".AAPL240119C150".
+type OptionSymbol struct {
+	underlying string
+	expiration int32
+	optionType OptionType
+	strike     float64
+}
+
+// NewOptionSymbol creates an option symbol. Expiration is a date in yyyymmdd format.
+func NewOptionSymbol(underlying string, expiration int32, optionType OptionType, strike float64) (*OptionSymbol, error) {
+	if underlying == "" || strings.ContainsAny(underlying, " {}&=") {
+		return nil, fmt.Errorf("invalid underlying %q", underlying)
+	}
+	if err := checkYearMonthDay(expiration); err != nil {
+		return nil, err
+	}
+	if expiration/10000 < 2000 || expiration/10000 > 2099 {
+		return nil, fmt.Errorf("expiration year of %d is out of range [2000, 2099]", expiration)
+	}
+	if optionType != Call && optionType != Put {
+		return nil, fmt.Errorf("invalid option type %q", rune(optionType))
+	}
+	if math.IsNaN(strike) || math.IsInf(strike, 0) || strike <= 0 {
+		return nil, fmt.Errorf("invalid strike %v", strike)
+	}
+	return &OptionSymbol{underlying: underlying, expiration: expiration, optionType: optionType, strike: strike}, nil
+}
+
+// ParseOption parses a dxFeed option symbol ".<underlying><yymmdd><C|P><strike>".
+func ParseOption(symbol string) (*OptionSymbol, error) {
+	match := optionPattern.FindStringSubmatch(symbol)
+	if match == nil {
+		return nil, fmt.Errorf("invalid option symbol %q", symbol)
+	}
+	strike, err := strconv.ParseFloat(match[4], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid strike in option symbol %q", symbol)
+	}
+	option, err := NewOptionSymbol(match[1], parseShortDate(match[2]), OptionType(match[3][0]), strike)
+	if err != nil {
+		return nil, fmt.Errorf("invalid option symbol %q: %w", symbol, err)
+	}
+	return option, nil
+}
+
+// ParseOSI parses an OCC Options Symbology Initiative symbol like "AAPL  240119C00150000".
+// The root may be padded with spaces to 6 characters (the standard 21-character form) or not padded at all.
+func ParseOSI(symbol string) (*OptionSymbol, error) {
+	match := osiPattern.FindStringSubmatch(symbol)
+	if match == nil || strings.Contains(symbol, " ") && len(symbol) != osiLength {
+		return nil, fmt.Errorf("invalid OSI symbol %q", symbol)
+	}
+	strike, _ := strconv.ParseInt(match[4], 10, 64)
+	option, err := NewOptionSymbol(match[1], parseShortDate(match[2]), OptionType(match[3][0]), float64(strike)/osiStrikeScale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OSI symbol %q: %w", symbol, err)
+	}
+	return option, nil
+}
+
+func (o *OptionSymbol) Underlying() string {
+	return o.underlying
+}
+
+// Expiration returns the expiration date in yyyymmdd format.
+func (o *OptionSymbol) Expiration() int32 {
+	return o.expiration
+}
+
+func (o *OptionSymbol) OptionType() OptionType {
+	return o.optionType
+}
+
+func (o *OptionSymbol) Strike() float64 {
+	return o.strike
+}
+
+// String returns the dxFeed option symbol.
+func (o *OptionSymbol) String() string {
+	return "." + o.underlying + formatShortDate(o.expiration) + string(rune(o.optionType)) +
+		strconv.FormatFloat(o.strike, 'f', -1, 64)
+}
+
+// OSI returns the 21-character OCC option symbol. It fails if the underlying is longer than 6 characters
+// or the strike can't be represented with 3 decimal digits in 8 positions.
+func (o *OptionSymbol) OSI() (string, error) {
+	if len(o.underlying) > osiRootLength || !osiPattern.MatchString(o.underlying+"000101C00000000") {
+		return "", fmt.Errorf("underlying %q can't be used as OSI root", o.underlying)
+	}
+	scaled := math.Round(o.strike * osiStrikeScale)
+	if scaled > maxOsiStrike || math.Abs(scaled-o.strike*osiStrikeScale) > 1e-6 {
+		return "", fmt.Errorf("strike %v can't be represented in OSI format", o.strike)
+	}
+	return fmt.Sprintf("%-6s%s%c%08d", o.underlying, formatShortDate(o.expiration), rune(o.optionType), int64(scaled)), nil
+}
+
+func parseShortDate(yymmdd string) int32 {
+	value, _ := strconv.ParseInt(yymmdd, 10, 32)
+	return 20000000 + int32(value)
+}
+
+func formatShortDate(yyyymmdd int32) string {
+	return fmt.Sprintf("%06d", yyyymmdd%1000000)
+}
+
+func checkYearMonthDay(yyyymmdd int32) error {
+	year, month, day := int(yyyymmdd/10000), time.Month(yyyymmdd/100%100), int(yyyymmdd%100)
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if yyyymmdd <= 0 || date.Year() != year || date.Month() != month || date.Day() != day {
+		return fmt.Errorf("invalid date %d", yyyymmdd)
+	}
+	return nil
+}
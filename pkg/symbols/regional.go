@@ -0,0 +1,57 @@
+package symbols
+
+import (
+	"fmt"
+	"strings"
+)
+
+const exchangeSeparator = '&'
+
+// RegionalSymbol is a symbol bound to a particular exchange like "AAPL&Q".
+type RegionalSymbol struct {
+	base     string
+	exchange rune
+}
+
+// NewRegionalSymbol creates a regional symbol. Exchange must be an ASCII letter or digit.
+func NewRegionalSymbol(base string, exchange rune) (*RegionalSymbol, error) {
+	if base == "" || strings.ContainsAny(base, "&{}") {
+		return nil, fmt.Errorf("invalid base symbol %q", base)
+	}
+	if err := Validate(base); err != nil {
+		return nil, fmt.Errorf("invalid base symbol: %w", err)
+	}
+	if !isExchangeCode(exchange) {
+		return nil, fmt.Errorf("invalid exchange code %q", exchange)
+	}
+	return &RegionalSymbol{base: base, exchange: exchange}, nil
+}
+
+// ParseRegional parses a regional symbol "<base>&<exchange>".
+func ParseRegional(symbol string) (*RegionalSymbol, error) {
+	index := strings.LastIndexByte(symbol, exchangeSeparator)
+	if index < 0 || index != len(symbol)-2 {
+		return nil, fmt.Errorf("invalid regional symbol %q", symbol)
+	}
+	regional, err := NewRegionalSymbol(symbol[:index], rune(symbol[index+1]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid regional symbol %q: %w", symbol, err)
+	}
+	return regional, nil
+}
+
+func (r *RegionalSymbol) Base() string {
+	return r.base
+}
+
+func (r *RegionalSymbol) Exchange() rune {
+	return r.exchange
+}
+
+func (r *RegionalSymbol) String() string {
+	return r.base + string(exchangeSeparator) + string(r.exchange)
+}
+
+func isExchangeCode(exchange rune) bool {
+	return exchange >= 'A' && exchange <= 'Z' || exchange >= 'a' && exchange <= 'z' || exchange >= '0' && exchange <= '9'
+}
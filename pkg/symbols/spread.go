@@ -0,0 +1,115 @@
+package symbols
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const spreadPrefix = "="
+
+// SpreadLeg is a single leg of a spread with a signed ratio, e.g. -2 for "-2*/ESH25".
+type SpreadLeg struct {
+	Ratio  float64
+	Symbol string
+}
+
+// SpreadSymbol is a structured representation of a spread symbol like "=/ESZ24-/ESH25" or "=2*AAPL-MSFT".
+type SpreadSymbol struct {
+	legs []SpreadLeg
+}
+
+// NewSpreadSymbol creates a spread symbol. Each leg must have a non-zero finite ratio and a valid non-spread symbol.
+func NewSpreadSymbol(legs ...SpreadLeg) (*SpreadSymbol, error) {
+	if len(legs) == 0 {
+		return nil, fmt.Errorf("spread must have at least one leg")
+	}
+	for _, leg := range legs {
+		if leg.Ratio == 0 || math.IsNaN(leg.Ratio) || math.IsInf(leg.Ratio, 0) {
+			return nil, fmt.Errorf("invalid ratio %v of spread leg %q", leg.Ratio, leg.Symbol)
+		}
+		if strings.HasPrefix(leg.Symbol, spreadPrefix) || strings.ContainsAny(leg.Symbol, "+-*") {
+			return nil, fmt.Errorf("invalid spread leg %q", leg.Symbol)
+		}
+		if err := Validate(leg.Symbol); err != nil {
+			return nil, fmt.Errorf("invalid spread leg: %w", err)
+		}
+	}
+	return &SpreadSymbol{legs: append([]SpreadLeg(nil), legs...)}, nil
+}
+
+// ParseSpread parses a spread symbol "=[<ratio>*]<symbol>{(+|-)[<ratio>*]<symbol>}".
+func ParseSpread(symbol string) (*SpreadSymbol, error) {
+	if !strings.HasPrefix(symbol, spreadPrefix) {
+		return nil, fmt.Errorf("invalid spread symbol %q", symbol)
+	}
+	var legs []SpreadLeg
+	body := symbol[len(spreadPrefix):]
+	start := 0
+	for i := 0; i <= len(body); i++ {
+		if i < len(body) && (i == start || body[i] != '+' && body[i] != '-') {
+			continue
+		}
+		leg, err := parseSpreadLeg(body[start:i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid spread symbol %q: %w", symbol, err)
+		}
+		legs = append(legs, leg)
+		start = i
+	}
+	spread, err := NewSpreadSymbol(legs...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spread symbol %q: %w", symbol, err)
+	}
+	return spread, nil
+}
+
+func parseSpreadLeg(value string) (SpreadLeg, error) {
+	leg := SpreadLeg{Ratio: 1, Symbol: value}
+	sign := 1.0
+	if strings.HasPrefix(leg.Symbol, "+") {
+		leg.Symbol = leg.Symbol[1:]
+	} else if strings.HasPrefix(leg.Symbol, "-") {
+		leg.Symbol = leg.Symbol[1:]
+		sign = -1
+	}
+	if index := strings.IndexByte(leg.Symbol, '*'); index >= 0 {
+		ratio, err := strconv.ParseFloat(leg.Symbol[:index], 64)
+		if err != nil || ratio <= 0 {
+			return leg, fmt.Errorf("invalid ratio in spread leg %q", value)
+		}
+		leg.Ratio = ratio
+		leg.Symbol = leg.Symbol[index+1:]
+	}
+	if leg.Symbol == "" {
+		return leg, fmt.Errorf("empty spread leg %q", value)
+	}
+	leg.Ratio *= sign
+	return leg, nil
+}
+
+// Legs returns a copy of the spread legs.
+func (s *SpreadSymbol) Legs() []SpreadLeg {
+	return append([]SpreadLeg(nil), s.legs...)
+}
+
+func (s *SpreadSymbol) String() string {
+	var sb strings.Builder
+	sb.WriteString(spreadPrefix)
+	for i, leg := range s.legs {
+		ratio := leg.Ratio
+		if ratio < 0 {
+			sb.WriteByte('-')
+			ratio = -ratio
+		} else if i > 0 {
+			sb.WriteByte('+')
+		}
+		if ratio != 1 {
+			sb.WriteString(strconv.FormatFloat(ratio, 'f', -1, 64))
+			sb.WriteByte('*')
+		}
+		sb.WriteString(leg.Symbol)
+	}
+	return sb.String()
+}
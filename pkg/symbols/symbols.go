@@ -0,0 +1,101 @@
+// Package symbols provides parsers and formatters for dxFeed symbols:
+//
+//	.AAPL240119C150        option, see ParseOption (and ParseOSI for OCC symbols like "AAPL  240119C00150000")
+//	/ESZ24                 future with a month code and a two-digit year, see ParseFuture
+//	=/ESZ24-/ESH25         spread of several legs with optional ratios like "2*", see ParseSpread
+//	AAPL&Q                 regional symbol of a particular exchange, see ParseRegional
+//	AAPL&Q{=5m,price=mark} candle symbol with attributes, see ParseCandle
+//
+// Validate checks any of these forms and can be used before adding symbols to a subscription.
+package symbols
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type Kind int32
+
+const (
+	KindPlain Kind = iota
+	KindOption
+	KindFuture
+	KindSpread
+	KindRegional
+	KindCandle
+	KindWildcard
+)
+
+const wildcard = "*"
+
+func (k Kind) String() string {
+	switch k {
+	case KindPlain:
+		return "Plain"
+	case KindOption:
+		return "Option"
+	case KindFuture:
+		return "Future"
+	case KindSpread:
+		return "Spread"
+	case KindRegional:
+		return "Regional"
+	case KindCandle:
+		return "Candle"
+	case KindWildcard:
+		return "Wildcard"
+	default:
+		return fmt.Sprintf("Kind: Wrong value %d", k)
+	}
+}
+
+// KindOf determines the kind of the symbol by its syntax. It doesn't validate the symbol.
+func KindOf(symbol string) Kind {
+	switch {
+	case symbol == wildcard:
+		return KindWildcard
+	case strings.ContainsRune(symbol, attributesOpen):
+		return KindCandle
+	case strings.HasPrefix(symbol, spreadPrefix):
+		return KindSpread
+	case strings.ContainsRune(symbol, exchangeSeparator):
+		return KindRegional
+	case strings.HasPrefix(symbol, "."):
+		return KindOption
+	case strings.HasPrefix(symbol, "/"):
+		return KindFuture
+	default:
+		return KindPlain
+	}
+}
+
+// Validate checks that the symbol is well-formed according to its kind.
+func Validate(symbol string) error {
+	if symbol == "" {
+		return fmt.Errorf("symbol is empty")
+	}
+	for _, r := range symbol {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || r > unicode.MaxASCII {
+			return fmt.Errorf("symbol %q contains invalid character %q", symbol, r)
+		}
+	}
+	var err error
+	switch KindOf(symbol) {
+	case KindCandle:
+		_, err = ParseCandle(symbol)
+	case KindSpread:
+		_, err = ParseSpread(symbol)
+	case KindOption:
+		_, err = ParseOption(symbol)
+	case KindFuture:
+		_, err = ParseFuture(symbol)
+	case KindRegional:
+		_, err = ParseRegional(symbol)
+	case KindPlain:
+		if strings.ContainsAny(symbol, "{}=*") {
+			err = fmt.Errorf("symbol %q contains reserved characters", symbol)
+		}
+	}
+	return err
+}
@@ -0,0 +1,197 @@
+package symbols
+
+import (
+	"testing"
+)
+
+func TestParseOption(t *testing.T) {
+	tests := []struct {
+		symbol     string
+		underlying string
+		expiration int32
+		optionType OptionType
+		strike     float64
+	}{
+		{".AAPL240119C150", "AAPL", 20240119, Call, 150},
+		{".SPXW241231P4750.5", "SPXW", 20241231, Put, 4750.5},
+		{"./ESZ24240119C4000", "/ESZ24", 20240119, Call, 4000},
+		{".BRK.B240229P0.25", "BRK.B", 20240229, Put, 0.25},
+	}
+	for _, test := range tests {
+		option, err := ParseOption(test.symbol)
+		if err != nil {
+			t.Fatalf(`ParseOption(%q) failed: %v`, test.symbol, err)
+		}
+		if option.Underlying() != test.underlying || option.Expiration() != test.expiration ||
+			option.OptionType() != test.optionType || option.Strike() != test.strike {
+			t.Errorf(`ParseOption(%q) returned unexpected %v %v %v %v`, test.symbol,
+				option.Underlying(), option.Expiration(), option.OptionType(), option.Strike())
+		}
+		if option.String() != test.symbol {
+			t.Errorf(`String() should be %s. But it equals %s`, test.symbol, option.String())
+		}
+	}
+	for _, symbol := range []string{".AAPL", ".AAPL240119X150", ".AAPL241319C150", ".AAPL240230C150", ".240119C150", ".AAPL240119C0", "AAPL240119C150"} {
+		if _, err := ParseOption(symbol); err == nil {
+			t.Errorf(`ParseOption(%q) should fail`, symbol)
+		}
+	}
+}
+
+func TestOSI(t *testing.T) {
+	option, err := ParseOSI("AAPL  240119C00150000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if option.String() != ".AAPL240119C150" {
+		t.Fatalf(`OSI symbol should be converted to .AAPL240119C150. But it equals %s`, option)
+	}
+	if compact, err := ParseOSI("SPXW241231P04750500"); err != nil || compact.String() != ".SPXW241231P4750.5" {
+		t.Fatalf(`Compact OSI symbol should be parsed. But it is %v, %v`, compact, err)
+	}
+	option, _ = NewOptionSymbol("SPXW", 20241231, Put, 4750.5)
+	if osi, err := option.OSI(); err != nil || osi != "SPXW  241231P04750500" {
+		t.Fatalf(`OSI() should be "SPXW  241231P04750500". But it is %q, %v`, osi, err)
+	}
+	for _, invalid := range []*OptionSymbol{
+		{underlying: "LONGROOT", expiration: 20240119, optionType: Call, strike: 1},
+		{underlying: "AAPL", expiration: 20240119, optionType: Call, strike: 0.0001},
+		{underlying: "AAPL", expiration: 20240119, optionType: Call, strike: 100000},
+	} {
+		if _, err := invalid.OSI(); err == nil {
+			t.Errorf(`OSI() of %s should fail`, invalid)
+		}
+	}
+	for _, symbol := range []string{"AAPL 240119C00150000", "AAPL  240119C0015000", "aapl  240119C00150000"} {
+		if _, err := ParseOSI(symbol); err == nil {
+			t.Errorf(`ParseOSI(%q) should fail`, symbol)
+		}
+	}
+}
+
+func TestFuture(t *testing.T) {
+	future, err := ParseFuture("/ZNZ24:XCBT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if future.Root() != "ZN" || future.Year() != 2024 || future.Month() != 12 || future.Exchange() != "XCBT" {
+		t.Fatalf(`Unexpected future %s %d %d %s`, future.Root(), future.Year(), future.Month(), future.Exchange())
+	}
+	if future.String() != "/ZNZ24:XCBT" {
+		t.Fatalf(`String() should be /ZNZ24:XCBT. But it equals %s`, future)
+	}
+	future, _ = NewFutureSymbol("ES", 2025, 3, "")
+	if future.String() != "/ESH25" {
+		t.Fatalf(`String() should be /ESH25. But it equals %s`, future)
+	}
+	for month := int32(1); month <= 12; month++ {
+		code, _ := MonthCode(month)
+		if m, err := MonthByCode(code); err != nil || m != month {
+			t.Fatalf(`MonthByCode(MonthCode(%d)) should be %d. But it equals %d`, month, month, m)
+		}
+	}
+	for _, symbol := range []string{"/ES", "/ESA24", "/ESZ4", "/ESZ24:", "ESZ24", "/esz24"} {
+		if _, err := ParseFuture(symbol); err == nil {
+			t.Errorf(`ParseFuture(%q) should fail`, symbol)
+		}
+	}
+}
+
+func TestSpread(t *testing.T) {
+	tests := []struct {
+		symbol string
+		legs   []SpreadLeg
+	}{
+		{"=ESZ24-ESH25", []SpreadLeg{{1, "ESZ24"}, {-1, "ESH25"}}},
+		{"=/ESZ24-/ESH25", []SpreadLeg{{1, "/ESZ24"}, {-1, "/ESH25"}}},
+		{"=2*AAPL-0.5*MSFT+IBM&N", []SpreadLeg{{2, "AAPL"}, {-0.5, "MSFT"}, {1, "IBM&N"}}},
+		{"=-AAPL+.AAPL240119C150", []SpreadLeg{{-1, "AAPL"}, {1, ".AAPL240119C150"}}},
+	}
+	for _, test := range tests {
+		spread, err := ParseSpread(test.symbol)
+		if err != nil {
+			t.Fatalf(`ParseSpread(%q) failed: %v`, test.symbol, err)
+		}
+		legs := spread.Legs()
+		if len(legs) != len(test.legs) {
+			t.Fatalf(`ParseSpread(%q) should have %d legs. But it has %d`, test.symbol, len(test.legs), len(legs))
+		}
+		for i := range legs {
+			if legs[i] != test.legs[i] {
+				t.Errorf(`Leg %d of %q should be %v. But it is %v`, i, test.symbol, test.legs[i], legs[i])
+			}
+		}
+		if spread.String() != test.symbol {
+			t.Errorf(`String() should be %s. But it equals %s`, test.symbol, spread)
+		}
+	}
+	for _, symbol := range []string{"=", "=AAPL-", "=0*AAPL", "=x*AAPL", "=AAPL--MSFT", "==AAPL", "=/ESZ4-AAPL"} {
+		if _, err := ParseSpread(symbol); err == nil {
+			t.Errorf(`ParseSpread(%q) should fail`, symbol)
+		}
+	}
+}
+
+func TestRegional(t *testing.T) {
+	regional, err := ParseRegional("AAPL&Q")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if regional.Base() != "AAPL" || regional.Exchange() != 'Q' || regional.String() != "AAPL&Q" {
+		t.Fatalf(`Unexpected regional symbol %s`, regional)
+	}
+	for _, symbol := range []string{"AAPL&", "&Q", "AAPL&QQ", "AAPL&#", "AAPL&Q&N"} {
+		if _, err := ParseRegional(symbol); err == nil {
+			t.Errorf(`ParseRegional(%q) should fail`, symbol)
+		}
+	}
+}
+
+func TestCandle(t *testing.T) {
+	c, err := ParseCandle("AAPL&Q{price=mark,=5m}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Base() != "AAPL&Q" || c.Exchange() != 'Q' || c.Period() != "5m" {
+		t.Fatalf(`Unexpected candle symbol %s`, c)
+	}
+	if value, ok := c.Attribute("price"); !ok || value != "mark" {
+		t.Fatalf(`Attribute("price") should be mark. But it is %q`, value)
+	}
+	if c.String() != "AAPL&Q{=5m,price=mark}" {
+		t.Fatalf(`Attributes should be sorted. But it is %s`, c)
+	}
+	changed, err := c.WithAttribute("", "1d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Period() != "5m" || changed.WithoutAttribute("price").String() != "AAPL&Q{=1d}" {
+		t.Fatalf(`WithAttribute should return a modified copy. But it is %s`, changed)
+	}
+	if plain, err := ParseCandle("AAPL"); err != nil || plain.Exchange() != 0 || *plain.EventSymbol().Symbol() != "AAPL" {
+		t.Fatalf(`Candle symbol without attributes should be parsed. But it is %v, %v`, plain, err)
+	}
+	for _, symbol := range []string{"AAPL{", "AAPL{=5m", "{=5m}", "AAPL{price}", "AAPL{=5m,=1d}", "AAPL{=}", "AAPL{=5m}{a=b}"} {
+		if _, err := ParseCandle(symbol); err == nil {
+			t.Errorf(`ParseCandle(%q) should fail`, symbol)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []string{"AAPL", "*", "$SPX", "BRK.B", ".AAPL240119C150", "/ESZ24", "=ESZ24-ESH25", "AAPL&Q", "/ESZ24&Q", "AAPL{=d}"}
+	for _, symbol := range valid {
+		if err := Validate(symbol); err != nil {
+			t.Errorf(`Validate(%q) failed: %v`, symbol, err)
+		}
+	}
+	invalid := []string{"", "AA PL", "AAPL\n", "AAPL=", "AA*PL", ".AAPL", "/ES", "=", "AAPL&", "AAPL{", "ÄAPL"}
+	for _, symbol := range invalid {
+		if err := Validate(symbol); err == nil {
+			t.Errorf(`Validate(%q) should fail`, symbol)
+		}
+	}
+	if KindOf("/ESZ24&Q") != KindRegional || KindOf("=A-B") != KindSpread || KindOf("A{=d}") != KindCandle {
+		t.Fatalf(`KindOf returned unexpected kind`)
+	}
+}
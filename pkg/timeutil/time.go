@@ -46,3 +46,25 @@ func GetYearMonthDayByDayId(dayId int32) int32 {
 		return -yyyymmdd
 	}
 }
+
+func GetDayIdByYearMonthDay(yyyymmdd int32) int32 {
+	abs := mathutil.Abs(yyyymmdd)
+	year := abs / 10000
+	if yyyymmdd < 0 {
+		year = -year
+	}
+	return GetDayIdByDate(year, abs/100%100, abs%100)
+}
+
+func GetDayIdByDate(year int32, month int32, day int32) int32 {
+	// this shifts the epoch so that March 1 is the first day of the year
+	if month <= 2 {
+		year--
+		month += 12
+	}
+	era := mathutil.Div(year, 400)
+	yoe := year - era*400
+	doy := (153*(month-3)+2)/5 + day - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
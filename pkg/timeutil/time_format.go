@@ -0,0 +1,368 @@
+package timeutil
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeFormat parses and formats times in the formats used across dxFeed API.
+//
+// Parse accepts the following forms:
+//
+//	0                                  the zero time
+//	1700000000000                      milliseconds since epoch (any number of digits except 8 and 14)
+//	20240102, 20240102150405           yyyyMMdd and yyyyMMddHHmmss
+//	20240102-150405[.123][zone]        dxFeed form, the fraction may contain up to 9 digits
+//	2024-01-02[T15:04[:05[.123]]][zone] full and partial ISO forms, ' ' and '-' may be used instead of 'T'
+//	15:04[:05[.123]][zone]             time of the current day
+//	-1d, -2h30m, -PT1.5S, +1h, P1DT2H  time relative to now, P... without a sign means in the past
+//
+// The zone is 'Z', GMT or UTC optionally followed by an offset, an offset like +03, +0300 or +03:00,
+// or a time zone name like America/New_York separated by a space. When there is no zone,
+// the time zone of the format is used.
+type TimeFormat struct {
+	location *time.Location
+	fraction int
+	fullIso  bool
+	now      func() time.Time
+}
+
+const (
+	noFraction     = 0
+	millisFraction = 3
+	nanosFraction  = 9
+)
+
+var (
+	// DefaultTimeFormat uses the local time zone.
+	DefaultTimeFormat = NewTimeFormat(time.Local)
+	GMTTimeFormat     = NewTimeFormat(time.UTC)
+)
+
+func NewTimeFormat(location *time.Location) *TimeFormat {
+	if location == nil {
+		location = time.Local
+	}
+	return &TimeFormat{location: location, now: time.Now}
+}
+
+func (f *TimeFormat) TimeZone() *time.Location {
+	return f.location
+}
+
+func (f *TimeFormat) WithTimeZone(location *time.Location) *TimeFormat {
+	result := *f
+	if location == nil {
+		location = time.Local
+	}
+	result.location = location
+	return &result
+}
+
+// WithMillis returns a format that always prints milliseconds.
+func (f *TimeFormat) WithMillis() *TimeFormat {
+	result := *f
+	result.fraction = millisFraction
+	return &result
+}
+
+// WithNanos returns a format that always prints nanoseconds.
+func (f *TimeFormat) WithNanos() *TimeFormat {
+	result := *f
+	result.fraction = nanosFraction
+	return &result
+}
+
+// AsFullIso returns a format that prints times like 2024-01-02T15:04:05Z.
+func (f *TimeFormat) AsFullIso() *TimeFormat {
+	result := *f
+	result.fullIso = true
+	return &result
+}
+
+// Parse parses the time and returns it in milliseconds since epoch.
+func (f *TimeFormat) Parse(value string) (int64, error) {
+	nanos, err := f.ParseNanos(value)
+	if err != nil {
+		return 0, err
+	}
+	return GetMillisFromNanos(nanos), nil
+}
+
+// ParseNanos parses the time and returns it in nanoseconds since epoch.
+func (f *TimeFormat) ParseNanos(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	nanos, err := f.parse(value)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse time %q: %w", value, err)
+	}
+	return nanos, nil
+}
+
+// Format formats the time in milliseconds. The zero time is formatted as "0".
+func (f *TimeFormat) Format(timeMillis int64) string {
+	if timeMillis == 0 {
+		return "0"
+	}
+	return f.format(time.UnixMilli(timeMillis))
+}
+
+// FormatNanos formats the time in nanoseconds. The zero time is formatted as "0".
+func (f *TimeFormat) FormatNanos(timeNanos int64) string {
+	if timeNanos == 0 {
+		return "0"
+	}
+	return f.format(time.Unix(0, timeNanos))
+}
+
+func (f *TimeFormat) format(t time.Time) string {
+	layout := "20060102-150405"
+	zone := "-07:00"
+	if f.fullIso {
+		layout = "2006-01-02T15:04:05"
+		zone = "Z07:00"
+	}
+	if f.fraction > noFraction {
+		layout += "." + strings.Repeat("0", f.fraction)
+	}
+	return t.In(f.location).Format(layout + zone)
+}
+
+func (f *TimeFormat) parse(value string) (int64, error) {
+	if value == "" {
+		return 0, fmt.Errorf("empty value")
+	}
+	if value == "0" {
+		return 0, nil
+	}
+	if isDigits(value) && len(value) != 8 && len(value) != 14 {
+		return parseMillis(value)
+	}
+	if value[0] == '-' && isDigits(value[1:]) {
+		return parseMillis(value)
+	}
+	if value[0] == '-' || value[0] == '+' || value[0] == 'P' || value[0] == 'p' {
+		return f.parseRelative(value)
+	}
+	return f.parseDateTime(value)
+}
+
+func parseMillis(value string) (int64, error) {
+	millis, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || millis > math.MaxInt64/NanosInMillis || millis < math.MinInt64/NanosInMillis {
+		return 0, fmt.Errorf("milliseconds out of range")
+	}
+	return millis * NanosInMillis, nil
+}
+
+func (f *TimeFormat) parseRelative(value string) (int64, error) {
+	sign := int64(-1)
+	switch value[0] {
+	case '+':
+		sign = 1
+		value = value[1:]
+	case '-':
+		value = value[1:]
+	}
+	period, err := ParseTimePeriod(value)
+	if err != nil {
+		return 0, err
+	}
+	if period.IsUnlimited() {
+		return 0, fmt.Errorf("relative time can't be unlimited")
+	}
+	return f.now().UnixNano() + sign*period.Nanos(), nil
+}
+
+// dateTimeScanner splits the date-time value into fixed-width numeric fields.
+type dateTimeScanner struct {
+	value string
+	pos   int
+}
+
+func (s *dateTimeScanner) peek() byte {
+	return s.peekAt(0)
+}
+
+func (s *dateTimeScanner) peekAt(offset int) byte {
+	if s.pos+offset < len(s.value) {
+		return s.value[s.pos+offset]
+	}
+	return 0
+}
+
+func (s *dateTimeScanner) skip(c byte) bool {
+	if s.peek() == c {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+func (s *dateTimeScanner) digits() int {
+	n := 0
+	for isDigit(s.peekAt(n)) {
+		n++
+	}
+	return n
+}
+
+func (s *dateTimeScanner) number(width int) int {
+	result, _ := strconv.Atoi(s.value[s.pos : s.pos+width])
+	s.pos += width
+	return result
+}
+
+func (f *TimeFormat) parseDateTime(value string) (int64, error) {
+	s := &dateTimeScanner{value: value}
+	hasDate, hasTime := true, false
+	var year, month, day, hour, minute, second, nanos int
+	switch n := s.digits(); {
+	case n == 8 || n == 14:
+		year, month, day = s.number(4), s.number(2), s.number(2)
+		if n == 14 {
+			hour, minute, second = s.number(2), s.number(2), s.number(2)
+			hasTime = true
+		}
+	case n == 4 && s.peekAt(4) == '-':
+		year = s.number(4)
+		s.skip('-')
+		if s.digits() != 2 {
+			return 0, fmt.Errorf("invalid month")
+		}
+		month = s.number(2)
+		if !s.skip('-') || s.digits() != 2 {
+			return 0, fmt.Errorf("invalid day")
+		}
+		day = s.number(2)
+	case n == 2 && s.peekAt(2) == ':':
+		hasDate = false
+	default:
+		return 0, fmt.Errorf("unknown format")
+	}
+
+	if !hasDate || !hasTime && isTimeSeparator(s.peek()) && isDigit(s.peekAt(1)) {
+		if hasDate {
+			s.pos++
+		}
+		switch n := s.digits(); {
+		case n == 6 || n == 4:
+			hour, minute = s.number(2), s.number(2)
+			if n == 6 {
+				second = s.number(2)
+			}
+		case n == 2:
+			hour = s.number(2)
+			if !s.skip(':') || s.digits() != 2 {
+				return 0, fmt.Errorf("invalid minutes")
+			}
+			minute = s.number(2)
+			if s.skip(':') {
+				if s.digits() != 2 {
+					return 0, fmt.Errorf("invalid seconds")
+				}
+				second = s.number(2)
+			}
+		default:
+			return 0, fmt.Errorf("invalid time")
+		}
+		hasTime = true
+	}
+	if hasTime && (s.skip('.') || s.skip(',')) {
+		n := s.digits()
+		if n == 0 || n > 9 {
+			return 0, fmt.Errorf("invalid fraction of second")
+		}
+		nanos = s.number(n)
+		for i := n; i < 9; i++ {
+			nanos *= 10
+		}
+	}
+
+	location, err := f.parseZone(value[s.pos:])
+	if err != nil {
+		return 0, err
+	}
+	if !hasDate {
+		today := f.now().In(location)
+		year, month, day = today.Year(), int(today.Month()), today.Day()
+	}
+	if month < 1 || month > 12 || day < 1 || hour > 23 || minute > 59 || second > 59 {
+		return 0, fmt.Errorf("field out of range")
+	}
+	t := time.Date(year, time.Month(month), day, hour, minute, second, nanos, location)
+	if t.Day() != day {
+		return 0, fmt.Errorf("invalid day of month")
+	}
+	return t.UnixNano(), nil
+}
+
+func (f *TimeFormat) parseZone(zone string) (*time.Location, error) {
+	switch {
+	case zone == "":
+		return f.location, nil
+	case zone == "Z":
+		return time.UTC, nil
+	case strings.HasPrefix(zone, "GMT") || strings.HasPrefix(zone, "UTC"):
+		if zone[3:] == "" {
+			return time.UTC, nil
+		}
+		return parseOffset(zone, zone[3:])
+	case zone[0] == '+' || zone[0] == '-':
+		return parseOffset(zone, zone)
+	case zone[0] == ' ':
+		location, err := time.LoadLocation(strings.TrimSpace(zone))
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", strings.TrimSpace(zone))
+		}
+		return location, nil
+	default:
+		return nil, fmt.Errorf("invalid time zone %q", zone)
+	}
+}
+
+func parseOffset(zone string, offset string) (*time.Location, error) {
+	if len(offset) < 3 || offset[0] != '+' && offset[0] != '-' {
+		return nil, fmt.Errorf("invalid time zone %q", zone)
+	}
+	digits := strings.Replace(offset[1:], ":", "", 1)
+	if !isDigits(digits) || len(digits) != 2 && len(digits) != 4 || len(offset) == 6 && offset[3] != ':' {
+		return nil, fmt.Errorf("invalid time zone %q", zone)
+	}
+	hours, _ := strconv.Atoi(digits[:2])
+	minutes := 0
+	if len(digits) == 4 {
+		minutes, _ = strconv.Atoi(digits[2:])
+	}
+	if hours > 18 || minutes > 59 {
+		return nil, fmt.Errorf("invalid time zone %q", zone)
+	}
+	seconds := hours*3600 + minutes*60
+	if offset[0] == '-' {
+		seconds = -seconds
+	}
+	return time.FixedZone(zone, seconds), nil
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if !isDigit(value[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isTimeSeparator(c byte) bool {
+	return c == '-' || c == 'T' || c == ' '
+}
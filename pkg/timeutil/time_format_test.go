@@ -0,0 +1,217 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+var (
+	berlin, _ = time.LoadLocation("Europe/Berlin")
+	now       = time.Date(2024, 3, 15, 12, 30, 0, 0, time.UTC)
+)
+
+func testFormat(location *time.Location) *TimeFormat {
+	f := NewTimeFormat(location)
+	f.now = func() time.Time {
+		return now
+	}
+	return f
+}
+
+func nanosOf(year int, month time.Month, day, hour, minute, second, nanos int, location *time.Location) int64 {
+	return time.Date(year, month, day, hour, minute, second, nanos, location).UnixNano()
+}
+
+func TestParse(t *testing.T) {
+	gmt := testFormat(time.UTC)
+	tests := []struct {
+		value    string
+		expected int64
+	}{
+		{"0", 0},
+		{"1700000000000", 1700000000000 * NanosInMillis},
+		{"-1000", -1000 * NanosInMillis},
+		{"123", 123 * NanosInMillis},
+		{"20240102", nanosOf(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"20240102150405", nanosOf(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"20240102-150405", nanosOf(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"20240102-1504", nanosOf(2024, 1, 2, 15, 4, 0, 0, time.UTC)},
+		{"20240102-150405.123", nanosOf(2024, 1, 2, 15, 4, 5, 123_000_000, time.UTC)},
+		{"20240102-150405.5", nanosOf(2024, 1, 2, 15, 4, 5, 500_000_000, time.UTC)},
+		{"20240102-150405.123456789", nanosOf(2024, 1, 2, 15, 4, 5, 123456789, time.UTC)},
+		{"20240102150405.001", nanosOf(2024, 1, 2, 15, 4, 5, 1_000_000, time.UTC)},
+		{"20240102-150405+0300", nanosOf(2024, 1, 2, 12, 4, 5, 0, time.UTC)},
+		{"20240102-150405-03:00", nanosOf(2024, 1, 2, 18, 4, 5, 0, time.UTC)},
+		{"20240102-150405.000-07:00", nanosOf(2024, 1, 2, 22, 4, 5, 0, time.UTC)},
+		{"20240102-150405+03", nanosOf(2024, 1, 2, 12, 4, 5, 0, time.UTC)},
+		{"20240102-150405Z", nanosOf(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"20240102-150405GMT", nanosOf(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"20240102-150405GMT+01:00", nanosOf(2024, 1, 2, 14, 4, 5, 0, time.UTC)},
+		{"20240102-150405UTC-0100", nanosOf(2024, 1, 2, 16, 4, 5, 0, time.UTC)},
+		{"20240102-150405 Europe/Berlin", nanosOf(2024, 1, 2, 15, 4, 5, 0, berlin)},
+		{"20240102+0100", nanosOf(2024, 1, 1, 23, 0, 0, 0, time.UTC)},
+		{"2024-01-02", nanosOf(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-02Z", nanosOf(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-02T15:04", nanosOf(2024, 1, 2, 15, 4, 0, 0, time.UTC)},
+		{"2024-01-02T15:04:05", nanosOf(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2024-01-02T15:04:05.123Z", nanosOf(2024, 1, 2, 15, 4, 5, 123_000_000, time.UTC)},
+		{"2024-01-02T15:04:05,123+01:00", nanosOf(2024, 1, 2, 14, 4, 5, 123_000_000, time.UTC)},
+		{"2024-01-02 15:04:05", nanosOf(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2024-01-02-15:04:05", nanosOf(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2024-02-29T00:00:00Z", nanosOf(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"15:04", nanosOf(2024, 3, 15, 15, 4, 0, 0, time.UTC)},
+		{"15:04:05.250", nanosOf(2024, 3, 15, 15, 4, 5, 250_000_000, time.UTC)},
+		{"15:04:05+01:00", nanosOf(2024, 3, 15, 14, 4, 5, 0, time.UTC)},
+		{"-1d", now.Add(-24 * time.Hour).UnixNano()},
+		{"-2h30m", now.Add(-150 * time.Minute).UnixNano()},
+		{"-100ms", now.Add(-100 * time.Millisecond).UnixNano()},
+		{"-1.5s", now.Add(-1500 * time.Millisecond).UnixNano()},
+		{"+1h", now.Add(time.Hour).UnixNano()},
+		{"+30", now.Add(30 * time.Second).UnixNano()},
+		{"-PT1.5S", now.Add(-1500 * time.Millisecond).UnixNano()},
+		{"P1DT2H", now.Add(-26 * time.Hour).UnixNano()},
+		{"-p1w", now.Add(-7 * 24 * time.Hour).UnixNano()},
+		{" 20240102 ", nanosOf(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		nanos, err := gmt.ParseNanos(test.value)
+		if err != nil {
+			t.Errorf(`ParseNanos(%q) failed: %v`, test.value, err)
+			continue
+		}
+		if nanos != test.expected {
+			t.Errorf(`ParseNanos(%q) should be %d. But it equals %d`, test.value, test.expected, nanos)
+		}
+		millis, _ := gmt.Parse(test.value)
+		if millis != GetMillisFromNanos(test.expected) {
+			t.Errorf(`Parse(%q) should be %d. But it equals %d`, test.value, GetMillisFromNanos(test.expected), millis)
+		}
+	}
+}
+
+func TestParseInTimeZone(t *testing.T) {
+	local := testFormat(berlin)
+	tests := []struct {
+		value    string
+		expected int64
+	}{
+		{"20240102-150405", nanosOf(2024, 1, 2, 15, 4, 5, 0, berlin)},
+		{"20240702-150405", nanosOf(2024, 7, 2, 15, 4, 5, 0, berlin)},
+		{"2024-07-02", nanosOf(2024, 7, 2, 0, 0, 0, 0, berlin)},
+		{"20240702-150405Z", nanosOf(2024, 7, 2, 15, 4, 5, 0, time.UTC)},
+		{"13:30", nanosOf(2024, 3, 15, 13, 30, 0, 0, berlin)},
+		{"1700000000000", 1700000000000 * NanosInMillis},
+	}
+	for _, test := range tests {
+		nanos, err := local.ParseNanos(test.value)
+		if err != nil {
+			t.Errorf(`ParseNanos(%q) failed: %v`, test.value, err)
+		} else if nanos != test.expected {
+			t.Errorf(`ParseNanos(%q) should be %d. But it equals %d`, test.value, test.expected, nanos)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	gmt := testFormat(time.UTC)
+	tests := []string{
+		"",
+		"abc",
+		"20241302",
+		"20240230",
+		"20240102-250000",
+		"20240102-156000",
+		"20240102-150460",
+		"20240102-15",
+		"20240102-150405.",
+		"20240102-150405.1234567890",
+		"20240102-150405+3",
+		"20240102-150405+19:00",
+		"20240102-150405+03:60",
+		"20240102-150405 Unknown/Zone",
+		"20240102-150405X",
+		"2024-1-02",
+		"2024-01-2",
+		"2024-01-02T15",
+		"2024-01-02T15:4",
+		"15:04:5",
+		"-1x",
+		"-",
+		"P",
+		"PT",
+		"99999999999999999999",
+	}
+	for _, test := range tests {
+		if value, err := gmt.Parse(test); err == nil {
+			t.Errorf(`Parse(%q) should fail. But it returned %d`, test, value)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	millis := nanosOf(2024, 1, 2, 15, 4, 5, 123_000_000, time.UTC) / NanosInMillis
+	nanos := nanosOf(2024, 7, 2, 15, 4, 5, 123456789, time.UTC)
+	tests := []struct {
+		format   *TimeFormat
+		nanos    bool
+		expected string
+	}{
+		{GMTTimeFormat, false, "20240102-150405+00:00"},
+		{GMTTimeFormat.WithMillis(), false, "20240102-150405.123+00:00"},
+		{GMTTimeFormat.WithNanos(), false, "20240102-150405.123000000+00:00"},
+		{GMTTimeFormat.AsFullIso(), false, "2024-01-02T15:04:05Z"},
+		{GMTTimeFormat.AsFullIso().WithMillis(), false, "2024-01-02T15:04:05.123Z"},
+		{GMTTimeFormat.WithTimeZone(berlin), false, "20240102-160405+01:00"},
+		{GMTTimeFormat.WithTimeZone(berlin).AsFullIso().WithMillis(), false, "2024-01-02T16:04:05.123+01:00"},
+		{GMTTimeFormat.WithNanos(), true, "20240702-150405.123456789+00:00"},
+		{GMTTimeFormat.WithTimeZone(berlin).WithMillis(), true, "20240702-170405.123+02:00"},
+		{GMTTimeFormat.AsFullIso().WithNanos(), true, "2024-07-02T15:04:05.123456789Z"},
+	}
+	for _, test := range tests {
+		var actual string
+		if test.nanos {
+			actual = test.format.FormatNanos(nanos)
+		} else {
+			actual = test.format.Format(millis)
+		}
+		if actual != test.expected {
+			t.Errorf(`Format should be %s. But it equals %s`, test.expected, actual)
+		}
+	}
+	if GMTTimeFormat.Format(0) != "0" || GMTTimeFormat.FormatNanos(0) != "0" {
+		t.Fatalf(`Zero time should be formatted as "0"`)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	formats := []*TimeFormat{
+		GMTTimeFormat.WithMillis(),
+		GMTTimeFormat.AsFullIso().WithMillis(),
+		GMTTimeFormat.WithTimeZone(berlin).WithMillis(),
+		DefaultTimeFormat.WithMillis(),
+	}
+	for _, millis := range []int64{1, -1, 1700000000123, 1719932645999, -86400001, 4102444800000} {
+		for _, f := range formats {
+			formatted := f.Format(millis)
+			parsed, err := f.Parse(formatted)
+			if err != nil || parsed != millis {
+				t.Errorf(`Parse(Format(%d)) = Parse(%s) should be %d. But it equals %d, %v`, millis, formatted, millis, parsed, err)
+			}
+		}
+	}
+	nanosFormats := []*TimeFormat{
+		GMTTimeFormat.WithNanos(),
+		GMTTimeFormat.AsFullIso().WithNanos(),
+		GMTTimeFormat.WithTimeZone(berlin).WithNanos(),
+	}
+	for _, nanos := range []int64{1, -1, 1700000000123456789, 1719932645999999999} {
+		for _, f := range nanosFormats {
+			formatted := f.FormatNanos(nanos)
+			parsed, err := f.ParseNanos(formatted)
+			if err != nil || parsed != nanos {
+				t.Errorf(`ParseNanos(FormatNanos(%d)) = ParseNanos(%s) should be %d. But it equals %d, %v`, nanos, formatted, nanos, parsed, err)
+			}
+		}
+	}
+}
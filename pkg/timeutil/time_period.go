@@ -0,0 +1,205 @@
+package timeutil
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimePeriod is a non-negative period of time in milliseconds written in dxFeed notation:
+// "5m", "1d", "1h30m", "0.1s", "PT0.1S", "P1DT2H" or "inf" for the unlimited period.
+type TimePeriod int64
+
+const (
+	ZeroPeriod      TimePeriod = 0
+	UnlimitedPeriod TimePeriod = math.MaxInt64
+)
+
+const unlimitedPeriodName = "inf"
+
+// TimePeriodOf returns the period for the duration truncated to milliseconds.
+func TimePeriodOf(duration time.Duration) TimePeriod {
+	if duration == math.MaxInt64 {
+		return UnlimitedPeriod
+	}
+	return TimePeriod(duration.Milliseconds())
+}
+
+// ParseTimePeriod parses the period. A number without a unit is in seconds.
+func ParseTimePeriod(value string) (TimePeriod, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, unlimitedPeriodName) {
+		return UnlimitedPeriod, nil
+	}
+	nanos, err := parsePeriodNanos(value)
+	if err != nil {
+		return 0, err
+	}
+	return TimePeriod((nanos + NanosInMillis/2) / NanosInMillis), nil
+}
+
+// parsePeriodNanos parses periods like "1d2h", "1.5s", "100ms" or ISO 8601 durations like "P1DT2H30M"
+// and returns them in nanoseconds.
+func parsePeriodNanos(value string) (int64, error) {
+	s := strings.ToUpper(value)
+	s = strings.TrimPrefix(s, "P")
+	if s == "" {
+		return 0, fmt.Errorf("empty period %q", value)
+	}
+	var result float64
+	parsed := false
+	for s != "" {
+		if s[0] == 'T' {
+			s = s[1:]
+			continue
+		}
+		end := 0
+		for end < len(s) && (isDigit(s[end]) || s[end] == '.') {
+			end++
+		}
+		if end == 0 {
+			return 0, fmt.Errorf("invalid period %q", value)
+		}
+		number, err := strconv.ParseFloat(s[:end], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid period %q", value)
+		}
+		s = s[end:]
+		unit := float64(time.Second)
+		switch {
+		case strings.HasPrefix(s, "MS"):
+			unit = float64(time.Millisecond)
+			s = s[2:]
+		case s == "" || s[0] == 'S':
+			s = strings.TrimPrefix(s, "S")
+		case s[0] == 'M':
+			unit = float64(time.Minute)
+			s = s[1:]
+		case s[0] == 'H':
+			unit = float64(time.Hour)
+			s = s[1:]
+		case s[0] == 'D':
+			unit = float64(24 * time.Hour)
+			s = s[1:]
+		case s[0] == 'W':
+			unit = float64(7 * 24 * time.Hour)
+			s = s[1:]
+		default:
+			return 0, fmt.Errorf("invalid period unit in %q", value)
+		}
+		result += number * unit
+		parsed = true
+	}
+	if !parsed {
+		return 0, fmt.Errorf("invalid period %q", value)
+	}
+	if result >= math.MaxInt64 {
+		return 0, fmt.Errorf("period %q is too large", value)
+	}
+	return int64(math.Round(result)), nil
+}
+
+func (p TimePeriod) IsUnlimited() bool {
+	return p == UnlimitedPeriod
+}
+
+func (p TimePeriod) Millis() int64 {
+	return int64(p)
+}
+
+// Seconds returns the period in whole seconds.
+func (p TimePeriod) Seconds() int64 {
+	return int64(p) / SECOND
+}
+
+// Nanos returns the period in nanoseconds, saturated to math.MaxInt64 for long and unlimited periods.
+func (p TimePeriod) Nanos() int64 {
+	if p > math.MaxInt64/NanosInMillis {
+		return math.MaxInt64
+	}
+	return int64(p) * NanosInMillis
+}
+
+// Duration converts the period to time.Duration, saturated like Nanos.
+func (p TimePeriod) Duration() time.Duration {
+	return time.Duration(p.Nanos())
+}
+
+// Add returns the sum of periods. The result is unlimited if any of the periods is unlimited or on overflow.
+func (p TimePeriod) Add(other TimePeriod) TimePeriod {
+	if p.IsUnlimited() || other.IsUnlimited() || p > UnlimitedPeriod-other {
+		return UnlimitedPeriod
+	}
+	return p + other
+}
+
+// Sub returns the difference of periods, but not less than zero. Unlimited period minus a limited one is unlimited.
+func (p TimePeriod) Sub(other TimePeriod) TimePeriod {
+	switch {
+	case p.IsUnlimited():
+		return UnlimitedPeriod
+	case other >= p:
+		return ZeroPeriod
+	default:
+		return p - other
+	}
+}
+
+// Multiply returns the period multiplied by non-negative n. The result is unlimited on overflow.
+func (p TimePeriod) Multiply(n int64) TimePeriod {
+	if n < 0 {
+		panic(fmt.Sprintf("negative multiplier %d", n))
+	}
+	if n == 0 {
+		return ZeroPeriod
+	}
+	if p.IsUnlimited() || int64(p) > math.MaxInt64/n {
+		return UnlimitedPeriod
+	}
+	return p * TimePeriod(n)
+}
+
+// Floor returns the largest time in milliseconds that is a multiple of the period and not greater than the time.
+func (p TimePeriod) Floor(timeMillis int64) int64 {
+	if p <= 0 || p.IsUnlimited() {
+		return timeMillis
+	}
+	return timeMillis - ((timeMillis%int64(p))+int64(p))%int64(p)
+}
+
+// String formats the period in ISO 8601 notation like "P1DT2H30M" or "PT0.1S", "0" for zero and "inf" for unlimited.
+func (p TimePeriod) String() string {
+	switch {
+	case p == ZeroPeriod:
+		return "0"
+	case p.IsUnlimited():
+		return unlimitedPeriodName
+	}
+	var sb strings.Builder
+	millis := int64(p)
+	if millis < 0 {
+		sb.WriteByte('-')
+		millis = -millis
+	}
+	sb.WriteByte('P')
+	if days := millis / DAY; days > 0 {
+		sb.WriteString(strconv.FormatInt(days, 10) + "D")
+	}
+	millis %= DAY
+	if millis == 0 {
+		return sb.String()
+	}
+	sb.WriteByte('T')
+	if hours := millis / HOUR; hours > 0 {
+		sb.WriteString(strconv.FormatInt(hours, 10) + "H")
+	}
+	if minutes := millis % HOUR / MINUTE; minutes > 0 {
+		sb.WriteString(strconv.FormatInt(minutes, 10) + "M")
+	}
+	if millis%MINUTE > 0 {
+		sb.WriteString(strconv.FormatFloat(float64(millis%MINUTE)/SECOND, 'f', -1, 64) + "S")
+	}
+	return sb.String()
+}
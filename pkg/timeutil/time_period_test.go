@@ -0,0 +1,77 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimePeriod(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected TimePeriod
+		str      string
+	}{
+		{"0", ZeroPeriod, "0"},
+		{"inf", UnlimitedPeriod, "inf"},
+		{"INF", UnlimitedPeriod, "inf"},
+		{"5m", 5 * MINUTE, "PT5M"},
+		{"1d", DAY, "P1D"},
+		{"1w", 7 * DAY, "P7D"},
+		{"1h30m", 90 * MINUTE, "PT1H30M"},
+		{"100ms", 100, "PT0.1S"},
+		{"0.1s", 100, "PT0.1S"},
+		{"PT0.1S", 100, "PT0.1S"},
+		{"pt0.1s", 100, "PT0.1S"},
+		{"P1DT2H", DAY + 2*HOUR, "P1DT2H"},
+		{"P1DT2H3M4.5S", DAY + 2*HOUR + 3*MINUTE + 4500, "P1DT2H3M4.5S"},
+		{"10", 10 * SECOND, "PT10S"},
+		{"1.5", 1500, "PT1.5S"},
+		{" 2s ", 2 * SECOND, "PT2S"},
+	}
+	for _, test := range tests {
+		period, err := ParseTimePeriod(test.value)
+		if err != nil {
+			t.Errorf(`ParseTimePeriod(%q) failed: %v`, test.value, err)
+			continue
+		}
+		if period != test.expected {
+			t.Errorf(`ParseTimePeriod(%q) should be %d. But it equals %d`, test.value, test.expected, period)
+		}
+		if period.String() != test.str {
+			t.Errorf(`String() of %q should be %s. But it equals %s`, test.value, test.str, period.String())
+		}
+		if parsed, err := ParseTimePeriod(period.String()); err != nil || parsed != period {
+			t.Errorf(`ParseTimePeriod(%s) should be %d. But it equals %d, %v`, period, period, parsed, err)
+		}
+	}
+	for _, value := range []string{"", "P", "PT", "m", "5x", "1..5s", "-5s", "infinite", "1e30d"} {
+		if _, err := ParseTimePeriod(value); err == nil {
+			t.Errorf(`ParseTimePeriod(%q) should fail`, value)
+		}
+	}
+}
+
+func TestTimePeriodArithmetic(t *testing.T) {
+	period := TimePeriod(5 * MINUTE)
+	if period.Duration() != 5*time.Minute || period.Seconds() != 300 || period.Nanos() != int64(5*time.Minute) {
+		t.Fatalf(`Unexpected conversions of %s`, period)
+	}
+	if TimePeriodOf(1500*time.Microsecond) != 1 || TimePeriodOf(time.Duration(UnlimitedPeriod)) != UnlimitedPeriod {
+		t.Fatalf(`TimePeriodOf should truncate to milliseconds`)
+	}
+	if UnlimitedPeriod.Duration() != time.Duration(UnlimitedPeriod) || TimePeriod(UnlimitedPeriod/2).Nanos() != int64(UnlimitedPeriod) {
+		t.Fatalf(`Nanos should saturate`)
+	}
+	if period.Add(period) != 10*MINUTE || period.Add(UnlimitedPeriod) != UnlimitedPeriod || (UnlimitedPeriod-1).Add(2) != UnlimitedPeriod {
+		t.Fatalf(`Unexpected Add results`)
+	}
+	if period.Sub(MINUTE) != 4*MINUTE || period.Sub(HOUR) != ZeroPeriod || UnlimitedPeriod.Sub(period) != UnlimitedPeriod {
+		t.Fatalf(`Unexpected Sub results`)
+	}
+	if period.Multiply(3) != 15*MINUTE || period.Multiply(0) != ZeroPeriod || period.Multiply(UnlimitedPeriod.Millis()) != UnlimitedPeriod {
+		t.Fatalf(`Unexpected Multiply results`)
+	}
+	if period.Floor(7*MINUTE+1) != 5*MINUTE || period.Floor(-1) != -5*MINUTE || ZeroPeriod.Floor(123) != 123 {
+		t.Fatalf(`Unexpected Floor results`)
+	}
+}